@@ -0,0 +1,407 @@
+// Package annotate suggests and inserts //gofn: directives on existing declarations,
+// lowering the cost of adopting gofn in an existing codebase.
+package annotate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Suggestion is a declaration matching a directive's heuristic
+type Suggestion struct {
+	File      string
+	Line      int // line the directive is inserted before
+	Name      string
+	Directive string
+	Reason    string
+}
+
+// Report is the outcome of scanning a directory for one directive
+type Report struct {
+	Suggestions []Suggestion
+	Scanned     int // candidate declarations considered
+	Annotated   int // declarations that already carry a //gofn: directive
+}
+
+// candidate is a declaration the heuristics are applied to
+type candidate struct {
+	name   string
+	line   int
+	strct  *ast.StructType
+	fn     *ast.FuncDecl
+	hasDir bool
+}
+
+// heuristic decides whether a candidate fits a directive, returning the reason when it does
+type heuristic func(c candidate, methods map[string]int) (string, bool)
+
+var heuristics = map[string]heuristic{
+	"record":    recordHeuristic,
+	"optional":  optionalHeuristic,
+	"match":     matchHeuristic,
+	"arbitrary": arbitraryHeuristic,
+	"actor":     actorHeuristic,
+	"curried":   curriedHeuristic,
+}
+
+// Directives returns the directives annotate has heuristics for, sorted
+func Directives() []string {
+	names := make([]string, 0, len(heuristics))
+	for name := range heuristics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Suggest scans the Go files of dir (tests and generated files excluded) for declarations fitting directive
+func Suggest(dir, directive string) (Report, error) {
+	h, ok := heuristics[directive]
+	if !ok {
+		return Report{}, fmt.Errorf("no heuristic for directive %q (supported: %s)", directive, strings.Join(Directives(), ", "))
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return Report{}, err
+	}
+
+	var report Report
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || strings.HasSuffix(path, "_gen.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return Report{}, err
+		}
+
+		methods := methodCounts(file)
+		pragma := hasFilePragma(file)
+		for _, c := range candidates(fset, file) {
+			wantsFunc := directive == "curried"
+			if (c.fn != nil) != wantsFunc {
+				continue
+			}
+			report.Scanned++
+			if c.hasDir || (pragma && c.strct != nil) {
+				report.Annotated++
+				continue
+			}
+			if reason, ok := h(c, methods); ok {
+				report.Suggestions = append(report.Suggestions, Suggestion{
+					File: path, Line: c.line, Name: c.name, Directive: directive, Reason: reason,
+				})
+			}
+		}
+	}
+	return report, nil
+}
+
+// Apply inserts the directive line of every suggestion above its declaration, indented like the declaration
+// Below a doc comment the directives are set apart by a `//` line, as gofmt would write them
+func Apply(suggestions []Suggestion) error {
+	byFile := map[string][]Suggestion{}
+	for _, s := range suggestions {
+		byFile[s.File] = append(byFile[s.File], s)
+	}
+
+	for path, list := range byFile {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		lines := strings.Split(string(src), "\n")
+
+		// insert bottom-up so earlier line numbers stay valid, all directives of a declaration at once
+		sort.SliceStable(list, func(i, j int) bool { return list[i].Line > list[j].Line })
+		for i := 0; i < len(list); {
+			idx := list[i].Line - 1
+			if idx < 0 || idx >= len(lines) {
+				return fmt.Errorf("%s:%d: line out of range", path, list[i].Line)
+			}
+			target := lines[idx]
+			indent := target[:len(target)-len(strings.TrimLeft(target, " \t"))]
+			var insert []string
+			if idx > 0 && isDocText(lines[idx-1]) {
+				insert = append(insert, indent+"//")
+			}
+			for ; i < len(list) && list[i].Line-1 == idx; i++ {
+				insert = append(insert, indent+"//gofn:"+list[i].Directive)
+			}
+			lines = append(lines[:idx], append(insert, lines[idx:]...)...)
+		}
+
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDocText reports whether line is a line comment of prose, which gofmt keeps apart from directives below it
+// An empty `//` line or a directive such as //go:generate or //gofn:record needs no separator
+func isDocText(line string) bool {
+	text, ok := strings.CutPrefix(strings.TrimSpace(line), "//")
+	if !ok || strings.TrimSpace(text) == "" || strings.HasPrefix(text, "gofn:") {
+		return false
+	}
+	name, _, ok := strings.Cut(text, ":")
+	return !ok || name == "" || strings.ContainsFunc(name, func(r rune) bool {
+		return !unicode.IsLower(r) && !unicode.IsDigit(r)
+	})
+}
+
+// candidates lists the top-level struct types and plain functions of a file
+func candidates(fset *token.FileSet, file *ast.File) []candidate {
+	var out []candidate
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts := spec.(*ast.TypeSpec)
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || ts.TypeParams != nil {
+					continue
+				}
+				// a lone spec is annotated above the `type` keyword, grouped specs above the spec itself
+				pos, doc := ts.Pos(), ts.Doc
+				if !d.Lparen.IsValid() {
+					pos = d.Pos()
+					if doc == nil {
+						doc = d.Doc
+					}
+				}
+				out = append(out, candidate{
+					name:   ts.Name.Name,
+					line:   fset.Position(pos).Line,
+					strct:  st,
+					hasDir: hasDirective(doc),
+				})
+			}
+		case *ast.FuncDecl:
+			if d.Recv != nil || d.Type.TypeParams != nil {
+				continue
+			}
+			out = append(out, candidate{
+				name:   d.Name.Name,
+				line:   fset.Position(d.Pos()).Line,
+				fn:     d,
+				hasDir: hasDirective(d.Doc),
+			})
+		}
+	}
+	return out
+}
+
+// methodCounts counts pointer-receiver methods per receiver type name
+func methodCounts(file *ast.File) map[string]int {
+	counts := map[string]int{}
+	for _, decl := range file.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 {
+			continue
+		}
+		if star, ok := fd.Recv.List[0].Type.(*ast.StarExpr); ok {
+			if id, ok := star.X.(*ast.Ident); ok {
+				counts[id.Name]++
+			}
+		}
+	}
+	return counts
+}
+
+// hasDirective reports whether a doc comment already carries a //gofn: directive
+func hasDirective(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if strings.HasPrefix(txt, "gofn:") && !isFilePragma(txt) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasFilePragma reports whether the file's structs are already covered by a //gofn:file pragma
+func hasFilePragma(file *ast.File) bool {
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			if isFilePragma(strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isFilePragma mirrors the parser's recognition of `//gofn:file ...`
+func isFilePragma(txt string) bool {
+	rest, ok := strings.CutPrefix(txt, "gofn:file")
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// isExported reports whether name starts with an upper-case letter
+func isExported(name string) bool {
+	return name != "" && unicode.IsUpper([]rune(name)[0])
+}
+
+// isValueType reports whether a field type is a plain value: a basic or named type, an array of one,
+// or a qualified type such as time.Time; pointers, slices, maps, channels and funcs share state
+func isValueType(e ast.Expr) bool {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.SelectorExpr:
+		return true
+	case *ast.ArrayType:
+		return t.Len != nil && isValueType(t.Elt)
+	default:
+		return false
+	}
+}
+
+// isGeneratable reports whether testing/quick can generate a field type
+func isGeneratable(e ast.Expr) bool {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return true
+	case *ast.ArrayType:
+		return isGeneratable(t.Elt)
+	case *ast.MapType:
+		return isGeneratable(t.Key) && isGeneratable(t.Value)
+	case *ast.StarExpr:
+		return isGeneratable(t.X)
+	default:
+		return false
+	}
+}
+
+// fields flattens a struct's fields, reporting false if any field is embedded
+func fields(st *ast.StructType) ([]*ast.Ident, []ast.Expr, bool) {
+	var names []*ast.Ident
+	var types []ast.Expr
+	for _, f := range st.Fields.List {
+		if len(f.Names) == 0 {
+			return nil, nil, false
+		}
+		for _, n := range f.Names {
+			names = append(names, n)
+			types = append(types, f.Type)
+		}
+	}
+	return names, types, true
+}
+
+// recordHeuristic: unexported structs whose fields are all unexported plain values
+func recordHeuristic(c candidate, _ map[string]int) (string, bool) {
+	if isExported(c.name) {
+		return "", false
+	}
+	names, types, ok := fields(c.strct)
+	if !ok || len(names) == 0 {
+		return "", false
+	}
+	for i, n := range names {
+		if isExported(n.Name) || !isValueType(types[i]) {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("unexported struct with %d unexported value fields", len(names)), true
+}
+
+// optionalHeuristic: exported configuration structs whose fields are all exported
+func optionalHeuristic(c candidate, _ map[string]int) (string, bool) {
+	if !isExported(c.name) {
+		return "", false
+	}
+	names, _, ok := fields(c.strct)
+	if !ok || len(names) == 0 {
+		return "", false
+	}
+	for _, n := range names {
+		if !isExported(n.Name) {
+			return "", false
+		}
+	}
+	for _, suffix := range []string{"Config", "Options", "Settings", "Params"} {
+		if strings.HasSuffix(c.name, suffix) {
+			return fmt.Sprintf("configuration struct (%s suffix) with exported fields", suffix), true
+		}
+	}
+	return "", false
+}
+
+// matchHeuristic: exported structs with exported value fields that can be compared pattern by pattern
+func matchHeuristic(c candidate, _ map[string]int) (string, bool) {
+	if !isExported(c.name) {
+		return "", false
+	}
+	names, types, ok := fields(c.strct)
+	if !ok || len(names) == 0 || len(names) > 6 {
+		return "", false
+	}
+	for i, n := range names {
+		if !isExported(n.Name) || !isValueType(types[i]) {
+			return "", false
+		}
+	}
+	return fmt.Sprintf("%d exported value fields usable as patterns", len(names)), true
+}
+
+// arbitraryHeuristic: structs whose fields testing/quick can generate
+func arbitraryHeuristic(c candidate, _ map[string]int) (string, bool) {
+	names, types, ok := fields(c.strct)
+	if !ok || len(names) == 0 {
+		return "", false
+	}
+	for _, t := range types {
+		if !isGeneratable(t) {
+			return "", false
+		}
+	}
+	return "every field is generatable by testing/quick", true
+}
+
+// actorHeuristic: structs guarding mutable state with a mutex and exposing pointer-receiver methods
+func actorHeuristic(c candidate, methods map[string]int) (string, bool) {
+	if methods[c.name] == 0 {
+		return "", false
+	}
+	for _, f := range c.strct.Fields.List {
+		if sel, ok := f.Type.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "sync" && (sel.Sel.Name == "Mutex" || sel.Sel.Name == "RWMutex") {
+				return fmt.Sprintf("mutex-guarded state with %d pointer methods", methods[c.name]), true
+			}
+		}
+	}
+	return "", false
+}
+
+// curriedHeuristic: plain functions of two or more parameters with a single result
+func curriedHeuristic(c candidate, _ map[string]int) (string, bool) {
+	params := 0
+	for _, p := range c.fn.Type.Params.List {
+		if len(p.Names) == 0 {
+			params++
+		}
+		params += len(p.Names)
+	}
+	if params < 2 || c.fn.Type.Results == nil || c.fn.Type.Results.NumFields() != 1 {
+		return "", false
+	}
+	return fmt.Sprintf("%d parameters and a single result", params), true
+}
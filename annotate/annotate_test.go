@@ -0,0 +1,72 @@
+package annotate
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApplyKeepsFilesGofmtClean(t *testing.T) {
+	src := `package fixture
+
+// user is a person with an account.
+type user struct {
+	name string
+}
+
+//go:noinline
+func add(a, b int) int { return a + b }
+
+type plain struct {
+	n int
+}
+`
+	want := `package fixture
+
+// user is a person with an account.
+//
+//gofn:record
+//gofn:stringer
+type user struct {
+	name string
+}
+
+//go:noinline
+//gofn:curried
+func add(a, b int) int { return a + b }
+
+//gofn:record
+type plain struct {
+	n int
+}
+`
+	path := filepath.Join(t.TempDir(), "fixture.go")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := Apply([]Suggestion{
+		{File: path, Line: 4, Name: "user", Directive: "record"},
+		{File: path, Line: 4, Name: "user", Directive: "stringer"},
+		{File: path, Line: 9, Name: "add", Directive: "curried"},
+		{File: path, Line: 11, Name: "plain", Directive: "record"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("Unexpected file:\n%s", got)
+	}
+	formatted, err := format.Source(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != string(got) {
+		t.Errorf("The annotated file is not gofmt-clean; gofmt writes:\n%s", formatted)
+	}
+}
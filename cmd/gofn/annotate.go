@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/snowmerak/gofn/annotate"
+)
+
+// runAnnotate implements `gofn annotate -directive=<name> [-w] [dir...]`
+func runAnnotate(args []string) int {
+	fs := flag.NewFlagSet("annotate", flag.ExitOnError)
+	directive := fs.String("directive", "", "directive to suggest: "+strings.Join(annotate.Directives(), ", "))
+	write := fs.Bool("w", false, "insert the suggested directives into the source files")
+	fs.Parse(args)
+
+	if *directive == "" {
+		fmt.Fprintln(os.Stderr, "annotate: -directive is required")
+		fs.Usage()
+		return 2
+	}
+	dirs := fs.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	var all []annotate.Suggestion
+	scanned, annotated := 0, 0
+	for _, dir := range dirs {
+		dir = filepath.Clean(dir)
+		report, err := annotate.Suggest(dir, *directive)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "annotate error:", err)
+			return 2
+		}
+		for _, s := range report.Suggestions {
+			fmt.Printf("%s:%d: %s -> //gofn:%s (%s)\n", s.File, s.Line, s.Name, s.Directive, s.Reason)
+		}
+		all = append(all, report.Suggestions...)
+		scanned += report.Scanned
+		annotated += report.Annotated
+	}
+
+	if *write {
+		if err := annotate.Apply(all); err != nil {
+			fmt.Fprintln(os.Stderr, "annotate error:", err)
+			return 3
+		}
+		fmt.Printf("annotated %d of %d declarations with //gofn:%s (%d already annotated)\n", len(all), scanned, *directive, annotated)
+		return 0
+	}
+	fmt.Printf("%d of %d declarations match //gofn:%s (%d already annotated); rerun with -w to insert\n", len(all), scanned, *directive, annotated)
+	return 0
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/snowmerak/gofn/gofnbench"
+)
+
+// runBench implements `gofn bench [-run regexp] [-benchtime d] [-out file] [-baseline file] [thresholds]`
+// It measures the gofnbench benchmarks, optionally writes the report, and compares it with a baseline report,
+// exiting 1 when a benchmark regressed so CI can guard the monad hot paths
+func runBench(args []string) int {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	run := fs.String("run", "", "only run benchmarks whose name matches this regexp")
+	benchtime := fs.String("benchtime", "1s", "time, or iterations such as 10000x, to run each benchmark for")
+	out := fs.String("out", "", "write the report to this JSON file")
+	baseline := fs.String("baseline", "", "compare with the report in this JSON file")
+	timeRatio := fs.Float64("time-ratio", gofnbench.DefaultThresholds.TimeRatio, "largest accepted ratio of ns/op to the baseline")
+	extraAllocs := fs.Int64("extra-allocs", gofnbench.DefaultThresholds.ExtraAllocs, "allocations per op accepted above the baseline")
+	fs.Parse(args)
+
+	var filter *regexp.Regexp
+	if *run != "" {
+		var err error
+		if filter, err = regexp.Compile(*run); err != nil {
+			fmt.Fprintln(os.Stderr, "bench error: -run:", err)
+			return 2
+		}
+	}
+	// testing.Benchmark reads its duration from the testing flags
+	testing.Init()
+	if err := flag.Set("test.benchtime", *benchtime); err != nil {
+		fmt.Fprintln(os.Stderr, "bench error: -benchtime:", err)
+		return 2
+	}
+
+	var base gofnbench.Report
+	if *baseline != "" {
+		var err error
+		if base, err = gofnbench.ReadReport(*baseline); err != nil {
+			fmt.Fprintln(os.Stderr, "bench error:", err)
+			return 2
+		}
+	}
+
+	report := gofnbench.Measure(filter)
+	for _, r := range report.Results {
+		fmt.Printf("%-32s %12.1f ns/op %8d B/op %6d allocs/op\n", r.Name, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp)
+	}
+	if *out != "" {
+		if err := gofnbench.WriteReport(*out, report); err != nil {
+			fmt.Fprintln(os.Stderr, "bench error:", err)
+			return 3
+		}
+	}
+	if *baseline == "" {
+		return 0
+	}
+
+	regressions := gofnbench.Compare(base, report, gofnbench.Thresholds{TimeRatio: *timeRatio, ExtraAllocs: *extraAllocs})
+	for _, r := range regressions {
+		fmt.Println("regression:", r)
+	}
+	if len(regressions) > 0 {
+		fmt.Printf("gofn: %d benchmark(s) regressed against %s\n", len(regressions), *baseline)
+		return 1
+	}
+	fmt.Println("gofn: no regression against", *baseline)
+	return 0
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/snowmerak/gofn/generator"
+	"github.com/snowmerak/gofn/parser"
+)
+
+// runCheck implements `gofn check [-src dir | dir] [-out dir] [generation flags]`
+// It generates into a scratch directory and compares the result with the committed output, so CI can
+// fail when generated code or schema documents drift from the annotated sources; nothing in out is written
+func runCheck(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	src := fs.String("src", ".", "source directory to scan")
+	out := fs.String("out", "", "directory holding the generated code (defaults to src)")
+	var opts generator.Options
+	registerOptionFlags(fs, &opts)
+	fs.Parse(args)
+
+	dir, err := sourceDir(fs, *src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fs.Usage()
+		return 2
+	}
+	absSrc, _ := filepath.Abs(dir)
+	if *out == "" {
+		*out = absSrc
+	}
+	pkg, err := parser.ParsePackage(absSrc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "parse error:", err)
+		return 2
+	}
+
+	scratch, err := os.MkdirTemp("", "gofn-check-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "check error:", err)
+		return 3
+	}
+	defer os.RemoveAll(scratch)
+	if err := quietly(func() error { return generator.GeneratePackage(scratch, pkg, opts) }); err != nil {
+		fmt.Fprintln(os.Stderr, "generate error:", err)
+		return 3
+	}
+
+	drift, err := compareGenerated(scratch, *out)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "check error:", err)
+		return 3
+	}
+	for _, d := range drift {
+		fmt.Println(d)
+	}
+	if len(drift) > 0 {
+		fmt.Printf("gofn: %d generated file(s) out of date in %s; rerun gofn\n", len(drift), *out)
+		return 1
+	}
+	fmt.Println("gofn: generated files are up to date in", *out)
+	return 0
+}
+
+// sourceDir returns the directory a command scans: its positional argument, or -src when there is none
+// Passing several directories, or one both ways, is a usage error rather than a silent choice of one
+func sourceDir(fs *flag.FlagSet, src string) (string, error) {
+	switch {
+	case fs.NArg() == 0:
+		return src, nil
+	case fs.NArg() > 1:
+		return "", fmt.Errorf("%s: expected one source directory, got %d arguments", fs.Name(), fs.NArg())
+	}
+	srcSet := false
+	fs.Visit(func(f *flag.Flag) { srcSet = srcSet || f.Name == "src" })
+	if srcSet {
+		return "", fmt.Errorf("%s: give the source directory either with -src or as an argument", fs.Name())
+	}
+	return fs.Arg(0), nil
+}
+
+// compareGenerated lists the differences between freshly generated files in want and the files in dir:
+// files missing or stale in dir, and files in dir generated by gofn that generation no longer produces
+func compareGenerated(want, dir string) ([]string, error) {
+	entries, err := os.ReadDir(want)
+	if err != nil {
+		return nil, err
+	}
+	var drift []string
+	produced := map[string]bool{}
+	for _, e := range entries {
+		produced[e.Name()] = true
+		fresh, err := os.ReadFile(filepath.Join(want, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(dir, e.Name())
+		current, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			drift = append(drift, "missing: "+path)
+		case err != nil:
+			return nil, err
+		case !bytes.Equal(current, fresh):
+			drift = append(drift, "stale: "+path)
+		}
+	}
+
+	existing, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range existing {
+		if produced[filepath.Base(path)] {
+			continue
+		}
+		generated, err := generator.IsGenerated(path)
+		if err != nil {
+			return nil, err
+		}
+		if generated {
+			drift = append(drift, "orphaned: "+path)
+		}
+	}
+	slices.Sort(drift)
+	return drift, nil
+}
+
+// quietly runs f with standard output discarded, hiding the progress lines the generator prints
+func quietly(f func() error) error {
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return f()
+	}
+	defer null.Close()
+	stdout := os.Stdout
+	os.Stdout = null
+	defer func() { os.Stdout = stdout }()
+	return f()
+}
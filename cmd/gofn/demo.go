@@ -0,0 +1,279 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/generator"
+	"github.com/snowmerak/gofn/parser"
+)
+
+// gofnModule is the import path scratch modules depend on
+const gofnModule = "github.com/snowmerak/gofn"
+
+// demos are small programs exercising one directive each; `gofn demo <name>` generates and runs them
+var demos = map[string]string{
+	"record": `package main
+
+import "fmt"
+
+//gofn:record
+type point struct {
+	x int
+	y int
+}
+
+func main() {
+	p := NewPoint(3, 4)
+	fmt.Println("point:", p.X(), p.Y())
+}
+`,
+	"optional": `package main
+
+import (
+	"fmt"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+//gofn:optional
+type Config struct {
+	Host string ` + "`default:\"localhost\"`" + `
+	Port int    ` + "`default:\"8080\"`" + `
+}
+
+func main() {
+	fmt.Println("with options:", NewConfigWithOptions(WithPort(9090)))
+	fmt.Println("from options:", NewConfigFromOptions(monad.Some("example.com"), monad.None[int]()))
+}
+`,
+	"curried": `package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+//gofn:curried result
+func div(a, b int) (int, error) {
+	if b == 0 {
+		return 0, errors.New("division by zero")
+	}
+	return a / b, nil
+}
+
+func main() {
+	half := DivCurried()(10)
+	q, err := half(2)
+	fmt.Println("curried:", q, err)
+	fmt.Println("curried result:", DivCurriedR()(10)(0))
+}
+`,
+	"pipe": `package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func trim(s string) string { return strings.TrimSpace(s) }
+
+func parse(s string) (int, error) { return strconv.Atoi(s) }
+
+func double(n int) int { return n * 2 }
+
+//gofn:pipe
+var doubled = []any{trim, parse, double}
+
+func main() {
+	fmt.Println(DoubledPipe(" 21 "))
+	fmt.Println(DoubledPipe("x"))
+	fmt.Println("task:", DoubledPipeTask("5")(context.Background()))
+}
+`,
+	"memoize": `package main
+
+import "fmt"
+
+//gofn:memoize size=16
+func square(n int) int {
+	fmt.Println("computing", n)
+	return n * n
+}
+
+func main() {
+	memo := NewSquareMemo()
+	fmt.Println(memo.Call(4), memo.Call(4), memo.Call(5))
+	fmt.Printf("metrics: %+v\n", memo.Metrics())
+}
+`,
+	"batch": `package main
+
+import (
+	"fmt"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+//gofn:batch size=10 window=2ms
+func lookup(id int) (string, error) { return fmt.Sprintf("user-%d", id), nil }
+
+func main() {
+	loader := NewLookupLoader(func(ids []int) ([]string, error) {
+		fmt.Println("one fetch for", ids)
+		return LookupMany(ids)
+	})
+	futures := []*monad.Future[string]{loader.Load(1), loader.Load(2), loader.Load(3)}
+	for _, f := range futures {
+		fmt.Println(f.Await())
+	}
+}
+`,
+	"builder": `package main
+
+import "fmt"
+
+//gofn:builder
+type Person struct {
+	Name string ` + "`validate:\"required,min=2\"`" + `
+	Age  int    ` + "`validate:\"lte=150\"`" + `
+}
+
+func main() {
+	p := NewPersonBuilder().Name("kim").Age(30).Build()
+	fmt.Println("built:", p)
+	v, _ := p.Unwrap()
+	fmt.Println("modified copy:", v.ToBuilder().Age(200).Build())
+}
+`,
+}
+
+// runDemo implements `gofn demo [-show] [-keep] [-gofn=dir] <directive>`
+func runDemo(args []string) int {
+	fs := flag.NewFlagSet("demo", flag.ExitOnError)
+	show := fs.Bool("show", false, "print the generated code before running")
+	keep := fs.Bool("keep", false, "keep the scratch module instead of deleting it")
+	gofnDir := fs.String("gofn", "", "gofn checkout to build against (default: the module in use, else the installed version)")
+	fs.Parse(args)
+
+	names := make([]string, 0, len(demos))
+	for name := range demos {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	src, ok := demos[fs.Arg(0)]
+	if fs.NArg() != 1 || !ok {
+		fmt.Fprintf(os.Stderr, "usage: gofn demo [-show] [-keep] [-gofn=dir] <directive>\ndirectives: %s\n", strings.Join(names, ", "))
+		return 2
+	}
+
+	dir, err := os.MkdirTemp("", "gofn-demo-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "demo error:", err)
+		return 3
+	}
+	if *keep {
+		fmt.Println("scratch module:", dir)
+	} else {
+		defer os.RemoveAll(dir)
+	}
+
+	if err := writeScratchModule(dir, src, *gofnDir); err != nil {
+		fmt.Fprintln(os.Stderr, "demo error:", err)
+		return 3
+	}
+	fmt.Printf("--- main.go\n%s\n", src)
+
+	pkg, err := parser.ParsePackage(dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "parse error:", err)
+		return 3
+	}
+	if err := generator.GeneratePackage(dir, pkg, generator.Options{}); err != nil {
+		fmt.Fprintln(os.Stderr, "generate error:", err)
+		return 3
+	}
+	if *show {
+		generated, _ := filepath.Glob(filepath.Join(dir, "*_gen.go"))
+		for _, path := range generated {
+			code, err := os.ReadFile(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "demo error:", err)
+				return 3
+			}
+			fmt.Printf("--- %s\n%s\n", filepath.Base(path), code)
+		}
+	}
+
+	fmt.Println("--- output")
+	run := exec.Command("go", "run", ".")
+	run.Dir, run.Stdout, run.Stderr = dir, os.Stdout, os.Stderr
+	if err := run.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "demo error:", err)
+		return 3
+	}
+	return 0
+}
+
+// writeScratchModule writes main.go and a go.mod depending on gofn into dir
+// A local gofn checkout is used through a replace directive; otherwise the installed version is fetched
+func writeScratchModule(dir, src, gofnDir string) error {
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		return err
+	}
+
+	if gofnDir == "" {
+		gofnDir = localGofnDir()
+	}
+	mod := "module gofndemo\n\ngo 1.25.0\n\n"
+	switch {
+	case gofnDir != "":
+		abs, err := filepath.Abs(gofnDir)
+		if err != nil {
+			return err
+		}
+		mod += fmt.Sprintf("require %s v0.0.0\n\nreplace %s => %s\n", gofnModule, gofnModule, abs)
+	default:
+		version := installedGofnVersion()
+		if version == "" {
+			return fmt.Errorf("cannot locate gofn sources; pass -gofn=<path to a gofn checkout>")
+		}
+		mod += fmt.Sprintf("require %s %s\n", gofnModule, version)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(mod), 0o644); err != nil {
+		return err
+	}
+	if gofnDir != "" {
+		return nil
+	}
+
+	tidy := exec.Command("go", "mod", "tidy")
+	tidy.Dir, tidy.Stderr = dir, os.Stderr
+	return tidy.Run()
+}
+
+// localGofnDir returns the directory of the gofn module used by the current module, if any
+func localGofnDir() string {
+	out, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", gofnModule).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// installedGofnVersion returns the released version this binary was built from, empty for development builds
+func installedGofnVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path != gofnModule || info.Main.Version == "(devel)" {
+		return ""
+	}
+	return info.Main.Version
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/snowmerak/gofn/generator"
+	"github.com/snowmerak/gofn/parser"
+)
+
+// runIndex implements `gofn index [-src dir | dir] [-out dir] [-o file] [generation flags]`
+// It generates into a scratch directory and writes a JSON index of the annotated declarations, their fields and
+// the symbols generated for them, to stdout unless -o names a file; nothing in out is written
+func runIndex(args []string) int {
+	fs := flag.NewFlagSet("index", flag.ExitOnError)
+	src := fs.String("src", ".", "source directory to scan")
+	out := fs.String("out", "", "directory holding the generated code (defaults to src)")
+	output := fs.String("o", "", "write the index to this file instead of stdout")
+	var opts generator.Options
+	registerOptionFlags(fs, &opts)
+	fs.Parse(args)
+
+	dir, err := sourceDir(fs, *src)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fs.Usage()
+		return 2
+	}
+	absSrc, _ := filepath.Abs(dir)
+	absOut := absSrc
+	if *out != "" {
+		absOut, _ = filepath.Abs(*out)
+	}
+	pkg, err := parser.ParsePackage(absSrc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "parse error:", err)
+		return 2
+	}
+
+	scratch, err := os.MkdirTemp("", "gofn-index-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "index error:", err)
+		return 3
+	}
+	defer os.RemoveAll(scratch)
+	var idx *generator.Index
+	err = quietly(func() error {
+		idx, err = generator.BuildIndex(pkg, scratch, absOut, opts)
+		return err
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "generate error:", err)
+		return 3
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "index error:", err)
+			return 3
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := idx.WriteJSON(w); err != nil {
+		fmt.Fprintln(os.Stderr, "index error:", err)
+		return 3
+	}
+	return 0
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/snowmerak/gofn/lift"
+)
+
+// runLift implements `gofn lift -func=<name> [-w] [dir]`
+func runLift(args []string) int {
+	fs := flag.NewFlagSet("lift", flag.ExitOnError)
+	fn := fs.String("func", "", "function returning (T, error) whose call sites are rewritten to Result chains")
+	write := fs.Bool("w", false, "write the rewritten files instead of printing them")
+	fs.Parse(args)
+
+	if *fn == "" {
+		fmt.Fprintln(os.Stderr, "lift: -func is required")
+		fs.Usage()
+		return 2
+	}
+	dir := "."
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+
+	report, err := lift.Lift(dir, *fn)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "lift error:", err)
+		return 2
+	}
+	for _, s := range report.Lifted {
+		fmt.Printf("%s:%d: lifted %s call in %s\n", s.File, s.Line, *fn, s.Caller)
+	}
+	for _, s := range report.Skipped {
+		fmt.Printf("%s:%d: skipped %s call in %s: %s\n", s.File, s.Line, *fn, s.Caller, s.Reason)
+	}
+
+	if *write {
+		if err := lift.Apply(report); err != nil {
+			fmt.Fprintln(os.Stderr, "lift error:", err)
+			return 3
+		}
+		fmt.Printf("lifted %d call sites of %s (%d skipped)\n", len(report.Lifted), *fn, len(report.Skipped))
+		return 0
+	}
+	for _, path := range report.Files() {
+		src, _ := report.Source(path)
+		fmt.Printf("\n--- %s\n%s", path, src)
+	}
+	fmt.Printf("%d call sites of %s can be lifted (%d skipped); rerun with -w to write\n", len(report.Lifted), *fn, len(report.Skipped))
+	return 0
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -11,23 +12,104 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "annotate":
+			os.Exit(runAnnotate(os.Args[2:]))
+		case "lift":
+			os.Exit(runLift(os.Args[2:]))
+		case "demo":
+			os.Exit(runDemo(os.Args[2:]))
+		case "check":
+			os.Exit(runCheck(os.Args[2:]))
+		case "bench":
+			os.Exit(runBench(os.Args[2:]))
+		case "index":
+			os.Exit(runIndex(os.Args[2:]))
+		}
+	}
+
 	src := flag.String("src", ".", "source directory to scan")
 	out := flag.String("out", "", "output directory for generated code (defaults to src)")
+	migrate := flag.Bool("migrate", false, "regenerate files written by an incompatible older gofn instead of refusing")
+	verbose := flag.Bool("v", false, "print parse cache statistics after generating")
+	report := flag.String("report", "", "write a generation report to this file: .json or .md")
+	var opts generator.Options
+	registerOptionFlags(flag.CommandLine, &opts)
 	flag.Parse()
 	absSrc, _ := filepath.Abs(*src)
 	if *out == "" {
 		*out = absSrc
 	}
-	structs, funcs, err := parser.ParseDir(absSrc)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, "parse error:", err)
-		os.Exit(2)
+	opts.Migrate = *migrate
+	if *report != "" {
+		opts.Report = &generator.Report{}
 	}
 
-	if err := generator.GenerateFor(*out, structs, funcs); err != nil {
-		fmt.Fprintln(os.Stderr, "generate error:", err)
+	// files are generated as they are parsed; declarations needing the whole package follow on Close
+	stream, genErr := generator.NewStream(*out, opts)
+	if genErr == nil {
+		err := parser.StreamPackage(absSrc, func(_ string, decl parser.Package) error {
+			genErr = stream.Add(decl)
+			return genErr
+		})
+		if err != nil && genErr == nil {
+			fmt.Fprintln(os.Stderr, "parse error:", err)
+			os.Exit(2)
+		}
+		if genErr == nil {
+			genErr = stream.Close()
+		}
+	}
+	// the report is written even when generation fails so CI keeps what was done so far
+	if *report != "" {
+		if err := writeReport(*report, opts.Report); err != nil {
+			fmt.Fprintln(os.Stderr, "report error:", err)
+			os.Exit(3)
+		}
+	}
+	if genErr != nil {
+		fmt.Fprintln(os.Stderr, "generate error:", genErr)
 		os.Exit(3)
 	}
 
+	if *verbose {
+		st := parser.SharedCache().Stats()
+		fmt.Printf("gofn: parse cache: %d hits, %d misses, %d files cached\n", st.Hits, st.Misses, st.Files)
+	}
 	fmt.Println("generated to", *out)
 }
+
+// registerOptionFlags registers the flags shaping the generated code on fs, shared by generation and `gofn check`
+func registerOptionFlags(fs *flag.FlagSet, opts *generator.Options) {
+	fs.StringVar(&opts.Artifacts, "artifacts", "", "emit pipeline/workflow charts next to the code: mermaid or dot")
+	fs.StringVar(&opts.Naming.ConstructorPrefix, "ctor-prefix", "", "prefix of generated constructors (default \"New\")")
+	fs.StringVar(&opts.Naming.GetterPrefix, "getter-prefix", "", "prefix of record getters, e.g. \"Get\" (default none)")
+	fs.StringVar(&opts.Naming.OptionPrefix, "option-prefix", "", "prefix of functional option functions (default \"With\")")
+	fs.StringVar(&opts.Naming.CurriedSuffix, "curried-suffix", "", "suffix of curried wrappers (default \"Curried\")")
+	fs.BoolVar(&opts.Naming.Unexported, "unexported", false, "keep identifiers generated for unexported declarations unexported (newPerson instead of NewPerson)")
+	fs.IntVar(&opts.Limits.MaxCurriedParams, "max-curried-params", 0, "largest parameter count accepted by curried wrappers (default 12)")
+	fs.IntVar(&opts.Limits.MaxMatchFields, "max-match-fields", 0, "largest field count accepted by match (default 16)")
+}
+
+// writeReport writes r to path in the format chosen by its extension
+func writeReport(path string, r *generator.Report) error {
+	var write func(io.Writer) error
+	switch filepath.Ext(path) {
+	case ".json":
+		write = r.WriteJSON
+	case ".md":
+		write = r.WriteMarkdown
+	default:
+		return fmt.Errorf("unknown report format for %s (want .json or .md)", path)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,17 +1,27 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/snowmerak/gofn/monad"
+	"github.com/snowmerak/gofn/monad/optics"
 )
 
 //go:generate go run github.com/snowmerak/gofn/cmd/gofn -src=. -out=.
 
 // Example definitions with gofn directives. The runnable demo is at the bottom (runExamples).
 
-//gofn:record
+//gofn:record iface-fakes
+//gofn:immutable
 type person struct {
 	name string
 	age  int
@@ -19,8 +29,8 @@ type person struct {
 
 //gofn:optional
 type Config struct {
-	Host string
-	Port int
+	Host string `default:"localhost"`
+	Port int    `default:"8080"`
 }
 
 // 필수 인자를 받는 생성자와 옵션 기반 생성자(WithX helpers)는
@@ -47,6 +57,37 @@ func DivMod(a, b int) (int, int) {
 	return a / b, a % b
 }
 
+// Greet takes ctx first; its CurriedCtxLast form lets the greeting be bound once and reused per request
+//
+//gofn:curried result
+func Greet(ctx context.Context, greeting string, names ...string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return greeting + ", " + strings.Join(names, " & "), nil
+}
+
+// ParseStamp returns five values, so its CurriedR wrapper uses a Tuple5 generated into this package
+//
+//gofn:curried result
+func ParseStamp(layout string, s string) (int, int, int, int, int, error) {
+	var y, mo, d, h, mi int
+	if _, err := fmt.Sscanf(s, layout, &y, &mo, &d, &h, &mi); err != nil {
+		return 0, 0, 0, 0, 0, err
+	}
+	return y, mo, d, h, mi, nil
+}
+
+// SafeDivMod also gets a SafeDivModCurriedR wrapper ending in a Result
+//
+//gofn:curried result
+func SafeDivMod(a, b int) (int, int, error) {
+	if b == 0 {
+		return 0, 0, errors.New("division by zero")
+	}
+	return a / b, a % b, nil
+}
+
 //gofn:pipeline
 type anyPipe struct {
 	first  int64
@@ -56,13 +97,14 @@ type anyPipe struct {
 }
 
 //gofn:match
+//gofn:lens
 type Address struct {
 	Street string
 	City   string
 	Zip    string
 }
 
-//gofn:reactive
+//gofn:reactive stream persist
 type Counter struct {
 	Value int
 	Name  string
@@ -74,6 +116,385 @@ type ListenAddress struct {
 	Port int
 }
 
+//gofn:arbitrary
+type Sample struct {
+	ID    int
+	Label string
+	Tags  []string
+	Ready bool
+	Seen  time.Time
+	Note  monad.Option[string]
+}
+
+//gofn:actor
+type Account struct {
+	balance int
+}
+
+// Deposit adds amount and returns the new balance.
+func (a *Account) Deposit(amount int) int {
+	a.balance += amount
+	return a.balance
+}
+
+// Withdraw removes amount, failing if the balance is insufficient.
+func (a *Account) Withdraw(amount int) (int, error) {
+	if amount > a.balance {
+		return a.balance, errors.New("insufficient funds")
+	}
+	a.balance -= amount
+	return a.balance, nil
+}
+
+//gofn:workflow
+type Checkout struct {
+	Reserve func(context.Context) error `step:"reserve" compensate:"Release"`
+	Release func(context.Context) error
+	Charge  func(context.Context) error `step:"charge"`
+}
+
+//gofn:batch size=10 window=2ms
+func LookupUser(id int) (string, error) {
+	return fmt.Sprintf("user-%d", id), nil
+}
+
+// Profile has an Option-typed field; its matcher takes SomeP/NoneP patterns for it
+//
+//gofn:match
+type Profile struct {
+	Name     string
+	Nickname monad.Option[string]
+}
+
+// Point is keyed by its Hash in memoized calls; Equal guards against collisions
+type Point struct {
+	X, Y int
+}
+
+// Hash mixes the coordinates into a cache key.
+func (p Point) Hash() uint64 {
+	return uint64(p.X)*31 + uint64(p.Y)
+}
+
+// Equal reports whether both coordinates match.
+func (p Point) Equal(o Point) bool {
+	return p.X == o.X && p.Y == o.Y
+}
+
+//gofn:memoize size=64
+func Manhattan(from Point, to Point) int {
+	fmt.Println("memoize: computing", from, to)
+	return abs(from.X-to.X) + abs(from.Y-to.Y)
+}
+
+// LookupRate is shared between processes through an external store; plug a Redis adapter in place of the default
+//
+//gofn:memoize store=external ttl=10m prefix=rates
+func LookupRate(ctx context.Context, from, to string) (float64, error) {
+	fmt.Println("memoize external: fetching", from, to)
+	if from == to {
+		return 1, nil
+	}
+	return 1.25, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func trimInput(s string) string { return strings.TrimSpace(s) }
+
+func parseAge(s string) (int, error) {
+	var n int
+	if _, err := fmt.Sscanf(s, "%d", &n); err != nil {
+		return 0, fmt.Errorf("age %q: %w", s, err)
+	}
+	return n, nil
+}
+
+func checkAge(n int) (int, error) {
+	if n < 0 || n > 150 {
+		return 0, fmt.Errorf("age %d out of range", n)
+	}
+	return n, nil
+}
+
+// ageInput is composed into AgeInputPipe; the stage types are checked when generating
+//
+//gofn:pipe
+var ageInput = []any{trimInput, parseAge, checkAge}
+
+// Signup is the request body of a signup endpoint; its JSON Schema is generated from the tags
+//
+//gofn:schema openapi
+type Signup struct {
+	Email    string   `json:"email" validate:"required,email"`
+	Name     string   `json:"name" validate:"min=1,max=64"`
+	Age      int      `json:"age,omitempty" validate:"gte=0,lte=150"`
+	Plan     string   `json:"plan" validate:"oneof=free pro"`
+	Tags     []string `json:"tags,omitempty" validate:"max=8"`
+	Referrer *Address `json:"referrer,omitempty"`
+}
+
+// Shipment is persisted with the compact binary encoding; the tags pin the field IDs
+// so fields can be added, reordered or removed without breaking stored records
+//
+//gofn:binary
+type Shipment struct {
+	ID       uint64        `binary:"1"`
+	Carrier  string        `binary:"2"`
+	Weight   float64       `binary:"3"`
+	Delay    time.Duration `binary:"4"`
+	Parcels  []int32       `binary:"5"`
+	Express  bool          `binary:"6"`
+	Shipped  time.Time     `binary:"7"`
+	Priority *int          `binary:"8"`
+	Label    []byte        `binary:"9"`
+	scratch  string        `binary:"-"`
+}
+
+// Roles is a named slice; its match pattern works on the underlying []string
+type Roles []string
+
+//gofn:match
+type Member struct {
+	Name   string
+	Roles  Roles
+	Quotas map[string]int
+}
+
+//gofn:builder
+type Invoice struct {
+	Number   string   `validate:"required,len=8"`
+	Currency string   `validate:"oneof=EUR USD KRW" default:"EUR"`
+	Lines    []string `validate:"min=1"`
+	Total    float64  `validate:"gt=0"`
+}
+
+//gofn:lens
+type Employee struct {
+	Name   string
+	Office Address
+}
+
+// Shape is a sum type of Circle and Rect: only the listed variants implement it, and MatchShape
+// needs a handler for each
+//
+//gofn:enum Circle Rect
+type Shape interface {
+	sealed()
+	Area() float64
+}
+
+type Circle struct {
+	Radius float64
+}
+
+func (c Circle) Area() float64 { return 3.14159 * c.Radius * c.Radius }
+
+type Rect struct {
+	Width, Height float64
+}
+
+func (r Rect) Area() float64 { return r.Width * r.Height }
+
+// Cart is the state of a CartStore: AddItem and Clear become actions, and the tagged Coupon gets SetCoupon
+//
+//gofn:store
+type Cart struct {
+	Items  []string
+	Total  int
+	Coupon string `store:"set"`
+}
+
+func (c Cart) AddItem(name string, price int) Cart {
+	c.Items = append(slices.Clip(c.Items), name)
+	c.Total += price
+	return c
+}
+
+func (c Cart) Clear() Cart {
+	return Cart{Coupon: c.Coupon}
+}
+
+// Coord is a standalone immutable value: NewCoord, the getters and the With copies are generated
+//
+//gofn:immutable
+type Coord struct {
+	x, y int
+}
+
+// Num, Sum and Product form the Expr visitor group: ExprVisitor and ExprNode are generated with their Accept methods
+//
+//gofn:visitor Expr
+type Num struct {
+	Value int
+}
+
+//gofn:visitor Expr
+type Sum struct {
+	Left, Right ExprNode
+}
+
+//gofn:visitor Expr
+type Product struct {
+	Left, Right ExprNode
+}
+
+// evaluator is an ExprVisitor computing the value of an expression
+type evaluator struct {
+	result int
+}
+
+func (e *evaluator) eval(n ExprNode) int {
+	n.Accept(e)
+	return e.result
+}
+
+func (e *evaluator) VisitNum(n Num)         { e.result = n.Value }
+func (e *evaluator) VisitSum(s Sum)         { e.result = e.eval(s.Left) + e.eval(s.Right) }
+func (e *evaluator) VisitProduct(p Product) { e.result = e.eval(p.Left) * e.eval(p.Right) }
+
+// Accounts registers Signups; NewAccountsHandler serves Register as JSON on POST /accounts/Register
+//
+//gofn:handler prefix=/accounts
+type Accounts struct {
+	registered int
+}
+
+// Registered is the response of Register
+type Registered struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+}
+
+func (a *Accounts) Register(ctx context.Context, s Signup) (Registered, error) {
+	if s.Email == "taken@example.com" {
+		return Registered{}, &monad.StatusError{Status: http.StatusConflict, Err: errors.New("email already registered")}
+	}
+	a.registered++
+	return Registered{ID: a.registered, Email: s.Email}, nil
+}
+
+// ProvideDSN and NewRepo are providers: the generated Container resolves their values once per request
+//
+//gofn:provide
+func ProvideDSN() string {
+	return "memory://orders"
+}
+
+// Repo is a request-scoped store handle
+type Repo struct {
+	DSN       string
+	RequestID any
+}
+
+type repoRequestKey struct{}
+
+//gofn:provide
+func NewRepo(ctx context.Context, dsn string) (*Repo, error) {
+	if dsn == "" {
+		return nil, errors.New("no dsn")
+	}
+	return &Repo{DSN: dsn, RequestID: ctx.Value(repoRequestKey{})}, nil
+}
+
+// OrderHandler gets its dependencies from InjectOrderHandler
+//
+//gofn:inject
+type OrderHandler struct {
+	Repo *Repo
+	DSN  string
+	Hits int `inject:"-"`
+}
+
+// Log formats a log line; its partial forms fix the level, then the component, leaving the variadic fields open
+//
+//gofn:partial
+func Log(level string, component string, fields ...string) string {
+	return "[" + level + "] " + component + ": " + strings.Join(fields, " ")
+}
+
+// splitPair, parsePort and checkPort are chained in declaration order into ParseHostPort by their compose group
+//
+//gofn:compose group=parseHostPort
+func splitPair(s string) ([2]string, error) {
+	host, port, ok := strings.Cut(s, ":")
+	if !ok {
+		return [2]string{}, fmt.Errorf("missing port in %q", s)
+	}
+	return [2]string{host, port}, nil
+}
+
+//gofn:compose group=parseHostPort
+func parsePort(pair [2]string) (int, error) {
+	var port int
+	_, err := fmt.Sscanf(pair[1], "%d", &port)
+	return port, err
+}
+
+//gofn:compose group=parseHostPort
+func checkPort(port int) (int, error) {
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("port %d out of range", port)
+	}
+	return port, nil
+}
+
+// Thermostat exposes its settings through generated accessors; changed records every change the setters make
+//
+//gofn:accessors onChange=changed
+type Thermostat struct {
+	target  float64
+	zones   []string
+	changes []string `accessors:"get"`
+}
+
+func (t *Thermostat) changed(field string, old, new any) {
+	t.changes = append(t.changes, fmt.Sprintf("%s: %v -> %v", field, old, new))
+}
+
+// dbCredentials prints its unexported fields through the generated String, without the password or token
+//
+//gofn:stringer redact=token
+type dbCredentials struct {
+	user     string
+	password string `gofn:"secret"`
+	token    string
+	port     int
+}
+
+// trade is a record read from and written to CSV; note is optional, so an empty cell is None
+//
+//gofn:record
+//gofn:csv
+type trade struct {
+	symbol string `csv:"sym"`
+	price  float64
+	qty    int
+	note   monad.Option[string]
+}
+
+// routeKey holds a slice, so it cannot be a Go map key; the generated Equal and Hash let it key a monad.HashMap
+//
+//gofn:equal hash
+type routeKey struct {
+	method   string
+	segments []string
+	params   map[string]string
+}
+
+// playlist wraps its tracks; the generated iterators let callers range over them without reaching into the field
+//
+//gofn:iterator
+type playlist struct {
+	name   string
+	tracks []string
+}
+
 // Demo: exercise all generated helpers.
 func main() {
 	// record: exported interface + constructor + getters
@@ -86,6 +507,8 @@ func main() {
 		WithPort(8080),
 	)
 	fmt.Println("optional:", cfg.Host, cfg.Port)
+	partial := NewConfigFromOptions(monad.None[string](), monad.Some(9090))
+	fmt.Println("optional from options:", partial.Host, partial.Port)
 
 	// curried: simple, variadic, and multi-result
 	sum := AddCurried()(1)(2)
@@ -144,6 +567,14 @@ func main() {
 	okCustom, errCustom := pipeWithCustom(42).Unwrap()
 	fmt.Println("  with custom handler:", okCustom, "err:", errCustom)
 
+	// warnings: a stage reports a degraded outcome without failing, and the composer carries it along
+	f1Warn := func(x int64) monad.Result[string] {
+		return monad.WithWarning(monad.Ok(fmt.Sprint(x)), errors.New("input truncated"))
+	}
+	warned := AnyPipeComposerWithErrorHandler(f1Warn, f2Err, f3, fallbackHandler)(42)
+	okWarned, errWarned := warned.Unwrap()
+	fmt.Println("  with warnings:", okWarned, "err:", errWarned, "warnings:", warned.Warnings())
+
 	// match: pattern matching for Address
 	addr := Address{
 		Street: "123 Main St",
@@ -260,6 +691,25 @@ func main() {
 
 	counter.SetValue(25) // Should trigger both counter and string reactive
 
+	// Stream changes as server-sent events (generated by the `stream` argument)
+	server := httptest.NewServer(http.HandlerFunc(counter.StreamCounterChanges))
+	if resp, err := http.Get(server.URL); err == nil {
+		reader := bufio.NewReader(resp.Body)
+		event, _ := reader.ReadString('\n')
+		data, _ := reader.ReadString('\n')
+		fmt.Printf("  SSE %s  %s", event, data)
+		resp.Body.Close()
+	}
+	server.Close()
+
+	// Snapshot and restore the state as JSON (generated by the `persist` argument)
+	var snapshot strings.Builder
+	if err := counter.SnapshotTo(&snapshot); err == nil {
+		if restored, err := RestoreReactiveCounter(strings.NewReader(snapshot.String())).Unwrap(); err == nil {
+			fmt.Printf("  restored from snapshot: %+v\n", restored.Get())
+		}
+	}
+
 	// Demonstrate the difference between None and Wildcard
 	fmt.Println("Demonstrating None vs Wildcard:")
 
@@ -283,4 +733,267 @@ func main() {
 		Default(func(a Address) {
 			fmt.Println("  No match")
 		})
+
+	// arbitrary: random values and shrinking for property-based tests
+	rnd := rand.New(rand.NewSource(1))
+	sample := ArbitrarySample(rnd)
+	fmt.Println("arbitrary: shrink candidates:", len(ShrinkSample(sample)))
+
+	// actor: method calls serialized through a mailbox, returning Futures
+	account := NewAccountActor(&Account{})
+	for i := 0; i < 3; i++ {
+		account.Deposit(10)
+	}
+	withdrawn := account.Withdraw(100)
+	account.Drain()
+	_, withdrawErr := withdrawn.Await().Unwrap()
+	fmt.Println("actor: withdraw:", withdrawErr)
+
+	// workflow: sequential steps with compensation on failure
+	checkout := &Checkout{
+		Reserve: func(ctx context.Context) error { return nil },
+		Release: func(ctx context.Context) error { fmt.Println("workflow: released reservation"); return nil },
+		Charge:  func(ctx context.Context) error { return errors.New("card declined") },
+	}
+	_, checkoutErr := checkout.Task()(context.Background()).Unwrap()
+	var wfErr *monad.WorkflowError
+	if errors.As(checkoutErr, &wfErr) {
+		for _, step := range wfErr.Trace.Steps {
+			fmt.Printf("workflow: %s %s\n", step.Name, step.Status)
+		}
+	}
+
+	// batch: concurrent loads coalesced into a single fetch
+	loader := NewLookupUserLoader(func(ids []int) ([]string, error) {
+		fmt.Println("batch: fetching", ids)
+		return LookupUserMany(ids)
+	})
+	users, _ := loader.LoadMany([]int{1, 2, 3}).Await().Unwrap()
+	fmt.Println("batch:", users)
+
+	// file pragma: records generated for every struct in models.go
+	b := NewBook("Dune", "Frank Herbert")
+	ch := NewChapter(1, "Dune")
+	fmt.Println("file pragma:", b.Title(), "by", b.Author(), "- chapter", ch.Number())
+	_ = draft{notes: "not generated"}
+
+	// record iface-fakes: settable fake implementing the record interface
+	var fake Person = FakePerson{NameValue: "bob", AgeValue: 41}
+	fmt.Println("record fake:", fake.Name(), fake.Age())
+
+	// Option-typed fields are matched by Some/None rather than structurally
+	fmt.Println("\n=== Option Field Matching ===")
+	for _, p := range []Profile{{Name: "kim", Nickname: monad.Some("k")}, {Name: "lee"}} {
+		label := MatchProfileReturn[string](p).
+			When(monad.W[string](), monad.SomeP(monad.S("k")), func(p Profile) string { return p.Name + " goes by k" }).
+			When(monad.W[string](), monad.NoneP[string](), func(p Profile) string { return p.Name + " has no nickname" }).
+			Default("unmatched")
+		fmt.Println(" ", label)
+	}
+
+	// memoize: struct arguments keyed by their Hash, repeated calls served from the cache
+	memo := NewManhattanMemo()
+	for range 2 {
+		fmt.Println("memoize:", memo.Call(Point{1, 2}, Point{4, 6}))
+	}
+	fmt.Println("memoize: hits", memo.Metrics().Hits)
+
+	// curried result: the final application returns a Result of a tuple
+	divide17 := SafeDivModCurriedR()(17)
+	for _, d := range []int{5, 0} {
+		text := monad.Map(divide17(d), func(t monad.Tuple2[int, int]) string {
+			return fmt.Sprintf("%d r %d", t.V1, t.V2)
+		})
+		fmt.Println("curried result:", text)
+	}
+
+	// curried ctx: arguments bound up front, ctx supplied per call
+	hello := GreetCurriedCtxLast()("hello")([]string{"kim", "lee"})
+	greeting, _ := hello(context.Background())
+	fmt.Println("curried ctx last:", greeting)
+	greeting, _ = GreetCurriedCtx(context.Background())("hi")("park")
+	fmt.Println("curried ctx bound:", greeting)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, cancelErr := hello(cancelled)
+	fmt.Println("curried ctx cancelled:", cancelErr)
+
+	// pipe: a composed function and its Task variant
+	for _, raw := range []string{" 42 ", "200", "x"} {
+		age, ageErr := AgeInputPipe(raw)
+		fmt.Println("pipe:", age, ageErr)
+	}
+	fmt.Println("pipe task:", AgeInputPipeTask("7")(context.Background()))
+
+	// helper types: a Tuple5 emitted once into the package for ParseStampCurriedR
+	stamp := ParseStampCurriedR()("%d-%d-%d %d:%d")("2024-05-17 09:30")
+	fmt.Println("helper tuple:", monad.Map(stamp, func(t Tuple5[int, int, int, int, int]) int { return t.V4*60 + t.V5 }))
+
+	// match table: build once, match many values in O(1) for all-Some cases
+	zones := NewAddressTable[string]().
+		When(monad.S("1 Gangnam-daero"), monad.S("Seoul"), monad.S("06000"), func(Address) string { return "HQ" }).
+		When(monad.S("123 Main St"), monad.S("Seoul"), monad.S("12345"), func(Address) string { return "branch" }).
+		When(monad.W[string](), monad.S("Busan"), monad.W[string](), func(Address) string { return "south office" }).
+		Default(func(a Address) string { return "remote: " + a.City })
+	for _, a := range []Address{addr, {City: "Busan"}, {City: "Daegu"}} {
+		fmt.Println("match table:", zones.Match(a))
+	}
+
+	// reactive ctx: the ctx given to SetCtx reaches SubscribeCtx callbacks
+	type requestIDKey struct{}
+	traced := NewReactiveCounter(Counter{Name: "traced"})
+	seen := make(chan string, 1)
+	traced.SubscribeCtx(func(ctx context.Context, _, new Counter) {
+		seen <- fmt.Sprintf("%v -> %d", ctx.Value(requestIDKey{}), new.Value)
+	})
+	traced.SetCtx(context.WithValue(context.Background(), requestIDKey{}, "req-42"), Counter{Name: "traced", Value: 1})
+	fmt.Println("reactive ctx:", <-seen)
+
+	// schema: the JSON Schema generated from Signup's tags
+	fmt.Println("schema: email format declared:", strings.Contains(SignupJSONSchema, `"format": "email"`))
+
+	// binary: records round-trip through the generated encoding
+	prio := 2
+	shipment := Shipment{ID: 7, Carrier: "post", Weight: 1.5, Delay: -time.Minute, Parcels: []int32{3, -1}, Express: true, Priority: &prio, Label: []byte("fragile")}
+	encoded, _ := shipment.MarshalBinary()
+	var decoded Shipment
+	if err := decoded.UnmarshalBinary(encoded); err != nil {
+		panic(err)
+	}
+	fmt.Println("binary:", len(encoded), "bytes,", decoded.Carrier, decoded.Weight, decoded.Delay, decoded.Parcels, decoded.Express, *decoded.Priority, string(decoded.Label))
+
+	// memoize external: results kept in a KVStore, here the in-memory default
+	rates := NewLookupRateMemo(nil)
+	for range 2 {
+		rate, _ := rates.Call(context.Background(), "EUR", "USD")
+		fmt.Println("memoize external:", rate)
+	}
+	rateKey, _ := LookupRateMemoKey("EUR", "USD").Unwrap()
+	fmt.Println("memoize external: key", rateKey, "hits", rates.Metrics().Hits)
+
+	// match collections: slice and map fields take Contains, ElemAt, Len and KeyIs patterns
+	member := Member{Name: "lee", Roles: Roles{"dev", "oncall"}, Quotas: map[string]int{"builds": 5}}
+	duty := MatchMemberReturn[string](member).
+		When(monad.W[string](), monad.Contains("admin"), monad.AnyP[map[string]int](), func(Member) string { return "admin" }).
+		When(monad.W[string](), monad.ElemAt(1, monad.S("oncall")), monad.KeyIs("builds", monad.W[int]()), func(m Member) string { return m.Name + " is on call" }).
+		Default("off duty")
+	fmt.Println("match collections:", duty)
+
+	// builder: chainable setters, validated Build and copy-and-modify with ToBuilder
+	invoice := NewInvoiceBuilder().Number("INV-0001").Lines([]string{"support"}).Total(120).Build()
+	fmt.Println("builder:", invoice)
+	if draft, err := invoice.Unwrap(); err == nil {
+		fmt.Println("builder copy:", draft.ToBuilder().Currency("JPY").Total(0).Build())
+	}
+
+	// lens: immutable updates of nested fields through composed lenses
+	officeCity := optics.Compose(EmployeeOfficeLens, AddressCityLens)
+	kim := Employee{Name: "kim", Office: Address{Street: "1 Gangnam-daero", City: "Seoul", Zip: "06000"}}
+	moved := officeCity.Set(kim, "Busan")
+	fmt.Println("lens:", officeCity.Get(kim), "->", officeCity.Get(moved), moved.Office.Street)
+	fmt.Println("lens modify:", EmployeeNameLens.Modify(kim, strings.ToUpper).Name, kim.Name)
+
+	// enum: exhaustive matching over the variants of a sealed interface
+	for _, shape := range []Shape{Circle{Radius: 1}, &Rect{Width: 2, Height: 3}} {
+		fmt.Println("enum:", MatchShape(shape,
+			func(c Circle) string { return fmt.Sprintf("circle r=%g", c.Radius) },
+			func(r Rect) string { return fmt.Sprintf("rect %gx%g", r.Width, r.Height) },
+		), shape.Area())
+	}
+
+	// store: actions reduce the state, middleware sees each action, and selectors notify on change
+	var actions []string
+	logActions := func(_ *monad.Store[Cart, CartAction], next func(CartAction)) func(CartAction) {
+		return func(a CartAction) {
+			actions = append(actions, fmt.Sprintf("%T", a))
+			next(a)
+		}
+	}
+	history := monad.NewHistory[Cart, CartAction](100)
+	cart := NewCartStore(Cart{}, logActions, history.Middleware())
+	total := monad.Select(cart.Store, func(c Cart) int { return c.Total })
+	cart.AddItem("book", 12)
+	cart.AddItem("pen", 3)
+	cart.SetCoupon("SPRING")
+	time.Sleep(10 * time.Millisecond)
+	fmt.Println("store:", cart.State(), "total", total.Get())
+	cart.Clear()
+	fmt.Println("store actions:", cart.State(), actions)
+	if back, err := history.ReplayTo(2).Unwrap(); err == nil {
+		fmt.Println("store time travel:", back, "of", len(history.Entries()), "actions")
+	}
+
+	// immutable: With methods return changed copies, through the record interface or on their own
+	older := p.WithAge(31)
+	fmt.Println("immutable:", p.Name(), p.Age(), "->", older.Name(), older.Age())
+	origin := NewCoord(0, 0)
+	moved2 := origin.WithX(3).WithY(4)
+	fmt.Println("immutable coord:", origin.X(), origin.Y(), "->", moved2.X(), moved2.Y())
+
+	// visitor: double dispatch over the structs of a group
+	expr := Sum{Left: Num{Value: 2}, Right: Product{Left: Num{Value: 3}, Right: Num{Value: 4}}}
+	fmt.Println("visitor: 2 + 3*4 =", (&evaluator{}).eval(expr))
+
+	// handler: JSON endpoints over service methods, with validation, middleware and error mapping
+	accounts := NewAccountsHandler(&Accounts{}, monad.HandlerOptions{
+		Middleware: []monad.TaskMiddleware{func(t monad.Task[any]) monad.Task[any] { return monad.WithTimeout(t, time.Second) }},
+	})
+	for _, body := range []string{
+		`{"email":"kim@example.com","name":"kim","plan":"pro"}`,
+		`{"email":"kim@example.com","name":"","plan":"gold"}`,
+		`{"email":"taken@example.com","name":"lee","plan":"free"}`,
+	} {
+		rec := httptest.NewRecorder()
+		accounts.ServeHTTP(rec, httptest.NewRequest("POST", "/accounts/Register", strings.NewReader(body)))
+		fmt.Println("handler:", rec.Code, strings.TrimSpace(rec.Body.String()))
+	}
+
+	// inject: a Container per request resolves each provider once
+	container := NewContainer(context.WithValue(context.Background(), repoRequestKey{}, "req-42"))
+	if orders, err := InjectOrderHandler(container); err == nil {
+		again, _ := container.Repo()
+		fmt.Println("inject:", orders.DSN, orders.Repo.RequestID, orders.Repo == again)
+	}
+
+	// partial application: fix the level, then the component as well
+	warn := LogPartial1("WARN")
+	fmt.Println("partial:", warn("db", "slow", "query"))
+	dbWarn := LogPartial2("WARN", "db")
+	fmt.Println("partial:", dbWarn("pool", "exhausted"), dbWarn())
+
+	// compose: the parseHostPort group chained in declaration order
+	port, portErr := ParseHostPort("localhost:8080")
+	fmt.Println("compose:", port, portErr)
+	_, portErr = ParseHostPort("localhost:99999")
+	fmt.Println("compose error:", portErr)
+
+	// accessors: setters call the change hook, skipping unchanged comparable values
+	thermostat := &Thermostat{target: 20}
+	thermostat.SetTarget(21.5)
+	thermostat.SetTarget(21.5)
+	thermostat.SetZones([]string{"hall", "kitchen"})
+	fmt.Println("accessors:", thermostat.Target(), thermostat.Zones(), thermostat.Changes())
+
+	// stringer: unexported fields are printed, secret ones redacted
+	fmt.Println("stringer:", dbCredentials{user: "app", password: "hunter2", token: "t0k", port: 5432})
+
+	// csv: columns are matched by header name, in any order, and an empty note is None
+	trades, tradeErr := NewTradeCSVReader(strings.NewReader("qty,sym,price,note\n10,GOFN,1.5,\n3,MONAD,20,block trade\n")).ReadAll()
+	fmt.Println("csv read:", len(trades), tradeErr, trades[1].note.UnwrapOr("-"), trades[0].note.IsSome())
+	var csvOut strings.Builder
+	fmt.Println("csv write:", NewTradeCSVWriter(&csvOut).WriteAll(trades))
+	fmt.Print(csvOut.String())
+
+	// equal: deep Equal and a matching Hash key a HashMap by route contents rather than slice identity
+	routes := monad.NewHashMap[routeKey, string]()
+	routes.Set(routeKey{method: "GET", segments: []string{"users", ":id"}}, "getUser")
+	lookup := routeKey{method: "GET", segments: []string{"users", ":id"}, params: map[string]string{}}
+	fmt.Println("equal:", lookup.Equal(routeKey{method: "GET", segments: []string{"users", ":id"}}), routes.Get(lookup).UnwrapOr("none"))
+
+	// iterator: range over the wrapped slice, lazily filtered, and copy it out
+	mix := playlist{name: "mix", tracks: []string{"Blue", "Red", "Black"}}
+	for track := range mix.Filter(func(t string) bool { return strings.HasPrefix(t, "B") }) {
+		fmt.Println("iterator:", mix.name, track)
+	}
+	fmt.Println("iterator collect:", mix.Collect(), slices.Collect(mix.All()))
 }
@@ -0,0 +1,22 @@
+//gofn:file record
+
+package main
+
+// Every struct in this file becomes a record through the file-level pragma above.
+
+type book struct {
+	title  string
+	author string
+}
+
+type chapter struct {
+	number int
+	title  string
+}
+
+// draft opts out of the file pragma.
+//
+//gofn:skip
+type draft struct {
+	notes string
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateAccessorsCode generates a getter and a `Set<Field>` setter on *s for every unexported field; fields
+// tagged `accessors:"get"` only get the getter, and those tagged `accessors:"-"` are skipped
+// With `onChange=<method>`, setters call s's method `(field string, old, new any)` after changing a field;
+// comparable fields skip the call, and the assignment, when the value is unchanged
+func generateAccessorsCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, naming Naming, args directiveArgs) error {
+	hook := args.get("onChange", "")
+	if hook != "" {
+		if err := checkChangeHook(s.Name, hook, types.funcs); err != nil {
+			return err
+		}
+	}
+
+	var fields []parser.FieldInfo
+	for _, f := range s.Fields {
+		if isPrivateIdent(f.Name) && reflect.StructTag(f.Tag).Get("accessors") != "-" {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("accessors needs an unexported field; exported fields are accessed directly")
+	}
+
+	declared := declaredMethods(types.funcs, s.Name)
+	recv := strings.ToLower(string(s.Name[0]))
+	for i, f := range fields {
+		getter, setter := naming.getter(f.Name), "Set"+exportName(f.Name)
+		readOnly := reflect.StructTag(f.Tag).Get("accessors") == "get"
+		methods := []string{getter}
+		if !readOnly {
+			methods = append(methods, setter)
+		}
+		for _, name := range methods {
+			if slices.Contains(declared, name) {
+				return fmt.Errorf("field %s: %s already declares %s", f.Name, s.Name, name)
+			}
+		}
+		param := fieldParamName(f.Name, i)
+		if param == recv || param == "previous" {
+			param = "value"
+		}
+
+		buf.WriteString(fmt.Sprintf("// %s returns %s\n", getter, f.Name))
+		buf.WriteString(fmt.Sprintf("func (%s *%s) %s() %s {\n\treturn %s.%s\n}\n\n", recv, s.Name, getter, f.Type, recv, f.Name))
+
+		field := recv + "." + f.Name
+		switch {
+		case readOnly:
+		case hook == "":
+			buf.WriteString(fmt.Sprintf("// %s sets %s\n", setter, f.Name))
+			buf.WriteString(fmt.Sprintf("func (%s *%s) %s(%s %s) {\n\t%s = %s\n}\n\n", recv, s.Name, setter, param, f.Type, field, param))
+		case types.isComparable(f.Type):
+			buf.WriteString(fmt.Sprintf("// %s sets %s, calling %s when the value changes\n", setter, f.Name, hook))
+			buf.WriteString(fmt.Sprintf("func (%s *%s) %s(%s %s) {\n", recv, s.Name, setter, param, f.Type))
+			buf.WriteString(fmt.Sprintf("\tprevious := %s\n\tif previous == %s {\n\t\treturn\n\t}\n", field, param))
+			buf.WriteString(fmt.Sprintf("\t%s = %s\n\t%s.%s(%q, previous, %s)\n}\n\n", field, param, recv, hook, f.Name, param))
+		default:
+			buf.WriteString(fmt.Sprintf("// %s sets %s and calls %s; %s values cannot be compared, so every call counts as a change\n", setter, f.Name, hook, f.Type))
+			buf.WriteString(fmt.Sprintf("func (%s *%s) %s(%s %s) {\n", recv, s.Name, setter, param, f.Type))
+			buf.WriteString(fmt.Sprintf("\tprevious := %s\n", field))
+			buf.WriteString(fmt.Sprintf("\t%s = %s\n\t%s.%s(%q, previous, %s)\n}\n\n", field, param, recv, hook, f.Name, param))
+		}
+	}
+	return nil
+}
+
+// checkChangeHook checks that the struct name declares the method hook as `(field string, old, new any)`
+func checkChangeHook(name, hook string, funcs []parser.FuncInfo) error {
+	for _, f := range funcs {
+		if strings.TrimPrefix(f.Receiver, "*") != name || f.Name != hook {
+			continue
+		}
+		isAny := func(t string) bool { return t == "any" || t == "interface{}" }
+		if len(f.Params) != 3 || f.Params[0].Type != "string" || !isAny(f.Params[1].Type) || !isAny(f.Params[2].Type) || len(f.Results) != 0 {
+			return fmt.Errorf("onChange method %s must have the signature (field string, old, new any)", hook)
+		}
+		return nil
+	}
+	return fmt.Errorf("onChange method %s is not declared on %s", hook, name)
+}
@@ -0,0 +1,93 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// actorLifecycle names the methods generated on every actor; source methods with these names are skipped
+var actorLifecycle = map[string]bool{"Stop": true, "Drain": true, "Stopped": true}
+
+// generateActorCode generates a mailbox-based actor facade for a struct and its methods
+// Every method call is queued on a monad.Mailbox and returns a *monad.Future of its outcome
+func generateActorCode(buf *bytes.Buffer, s parser.StructInfo, methods []parser.FuncInfo, naming Naming) error {
+	structName := s.Name
+	actorName := exportName(structName) + "Actor"
+
+	buf.WriteString("import \"github.com/snowmerak/gofn/monad\"\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s serializes every call to a *%s through a single mailbox\n", actorName, structName))
+	buf.WriteString("// Methods return Futures immediately and never run concurrently with each other\n")
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", actorName))
+	buf.WriteString(fmt.Sprintf("\tstate   *%s\n", structName))
+	buf.WriteString("\tmailbox *monad.Mailbox\n")
+	buf.WriteString("}\n\n")
+
+	ctorName := naming.constructor(actorName)
+	buf.WriteString(fmt.Sprintf("// %s creates an actor owning state; state must not be used directly afterwards\n", ctorName))
+	buf.WriteString(fmt.Sprintf("func %s(state *%s) *%s {\n", ctorName, structName, actorName))
+	buf.WriteString(fmt.Sprintf("\treturn &%s{state: state, mailbox: monad.NewMailbox()}\n", actorName))
+	buf.WriteString("}\n\n")
+
+	for _, m := range methods {
+		if actorLifecycle[m.Name] {
+			buf.WriteString(fmt.Sprintf("// %s.%s is not exposed: the name is reserved for the actor lifecycle\n\n", structName, m.Name))
+			continue
+		}
+		if err := writeActorMethod(buf, structName, actorName, m); err != nil {
+			return err
+		}
+	}
+
+	buf.WriteString("// Stop rejects new calls and fails the queued ones with monad.ErrMailboxStopped\n")
+	buf.WriteString(fmt.Sprintf("func (a *%s) Stop() {\n", actorName))
+	buf.WriteString("\ta.mailbox.Stop()\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Drain rejects new calls and blocks until the queued ones have been processed\n")
+	buf.WriteString(fmt.Sprintf("func (a *%s) Drain() {\n", actorName))
+	buf.WriteString("\ta.mailbox.Drain()\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Stopped returns a channel closed once the actor is stopped or draining\n")
+	buf.WriteString(fmt.Sprintf("func (a *%s) Stopped() <-chan struct{} {\n", actorName))
+	buf.WriteString("\treturn a.mailbox.Stopped()\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// writeActorMethod writes the async variant of one method
+// Supported result shapes are (), (T), (error) and (T, error); a lone error becomes a Future[monad.Unit]
+func writeActorMethod(buf *bytes.Buffer, structName, actorName string, m parser.FuncInfo) error {
+	call := fmt.Sprintf("a.state.%s(%s)", m.Name, callArgs(m.Params))
+
+	var valueType, body string
+	switch {
+	case len(m.Results) == 0:
+		valueType = "monad.Unit"
+		body = fmt.Sprintf("\t\t%s\n\t\treturn monad.OkUnit()\n", call)
+	case len(m.Results) == 1 && m.Results[0].Type == "error":
+		valueType = "monad.Unit"
+		body = fmt.Sprintf("\t\treturn monad.UnitFrom(%s)\n", call)
+	case len(m.Results) == 1:
+		valueType = m.Results[0].Type
+		body = fmt.Sprintf("\t\treturn monad.Ok(%s)\n", call)
+	case len(m.Results) == 2 && m.Results[1].Type == "error":
+		valueType = m.Results[0].Type
+		body = fmt.Sprintf("\t\tv, err := %s\n\t\tif err != nil {\n\t\t\treturn monad.Err[%s](err)\n\t\t}\n\t\treturn monad.Ok(v)\n", call, valueType)
+	default:
+		buf.WriteString(fmt.Sprintf("// %s.%s is not exposed: only (), (T), (error) and (T, error) results are supported\n\n", structName, m.Name))
+		return nil
+	}
+
+	buf.WriteString(fmt.Sprintf("// %s queues a call to %s.%s on the actor's mailbox\n", m.Name, structName, m.Name))
+	buf.WriteString(fmt.Sprintf("func (a *%s) %s(%s) *monad.Future[%s] {\n", actorName, m.Name, paramList(m.Params), valueType))
+	buf.WriteString(fmt.Sprintf("\treturn monad.Ask(a.mailbox, func() monad.Result[%s] {\n", valueType))
+	buf.WriteString(body)
+	buf.WriteString("\t})\n")
+	buf.WriteString("}\n\n")
+	return nil
+}
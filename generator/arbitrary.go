@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateArbitraryCode generates random value generators and shrinkers for a struct
+func generateArbitraryCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex) error {
+	structName := s.Name
+	arbName := "Arbitrary" + exportName(structName)
+	shrinkName := "Shrink" + exportName(structName)
+	recv := strings.ToLower(string(structName[0]))
+
+	// the body is written first, since the imports depend on the fields it had to fill by hand
+	g := &arbitraryGen{types: types}
+	for _, field := range s.Fields {
+		if field.Name == "" || field.Name == "_" {
+			continue
+		}
+		g.value(field.Name, "v."+field.Name, field.Type, "\t", 0)
+	}
+
+	buf.WriteString("import (\n")
+	if g.errors {
+		buf.WriteString("\t\"errors\"\n")
+	}
+	buf.WriteString("\t\"math/rand\"\n")
+	buf.WriteString("\t\"reflect\"\n")
+	buf.WriteString("\t\"testing/quick\"\n")
+	if g.time {
+		buf.WriteString("\t\"time\"\n")
+	}
+	if g.monad {
+		buf.WriteString("\n\t\"github.com/snowmerak/gofn/monad\"\n")
+	}
+	buf.WriteString(")\n\n")
+
+	// Generate Arbitrary function
+	buf.WriteString(fmt.Sprintf("// %s returns a pseudo-random %s drawn from r\n", arbName, structName))
+	buf.WriteString("// Fields whose types testing/quick cannot generate are left at their zero value\n")
+	buf.WriteString(fmt.Sprintf("func %s(r *rand.Rand) %s {\n", arbName, structName))
+	buf.WriteString(fmt.Sprintf("\tvar v %s\n", structName))
+	buf.WriteString(g.body.String())
+	buf.WriteString("\treturn v\n")
+	buf.WriteString("}\n\n")
+
+	// Generate quick.Generator implementation
+	buf.WriteString(fmt.Sprintf("// Generate implements quick.Generator so %s can be used with quick.Check\n", structName))
+	buf.WriteString(fmt.Sprintf("func (%s %s) Generate(r *rand.Rand, size int) reflect.Value {\n", recv, structName))
+	buf.WriteString(fmt.Sprintf("\treturn reflect.ValueOf(%s(r))\n", arbName))
+	buf.WriteString("}\n\n")
+
+	// Generate Shrink function
+	buf.WriteString(fmt.Sprintf("// %s returns simpler variants of v, each moving a single field toward its zero value\n", shrinkName))
+	buf.WriteString(fmt.Sprintf("func %s(v %s) []%s {\n", shrinkName, structName, structName))
+	buf.WriteString(fmt.Sprintf("\tvar out []%s\n", structName))
+	for _, field := range s.Fields {
+		if field.Name == "" || field.Name == "_" {
+			continue
+		}
+		writeShrinkField(buf, field)
+	}
+	buf.WriteString("\treturn out\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// writeShrinkField writes the shrink candidates for a single field, based on its type
+func writeShrinkField(buf *bytes.Buffer, field parser.FieldInfo) {
+	name := field.Name
+	switch {
+	case field.Type == "bool":
+		buf.WriteString(fmt.Sprintf("\tif v.%s {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tc := v\n\t\tc.%s = false\n\t\tout = append(out, c)\n", name))
+		buf.WriteString("\t}\n")
+	case field.Type == "string":
+		buf.WriteString(fmt.Sprintf("\tif v.%s != \"\" {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tc := v\n\t\tc.%s = \"\"\n\t\tout = append(out, c)\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tif len(v.%s) > 1 {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\t\tc := v\n\t\t\tc.%s = v.%s[:len(v.%s)/2]\n\t\t\tout = append(out, c)\n", name, name, name))
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t}\n")
+	case isNumericType(field.Type):
+		buf.WriteString(fmt.Sprintf("\tif v.%s != 0 {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tc := v\n\t\tc.%s = 0\n\t\tout = append(out, c)\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tif v.%s/2 != 0 {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\t\tc := v\n\t\t\tc.%s = v.%s / 2\n\t\t\tout = append(out, c)\n", name, name))
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t}\n")
+	case strings.HasPrefix(field.Type, "[]"):
+		buf.WriteString(fmt.Sprintf("\tif len(v.%s) > 0 {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tc := v\n\t\tc.%s = nil\n\t\tout = append(out, c)\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tif len(v.%s) > 1 {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\t\tc := v\n\t\t\tc.%s = v.%s[:len(v.%s)/2]\n\t\t\tout = append(out, c)\n", name, name, name))
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t}\n")
+	case strings.HasPrefix(field.Type, "*") || strings.HasPrefix(field.Type, "map["):
+		buf.WriteString(fmt.Sprintf("\tif v.%s != nil {\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tc := v\n\t\tc.%s = nil\n\t\tout = append(out, c)\n", name))
+		buf.WriteString("\t}\n")
+	}
+}
+
+// arbitraryGen writes the statements of an Arbitrary function filling its fields
+// testing/quick panics setting a struct with unexported fields, such as time.Time or monad.Option, so those are
+// filled by hand, or left at their zero value when their fields cannot be seen from the declaring package
+type arbitraryGen struct {
+	types  typeIndex
+	body   bytes.Buffer
+	errors bool // whether the statements use the errors package
+	monad  bool // whether the statements use the monad package
+	time   bool // whether the statements use the time package
+}
+
+// value writes the statements setting target, of type t, to a random value drawn from r
+// depth numbers the variables of nested statements so they do not shadow each other
+func (g *arbitraryGen) value(field, target, t, indent string, depth int) {
+	t = strings.TrimSpace(t)
+	w := func(format string, a ...any) { g.body.WriteString(indent + fmt.Sprintf(format, a...) + "\n") }
+	e, i := fmt.Sprintf("e%d", depth), fmt.Sprintf("i%d", depth)
+	switch {
+	case g.quickSafe(t, map[string]bool{}):
+		w("if x, ok := quick.Value(reflect.TypeOf(&%s).Elem(), r); ok {", target)
+		w("\treflect.ValueOf(&%s).Elem().Set(x)", target)
+		w("}")
+	case t == "time.Time":
+		g.time = true
+		w("%s = time.Unix(r.Int63n(1<<32), 0)", target)
+	case strings.HasPrefix(t, "monad.Option[") && strings.HasSuffix(t, "]"):
+		elem := strings.TrimSuffix(strings.TrimPrefix(t, "monad.Option["), "]")
+		w("if r.Intn(2) == 0 {")
+		w("\tvar %s %s", e, elem)
+		g.value(field, e, elem, indent+"\t", depth+1)
+		g.monad = true
+		w("\t%s = monad.Some(%s)", target, e)
+		w("}")
+	case strings.HasPrefix(t, "monad.Result[") && strings.HasSuffix(t, "]"):
+		elem := strings.TrimSuffix(strings.TrimPrefix(t, "monad.Result["), "]")
+		g.errors, g.monad = true, true
+		w("if r.Intn(2) == 0 {")
+		w("\tvar %s %s", e, elem)
+		g.value(field, e, elem, indent+"\t", depth+1)
+		w("\t%s = monad.Ok(%s)", target, e)
+		w("} else {")
+		w("\t%s = monad.Err[%s](errors.New(\"arbitrary %s error\"))", target, elem, field)
+		w("}")
+	case strings.HasPrefix(t, "*"):
+		elem := t[1:]
+		w("if r.Intn(2) == 0 {")
+		w("\tvar %s %s", e, elem)
+		g.value(field, e, elem, indent+"\t", depth+1)
+		w("\t%s = &%s", target, e)
+		w("}")
+	case strings.HasPrefix(t, "[]"):
+		w("%s = make(%s, r.Intn(8))", target, t)
+		w("for %s := range %s {", i, target)
+		g.value(field, target+"["+i+"]", t[2:], indent+"\t", depth+1)
+		w("}")
+	case strings.HasPrefix(t, "map["):
+		key, elem := splitMapType(t)
+		k := fmt.Sprintf("k%d", depth)
+		w("%s = make(%s)", target, t)
+		w("for range r.Intn(8) {")
+		w("\tvar %s %s", k, key)
+		g.value(field, k, key, indent+"\t", depth+1)
+		w("\tvar %s %s", e, elem)
+		g.value(field, e, elem, indent+"\t", depth+1)
+		w("\t%s[%s] = %s", target, k, e)
+		w("}")
+	case strings.HasPrefix(t, "["):
+		w("for %s := range %s {", i, target)
+		g.value(field, target+"["+i+"]", t[strings.Index(t, "]")+1:], indent+"\t", depth+1)
+		w("}")
+	default:
+		w("// %s is left at its zero value: testing/quick cannot fill %s", field, t)
+	}
+}
+
+// quickSafe reports whether testing/quick.Value can be given type t without panicking
+// It panics setting unexported fields, so a struct qualifies when it implements quick.Generator or every field
+// is exported and qualifies; types from other packages cannot be inspected and only a few known ones qualify
+func (g *arbitraryGen) quickSafe(t string, seen map[string]bool) bool {
+	t = strings.TrimSpace(t)
+	switch {
+	case t == "bool", t == "string", t == "complex64", t == "complex128", isNumericType(t):
+		return true
+	case t == "any", strings.HasPrefix(t, "interface"), strings.HasPrefix(t, "func"), strings.HasPrefix(t, "chan"),
+		strings.HasPrefix(t, "<-chan"):
+		return true // quick.Value reports these as unsupported rather than panicking
+	case t == "time.Duration", t == "time.Month", t == "time.Weekday":
+		return true
+	case strings.HasPrefix(t, "*"):
+		return g.quickSafe(t[1:], seen)
+	case strings.HasPrefix(t, "[]"):
+		return g.quickSafe(t[2:], seen)
+	case strings.HasPrefix(t, "map["):
+		key, elem := splitMapType(t)
+		return g.quickSafe(key, seen) && g.quickSafe(elem, seen)
+	case strings.HasPrefix(t, "["):
+		return g.quickSafe(t[strings.Index(t, "]")+1:], seen)
+	}
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+	if nt, ok := g.types.types[t]; ok {
+		return nt.Kind != parser.KindStruct && g.quickSafe(nt.Underlying, seen)
+	}
+	s, ok := g.types.structs[t]
+	if !ok {
+		return false
+	}
+	if g.types.hasDirective(t, "arbitrary") || slices.Contains(declaredMethods(g.types.funcs, t), "Generate") {
+		return true
+	}
+	for _, f := range s.Fields {
+		name := f.Name
+		if name == "" {
+			name = strings.TrimPrefix(f.Type, "*")
+		}
+		if isPrivateIdent(name) || name == "_" || !g.quickSafe(f.Type, seen) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArbitraryFillsFieldsWithUnexportedFields(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"event.go": `package fixture
+
+import (
+	"time"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+type hidden struct{ n int }
+
+//gofn:arbitrary
+type Event struct {
+	ID     int
+	At     time.Time
+	Note   monad.Option[string]
+	Due    monad.Option[time.Time]
+	Status monad.Result[int]
+	Stamps []time.Time
+	ByName map[string]time.Time
+	Prev   *time.Time
+	Inner  hidden
+	Wait   time.Duration
+}
+`,
+		"event_test.go": `package fixture
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestArbitraryEvent(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	set := false
+	for range 100 {
+		set = set || !ArbitraryEvent(r).At.IsZero()
+	}
+	if !set {
+		t.Error("At was never filled")
+	}
+	if err := quick.Check(func(Event) bool { return true }, nil); err != nil {
+		t.Error(err)
+	}
+}
+`,
+	})
+	if err := generateDir(t, dir, Options{}); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, "Event_arbitrary_gen.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"v.At = time.Unix(", "v.Note = monad.Some(e0)", "// Inner is left at its zero value"} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("Generated code lacks %q:\n%s", want, src)
+		}
+	}
+	for _, field := range []string{"At", "Note", "Due", "Status", "Stamps", "ByName", "Prev", "Inner"} {
+		if strings.Contains(string(src), "quick.Value(reflect.TypeOf(&v."+field+")") {
+			t.Errorf("%s must not be filled by quick.Value, which panics on unexported fields", field)
+		}
+	}
+	goTest(t, dir)
+}
@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateBatchCode generates a DataLoader-style batching loader for a single-key function
+// The function must look like `Get(key K) (V, error)`; `size` and `window` arguments tune the batches
+func generateBatchCode(buf *bytes.Buffer, f parser.FuncInfo, args directiveArgs, naming Naming) error {
+	if f.Receiver != "" {
+		return fmt.Errorf("batch is only supported on functions, not methods")
+	}
+	if len(f.Params) != 1 || len(f.Results) != 2 || f.Results[1].Type != "error" {
+		return fmt.Errorf("batch requires a signature of the form func(key K) (V, error)")
+	}
+
+	size, err := strconv.Atoi(args.get("size", "100"))
+	if err != nil || size < 1 {
+		return fmt.Errorf("invalid batch size %q", args.get("size", ""))
+	}
+	window, err := time.ParseDuration(args.get("window", "5ms"))
+	if err != nil || window <= 0 {
+		return fmt.Errorf("invalid batch window %q", args.get("window", ""))
+	}
+
+	keyType := f.Params[0].Type
+	valueType := f.Results[0].Type
+	base := exportName(f.Name)
+	loaderName := base + "Loader"
+	manyName := base + "Many"
+
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"time\"\n\n")
+	buf.WriteString("\t\"github.com/snowmerak/gofn/monad\"\n")
+	buf.WriteString(")\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s coalesces concurrent %s calls into batches of up to %d keys, dispatched %s after the first key\n", loaderName, f.Name, size, window))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", loaderName))
+	buf.WriteString(fmt.Sprintf("\tbatcher *monad.Batcher[%s, %s]\n", keyType, valueType))
+	buf.WriteString("}\n\n")
+
+	ctorName := naming.constructor(loaderName)
+	buf.WriteString(fmt.Sprintf("// %s creates a loader calling fetch with each batch\n", ctorName))
+	buf.WriteString("// fetch must return one value per key, in key order; pass " + manyName + " when the backend has no batch API\n")
+	buf.WriteString(fmt.Sprintf("func %s(fetch func(keys []%s) ([]%s, error)) *%s {\n", ctorName, keyType, valueType, loaderName))
+	buf.WriteString(fmt.Sprintf("\treturn &%s{batcher: monad.NewBatcher(%d, %s, fetch)}\n", loaderName, size, durationLiteral(window)))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// Load queues key for the next batch and returns a Future of its %s\n", valueType))
+	buf.WriteString(fmt.Sprintf("func (l *%s) Load(key %s) *monad.Future[%s] {\n", loaderName, keyType, valueType))
+	buf.WriteString("\treturn l.batcher.Load(key)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// LoadMany queues every key and returns a Future of the values in key order\n")
+	buf.WriteString(fmt.Sprintf("func (l *%s) LoadMany(keys []%s) *monad.Future[[]%s] {\n", loaderName, keyType, valueType))
+	buf.WriteString("\treturn l.batcher.LoadMany(keys)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Flush dispatches the pending batch immediately\n")
+	buf.WriteString(fmt.Sprintf("func (l *%s) Flush() {\n", loaderName))
+	buf.WriteString("\tl.batcher.Flush()\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s calls %s for each key in turn, stopping at the first error\n", manyName, f.Name))
+	buf.WriteString(fmt.Sprintf("func %s(keys []%s) ([]%s, error) {\n", manyName, keyType, valueType))
+	buf.WriteString(fmt.Sprintf("\tvalues := make([]%s, len(keys))\n", valueType))
+	buf.WriteString("\tfor i, key := range keys {\n")
+	buf.WriteString(fmt.Sprintf("\t\tv, err := %s(key)\n", f.Name))
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\treturn nil, err\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tvalues[i] = v\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn values, nil\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// durationLiteral renders d as Go source using the largest unit that divides it evenly, e.g. 5 * time.Millisecond
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+		{time.Microsecond, "time.Microsecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
@@ -0,0 +1,332 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// Wire types of the //gofn:binary encoding, stored in the low 3 bits of each field key like protobuf
+const (
+	wireVarint  = 0 // bools and integers; signed integers are zigzag encoded
+	wireFixed64 = 1 // floats as little-endian IEEE 754 bits
+	wireBytes   = 2 // strings, byte slices and nested encodings, prefixed with their length
+)
+
+// maxBinaryFieldID keeps field keys within 32 bits
+const maxBinaryFieldID = 1<<28 - 1
+
+// binaryField is a field of a //gofn:binary struct with its field ID and element codec
+type binaryField struct {
+	name  string
+	elem  string // type of the encoded values: the field type without the pointer or slice
+	id    int
+	codec binaryCodec
+	ptr   bool // *T: encoded when non-nil
+	slice bool // []T: one entry per element, all with the field's ID
+}
+
+// binaryCodec encodes and decodes one value of a field type
+type binaryCodec struct {
+	wire int
+	// encode returns statements appending the payload of expr to b
+	encode func(expr string) string
+	// decode returns statements leaving the decoded value in x; they read num for varint and
+	// fixed64 fields and raw for length-prefixed ones
+	decode func(typ string) string
+	// zero is the condition under which a plain field is omitted; empty means always encoded
+	zero func(expr string) string
+	// nonZero, when set, replaces encode for plain fields that passed the zero check
+	nonZero string
+}
+
+// generateBinaryCode generates MarshalBinary, AppendBinary and UnmarshalBinary for a struct
+// Every field is written as a key (field ID and wire type) followed by its payload, so a decoder skips the
+// fields it does not know and a record written by a newer version of the struct still decodes
+// Field IDs come from `binary:"N"` tags, defaulting to the 1-based position of the field; `binary:"-"` skips a field
+func generateBinaryCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex) error {
+	fields, err := binaryFields(s, types)
+	if err != nil {
+		return err
+	}
+	name := s.Name
+	usesMath, usesTime, usesNum, usesRaw := false, false, false, false
+	for _, f := range fields {
+		usesMath = usesMath || f.codec.wire == wireFixed64
+		usesTime = usesTime || strings.HasPrefix(f.elem, "time.")
+		usesNum = usesNum || f.codec.wire != wireBytes
+		usesRaw = usesRaw || f.codec.wire == wireBytes
+	}
+
+	buf.WriteString("import (\n\t\"encoding/binary\"\n\t\"errors\"\n\t\"fmt\"\n")
+	if usesMath {
+		buf.WriteString("\t\"math\"\n")
+	}
+	if usesTime {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString(")\n\n")
+
+	buf.WriteString(fmt.Sprintf("// MarshalBinary implements encoding.BinaryMarshaler with the gofn binary encoding of %s\n", name))
+	buf.WriteString(fmt.Sprintf("func (v %s) MarshalBinary() ([]byte, error) {\n", name))
+	buf.WriteString("\treturn v.AppendBinary(nil)\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// AppendBinary implements encoding.BinaryAppender, appending the encoding of v to b\n")
+	buf.WriteString("// Zero values and nil pointers are left out; UnmarshalBinary restores them as zero\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) AppendBinary(b []byte) ([]byte, error) {\n", name))
+	buf.WriteString("\tb = append(b, 1) // encoding format\n")
+	for _, f := range fields {
+		key := fmt.Sprintf("\tb = binary.AppendUvarint(b, %d<<3|%d)\n", f.id, f.codec.wire)
+		field := "v." + f.name
+		switch {
+		case f.slice:
+			buf.WriteString(fmt.Sprintf("\tfor _, e := range %s {\n", field))
+			buf.WriteString(indent(key + f.codec.encode("e")))
+			buf.WriteString("\t}\n")
+		case f.ptr:
+			buf.WriteString(fmt.Sprintf("\tif %s != nil {\n", field))
+			buf.WriteString(indent(key + f.codec.encode("(*"+field+")")))
+			buf.WriteString("\t}\n")
+		case f.codec.zero != nil:
+			encode := f.codec.encode(field)
+			if f.codec.nonZero != "" {
+				encode = f.codec.nonZero
+			}
+			buf.WriteString(fmt.Sprintf("\tif %s {\n", f.codec.zero(field)))
+			buf.WriteString(indent(key + encode))
+			buf.WriteString("\t}\n")
+		default:
+			buf.WriteString(key + f.codec.encode(field))
+		}
+	}
+	buf.WriteString("\treturn b, nil\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// UnmarshalBinary implements encoding.BinaryUnmarshaler, decoding an encoding written by MarshalBinary\n")
+	buf.WriteString("// Fields with an unknown ID or wire type are skipped, so encodings from newer versions of the struct decode\n")
+	buf.WriteString(fmt.Sprintf("func (v *%s) UnmarshalBinary(data []byte) error {\n", name))
+	buf.WriteString("\tif len(data) == 0 {\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn errors.New(\"%s: empty binary encoding\")\n", name))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif data[0] != 1 {\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"%s: unsupported binary format %%d\", data[0])\n", name))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tdata = data[1:]\n")
+	buf.WriteString(fmt.Sprintf("\tvar out %s\n", name))
+	buf.WriteString("\tfor len(data) > 0 {\n")
+	buf.WriteString("\t\tkey, n := binary.Uvarint(data)\n")
+	buf.WriteString("\t\tif n <= 0 {\n")
+	buf.WriteString(fmt.Sprintf("\t\t\treturn errors.New(\"%s: malformed field key\")\n", name))
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tdata = data[n:]\n")
+	if usesNum {
+		buf.WriteString("\t\tvar num uint64\n")
+	}
+	if usesRaw {
+		buf.WriteString("\t\tvar raw []byte\n")
+	}
+	num := "_"
+	if usesNum {
+		num = "num"
+	}
+	buf.WriteString("\t\tswitch key & 7 {\n")
+	buf.WriteString(fmt.Sprintf("\t\tcase %d:\n", wireVarint))
+	buf.WriteString(fmt.Sprintf("\t\t\t%s, n = binary.Uvarint(data)\n", num))
+	buf.WriteString("\t\t\tif n <= 0 {\n")
+	buf.WriteString(fmt.Sprintf("\t\t\t\treturn fmt.Errorf(\"%s: malformed varint in field %%d\", key>>3)\n", name))
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\t\tdata = data[n:]\n")
+	buf.WriteString(fmt.Sprintf("\t\tcase %d:\n", wireFixed64))
+	buf.WriteString("\t\t\tif len(data) < 8 {\n")
+	buf.WriteString(fmt.Sprintf("\t\t\t\treturn fmt.Errorf(\"%s: truncated field %%d\", key>>3)\n", name))
+	buf.WriteString("\t\t\t}\n")
+	if usesNum {
+		buf.WriteString("\t\t\tnum = binary.LittleEndian.Uint64(data)\n")
+	}
+	buf.WriteString("\t\t\tdata = data[8:]\n")
+	buf.WriteString(fmt.Sprintf("\t\tcase %d:\n", wireBytes))
+	buf.WriteString("\t\t\tsize, n := binary.Uvarint(data)\n")
+	buf.WriteString("\t\t\tif n <= 0 || size > uint64(len(data)-n) {\n")
+	buf.WriteString(fmt.Sprintf("\t\t\t\treturn fmt.Errorf(\"%s: truncated field %%d\", key>>3)\n", name))
+	buf.WriteString("\t\t\t}\n")
+	if usesRaw {
+		buf.WriteString("\t\t\traw = data[n : n+int(size)]\n")
+	}
+	buf.WriteString("\t\t\tdata = data[n+int(size):]\n")
+	buf.WriteString("\t\tdefault:\n")
+	buf.WriteString(fmt.Sprintf("\t\t\treturn fmt.Errorf(\"%s: unknown wire type %%d in field %%d\", key&7, key>>3)\n", name))
+	buf.WriteString("\t\t}\n")
+
+	if len(fields) > 0 {
+		buf.WriteString("\t\tswitch key {\n")
+		for _, f := range fields {
+			buf.WriteString(fmt.Sprintf("\t\tcase %d<<3 | %d:\n", f.id, f.codec.wire))
+			buf.WriteString(indent(indent(f.codec.decode(f.elem))))
+			switch {
+			case f.slice:
+				buf.WriteString(fmt.Sprintf("\t\t\tout.%s = append(out.%s, x)\n", f.name, f.name))
+			case f.ptr:
+				buf.WriteString(fmt.Sprintf("\t\t\tout.%s = &x\n", f.name))
+			default:
+				buf.WriteString(fmt.Sprintf("\t\t\tout.%s = x\n", f.name))
+			}
+		}
+		buf.WriteString("\t\t}\n")
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("\t*v = out\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+// binaryFields resolves the encoded fields of s with their IDs, in declaration order
+func binaryFields(s parser.StructInfo, types typeIndex) ([]binaryField, error) {
+	var fields []binaryField
+	seen := map[int]string{}
+	for i, f := range s.Fields {
+		tag, tagged := reflect.StructTag(f.Tag).Lookup("binary")
+		if tag == "-" || f.Name == "_" {
+			continue
+		}
+		if f.Name == "" {
+			return nil, fmt.Errorf("embedded field %s is not supported; tag it binary:\"-\"", f.Type)
+		}
+
+		id := i + 1
+		if tagged {
+			n, err := strconv.Atoi(tag)
+			if err != nil || n < 1 || n > maxBinaryFieldID {
+				return nil, fmt.Errorf("field %s: binary tag %q is not a field ID between 1 and %d", f.Name, tag, maxBinaryFieldID)
+			}
+			id = n
+		}
+		if other, ok := seen[id]; ok {
+			return nil, fmt.Errorf("fields %s and %s share binary field ID %d", other, f.Name, id)
+		}
+		seen[id] = f.Name
+
+		field := binaryField{name: f.Name, id: id}
+		elem := f.Type
+		if e, ok := strings.CutPrefix(elem, "*"); ok {
+			field.ptr, elem = true, e
+		} else if e, ok := strings.CutPrefix(elem, "[]"); ok && elem != "[]byte" && elem != "[]uint8" {
+			field.slice, elem = true, e
+		}
+		codec, ok := binaryCodecFor(elem, types)
+		if !ok {
+			return nil, fmt.Errorf("field %s: type %s has no binary encoding; tag it binary:\"-\"", f.Name, f.Type)
+		}
+		field.codec, field.elem = codec, elem
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// binaryCodecFor returns the codec of a field element type
+// Named types of the package encode as their underlying type; time.Time and structs carrying
+// //gofn:binary nest their own encoding
+func binaryCodecFor(t string, types typeIndex) (binaryCodec, bool) {
+	switch t {
+	case "bool":
+		return binaryCodec{
+			wire: wireVarint,
+			encode: func(x string) string {
+				return fmt.Sprintf("\tif %s {\n\t\tb = append(b, 1)\n\t} else {\n\t\tb = append(b, 0)\n\t}\n", x)
+			},
+			decode:  func(typ string) string { return fmt.Sprintf("\tx := %s(num != 0)\n", typ) },
+			zero:    func(x string) string { return x },
+			nonZero: "\tb = append(b, 1)\n",
+		}, true
+	case "int", "int8", "int16", "int32", "int64", "rune", "time.Duration":
+		return binaryCodec{
+			wire:   wireVarint,
+			encode: func(x string) string { return fmt.Sprintf("\tb = binary.AppendVarint(b, int64(%s))\n", x) },
+			decode: func(typ string) string { return fmt.Sprintf("\tx := %s(int64(num>>1) ^ -int64(num&1))\n", typ) },
+			zero:   func(x string) string { return x + " != 0" },
+		}, true
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return binaryCodec{
+			wire:   wireVarint,
+			encode: func(x string) string { return fmt.Sprintf("\tb = binary.AppendUvarint(b, uint64(%s))\n", x) },
+			decode: func(typ string) string { return fmt.Sprintf("\tx := %s(num)\n", typ) },
+			zero:   func(x string) string { return x + " != 0" },
+		}, true
+	case "float32", "float64":
+		return binaryCodec{
+			wire: wireFixed64,
+			encode: func(x string) string {
+				return fmt.Sprintf("\tb = binary.LittleEndian.AppendUint64(b, math.Float64bits(float64(%s)))\n", x)
+			},
+			decode: func(typ string) string { return fmt.Sprintf("\tx := %s(math.Float64frombits(num))\n", typ) },
+			zero:   func(x string) string { return x + " != 0" },
+		}, true
+	case "string", "[]byte", "[]uint8":
+		decode := func(typ string) string { return fmt.Sprintf("\tx := %s(raw)\n", typ) }
+		zero := func(x string) string { return x + ` != ""` }
+		if t != "string" {
+			decode = func(typ string) string { return fmt.Sprintf("\tx := %s(append([]byte(nil), raw...))\n", typ) }
+			zero = func(x string) string { return "len(" + x + ") > 0" }
+		}
+		return binaryCodec{
+			wire: wireBytes,
+			encode: func(x string) string {
+				return fmt.Sprintf("\tb = binary.AppendUvarint(b, uint64(len(%s)))\n\tb = append(b, %s...)\n", x, x)
+			},
+			decode: decode,
+			zero:   zero,
+		}, true
+	}
+
+	if _, ok := types.structs[t]; ok {
+		if !types.hasDirective(t, "binary") {
+			return binaryCodec{}, false
+		}
+		return nestedBinaryCodec(), true
+	}
+	if t == "time.Time" {
+		return nestedBinaryCodec(), true
+	}
+	if nt, ok := types.types[t]; ok {
+		return binaryCodecFor(nt.Underlying, types)
+	}
+	return binaryCodec{}, false
+}
+
+// nestedBinaryCodec encodes a value through its own MarshalBinary, as a length-prefixed payload
+func nestedBinaryCodec() binaryCodec {
+	return binaryCodec{
+		wire: wireBytes,
+		encode: func(x string) string {
+			var b strings.Builder
+			b.WriteString(fmt.Sprintf("\tif enc, err := %s.MarshalBinary(); err != nil {\n", x))
+			b.WriteString("\t\treturn nil, err\n")
+			b.WriteString("\t} else {\n")
+			b.WriteString("\t\tb = binary.AppendUvarint(b, uint64(len(enc)))\n")
+			b.WriteString("\t\tb = append(b, enc...)\n")
+			b.WriteString("\t}\n")
+			return b.String()
+		},
+		decode: func(typ string) string {
+			return fmt.Sprintf("\tvar x %s\n\tif err := x.UnmarshalBinary(raw); err != nil {\n\t\treturn err\n\t}\n", typ)
+		},
+	}
+}
+
+// indent indents every line of code by one tab
+func indent(code string) string {
+	lines := strings.SplitAfter(code, "\n")
+	var b strings.Builder
+	for _, l := range lines {
+		if l != "" && l != "\n" {
+			b.WriteString("\t")
+		}
+		b.WriteString(l)
+	}
+	return b.String()
+}
@@ -0,0 +1,241 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateBuilderCode generates `<Name>Builder` with one chainable setter per field, `<New><Name>Builder`
+// starting from the `default` tags, and `ToBuilder` for copy-and-modify
+// Build returns the struct, or a monad.Result of it when any field has a `validate` tag: the rules in
+// builderRules are checked and every failure is reported together in a monad.Errors
+func generateBuilderCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, naming Naming) error {
+	name := exportName(s.Name) + "Builder"
+	defaults := []string{}
+	var checks []string
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			continue
+		}
+		if exportName(f.Name) == "Build" {
+			return fmt.Errorf("field %s: the setter would collide with Build", f.Name)
+		}
+		tag := reflect.StructTag(f.Tag)
+		if value, ok := tag.Lookup("default"); ok {
+			lit, err := defaultLiteral(f.Type, value)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			defaults = append(defaults, fmt.Sprintf("%s: %s", f.Name, lit))
+		}
+		if rules, ok := tag.Lookup("validate"); ok {
+			check, err := validateCheck(f, rules, types)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+			checks = append(checks, check)
+		}
+	}
+	validated := len(checks) > 0
+
+	switch {
+	case strings.Join(checks, "") != "":
+		buf.WriteString("import (\n\t\"errors\"\n\n\t\"github.com/snowmerak/gofn/monad\"\n)\n\n")
+	case validated:
+		buf.WriteString("import \"github.com/snowmerak/gofn/monad\"\n\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("// %s builds a %s one field at a time; setters return the builder so calls chain\n", name, s.Name))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n\tvalue %s\n}\n\n", name, s.Name))
+
+	ctor := naming.constructor(name)
+	buf.WriteString(fmt.Sprintf("// %s returns a %s starting from the default tags of %s\n", ctor, name, s.Name))
+	buf.WriteString(fmt.Sprintf("func %s() *%s {\n\treturn &%s{value: %s{%s}}\n}\n\n", ctor, name, name, s.Name, strings.Join(defaults, ", ")))
+
+	buf.WriteString(fmt.Sprintf("// ToBuilder returns a %s starting from a copy of v, which it leaves untouched\n", name))
+	buf.WriteString("// Slices, maps and pointers are copied shallowly, so they are shared until a setter replaces them\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) ToBuilder() *%s {\n\treturn &%s{value: v}\n}\n\n", s.Name, name, name))
+
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			continue
+		}
+		setter := exportName(f.Name)
+		buf.WriteString(fmt.Sprintf("// %s sets %s\n", setter, f.Name))
+		buf.WriteString(fmt.Sprintf("func (b *%s) %s(v %s) *%s {\n\tb.value.%s = v\n\treturn b\n}\n\n", name, setter, f.Type, name, f.Name))
+	}
+
+	if !validated {
+		buf.WriteString(fmt.Sprintf("// Build returns the %s built so far; the builder can keep being used\n", s.Name))
+		buf.WriteString(fmt.Sprintf("func (b *%s) Build() %s {\n\treturn b.value\n}\n\n", name, s.Name))
+		return nil
+	}
+	buf.WriteString(fmt.Sprintf("// Build returns the %s built so far once its validate rules hold, or every failure in a monad.Errors\n", s.Name))
+	buf.WriteString(fmt.Sprintf("func (b *%s) Build() monad.Result[%s] {\n", name, s.Name))
+	buf.WriteString("\tv := b.value\n\tvar errs monad.Errors\n")
+	for _, check := range checks {
+		buf.WriteString(check)
+	}
+	buf.WriteString(fmt.Sprintf("\tif len(errs) > 0 {\n\t\treturn monad.Err[%s](errs)\n\t}\n\treturn monad.Ok(v)\n}\n\n", s.Name))
+	return nil
+}
+
+// builderRules are the go-playground style validate rules Build checks; others, such as email, are left to a
+// validator library and ignored
+var builderRules = map[string]bool{
+	"required": true, "omitempty": true, "min": true, "max": true, "len": true,
+	"gt": true, "gte": true, "lt": true, "lte": true, "oneof": true,
+}
+
+// validateCheck renders a switch checking the validate rules of f against v, appending the first failure to errs
+// Length rules measure strings, slices and maps; bounds apply to numbers, and oneof to strings and numbers
+func validateCheck(f parser.FieldInfo, rules string, types typeIndex) (string, error) {
+	kind := validateKind(f.Type, types)
+	value := "v." + f.Name
+	measured := value
+	if kind == "string" || kind == "collection" {
+		measured = "len(" + value + ")"
+	}
+
+	var conds, msgs []string
+	omitempty := false
+	fail := func(cond, msg string) {
+		conds = append(conds, cond)
+		msgs = append(msgs, strconv.Quote(f.Name+": "+msg))
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		rule, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		if !builderRules[rule] {
+			continue
+		}
+		switch rule {
+		case "omitempty":
+			omitempty = true
+			continue
+		case "required":
+			cond, ok := zeroCheck(value, kind)
+			if !ok {
+				return "", fmt.Errorf("validate rule required is not supported for type %s", f.Type)
+			}
+			fail(cond, "is required")
+			continue
+		case "oneof":
+			if kind != "string" && kind != "number" {
+				return "", fmt.Errorf("validate rule oneof is not supported for type %s", f.Type)
+			}
+			var conds []string
+			for _, option := range strings.Fields(arg) {
+				if kind == "string" {
+					option = strconv.Quote(option)
+				} else if _, err := strconv.ParseFloat(option, 64); err != nil {
+					return "", fmt.Errorf("validate rule oneof has a non-numeric value %q", option)
+				}
+				conds = append(conds, fmt.Sprintf("%s != %s", value, option))
+			}
+			fail(strings.Join(conds, " && "), "must be one of "+arg)
+			continue
+		}
+
+		if kind != "string" && kind != "collection" && kind != "number" {
+			return "", fmt.Errorf("validate rule %s is not supported for type %s", rule, f.Type)
+		}
+		unit := ""
+		switch kind {
+		case "string":
+			unit = " characters"
+		case "collection":
+			unit = " elements"
+		}
+		if _, err := strconv.ParseFloat(arg, 64); err != nil || unit != "" && strings.ContainsAny(arg, ".eE") {
+			return "", fmt.Errorf("validate rule %s needs a number, got %q", rule, arg)
+		}
+		switch rule {
+		case "min", "gte":
+			fail(fmt.Sprintf("%s < %s", measured, arg), "must be at least "+arg+unit)
+		case "max", "lte":
+			fail(fmt.Sprintf("%s > %s", measured, arg), "must be at most "+arg+unit)
+		case "gt":
+			fail(fmt.Sprintf("%s <= %s", measured, arg), "must be more than "+arg+unit)
+		case "lt":
+			fail(fmt.Sprintf("%s >= %s", measured, arg), "must be less than "+arg+unit)
+		case "len":
+			fail(fmt.Sprintf("%s != %s", measured, arg), "must be exactly "+arg+unit)
+		}
+	}
+	switch {
+	case len(conds) == 0:
+		return "", nil
+	case len(conds) == 1 && !omitempty:
+		return fmt.Sprintf("\tif %s {\n\t\terrs = append(errs, errors.New(%s))\n\t}\n", conds[0], msgs[0]), nil
+	}
+	// the first failing rule of a field is reported; omitempty skips them all for a zero value
+	var b strings.Builder
+	b.WriteString("\tswitch {\n")
+	if omitempty {
+		zero, ok := zeroCheck(value, kind)
+		if !ok {
+			return "", fmt.Errorf("validate rule omitempty is not supported for type %s", f.Type)
+		}
+		b.WriteString(fmt.Sprintf("\tcase %s:\n", zero))
+	}
+	for i, cond := range conds {
+		b.WriteString(fmt.Sprintf("\tcase %s:\n\t\terrs = append(errs, errors.New(%s))\n", cond, msgs[i]))
+	}
+	b.WriteString("\t}\n")
+	return b.String(), nil
+}
+
+// validateKind classifies a field type for validateCheck: string, number, bool, collection (slice or map),
+// nilable (pointer, interface, func or chan), option, time or other; named types are classified by
+// their underlying type
+func validateKind(t string, types typeIndex) string {
+	t = strings.TrimSpace(t)
+	switch {
+	case t == "string":
+		return "string"
+	case t == "bool":
+		return "bool"
+	case isNumericType(t) || t == "time.Duration":
+		return "number"
+	case t == "time.Time":
+		return "time"
+	case strings.HasPrefix(t, "[]"), strings.HasPrefix(t, "map["):
+		return "collection"
+	case strings.HasPrefix(t, "*"), strings.HasPrefix(t, "func"), strings.HasPrefix(t, "chan"), strings.HasPrefix(t, "<-chan"),
+		t == "any", t == "error", strings.HasPrefix(t, "interface"):
+		return "nilable"
+	}
+	if _, ok := optionElem(t); ok {
+		return "option"
+	}
+	if nt, ok := types.types[t]; ok && nt.Kind != parser.KindStruct {
+		return validateKind(nt.Underlying, types)
+	}
+	return "other"
+}
+
+// zeroCheck returns the condition holding when value, of the given validateKind, is its zero value
+func zeroCheck(value, kind string) (string, bool) {
+	switch kind {
+	case "string":
+		return value + ` == ""`, true
+	case "number":
+		return value + " == 0", true
+	case "bool":
+		return "!" + value, true
+	case "collection":
+		return "len(" + value + ") == 0", true
+	case "nilable":
+		return value + " == nil", true
+	case "option":
+		return "!" + value + ".IsSome()", true
+	case "time":
+		return value + ".IsZero()", true
+	}
+	return "", false
+}
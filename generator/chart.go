@@ -0,0 +1,208 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// Chart formats accepted by Options.Artifacts
+const (
+	ArtifactsMermaid = "mermaid"
+	ArtifactsDot     = "dot"
+)
+
+// chartNode is a node of a control flow chart
+type chartNode struct {
+	id, label string
+	terminal  bool
+}
+
+// chartEdge is a transition between chart nodes; dashed edges are failure paths
+type chartEdge struct {
+	from, to, label string
+	dashed          bool
+}
+
+// chart is a format independent control flow graph rendered as Mermaid or Graphviz dot
+type chart struct {
+	name  string
+	nodes []chartNode
+	edges []chartEdge
+}
+
+// node adds a node and returns its id
+func (c *chart) node(id, label string, terminal bool) string {
+	c.nodes = append(c.nodes, chartNode{id: id, label: label, terminal: terminal})
+	return id
+}
+
+// edge adds a transition
+func (c *chart) edge(from, to, label string, dashed bool) {
+	c.edges = append(c.edges, chartEdge{from: from, to: to, label: label, dashed: dashed})
+}
+
+// mermaid renders the chart as a Mermaid flowchart
+func (c *chart) mermaid() string {
+	var b strings.Builder
+	b.WriteString("%% " + c.name + " - generated by gofn; DO NOT EDIT.\n")
+	b.WriteString("flowchart LR\n")
+	for _, n := range c.nodes {
+		if n.terminal {
+			b.WriteString(fmt.Sprintf("    %s((%q))\n", n.id, n.label))
+		} else {
+			b.WriteString(fmt.Sprintf("    %s[%q]\n", n.id, n.label))
+		}
+	}
+	for _, e := range c.edges {
+		arrow := "-->"
+		if e.dashed {
+			arrow = "-.->"
+		}
+		if e.label != "" {
+			b.WriteString(fmt.Sprintf("    %s %s|%s| %s\n", e.from, arrow, e.label, e.to))
+		} else {
+			b.WriteString(fmt.Sprintf("    %s %s %s\n", e.from, arrow, e.to))
+		}
+	}
+	return b.String()
+}
+
+// dot renders the chart as a Graphviz digraph
+func (c *chart) dot() string {
+	var b strings.Builder
+	b.WriteString("// " + c.name + " - generated by gofn; DO NOT EDIT.\n")
+	b.WriteString(fmt.Sprintf("digraph %q {\n", c.name))
+	b.WriteString("    rankdir=LR;\n")
+	for _, n := range c.nodes {
+		shape := "box"
+		if n.terminal {
+			shape = "circle"
+		}
+		b.WriteString(fmt.Sprintf("    %s [label=%q, shape=%s];\n", n.id, n.label, shape))
+	}
+	for _, e := range c.edges {
+		attrs := []string{}
+		if e.label != "" {
+			attrs = append(attrs, fmt.Sprintf("label=%q", e.label))
+		}
+		if e.dashed {
+			attrs = append(attrs, "style=dashed")
+		}
+		line := fmt.Sprintf("    %s -> %s", e.from, e.to)
+		if len(attrs) > 0 {
+			line += " [" + strings.Join(attrs, ", ") + "]"
+		}
+		b.WriteString(line + ";\n")
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// pipelineChart describes the stages of a pipeline struct: each field type is a stage, each function an edge
+func pipelineChart(s parser.StructInfo) *chart {
+	c := &chart{name: s.Name}
+	c.node("start", "start", true)
+	failed := c.node("failed", "error", true)
+	prev := "start"
+	for i, f := range s.Fields {
+		id := c.node(fmt.Sprintf("s%d", i), f.Type, false)
+		label := ""
+		if i > 0 {
+			label = fmt.Sprintf("f%d", i)
+			c.edge(prev, failed, "", true)
+		}
+		c.edge(prev, id, label, false)
+		prev = id
+	}
+	c.edge(prev, c.node("done", "done", true), "", false)
+	return c
+}
+
+// workflowChart describes a workflow: steps in order, and for every failure the reverse compensation chain
+func workflowChart(s parser.StructInfo) (*chart, error) {
+	steps, err := workflowSteps(s)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &chart{name: s.Name}
+	prev := c.node("start", "start", true)
+	for i, step := range steps {
+		c.node(fmt.Sprintf("step%d", i), step.name, false)
+		c.edge(prev, fmt.Sprintf("step%d", i), "", false)
+		prev = fmt.Sprintf("step%d", i)
+	}
+	c.edge(prev, c.node("done", "done", true), "", false)
+	failed := c.node("failed", "failed", true)
+
+	// undoFrom returns the first compensation to run when step i fails
+	undoFrom := func(i int) string {
+		for j := i - 1; j >= 0; j-- {
+			if steps[j].compensate != "" {
+				return fmt.Sprintf("undo%d", j)
+			}
+		}
+		return failed
+	}
+	for j, step := range steps {
+		if step.compensate != "" {
+			c.node(fmt.Sprintf("undo%d", j), step.compensate, false)
+		}
+	}
+	for j, step := range steps {
+		if step.compensate != "" {
+			c.edge(fmt.Sprintf("undo%d", j), undoFrom(j), "", false)
+		}
+	}
+	for i := range steps {
+		c.edge(fmt.Sprintf("step%d", i), undoFrom(i), "fails", true)
+	}
+	return c, nil
+}
+
+// writeChart writes the control flow chart for s next to its generated code
+// Charts are produced for pipeline and workflow directives when opts.Artifacts is set or the directive has the fsmchart argument
+func writeChart(outDir string, s parser.StructInfo, name string, args directiveArgs, opts Options) error {
+	format := opts.Artifacts
+	if format == "" && args.has("fsmchart") {
+		format = args.get("fsmchart", ArtifactsMermaid)
+	}
+	if format == "" {
+		return nil
+	}
+
+	var c *chart
+	switch name {
+	case "pipeline":
+		c = pipelineChart(s)
+	case "workflow":
+		var err error
+		if c, err = workflowChart(s); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	var content, ext string
+	switch format {
+	case ArtifactsMermaid:
+		content, ext = c.mermaid(), "mmd"
+	case ArtifactsDot:
+		content, ext = c.dot(), "dot"
+	default:
+		return fmt.Errorf("unknown artifacts format %q (want %s or %s)", format, ArtifactsMermaid, ArtifactsDot)
+	}
+
+	out := filepath.Join(outDir, fmt.Sprintf("%s_%s.%s", s.Name, normalizeDirective(name), ext))
+	if err := os.WriteFile(out, []byte(content), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("gofn: generated %s\n", out)
+	opts.Report.generated(s.Name, name, out, []byte(content))
+	return nil
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// composeMembers returns the functions annotated //gofn:compose group=<group>, in declaration order
+func composeMembers(funcs []parser.FuncInfo, group string) []parser.FuncInfo {
+	var members []parser.FuncInfo
+	for _, f := range funcs {
+		name, args := splitDirective(f.Directive)
+		if name == "compose" && f.Receiver == "" && args.get("group", "") == group {
+			members = append(members, f)
+		}
+	}
+	return members
+}
+
+// generateComposeCode generates the composition of every function annotated //gofn:compose group=<group>,
+// chained in declaration order, as `<Group>` plus the Task variant `<Group>Task`; the stages follow the
+// rules of //gofn:pipe
+// The first member of a group writes the composition; it returns false for the others, which write nothing
+func generateComposeCode(buf *bytes.Buffer, f parser.FuncInfo, args directiveArgs, funcs []parser.FuncInfo) (bool, error) {
+	if f.Receiver != "" {
+		return false, fmt.Errorf("compose is only supported on functions, not methods")
+	}
+	group := args.get("group", "")
+	if group == "" || strings.ContainsAny(group, ".,") {
+		return false, fmt.Errorf("compose requires a group name, e.g. //gofn:compose group=parseFlow")
+	}
+	members := composeMembers(funcs, group)
+	if len(members) == 0 || members[0].Name != f.Name {
+		return false, nil
+	}
+
+	stages := make([]pipeStage, len(members))
+	for i, m := range members {
+		stage, err := nextStage(stages[:i], m)
+		if err != nil {
+			return false, fmt.Errorf("group %s: %w", group, err)
+		}
+		stages[i] = stage
+	}
+	writeComposition(buf, exportName(group), stages)
+	return true, nil
+}
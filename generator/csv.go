@@ -0,0 +1,241 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// csvField is a field of a //gofn:csv struct with its column name and cell codec
+type csvField struct {
+	name   string
+	column string
+	elem   string // the field type, or the element type of an Option
+	option bool   // monad.Option[elem]: None is an empty cell
+	codec  csvCodec
+}
+
+// csvCodec formats one value as a cell and parses it back
+type csvCodec struct {
+	// encode returns the expression formatting x as a string
+	encode func(x string) string
+	// decode returns statements parsing the cell s into x of type typ; when fails is set they also
+	// declare err, which the caller checks
+	decode func(typ string) string
+	fails  bool
+	pkg    string // import the codec needs, if any
+}
+
+// generateCSVCode generates CSVHeader, MarshalCSVRow, CSVColumns, UnmarshalCSVColumns and UnmarshalCSVRow for a
+// struct, plus `New<Name>CSVReader` and `New<Name>CSVWriter` over monad.CSVReader and monad.CSVWriter
+// Columns are named by `csv:"name"` tags, defaulting to the field name; `csv:"-"` skips a field
+// The `tsv` argument makes the reader and writer separate fields with tabs
+func generateCSVCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, naming Naming, args directiveArgs) error {
+	var fields []csvField
+	imports := map[string]bool{"fmt": true, "io": true}
+	columns := map[string]string{}
+	for _, f := range s.Fields {
+		column := reflect.StructTag(f.Tag).Get("csv")
+		if f.Name == "" || column == "-" {
+			continue
+		}
+		if column == "" {
+			column = f.Name
+		}
+		if prev, ok := columns[column]; ok {
+			return fmt.Errorf("fields %s and %s both use the column %q", prev, f.Name, column)
+		}
+		columns[column] = f.Name
+
+		field := csvField{name: f.Name, column: column, elem: f.Type}
+		if elem, ok := optionElem(f.Type); ok {
+			field.elem, field.option = elem, true
+		}
+		codec, ok := csvCodecFor(field.elem, types)
+		if !ok {
+			return fmt.Errorf("field %s: type %s has no CSV cell encoding; use a string, bool, number, time.Time, time.Duration or a monad.Option of one", f.Name, f.Type)
+		}
+		field.codec = codec
+		imports["errors"] = imports["errors"] || !field.option
+		if codec.pkg != "" {
+			imports[codec.pkg] = true
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("csv needs at least one field")
+	}
+
+	comma, format := `','`, "CSV"
+	if args.has("tsv") {
+		comma, format = `'\t'`, "TSV"
+	}
+
+	buf.WriteString("import (\n")
+	for _, pkg := range []string{"errors", "fmt", "io", "strconv", "time"} {
+		if imports[pkg] {
+			buf.WriteString(fmt.Sprintf("\t%q\n", pkg))
+		}
+	}
+	buf.WriteString("\n\t\"github.com/snowmerak/gofn/monad\"\n)\n\n")
+
+	names := make([]string, len(fields))
+	for i, f := range fields {
+		names[i] = strconv.Quote(f.column)
+	}
+	buf.WriteString(fmt.Sprintf("// CSVHeader returns the column names of %s rows, in the order MarshalCSVRow writes them\n", s.Name))
+	buf.WriteString(fmt.Sprintf("func (%s) CSVHeader() []string {\n\treturn []string{%s}\n}\n\n", s.Name, strings.Join(names, ", ")))
+
+	buf.WriteString("// MarshalCSVRow returns the cells of v in header order; a None option is an empty cell\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) MarshalCSVRow() []string {\n", s.Name))
+	buf.WriteString(fmt.Sprintf("\trow := make([]string, %d)\n", len(fields)))
+	for i, f := range fields {
+		if f.option {
+			buf.WriteString(fmt.Sprintf("\tif v.%s.IsSome() {\n\t\trow[%d] = %s\n\t}\n", f.name, i, f.codec.encode("v."+f.name+".Unwrap()")))
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("\trow[%d] = %s\n", i, f.codec.encode("v."+f.name)))
+	}
+	buf.WriteString("\treturn row\n}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// CSVColumns returns the index in header of each column of %s, in CSVHeader order, or -1 for a missing\n", s.Name))
+	buf.WriteString("// option column; any other missing column is an error, and unknown columns are ignored\n")
+	buf.WriteString(fmt.Sprintf("func (%s) CSVColumns(header []string) ([]int, error) {\n", s.Name))
+	minus := make([]string, len(fields))
+	for i := range minus {
+		minus[i] = "-1"
+	}
+	buf.WriteString(fmt.Sprintf("\tcols := []int{%s}\n", strings.Join(minus, ", ")))
+	buf.WriteString("\tfor i, name := range header {\n\t\tswitch name {\n")
+	for i, f := range fields {
+		buf.WriteString(fmt.Sprintf("\t\tcase %q:\n\t\t\tcols[%d] = i\n", f.column, i))
+	}
+	buf.WriteString("\t\t}\n\t}\n")
+	for i, f := range fields {
+		if !f.option {
+			buf.WriteString(fmt.Sprintf("\tif cols[%d] < 0 {\n\t\treturn nil, errors.New(%s)\n\t}\n", i, strconv.Quote(fmt.Sprintf("missing column %q", f.column))))
+		}
+	}
+	buf.WriteString("\treturn cols, nil\n}\n\n")
+
+	buf.WriteString("// UnmarshalCSVColumns sets v from row, reading each field from the cell CSVColumns found for it\n")
+	buf.WriteString("// An empty or missing cell of an option column is None\n")
+	buf.WriteString(fmt.Sprintf("func (v *%s) UnmarshalCSVColumns(cols []int, row []string) error {\n", s.Name))
+	buf.WriteString(fmt.Sprintf("\tif len(cols) != %d {\n\t\treturn fmt.Errorf(\"got %%d columns, want %d\", len(cols))\n\t}\n", len(fields), len(fields)))
+	buf.WriteString("\tfor i, col := range cols {\n")
+	buf.WriteString(fmt.Sprintf("\t\tif col >= len(row) {\n\t\t\treturn fmt.Errorf(\"row has %%d cells, column %%q is cell %%d\", len(row), %s{}.CSVHeader()[i], col+1)\n\t\t}\n", s.Name))
+	buf.WriteString("\t}\n")
+	for i, f := range fields {
+		decode := f.codec.decode(f.elem)
+		if f.codec.fails {
+			decode += fmt.Sprintf("\tif err != nil {\n\t\treturn fmt.Errorf(\"column %%q: %%w\", %q, err)\n\t}\n", f.column)
+		}
+		if f.option {
+			buf.WriteString(fmt.Sprintf("\tv.%s = monad.None[%s]()\n", f.name, f.elem))
+			buf.WriteString(fmt.Sprintf("\tif i := cols[%d]; i >= 0 && row[i] != \"\" {\n\t\ts := row[i]\n", i))
+			buf.WriteString(indent(decode))
+			buf.WriteString(fmt.Sprintf("\t\tv.%s = monad.Some(x)\n\t}\n", f.name))
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("\t{\n\t\ts := row[cols[%d]]\n", i))
+		buf.WriteString(indent(decode))
+		buf.WriteString(fmt.Sprintf("\t\tv.%s = x\n\t}\n", f.name))
+	}
+	buf.WriteString("\treturn nil\n}\n\n")
+
+	buf.WriteString("// UnmarshalCSVRow sets v from row, matching its cells to fields by the column names in header\n")
+	buf.WriteString(fmt.Sprintf("func (v *%s) UnmarshalCSVRow(header, row []string) error {\n", s.Name))
+	buf.WriteString("\tcols, err := v.CSVColumns(header)\n\tif err != nil {\n\t\treturn err\n\t}\n")
+	buf.WriteString("\treturn v.UnmarshalCSVColumns(cols, row)\n}\n\n")
+
+	base := exportName(s.Name)
+	reader, writer := naming.constructor(base+"CSVReader"), naming.constructor(base+"CSVWriter")
+	buf.WriteString(fmt.Sprintf("// %s returns a reader decoding %s rows of r, matched to fields by its header line\n", reader, s.Name))
+	buf.WriteString(fmt.Sprintf("func %s(r io.Reader) *monad.CSVReader[%s, *%s] {\n", reader, s.Name, s.Name))
+	buf.WriteString(fmt.Sprintf("\treturn monad.NewCSVReader[%s, *%s](r, %s)\n}\n\n", s.Name, s.Name, comma))
+	buf.WriteString(fmt.Sprintf("// %s returns a writer encoding %s values as %s rows of w, after a header line\n", writer, s.Name, format))
+	buf.WriteString(fmt.Sprintf("func %s(w io.Writer) *monad.CSVWriter[%s] {\n", writer, s.Name))
+	buf.WriteString(fmt.Sprintf("\treturn monad.NewCSVWriter[%s](w, %s)\n}\n\n", s.Name, comma))
+	return nil
+}
+
+// csvCodecFor returns the cell codec of a field type: strings, bools, numbers, time.Time as RFC 3339,
+// time.Duration in its String form, and named types over one of those
+func csvCodecFor(t string, types typeIndex) (csvCodec, bool) {
+	parse := func(call, conv string) func(typ string) string {
+		return func(typ string) string {
+			return fmt.Sprintf("\tn, err := %s\n\tx := %s(%s)\n", call, typ, conv)
+		}
+	}
+	switch t {
+	case "string":
+		return csvCodec{
+			encode: func(x string) string { return x },
+			decode: func(string) string { return "\tx := s\n" },
+		}, true
+	case "bool":
+		return csvCodec{
+			encode: func(x string) string { return "strconv.FormatBool(bool(" + x + "))" },
+			decode: parse("strconv.ParseBool(s)", "n"),
+			fails:  true, pkg: "strconv",
+		}, true
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return csvCodec{
+			encode: func(x string) string { return "strconv.FormatInt(int64(" + x + "), 10)" },
+			decode: parse(fmt.Sprintf("strconv.ParseInt(s, 10, %d)", intBits(t)), "n"),
+			fails:  true, pkg: "strconv",
+		}, true
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return csvCodec{
+			encode: func(x string) string { return "strconv.FormatUint(uint64(" + x + "), 10)" },
+			decode: parse(fmt.Sprintf("strconv.ParseUint(s, 10, %d)", intBits(t)), "n"),
+			fails:  true, pkg: "strconv",
+		}, true
+	case "float32", "float64":
+		bits := strings.TrimPrefix(t, "float")
+		return csvCodec{
+			encode: func(x string) string { return fmt.Sprintf("strconv.FormatFloat(float64(%s), 'g', -1, %s)", x, bits) },
+			decode: parse(fmt.Sprintf("strconv.ParseFloat(s, %s)", bits), "n"),
+			fails:  true, pkg: "strconv",
+		}, true
+	case "time.Time":
+		return csvCodec{
+			encode: func(x string) string { return x + ".Format(time.RFC3339Nano)" },
+			decode: parse("time.Parse(time.RFC3339Nano, s)", "n"),
+			fails:  true, pkg: "time",
+		}, true
+	case "time.Duration":
+		return csvCodec{
+			encode: func(x string) string { return x + ".String()" },
+			decode: parse("time.ParseDuration(s)", "n"),
+			fails:  true, pkg: "time",
+		}, true
+	}
+	// named types convert to and from their underlying type, which time.Time and time.Duration methods cannot
+	if nt, ok := types.types[t]; ok && nt.Kind != parser.KindStruct && !strings.HasPrefix(nt.Underlying, "time.") {
+		codec, ok := csvCodecFor(nt.Underlying, types)
+		if ok && nt.Underlying == "string" {
+			codec.encode = func(x string) string { return "string(" + x + ")" }
+			codec.decode = func(typ string) string { return fmt.Sprintf("\tx := %s(s)\n", typ) }
+		}
+		return codec, ok
+	}
+	return csvCodec{}, false
+}
+
+// intBits returns the bit size strconv parses an integer type with
+func intBits(t string) int {
+	switch t {
+	case "int8", "uint8", "byte":
+		return 8
+	case "int16", "uint16":
+		return 16
+	case "int32", "uint32", "rune":
+		return 32
+	}
+	return 64
+}
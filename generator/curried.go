@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateCurriedResultFunc generates `<Name>CurriedR` for a function whose last result is error
+// Its final application returns monad.Result[T], monad.Result[monad.TupleN[...]] for several values
+// (with a generated helper tuple beyond monad.Tuple4), or monad.Result[monad.Unit] when error is the only result
+func generateCurriedResultFunc(f parser.FuncInfo, naming Naming, helpers *helperTypes) (string, error) {
+	n := len(f.Results)
+	if n == 0 || f.Results[n-1].Type != "error" {
+		return "", fmt.Errorf("curried result requires the last result to be error")
+	}
+	values := f.Results[:n-1]
+
+	types := make([]string, len(values))
+	names := make([]string, len(values))
+	for i, r := range values {
+		types[i] = r.Type
+		names[i] = fmt.Sprintf("v%d", i+1)
+	}
+	call := fmt.Sprintf("%s(%s)", f.Name, callArgs(f.Params))
+
+	var valueType, body string
+	switch len(values) {
+	case 0:
+		valueType = "monad.Unit"
+		body = fmt.Sprintf("\treturn monad.UnitFrom(%s)\n", call)
+	case 1:
+		valueType = types[0]
+		body = fmt.Sprintf("\treturn monad.ResultOf(%s)\n", call)
+	default:
+		var ctor string
+		valueType, ctor = helpers.tuple(f.Package, types)
+		body = fmt.Sprintf("\t%s, err := %s\n", strings.Join(names, ", "), call) +
+			fmt.Sprintf("\treturn monad.ResultOf(%s(%s), err)\n", ctor, strings.Join(names, ", "))
+	}
+	resultType := "monad.Result[" + valueType + "]"
+
+	wrapperName := naming.curried(f.Name) + "R"
+	adapterName := fieldParamName(exportName(f.Name), 0) + "AsResult"
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("// %s adapts %s to return a single Result for %s\n", adapterName, f.Name, wrapperName))
+	b.WriteString(fmt.Sprintf("func %s(%s) %s {\n", adapterName, paramList(f.Params), resultType))
+	b.WriteString(body)
+	b.WriteString("}\n\n")
+
+	adapted := parser.FuncInfo{
+		Package: f.Package,
+		Name:    adapterName,
+		Params:  f.Params,
+		Results: []parser.ParamInfo{{Type: resultType}},
+	}
+	b.WriteString(fmt.Sprintf("// %s is the curried form of %s whose final application returns a %s\n", wrapperName, f.Name, resultType))
+	b.WriteString(curriedWrapper(adapted, wrapperName))
+	return b.String(), nil
+}
+
+// hasLeadingContext reports whether the first parameter of f is a context.Context
+func hasLeadingContext(f parser.FuncInfo) bool {
+	return len(f.Params) > 0 && f.Params[0].Type == "context.Context"
+}
+
+// generateCurriedCtxFuncs generates the context-aware curried forms of a function taking ctx first:
+// `<Name>CurriedCtx(ctx)` binds ctx up front, and `<Name>CurriedCtxLast()` takes the other arguments
+// first and ctx last, so partially applied functions can be built once and called per request
+func generateCurriedCtxFuncs(f parser.FuncInfo, naming Naming) string {
+	named := namedParams(f.Params)
+	ctx, rest := named[0], named[1:]
+	curried := naming.curried(f.Name)
+
+	var b strings.Builder
+	boundName := curried + "Ctx"
+	boundType := curriedType(rest, f.Results)
+	b.WriteString(fmt.Sprintf("// %s binds ctx and returns %s curried over the remaining arguments\n", boundName, f.Name))
+	b.WriteString(fmt.Sprintf("func %s(%s %s) %s {\n", boundName, ctx.Name, ctx.Type, boundType))
+	b.WriteString(fmt.Sprintf("\treturn %s()(%s)\n", curried, ctx.Name))
+	b.WriteString("}\n\n")
+
+	lastName := curried + "CtxLast"
+	adapterName := fieldParamName(exportName(f.Name), 0) + "CtxLast"
+	reordered := append(append([]parser.ParamInfo{}, rest...), ctx)
+	for i, p := range reordered {
+		// a variadic parameter can no longer come last, so it is taken as a slice
+		if elem, ok := strings.CutPrefix(p.Type, "..."); ok {
+			reordered[i].Type = "[]" + elem
+		}
+	}
+	b.WriteString(fmt.Sprintf("// %s calls %s with ctx moved to the last parameter\n", adapterName, f.Name))
+	b.WriteString(fmt.Sprintf("func %s(%s) %s {\n", adapterName, paramList(reordered), curriedType(nil, f.Results)))
+	if len(f.Results) == 0 {
+		b.WriteString(fmt.Sprintf("\t%s(%s)\n", f.Name, callArgs(named)))
+	} else {
+		b.WriteString(fmt.Sprintf("\treturn %s(%s)\n", f.Name, callArgs(named)))
+	}
+	b.WriteString("}\n\n")
+
+	adapted := parser.FuncInfo{Package: f.Package, Name: adapterName, Params: reordered, Results: f.Results}
+	b.WriteString(fmt.Sprintf("// %s is the curried form of %s taking ctx last\n", lastName, f.Name))
+	b.WriteString(curriedWrapper(adapted, lastName))
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateEnumCode turns the interface t into a sum type of the variant structs: each variant gets the sealed()
+// method t declares, so no other type implements t, and `Match<Name>` takes one handler per variant
+// A new variant adds a parameter to Match<Name>, so every call that does not handle it stops compiling
+func generateEnumCode(buf *bytes.Buffer, t parser.TypeInfo, variants []string, pkg parser.Package) error {
+	if t.Kind != parser.KindInterface {
+		return fmt.Errorf("//gofn:enum needs an interface, %s is a %s type", t.Name, t.Kind)
+	}
+	if len(variants) == 0 {
+		return fmt.Errorf("no variants listed, e.g. //gofn:enum Circle Square")
+	}
+	if !slices.Contains(t.Methods, "sealed") {
+		return fmt.Errorf("the interface must declare sealed() so only its variants implement it")
+	}
+	for i, v := range variants {
+		if slices.Contains(variants[:i], v) {
+			return fmt.Errorf("variant %s is listed twice", v)
+		}
+		if !slices.ContainsFunc(pkg.Structs, func(s parser.StructInfo) bool { return s.Name == v }) {
+			return fmt.Errorf("variant %s is not a struct of the package", v)
+		}
+		for _, other := range pkg.Types {
+			dir := strings.TrimSpace(other.Directive)
+			name, _ := splitDirective(dir)
+			if other.Name != t.Name && name == "enum" && slices.Contains(positionalArgs(dir), v) {
+				return fmt.Errorf("variant %s is also a variant of %s; a struct has one sealed() method", v, other.Name)
+			}
+		}
+	}
+
+	buf.WriteString("import \"fmt\"\n\n")
+	for _, v := range variants {
+		buf.WriteString(fmt.Sprintf("// sealed makes %s a variant of %s\n", v, t.Name))
+		buf.WriteString(fmt.Sprintf("func (%s) sealed() {}\n\n", v))
+	}
+	buf.WriteString(fmt.Sprintf("// the variants must implement the rest of %s with value receivers\n", t.Name))
+	buf.WriteString("var (\n")
+	for _, v := range variants {
+		buf.WriteString(fmt.Sprintf("\t_ %s = %s{}\n", t.Name, v))
+	}
+	buf.WriteString(")\n\n")
+
+	match := "Match" + exportName(t.Name)
+	handlers := make([]string, len(variants))
+	for i, v := range variants {
+		handlers[i] = fmt.Sprintf("on%s func(%s) R", exportName(v), v)
+	}
+	buf.WriteString(fmt.Sprintf("// %s calls the handler of v's variant and returns its result; a pointer to a variant is handled\n", match))
+	buf.WriteString("// as the variant. It panics when v is nil\n")
+	buf.WriteString(fmt.Sprintf("func %s[R any](v %s, %s) R {\n", match, t.Name, strings.Join(handlers, ", ")))
+	buf.WriteString("\tswitch x := v.(type) {\n")
+	for _, v := range variants {
+		handler := "on" + exportName(v)
+		buf.WriteString(fmt.Sprintf("\tcase %s:\n\t\treturn %s(x)\n", v, handler))
+		buf.WriteString(fmt.Sprintf("\tcase *%s:\n\t\treturn %s(*x)\n", v, handler))
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\tpanic(fmt.Sprintf(\"%s: %%T is not a variant of %s\", v))\n}\n", match, t.Name))
+	return nil
+}
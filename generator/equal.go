@@ -0,0 +1,325 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateEqualCode generates `Equal(other T) bool` comparing every field of s deeply: pointers by what they
+// point to (two nils are equal), slices and maps element by element with nil equal to empty, options by
+// presence and value, time.Time by instant and nested package structs by their own Equal
+// With the `hash` argument it also generates `Hash() uint64`, agreeing with Equal, so s can key a monad.HashMap
+// or a //gofn:memoize cache; maps are hashed independently of iteration order
+// Fields tagged `equal:"-"` are ignored by both; interface and func fields must be, since they cannot be
+// compared deeply
+func generateEqualCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, args directiveArgs) error {
+	for _, m := range declaredMethods(types.funcs, s.Name) {
+		if m == "Equal" || m == "Hash" && args.has("hash") {
+			return fmt.Errorf("%s already declares %s", s.Name, m)
+		}
+	}
+
+	g := &equalGen{types: types}
+	var conds, hashes []string
+	for _, f := range s.Fields {
+		if reflect.StructTag(f.Tag).Get("equal") == "-" {
+			continue
+		}
+		name := f.Name
+		if name == "" {
+			name = strings.TrimPrefix(f.Type, "*")
+			name = name[strings.LastIndex(name, ".")+1:]
+		}
+		cond, err := g.equal("v."+name, "other."+name, f.Type, 0)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		conds = append(conds, cond)
+		if !args.has("hash") {
+			continue
+		}
+		lines, err := g.hash("h", "v."+name, f.Type, 0)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", name, err)
+		}
+		hashes = append(hashes, lines...)
+	}
+
+	var imports []string
+	if g.maps {
+		imports = append(imports, "\t\"maps\"\n")
+	}
+	if g.slices {
+		imports = append(imports, "\t\"slices\"\n")
+	}
+	if args.has("hash") {
+		if len(imports) > 0 {
+			imports = append(imports, "\n")
+		}
+		imports = append(imports, "\t\"github.com/snowmerak/gofn/monad\"\n")
+	}
+	if len(imports) > 0 {
+		buf.WriteString("import (\n" + strings.Join(imports, "") + ")\n\n")
+	}
+
+	body := "true"
+	if len(conds) > 0 {
+		body = strings.Join(conds, " &&\n\t\t")
+	}
+	buf.WriteString("// Equal reports whether v and other hold deeply equal fields; pointers are compared by what they point to\n")
+	buf.WriteString("// and nil slices and maps are equal to empty ones\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) Equal(other %s) bool {\n\treturn %s\n}\n\n", s.Name, s.Name, body))
+
+	if !args.has("hash") {
+		return nil
+	}
+	buf.WriteString("// Hash returns a hash of the fields Equal compares, so Equal values hash alike\n")
+	buf.WriteString(fmt.Sprintf("func (v %s) Hash() uint64 {\n\tvar h monad.Hasher\n", s.Name))
+	for _, line := range hashes {
+		buf.WriteString("\t" + line + "\n")
+	}
+	buf.WriteString("\treturn h.Sum64()\n}\n\n")
+	return nil
+}
+
+// equalGen renders the comparisons and hashing of generateEqualCode, remembering the imports they use
+type equalGen struct {
+	types        typeIndex
+	slices, maps bool
+}
+
+// equal returns an expression reporting whether a and b, of type t, are deeply equal
+// depth numbers the closure parameters of nested slices and maps
+func (g *equalGen) equal(a, b, t string, depth int) (string, error) {
+	t = strings.TrimSpace(t)
+	if t == "time.Time" {
+		return fmt.Sprintf("%s.Equal(%s)", a, b), nil
+	}
+	if elem, ok := optionElem(t); ok {
+		inner, err := g.equal(a+".Unwrap()", b+".Unwrap()", elem, depth)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s.IsSome() == %s.IsSome() && (!%s.IsSome() || %s)", a, b, a, inner), nil
+	}
+	switch {
+	case strings.HasPrefix(t, "*"):
+		deref := "(*" + a + ")"
+		if _, ok := g.types.structs[t[1:]]; ok {
+			deref = a // Equal is called through the pointer
+		}
+		inner, err := g.equal(deref, "*"+b, t[1:], depth)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("(%s == %s || %s != nil && %s != nil && %s)", a, b, a, b, inner), nil
+	case strings.HasPrefix(t, "[]"):
+		return g.elements("slices", a, b, t[2:], depth)
+	case strings.HasPrefix(t, "map["):
+		_, elem := splitMapType(t)
+		return g.elements("maps", a, b, elem, depth)
+	case strings.HasPrefix(t, "["):
+		_, elem, _ := strings.Cut(t, "]")
+		if g.plain(elem) {
+			return a + " == " + b, nil
+		}
+		return g.elements("slices", a+"[:]", b+"[:]", elem, depth)
+	case strings.HasPrefix(t, "func"), t == "any", strings.HasPrefix(t, "interface"):
+		return "", fmt.Errorf("%s cannot be compared deeply; tag the field `equal:\"-\"` to ignore it", t)
+	}
+	if _, ok := g.types.structs[t]; ok {
+		if g.types.equalTakesPointer(t) {
+			return fmt.Sprintf("%s.Equal(%s)", a, strings.TrimPrefix("&"+b, "&*")), nil
+		}
+		return fmt.Sprintf("%s.Equal(%s)", a, b), nil
+	}
+	if nt, ok := g.types.types[t]; ok && nt.Kind != parser.KindStruct {
+		return g.equal(a, b, nt.Underlying, depth)
+	}
+	return a + " == " + b, nil
+}
+
+// elements compares slices or maps a and b with pkg's Equal, or EqualFunc when elements of type elem need
+// more than ==
+func (g *equalGen) elements(pkg, a, b, elem string, depth int) (string, error) {
+	if pkg == "slices" {
+		g.slices = true
+	} else {
+		g.maps = true
+	}
+	if g.plain(elem) {
+		return fmt.Sprintf("%s.Equal(%s, %s)", pkg, a, b), nil
+	}
+	x, y := fmt.Sprintf("x%d", depth), fmt.Sprintf("y%d", depth)
+	inner, err := g.equal(x, y, elem, depth+1)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.EqualFunc(%s, %s, func(%s, %s %s) bool { return %s })", pkg, a, b, x, y, strings.TrimSpace(elem), inner), nil
+}
+
+// plain reports whether values of type t are deeply equal exactly when they are ==
+func (g *equalGen) plain(t string) bool {
+	probe := &equalGen{types: g.types}
+	expr, err := probe.equal("x", "y", t, 0)
+	return err == nil && expr == "x == y"
+}
+
+// hash returns the statements adding v, of type t, to the monad.Hasher h
+func (g *equalGen) hash(h, v, t string, depth int) ([]string, error) {
+	t = strings.TrimSpace(t)
+	switch t {
+	case "time.Time":
+		return []string{fmt.Sprintf("%s.WriteInt64(%s.UnixNano())", h, v)}, nil
+	case "time.Duration":
+		return []string{fmt.Sprintf("%s.WriteInt64(int64(%s))", h, v)}, nil
+	}
+	if line, ok := hashBasic(h, v, t, t); ok {
+		return []string{line}, nil
+	}
+	x := fmt.Sprintf("x%d", depth)
+	if elem, ok := optionElem(t); ok {
+		inner, err := g.hash(h, x, elem, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		lines := []string{fmt.Sprintf("%s.WriteBool(%s.IsSome())", h, v), fmt.Sprintf("if %s.IsSome() {", v), fmt.Sprintf("\t%s := %s.Unwrap()", x, v)}
+		return append(append(lines, indentLines(inner)...), "}"), nil
+	}
+	switch {
+	case strings.HasPrefix(t, "*"):
+		deref := "(*" + v + ")"
+		if _, ok := g.types.structs[t[1:]]; ok {
+			deref = v // Hash is called through the pointer
+		}
+		inner, err := g.hash(h, deref, t[1:], depth)
+		if err != nil {
+			return nil, err
+		}
+		lines := []string{fmt.Sprintf("%s.WriteBool(%s != nil)", h, v), fmt.Sprintf("if %s != nil {", v)}
+		return append(append(lines, indentLines(inner)...), "}"), nil
+	case t == "[]byte":
+		return []string{fmt.Sprintf("%s.WriteBytes(%s)", h, v)}, nil
+	case strings.HasPrefix(t, "["):
+		_, elem, _ := strings.Cut(t, "]")
+		inner, err := g.hash(h, x, elem, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		lines := []string{fmt.Sprintf("%s.WriteUint64(uint64(len(%s)))", h, v), fmt.Sprintf("for _, %s := range %s {", x, v)}
+		return append(append(lines, indentLines(inner)...), "}"), nil
+	case strings.HasPrefix(t, "map["):
+		// entries are hashed on their own and summed, so the iteration order does not matter
+		key, elem := splitMapType(t)
+		k, eh, sum := fmt.Sprintf("k%d", depth), fmt.Sprintf("h%d", depth+1), fmt.Sprintf("sum%d", depth)
+		keyLines, err := g.hash(eh, k, key, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		elemLines, err := g.hash(eh, x, elem, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		lines := []string{"{", fmt.Sprintf("\tvar %s uint64", sum), fmt.Sprintf("\tfor %s, %s := range %s {", k, x, v), fmt.Sprintf("\t\tvar %s monad.Hasher", eh)}
+		lines = append(lines, indentLines(indentLines(append(keyLines, elemLines...)))...)
+		lines = append(lines, fmt.Sprintf("\t\t%s += %s.Sum64()", sum, eh), "\t}", fmt.Sprintf("\t%s.WriteUint64(%s)", h, sum), "}")
+		return lines, nil
+	}
+	if _, ok := g.types.structs[t]; ok {
+		if !g.types.isHashable(t) {
+			return nil, fmt.Errorf("%s has no Hash method; add //gofn:equal hash to it", t)
+		}
+		return []string{fmt.Sprintf("%s.WriteUint64(%s.Hash())", h, v)}, nil
+	}
+	if nt, ok := g.types.types[t]; ok && nt.Kind != parser.KindStruct {
+		under := strings.TrimSpace(nt.Underlying)
+		if line, ok := hashBasic(h, v, t, under); ok {
+			return []string{line}, nil
+		}
+		return g.hash(h, v, under, depth)
+	}
+	return nil, fmt.Errorf("%s cannot be hashed; tag the field `equal:\"-\"` to ignore it", t)
+}
+
+// hashBasic returns the Hasher call adding v, of type t with the predeclared underlying type under
+func hashBasic(h, v, t, under string) (string, bool) {
+	conv := func(to string) string {
+		if t == to {
+			return v
+		}
+		return to + "(" + v + ")"
+	}
+	switch under {
+	case "string":
+		return fmt.Sprintf("%s.WriteString(%s)", h, conv("string")), true
+	case "bool":
+		return fmt.Sprintf("%s.WriteBool(%s)", h, conv("bool")), true
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return fmt.Sprintf("%s.WriteInt64(%s)", h, conv("int64")), true
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return fmt.Sprintf("%s.WriteUint64(%s)", h, conv("uint64")), true
+	case "float32", "float64":
+		return fmt.Sprintf("%s.WriteFloat64(%s)", h, conv("float64")), true
+	case "complex64", "complex128":
+		return fmt.Sprintf("%s.WriteFloat64(real(%s))\n%s.WriteFloat64(imag(%s))", h, v, h, v), true
+	}
+	return "", false
+}
+
+// indentLines indents each statement line by one tab
+func indentLines(lines []string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = "\t" + strings.ReplaceAll(line, "\n", "\n\t")
+	}
+	return out
+}
+
+// equalRequires enables //gofn:equal on the package structs an equal struct nests, directly or through
+// pointers, slices, maps and options, unless they declare their own Equal; it passes on the hash argument
+func equalRequires(s parser.StructInfo, types typeIndex) []requirement {
+	directive := "equal"
+	if _, args := splitDirective(s.Directive); args.has("hash") {
+		directive = "equal hash"
+	}
+	var reqs []requirement
+	for _, f := range s.Fields {
+		if reflect.StructTag(f.Tag).Get("equal") == "-" {
+			continue
+		}
+		for _, decl := range nestedStructs(f.Type, types) {
+			if !slices.Contains(declaredMethods(types.funcs, decl), "Equal") {
+				reqs = append(reqs, requirement{decl: decl, directive: directive, reason: fmt.Sprintf("field %s of %s", f.Name, s.Name)})
+			}
+		}
+	}
+	return reqs
+}
+
+// nestedStructs returns the package structs t holds, looking through pointers, slices, arrays, map values and
+// options; map keys are compared with ==
+func nestedStructs(t string, types typeIndex) []string {
+	t = strings.TrimSpace(t)
+	if elem, ok := optionElem(t); ok {
+		return nestedStructs(elem, types)
+	}
+	switch {
+	case strings.HasPrefix(t, "*"):
+		return nestedStructs(t[1:], types)
+	case strings.HasPrefix(t, "map["):
+		_, elem := splitMapType(t)
+		return nestedStructs(elem, types)
+	case strings.HasPrefix(t, "["):
+		_, elem, _ := strings.Cut(t, "]")
+		return nestedStructs(elem, types)
+	}
+	if _, ok := types.structs[t]; ok {
+		return []string{t}
+	}
+	return nil
+}
@@ -9,20 +9,106 @@ import (
 	"github.com/snowmerak/gofn/parser"
 )
 
-func generateFuncs(outDir string, funcs []parser.FuncInfo) error {
+// generateFuncs generates code for functions based on directives
+// pkg holds the declarations known so far, and helpers collects the helper types the generated code needs
+// beyond those in the monad package
+func generateFuncs(outDir string, funcs []parser.FuncInfo, pkg parser.Package, helpers *helperTypes, opts Options) error {
+	naming := opts.Naming.withDefaults()
+	limits := opts.Limits.withDefaults()
+	reserved := packageNames(pkg)
+	types := newTypeIndex(pkg)
 	for _, f := range funcs {
 		if f.Directive == "" {
 			continue
 		}
+		imports := newFileImports()
+		f := imports.qualifyFunc(f)
+
 		// multi-result functions are supported by the generator
 		var buf bytes.Buffer
-		hdr := fmt.Sprintf("// Code generated by gofn; DO NOT EDIT.\n// gofn: %s\n\n", f.Directive)
+		hdr := fileHeader(f.Directive)
 		buf.WriteString(hdr)
 		buf.WriteString("package " + f.Package + "\n\n")
-		wrapper := generateCurriedFunc(f)
-		buf.WriteString(wrapper + "\n")
 
-		fname := fmt.Sprintf("%s_%s_gen.go", f.Name, normalizeDirective(f.Directive))
+		// generation per-directive; any other directive produces the curried wrapper
+		name, args := splitDirective(f.Directive)
+		switch name {
+		case "batch":
+			if err := generateBatchCode(&buf, f, args, naming); err != nil {
+				return fmt.Errorf("generating batch code for %s: %w", f.Name, err)
+			}
+
+		case "compose":
+			owner, err := generateComposeCode(&buf, f, args, pkg.Funcs)
+			if err != nil {
+				return fmt.Errorf("generating compose code for %s: %w", f.Name, err)
+			}
+			if !owner {
+				continue // the group is written by its first member
+			}
+
+		case "memoize":
+			if err := generateMemoizeCode(&buf, f, args, types, naming); err != nil {
+				return fmt.Errorf("generating memoize code for %s: %w", f.Name, err)
+			}
+
+		case "partial":
+			if err := generatePartialCode(&buf, f); err != nil {
+				return fmt.Errorf("generating partial code for %s: %w", f.Name, err)
+			}
+
+		case "provide":
+			if err := generateProvideCode(&buf, f, pkg.Funcs, imports, naming); err != nil {
+				return fmt.Errorf("generating provide code for %s: %w", f.Name, err)
+			}
+
+		default:
+			if err := limits.checkCurried(f.Pos, len(f.Params)); err != nil {
+				return fmt.Errorf("generating curried code for %s: %w", f.Name, err)
+			}
+			var resultWrapper string
+			if args.has("result") {
+				var err error
+				if resultWrapper, err = generateCurriedResultFunc(f, naming, helpers); err != nil {
+					return fmt.Errorf("generating curried code for %s: %w", f.Name, err)
+				}
+			}
+			ctxWrappers := hasLeadingContext(f)
+			switch {
+			case ctxWrappers && resultWrapper != "":
+				buf.WriteString("import (\n\t\"context\"\n\n\t\"github.com/snowmerak/gofn/monad\"\n)\n\n")
+			case ctxWrappers:
+				buf.WriteString("import \"context\"\n\n")
+			case resultWrapper != "":
+				buf.WriteString("import \"github.com/snowmerak/gofn/monad\"\n\n")
+			}
+			wrapper := generateCurriedFunc(f, naming)
+			buf.WriteString(wrapper + "\n")
+			if resultWrapper != "" {
+				buf.WriteString(resultWrapper + "\n")
+			}
+			if ctxWrappers {
+				buf.WriteString(generateCurriedCtxFuncs(f, naming) + "\n")
+			}
+		}
+
+		src, err := imports.addTo(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("generating %s code for %s: %w", name, f.Name, err)
+		}
+		buf.Reset()
+		buf.Write(src)
+
+		if naming.keepUnexported(f.Name, args) {
+			src, err := unexportGenerated(buf.Bytes(), reserved)
+			if err != nil {
+				return fmt.Errorf("generating %s code for %s: %w", name, f.Name, err)
+			}
+			buf.Reset()
+			buf.Write(src)
+		}
+
+		fname := fmt.Sprintf("%s_%s_gen.go", f.Name, normalizeDirective(name))
 		out := filepath.Join(outDir, fname)
 
 		// attempt to use function position filename as source reference
@@ -37,12 +123,17 @@ func generateFuncs(outDir string, funcs []parser.FuncInfo) error {
 			return err
 		}
 
+		migrate, err := checkSchema(out, opts)
+		if err != nil {
+			return err
+		}
 		doGen, reason, serr := shouldGenerate(srcPath, out)
 		if serr != nil {
 			fmt.Printf("gofn: check should-generate for %s: %v\n", fname, serr)
 		}
-		if !doGen {
+		if !doGen && !migrate {
 			fmt.Printf("gofn: skip %s - %s\n", fname, reason)
+			opts.Report.skipped(f.Name, name, reason)
 			continue
 		}
 
@@ -51,6 +142,7 @@ func generateFuncs(outDir string, funcs []parser.FuncInfo) error {
 			return err
 		}
 		fmt.Printf("gofn: generated %s\n", out)
+		opts.Report.generated(f.Name, name, out, formatted)
 	}
 	return nil
 }
@@ -8,19 +8,41 @@ import (
 	"github.com/snowmerak/gofn/parser"
 )
 
+// Options configures optional generator behaviour; the zero value generates code only
+type Options struct {
+	// Artifacts selects a chart format (ArtifactsMermaid or ArtifactsDot) emitted next to the code
+	// of pipeline and workflow directives; empty disables charts
+	Artifacts string
+	// Naming customises the prefixes and suffixes of generated identifiers
+	Naming Naming
+	// Limits bounds the size of declarations the generators accept
+	Limits Limits
+	// Migrate regenerates files written by an incompatible older gofn (see SchemaVersion) instead of refusing them
+	Migrate bool
+	// Report, when set, receives the files written and the declarations skipped
+	Report *Report
+}
+
 // GenerateFor orchestrates generation for structs and funcs
 func GenerateFor(outDir string, structs []parser.StructInfo, funcs []parser.FuncInfo) error {
-	if err := os.MkdirAll(outDir, 0o755); err != nil {
-		return err
-	}
+	return GenerateWithOptions(outDir, structs, funcs, Options{})
+}
+
+// GenerateWithOptions orchestrates generation for structs and funcs using opts
+func GenerateWithOptions(outDir string, structs []parser.StructInfo, funcs []parser.FuncInfo, opts Options) error {
+	return GeneratePackage(outDir, parser.Package{Structs: structs, Funcs: funcs}, opts)
+}
 
-	if err := generateStructs(outDir, structs); err != nil {
+// GeneratePackage orchestrates generation for everything parsed from a package, including named non-struct types
+func GeneratePackage(outDir string, pkg parser.Package, opts Options) error {
+	stream, err := NewStream(outDir, opts)
+	if err != nil {
 		return err
 	}
-	if err := generateFuncs(outDir, funcs); err != nil {
+	if err := stream.Add(pkg); err != nil {
 		return err
 	}
-	return nil
+	return stream.Close()
 }
 
 // shouldGenerate returns (generate, reason, error)
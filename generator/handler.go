@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateHandlerCode generates `New<Name>Handler`, an http.Handler serving each method of the service s shaped
+// `func(context.Context, Req) (Resp, error)` as JSON on POST /<Method> through monad.ServeTask
+// A request struct of the package with `validate` tags is checked first, with the rules of //gofn:builder;
+// the `prefix` argument, e.g. prefix=/users, is prepended to every route
+func generateHandlerCode(buf *bytes.Buffer, s parser.StructInfo, methods []parser.FuncInfo, types typeIndex, args directiveArgs, naming Naming) error {
+	prefix := strings.TrimSuffix(args.get("prefix", ""), "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		return fmt.Errorf("prefix %q must start with /", prefix)
+	}
+
+	var routes, validators []string
+	usesErrors := false
+	for _, m := range methods {
+		if len(m.Params) != 2 || m.Params[0].Type != "context.Context" || len(m.Results) != 2 || m.Results[1].Type != "error" {
+			continue
+		}
+		validate := "nil"
+		req := m.Params[1].Type
+		if st, ok := types.structs[strings.TrimPrefix(req, "*")]; ok {
+			var checks []string
+			for _, f := range st.Fields {
+				rules, ok := reflect.StructTag(f.Tag).Lookup("validate")
+				if f.Name == "" || !ok {
+					continue
+				}
+				check, err := validateCheck(f, rules, types)
+				if err != nil {
+					return fmt.Errorf("method %s: request field %s: %w", m.Name, f.Name, err)
+				}
+				if check != "" {
+					checks = append(checks, check)
+				}
+			}
+			if len(checks) > 0 {
+				usesErrors = true
+				validate = "validate" + exportName(s.Name) + m.Name
+				var b strings.Builder
+				b.WriteString(fmt.Sprintf("// %s checks the validate tags of a %s request\n", validate, m.Name))
+				b.WriteString(fmt.Sprintf("func %s(v %s) error {\n", validate, req))
+				if strings.HasPrefix(req, "*") {
+					b.WriteString("\tif v == nil {\n\t\treturn errors.New(\"the request body is required\")\n\t}\n")
+				}
+				b.WriteString("\tvar errs monad.Errors\n")
+				for _, check := range checks {
+					b.WriteString(check)
+				}
+				b.WriteString("\tif len(errs) > 0 {\n\t\treturn errs\n\t}\n\treturn nil\n}\n\n")
+				validators = append(validators, b.String())
+			}
+		}
+		routes = append(routes, fmt.Sprintf("\tmux.HandleFunc(\"POST %s/%s\", func(w http.ResponseWriter, r *http.Request) {\n\t\tmonad.ServeTask(w, r, opts, %s, svc.%s)\n\t})\n",
+			prefix, m.Name, validate, m.Name))
+	}
+	if len(routes) == 0 {
+		return fmt.Errorf("no methods shaped func(context.Context, Req) (Resp, error)")
+	}
+
+	buf.WriteString("import (\n")
+	if usesErrors {
+		buf.WriteString("\t\"errors\"\n")
+	}
+	buf.WriteString("\t\"net/http\"\n\n\t\"github.com/snowmerak/gofn/monad\"\n)\n\n")
+
+	ctor := naming.constructor(s.Name + "Handler")
+	buf.WriteString(fmt.Sprintf("// %s serves the methods of svc as JSON, each on POST %s/<Method>\n", ctor, prefix))
+	buf.WriteString("// Calls run through the middleware of opts, and their errors are mapped to statuses by it\n")
+	buf.WriteString(fmt.Sprintf("func %s(svc *%s, opts monad.HandlerOptions) http.Handler {\n", ctor, s.Name))
+	buf.WriteString("\tmux := http.NewServeMux()\n")
+	for _, r := range routes {
+		buf.WriteString(r)
+	}
+	buf.WriteString("\treturn mux\n}\n\n")
+	for _, v := range validators {
+		buf.WriteString(v)
+	}
+	return nil
+}
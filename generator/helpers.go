@@ -34,41 +34,23 @@ func valuesForFields(fields []parser.FieldInfo) string {
 	return strings.Join(parts, ", ")
 }
 
-func generateCurriedFunc(f parser.FuncInfo) string {
+func generateCurriedFunc(f parser.FuncInfo, naming Naming) string {
+	// exported wrapper name (capitalize original name then append the curried suffix)
+	return "// Generated curried wrapper for " + f.Name + "\n" + curriedWrapper(f, naming.curried(f.Name))
+}
+
+// curriedWrapper returns the declaration of wrapperName, the curried form of f
+func curriedWrapper(f parser.FuncInfo, wrapperName string) string {
 	var b strings.Builder
 	n := len(f.Params)
 	resCount := len(f.Results)
 
 	// helper to build remaining nested type starting at index i
+	named := namedParams(f.Params)
 	remainingType := func(i int) string {
-		var sb strings.Builder
-		for j := i; j < n; j++ {
-			sb.WriteString("func(")
-			// if this param is variadic, it should be represented with ellipsis
-			ptype := f.Params[j].Type
-			sb.WriteString(paramName(f.Params[j], j))
-			sb.WriteString(" ")
-			sb.WriteString(ptype)
-			sb.WriteString(") ")
-		}
-		// append result types
-		if resCount == 1 {
-			sb.WriteString(f.Results[0].Type)
-		} else if resCount > 1 {
-			// multiple results: (t1, t2, ...)
-			parts := []string{}
-			for _, r := range f.Results {
-				parts = append(parts, r.Type)
-			}
-			sb.WriteString("(" + strings.Join(parts, ", ") + ")")
-		}
-		return sb.String()
+		return curriedType(named[i:], f.Results)
 	}
 
-	b.WriteString("// Generated curried wrapper for " + f.Name + "\n")
-	// exported wrapper name (capitalize original name then append Curried)
-	wrapperName := exportName(f.Name) + "Curried"
-
 	// Top-level signature
 	if n == 0 {
 		// no params: just return original result directly
@@ -137,6 +119,36 @@ func generateCurriedFunc(f parser.FuncInfo) string {
 	return b.String()
 }
 
+// curriedType returns the nested function type taking params one at a time and returning results,
+// e.g. "func(a int) func(b int) (int, error)"; params must be named, see namedParams
+func curriedType(params, results []parser.ParamInfo) string {
+	var sb strings.Builder
+	for _, p := range params {
+		sb.WriteString("func(" + p.Name + " " + p.Type + ") ")
+	}
+	switch len(results) {
+	case 0:
+	case 1:
+		sb.WriteString(results[0].Type)
+	default:
+		parts := []string{}
+		for _, r := range results {
+			parts = append(parts, r.Type)
+		}
+		sb.WriteString("(" + strings.Join(parts, ", ") + ")")
+	}
+	return sb.String()
+}
+
+// namedParams returns params with anonymous ones named p0..pn by position
+func namedParams(params []parser.ParamInfo) []parser.ParamInfo {
+	named := make([]parser.ParamInfo, len(params))
+	for i, p := range params {
+		named[i] = parser.ParamInfo{Name: paramName(p, i), Type: p.Type}
+	}
+	return named
+}
+
 func paramName(p parser.ParamInfo, i int) string {
 	if p.Name != "" {
 		return p.Name
@@ -175,6 +187,63 @@ func exportName(s string) string {
 	return string(rs)
 }
 
+// typeIdent turns a type expression into an identifier fragment for generated method names,
+// e.g. "int" -> "Int", "[]string" -> "SliceString", "monad.Option[int]" -> "MonadOptionInt"
+func typeIdent(t string) string {
+	var b strings.Builder
+	word := []rune{}
+	flush := func() {
+		if len(word) > 0 {
+			b.WriteString(exportName(string(word)))
+			word = word[:0]
+		}
+	}
+	rs := []rune(t)
+	for i, r := range rs {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			word = append(word, r)
+		case r == '[' && i+1 < len(rs) && rs[i+1] == ']':
+			flush()
+			b.WriteString("Slice")
+		case r == '*':
+			flush()
+			b.WriteString("Ptr")
+		default:
+			flush()
+		}
+	}
+	flush()
+	return b.String()
+}
+
+// optionElem returns T for a field of type monad.Option[T]
+func optionElem(t string) (string, bool) {
+	elem, ok := strings.CutPrefix(t, "monad.Option[")
+	if !ok || !strings.HasSuffix(elem, "]") {
+		return "", false
+	}
+	return strings.TrimSuffix(elem, "]"), true
+}
+
+// splitMapType returns the key and element types of the map type t, whose key may itself hold brackets
+func splitMapType(t string) (key, elem string) {
+	rest := strings.TrimPrefix(t, "map[")
+	depth := 0
+	for i, r := range rest {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				return strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i+1:])
+			}
+			depth--
+		}
+	}
+	return rest, ""
+}
+
 func fieldParamName(field string, i int) string {
 	if field != "" {
 		// if field already starts with lowercase, use as-is; otherwise lowercase first rune
@@ -192,3 +261,108 @@ func isPrivateIdent(s string) bool {
 	r := []rune(s)[0]
 	return unicode.IsLower(r)
 }
+
+// isNumericType reports whether t names one of Go's predeclared numeric types
+func isNumericType(t string) bool {
+	switch t {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "byte", "rune":
+		return true
+	}
+	return false
+}
+
+// directiveArgs holds the arguments following a directive name, e.g. `batch size=100 window=5ms`
+// Bare words such as `stream` are stored with an empty value
+type directiveArgs map[string]string
+
+// splitDirective splits a raw directive into its name and space separated arguments
+func splitDirective(d string) (string, directiveArgs) {
+	fields := strings.Fields(d)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	args := directiveArgs{}
+	for _, f := range fields[1:] {
+		key, value, _ := strings.Cut(f, "=")
+		args[key] = value
+	}
+	return fields[0], args
+}
+
+// positionalArgs returns the arguments of a directive that name declarations, in order, e.g. Circle and Square
+// for `//gofn:enum Circle Square`; key=value arguments and the unexported flag are not positional
+func positionalArgs(directive string) []string {
+	fields := strings.Fields(directive)
+	if len(fields) == 0 {
+		return nil
+	}
+	var names []string
+	for _, f := range fields[1:] {
+		if strings.Contains(f, "=") || f == "unexported" {
+			continue
+		}
+		names = append(names, f)
+	}
+	return names
+}
+
+// has reports whether the argument was given, with or without a value
+func (a directiveArgs) has(key string) bool {
+	_, ok := a[key]
+	return ok
+}
+
+// get returns the value of the argument or def when it is absent or empty
+func (a directiveArgs) get(key, def string) string {
+	if v := a[key]; v != "" {
+		return v
+	}
+	return def
+}
+
+// methodsOf returns the exported methods declared on typeName or *typeName, in source order
+func methodsOf(funcs []parser.FuncInfo, typeName string) []parser.FuncInfo {
+	var methods []parser.FuncInfo
+	for _, f := range funcs {
+		if strings.TrimPrefix(f.Receiver, "*") != typeName || isPrivateIdent(f.Name) {
+			continue
+		}
+		methods = append(methods, f)
+	}
+	return methods
+}
+
+// callArgs returns the argument list forwarding params, expanding a variadic last parameter
+func callArgs(params []parser.ParamInfo) string {
+	args := make([]string, len(params))
+	for i, p := range params {
+		args[i] = paramName(p, i)
+		if strings.HasPrefix(p.Type, "...") {
+			args[i] += "..."
+		}
+	}
+	return strings.Join(args, ", ")
+}
+
+// paramList returns the parameter declarations of params, naming anonymous ones p0..pn
+func paramList(params []parser.ParamInfo) string {
+	parts := make([]string, len(params))
+	for i, p := range params {
+		parts[i] = paramName(p, i) + " " + p.Type
+	}
+	return strings.Join(parts, ", ")
+}
+
+// declaredMethods returns the names of the methods written for typeName or *typeName
+// Methods in gofn's own _gen.go files are left out: an earlier run generated them and this one replaces them
+func declaredMethods(funcs []parser.FuncInfo, typeName string) []string {
+	var names []string
+	for _, m := range funcs {
+		if strings.TrimPrefix(m.Receiver, "*") == typeName && !strings.HasSuffix(m.Pos.Filename, "_gen.go") {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
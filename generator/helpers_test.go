@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// writePackage writes files into a new module requiring this one and returns its directory
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/fixture\n\ngo 1.25.0\n\nrequire github.com/snowmerak/gofn v0.0.0\n\nreplace github.com/snowmerak/gofn => " + root + "\n"
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// generateDir parses the package in dir and generates it in place with opts
+func generateDir(t *testing.T, dir string, opts Options) error {
+	t.Helper()
+	pkg, err := parser.ParsePackage(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return GeneratePackage(dir, pkg, opts)
+}
+
+// goTest runs the tests of the module in dir, skipping when the go command is unavailable
+func goTest(t *testing.T, dir string) {
+	t.Helper()
+	goCommand(t, dir, "test", "-count=1", ".")
+}
+
+// goVet type-checks the module in dir, which may have no tests, skipping when the go command is unavailable
+func goVet(t *testing.T, dir string) {
+	t.Helper()
+	goCommand(t, dir, "vet", ".")
+}
+
+func goCommand(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("builds generated code")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go %s in the generated package: %v\n%s", args[0], err, out)
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// helpersFile is the per-package file holding helper types needed by generated code
+const helpersFile = "gofn_helpers_gen.go"
+
+// maxMonadTuple is the largest TupleN shipped by the monad package
+const maxMonadTuple = 4
+
+// helperTypes collects generic helper types that generated code needs beyond what the monad package ships,
+// so directives can use them instead of failing; each type is written once per output package
+type helperTypes struct {
+	pkg    string
+	tuples map[int]bool
+}
+
+func newHelperTypes() *helperTypes {
+	return &helperTypes{tuples: map[int]bool{}}
+}
+
+// tuple returns the type and constructor names grouping values of the given types,
+// e.g. ("monad.Tuple2[int, string]", "monad.TupleOf2") or ("Tuple5[...]", "TupleOf5") for a local helper
+func (h *helperTypes) tuple(pkg string, types []string) (typ, ctor string) {
+	n := len(types)
+	prefix := "monad."
+	if n > maxMonadTuple {
+		h.pkg = pkg
+		h.tuples[n] = true
+		prefix = ""
+	}
+	return fmt.Sprintf("%sTuple%d[%s]", prefix, n, strings.Join(types, ", ")), fmt.Sprintf("%sTupleOf%d", prefix, n)
+}
+
+// write emits the collected helper types to outDir, removing a stale helpers file when none are needed
+func (h *helperTypes) write(outDir string, opts Options) error {
+	out := filepath.Join(outDir, helpersFile)
+	if len(h.tuples) == 0 {
+		if err := os.Remove(out); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fileHeader("helper types shared by generated files"))
+	buf.WriteString("package " + h.pkg + "\n\n")
+
+	arities := make([]int, 0, len(h.tuples))
+	for n := range h.tuples {
+		arities = append(arities, n)
+	}
+	slices.Sort(arities)
+	for _, n := range arities {
+		writeTupleType(&buf, n)
+	}
+
+	formatted, err := formatSource(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	if _, err := checkSchema(out, opts); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, formatted, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("gofn: generated %s\n", out)
+	opts.Report.generated(h.pkg, "helpers", out, formatted)
+	return nil
+}
+
+// writeTupleType writes TupleN with its constructor and Unpack, mirroring monad.Tuple2..Tuple4
+func writeTupleType(buf *bytes.Buffer, n int) {
+	params := make([]string, n)
+	fields := make([]string, n)
+	args := make([]string, n)
+	values := make([]string, n)
+	for i := range n {
+		params[i] = fmt.Sprintf("T%d", i+1)
+		fields[i] = fmt.Sprintf("V%d: v%d", i+1, i+1)
+		args[i] = fmt.Sprintf("v%d T%d", i+1, i+1)
+		values[i] = fmt.Sprintf("t.V%d", i+1)
+	}
+	typeParams := strings.Join(params, ", ")
+	inst := fmt.Sprintf("Tuple%d[%s]", n, typeParams)
+
+	buf.WriteString(fmt.Sprintf("// Tuple%d groups %d values; the monad package ships tuples up to Tuple%d\n", n, n, maxMonadTuple))
+	buf.WriteString(fmt.Sprintf("type Tuple%d[%s any] struct {\n", n, typeParams))
+	for i := range n {
+		buf.WriteString(fmt.Sprintf("\tV%d T%d\n", i+1, i+1))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// TupleOf%d creates a Tuple%d\n", n, n))
+	buf.WriteString(fmt.Sprintf("func TupleOf%d[%s any](%s) %s {\n", n, typeParams, strings.Join(args, ", "), inst))
+	buf.WriteString(fmt.Sprintf("\treturn %s{%s}\n", inst, strings.Join(fields, ", ")))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Unpack returns the values of the tuple\n")
+	buf.WriteString(fmt.Sprintf("func (t %s) Unpack() (%s) {\n", inst, typeParams))
+	buf.WriteString(fmt.Sprintf("\treturn %s\n", strings.Join(values, ", ")))
+	buf.WriteString("}\n\n")
+}
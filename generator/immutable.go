@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// recordInterface returns the name of the interface //gofn:record generates for the struct name
+func recordInterface(name string, naming Naming, args directiveArgs) string {
+	if naming.keepUnexported(name, args) {
+		// the unexported interface would collide with the struct itself
+		return exportName(name) + "Record"
+	}
+	return exportName(name)
+}
+
+// generateImmutableCode generates `With<Field>` methods returning a copy of s with one field replaced
+// Every field must be unexported, so the copies are the only way to change a value. Alone, the directive also
+// generates a constructor and getters; next to //gofn:record, which has those, the With methods return the
+// record interface, which lists them
+func generateImmutableCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, naming Naming, args directiveArgs) error {
+	for _, f := range s.Fields {
+		if f.Name != "" && !isPrivateIdent(f.Name) {
+			return fmt.Errorf("field %s is exported, so it can change without a copy; unexport it", f.Name)
+		}
+	}
+
+	recv := strings.ToLower(string(s.Name[0]))
+	result := s.Name
+	record := types.hasDirective(s.Name, "record") && isPrivateIdent(s.Name)
+	if record {
+		// the record file names its interface after its own arguments, and unexports it along with the rest
+		recordArgs := types.argsOf(s.Name, "record")
+		result = recordInterface(s.Name, naming, recordArgs)
+		if naming.keepUnexported(s.Name, recordArgs) {
+			result = unexportName(result)
+		}
+	} else {
+		var params, assigns []string
+		for i, f := range s.Fields {
+			if f.Name == "" {
+				continue
+			}
+			param := fieldParamName(f.Name, i)
+			params = append(params, fmt.Sprintf("%s %s", param, f.Type))
+			assigns = append(assigns, fmt.Sprintf("%s: %s", f.Name, param))
+		}
+		ctor := naming.constructor(s.Name)
+		buf.WriteString(fmt.Sprintf("// %s returns a %s; it only changes through its With methods, which return copies\n", ctor, s.Name))
+		buf.WriteString(fmt.Sprintf("func %s(%s) %s {\n\treturn %s{%s}\n}\n\n", ctor, strings.Join(params, ", "), s.Name, s.Name, strings.Join(assigns, ", ")))
+
+		for _, f := range s.Fields {
+			if f.Name == "" {
+				continue
+			}
+			getter := naming.getter(f.Name)
+			buf.WriteString(fmt.Sprintf("// %s returns %s\n", getter, f.Name))
+			buf.WriteString(fmt.Sprintf("func (%s %s) %s() %s {\n\treturn %s.%s\n}\n\n", recv, s.Name, getter, f.Type, recv, f.Name))
+		}
+	}
+
+	for i, f := range s.Fields {
+		if f.Name == "" {
+			continue
+		}
+		param := fieldParamName(f.Name, i)
+		if param == recv {
+			param = "value"
+		}
+		with := "With" + exportName(f.Name)
+		buf.WriteString(fmt.Sprintf("// %s returns a copy of %s with %s set to %s; %s is left untouched\n", with, recv, f.Name, param, recv))
+		buf.WriteString(fmt.Sprintf("func (%s %s) %s(%s %s) %s {\n\t%s.%s = %s\n\treturn %s\n}\n\n",
+			recv, s.Name, with, param, f.Type, result, recv, f.Name, param, recv))
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package generator
+
+import "testing"
+
+func TestImmutableNextToUnexportedRecord(t *testing.T) {
+	cases := map[string]struct {
+		directive string
+		opts      Options
+	}{
+		"record argument": {directive: "//gofn:record unexported", opts: Options{}},
+		"unexported flag": {directive: "//gofn:record", opts: Options{Naming: Naming{Unexported: true}}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := writePackage(t, map[string]string{"person.go": `package fixture
+
+` + c.directive + `
+//gofn:immutable
+type person struct {
+	name string
+	age  int
+}
+
+var _ = person{}.WithName("x").WithAge(3)
+`})
+			if err := generateDir(t, dir, c.opts); err != nil {
+				t.Fatal(err)
+			}
+			goVet(t, dir)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	gofnparser "github.com/snowmerak/gofn/parser"
+)
+
+// generatorImports are the packages generators import on their own, by the name they use for them
+// A declaration importing another package under one of these names gets an alias in the generated file
+var generatorImports = map[string]string{
+	"atomic":  "sync/atomic",
+	"binary":  "encoding/binary",
+	"context": "context",
+	"errors":  "errors",
+	"fmt":     "fmt",
+	"http":    "net/http",
+	"json":    "encoding/json",
+	"math":    "math",
+	"monad":   "github.com/snowmerak/gofn/monad",
+	"optics":  "github.com/snowmerak/gofn/monad/optics",
+	"slices":  "slices",
+	"slog":    "log/slog",
+	"strconv": "strconv",
+	"strings": "strings",
+	"sync":    "sync",
+	"time":    "time",
+	"weak":    "weak",
+}
+
+// fileImports are the imports of one generated file
+// Type expressions copied from declarations are rewritten to the names of this file with qualify, and the
+// packages they use are imported by addTo; two packages sharing a name are told apart by numbering the later one
+type fileImports struct {
+	names map[string]string // name in the generated file -> import path
+	used  map[string]bool   // names qualify produced; addTo imports those the generated code refers to
+}
+
+// newFileImports returns the imports of a generated file, with the generator's own packages reserved
+func newFileImports() *fileImports {
+	f := &fileImports{names: map[string]string{}, used: map[string]bool{}}
+	for name, path := range generatorImports {
+		f.names[name] = path
+	}
+	return f
+}
+
+// name returns the name path has in the generated file, preferring want and numbering it when it is taken
+func (f *fileImports) name(path, want string) string {
+	for name, p := range f.names {
+		if p == path {
+			return name
+		}
+	}
+	name := want
+	for n := 2; ; n++ {
+		if _, taken := f.names[name]; !taken {
+			break
+		}
+		name = want + strconv.Itoa(n)
+	}
+	f.names[name] = path
+	return name
+}
+
+// qualify rewrites the package qualifiers of a type expression declared in a file with imports to their
+// names in the generated file, e.g. `tm.Time` to `time.Time`; qualifiers from no import are kept as is
+func (f *fileImports) qualify(expr string, imports []gofnparser.ImportInfo) string {
+	if len(imports) == 0 || !strings.Contains(expr, ".") {
+		return expr
+	}
+	var s scanner.Scanner
+	fset := token.NewFileSet()
+	s.Init(fset.AddFile("", -1, len(expr)), []byte(expr), nil, 0)
+
+	type scanned struct {
+		off int
+		tok token.Token
+		lit string
+	}
+	var toks []scanned
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		toks = append(toks, scanned{fset.Position(pos).Offset, tok, lit})
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, t := range toks {
+		qualifier := t.tok == token.IDENT && i+2 < len(toks) && toks[i+1].tok == token.PERIOD && toks[i+2].tok == token.IDENT &&
+			(i == 0 || toks[i-1].tok != token.PERIOD)
+		if !qualifier {
+			continue
+		}
+		idx := slices.IndexFunc(imports, func(im gofnparser.ImportInfo) bool { return im.Name == t.lit })
+		if idx < 0 {
+			continue
+		}
+		name := f.name(imports[idx].Path, t.lit)
+		f.used[name] = true
+		b.WriteString(expr[last:t.off])
+		b.WriteString(name)
+		last = t.off + len(t.lit)
+	}
+	b.WriteString(expr[last:])
+	return b.String()
+}
+
+// qualifyStruct returns s with its field types qualified for the generated file
+func (f *fileImports) qualifyStruct(s gofnparser.StructInfo) gofnparser.StructInfo {
+	fields := make([]gofnparser.FieldInfo, len(s.Fields))
+	for i, fd := range s.Fields {
+		fd.Type = f.qualify(fd.Type, s.Imports)
+		fields[i] = fd
+	}
+	s.Fields = fields
+	return s
+}
+
+// qualifyFunc returns fn with its parameter and result types qualified for the generated file
+func (f *fileImports) qualifyFunc(fn gofnparser.FuncInfo) gofnparser.FuncInfo {
+	qualify := func(list []gofnparser.ParamInfo) []gofnparser.ParamInfo {
+		out := make([]gofnparser.ParamInfo, len(list))
+		for i, p := range list {
+			p.Type = f.qualify(p.Type, fn.Imports)
+			out[i] = p
+		}
+		return out
+	}
+	fn.Params = qualify(fn.Params)
+	fn.Results = qualify(fn.Results)
+	fn.Receiver = f.qualify(fn.Receiver, fn.Imports)
+	return fn
+}
+
+// addTo adds the imports of the qualified types src uses but does not import yet
+func (f *fileImports) addTo(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("adding imports: %w", err)
+	}
+	have := map[string]bool{}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		have[path] = true
+	}
+	refs := map[string]bool{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && f.used[x.Name] {
+				refs[x.Name] = true
+			}
+		}
+		return true
+	})
+
+	var specs []string
+	for name := range refs {
+		path := f.names[name]
+		if have[path] {
+			continue
+		}
+		if name == gofnparser.ImportName(path) {
+			specs = append(specs, strconv.Quote(path))
+		} else {
+			specs = append(specs, name+" "+strconv.Quote(path))
+		}
+	}
+	if len(specs) == 0 {
+		return src, nil
+	}
+	slices.Sort(specs)
+	lines := "\t" + strings.Join(specs, "\n\t") + "\n"
+
+	// extend the first import declaration, or add one after the package clause
+	from := fset.Position(file.Name.End()).Offset
+	to, insert := from, "\n\nimport (\n"+lines+")"
+	if gd, ok := firstImport(file); ok {
+		from, to = fset.Position(gd.Pos()).Offset, fset.Position(gd.End()).Offset
+		if gd.Lparen.IsValid() {
+			from, to = fset.Position(gd.Rparen).Offset, fset.Position(gd.Rparen).Offset
+			insert = lines
+		} else {
+			insert = "import (\n\t" + string(src[fset.Position(gd.Specs[0].Pos()).Offset:to]) + "\n" + lines + ")"
+		}
+	}
+	out := make([]byte, 0, len(src)+len(insert))
+	out = append(out, src[:from]...)
+	out = append(out, insert...)
+	return append(out, src[to:]...), nil
+}
+
+// firstImport returns the first import declaration of file
+func firstImport(file *ast.File) (*ast.GenDecl, bool) {
+	if len(file.Decls) == 0 {
+		return nil, false
+	}
+	gd, ok := file.Decls[0].(*ast.GenDecl)
+	return gd, ok && gd.Tok == token.IMPORT
+}
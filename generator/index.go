@@ -0,0 +1,245 @@
+package generator
+
+import (
+	"cmp"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gofnparser "github.com/snowmerak/gofn/parser"
+)
+
+// IndexVersion identifies the shape of the JSON Index; it is bumped whenever a field is renamed or removed
+const IndexVersion = 1
+
+// Index lists the directive-annotated declarations of a package with the files and symbols gofn generates
+// for them, so editors and tools can navigate between source and generated code without parsing either
+type Index struct {
+	Version int         `json:"version"`
+	Package string      `json:"package"`
+	Decls   []IndexDecl `json:"decls"`
+	// Helpers are the shared declarations of gofn_helpers_gen.go, which no single declaration owns
+	Helpers []IndexSymbol `json:"helpers,omitempty"`
+}
+
+// IndexDecl is an annotated declaration of the package
+type IndexDecl struct {
+	Name       string           `json:"name"`
+	Kind       string           `json:"kind"` // struct, func, method, type or var
+	Receiver   string           `json:"receiver,omitempty"`
+	Pos        IndexPos         `json:"pos"`
+	Fields     []IndexField     `json:"fields,omitempty"`
+	Directives []IndexDirective `json:"directives"`
+}
+
+// IndexField is a field of an annotated struct
+type IndexField struct {
+	Name string `json:"name"` // empty for an embedded field
+	Type string `json:"type"`
+	Tag  string `json:"tag,omitempty"`
+}
+
+// IndexDirective is one directive of a declaration and what it generates
+type IndexDirective struct {
+	Directive string `json:"directive"` // as written after //gofn:, arguments included
+	// EnabledBy names the directive that made gofn add this one, which the source does not carry
+	EnabledBy string        `json:"enabled_by,omitempty"`
+	Files     []string      `json:"files,omitempty"`
+	Symbols   []IndexSymbol `json:"symbols,omitempty"`
+}
+
+// IndexSymbol is a top-level identifier declared in a generated file
+type IndexSymbol struct {
+	Name     string   `json:"name"`
+	Kind     string   `json:"kind"` // func, method, type, var or const
+	Receiver string   `json:"receiver,omitempty"`
+	Pos      IndexPos `json:"pos"`
+}
+
+// IndexPos is a position in a file; Line and Column start at 1, and Column counts bytes
+type IndexPos struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// BuildIndex generates pkg into genDir, which should be an empty scratch directory, and indexes its annotated
+// declarations with what was generated for them; generated positions name the files in outDir, where gofn
+// writes them, and hold for the committed files as long as they are up to date (see `gofn check`)
+// opts shapes the generated code as for GeneratePackage; its Report is replaced
+func BuildIndex(pkg gofnparser.Package, genDir, outDir string, opts Options) (*Index, error) {
+	report := &Report{}
+	opts.Report = report
+	if err := GeneratePackage(genDir, pkg, opts); err != nil {
+		return nil, err
+	}
+
+	idx := &Index{Version: IndexVersion, Decls: []IndexDecl{}}
+	byKey := map[string]int{}
+	add := func(key string, d IndexDecl, directive string) {
+		i, ok := byKey[key]
+		if !ok {
+			i = len(idx.Decls)
+			byKey[key] = i
+			idx.Decls = append(idx.Decls, d)
+		}
+		idx.Decls[i].Directives = append(idx.Decls[i].Directives, IndexDirective{Directive: directive})
+	}
+	structDecl := func(s gofnparser.StructInfo) IndexDecl {
+		fields := make([]IndexField, len(s.Fields))
+		for i, f := range s.Fields {
+			fields[i] = IndexField{Name: f.Name, Type: f.Type, Tag: f.Tag}
+		}
+		return IndexDecl{Name: s.Name, Kind: "struct", Pos: indexPos(s.Pos), Fields: fields}
+	}
+	for _, s := range pkg.Structs {
+		idx.Package = cmp.Or(idx.Package, s.Package)
+		if s.Directive != "" {
+			add(s.Name, structDecl(s), s.Directive)
+		}
+	}
+	for _, f := range pkg.Funcs {
+		idx.Package = cmp.Or(idx.Package, f.Package)
+		if f.Directive == "" {
+			continue
+		}
+		kind := "func"
+		if f.Receiver != "" {
+			kind = "method"
+		}
+		add(f.Receiver+"."+f.Name, IndexDecl{Name: f.Name, Kind: kind, Receiver: f.Receiver, Pos: indexPos(f.Pos)}, f.Directive)
+	}
+	for _, t := range pkg.Types {
+		idx.Package = cmp.Or(idx.Package, t.Package)
+		if t.Directive != "" {
+			add(t.Name, IndexDecl{Name: t.Name, Kind: "type", Pos: indexPos(t.Pos)}, t.Directive)
+		}
+	}
+	for _, v := range pkg.Vars {
+		idx.Package = cmp.Or(idx.Package, v.Package)
+		if v.Directive != "" {
+			add(v.Name, IndexDecl{Name: v.Name, Kind: "var", Pos: indexPos(v.Pos)}, v.Directive)
+		}
+	}
+	// directives gofn enabled may annotate structs that carry none in the source
+	for _, e := range report.Enabled {
+		i := slices.IndexFunc(pkg.Structs, func(s gofnparser.StructInfo) bool { return s.Name == e.Decl })
+		if i < 0 {
+			continue
+		}
+		add(e.Decl, structDecl(pkg.Structs[i]), e.Directive)
+		d := &idx.Decls[byKey[e.Decl]]
+		d.Directives[len(d.Directives)-1].EnabledBy = e.RequiredBy
+	}
+
+	// the report names a file's declaration and directive; methods are reported by name alone
+	for _, file := range report.Files {
+		out := filepath.Join(outDir, filepath.Base(file.Path))
+		var symbols []IndexSymbol
+		if strings.HasSuffix(file.Path, ".go") {
+			var err error
+			if symbols, err = generatedSymbols(file.Path, out); err != nil {
+				return nil, err
+			}
+		}
+		if file.Directive == "helpers" {
+			idx.Helpers = append(idx.Helpers, symbols...)
+			continue
+		}
+		d := findIndexDirective(idx, file.Decl, file.Directive)
+		if d == nil {
+			continue
+		}
+		d.Files = append(d.Files, out)
+		d.Symbols = append(d.Symbols, symbols...)
+	}
+
+	slices.SortStableFunc(idx.Decls, func(a, b IndexDecl) int {
+		return cmp.Or(cmp.Compare(a.Pos.File, b.Pos.File), cmp.Compare(a.Pos.Line, b.Pos.Line), cmp.Compare(a.Pos.Column, b.Pos.Column))
+	})
+	return idx, nil
+}
+
+// findIndexDirective returns the directive of the declaration named decl whose name is directive
+func findIndexDirective(idx *Index, decl, directive string) *IndexDirective {
+	for i := range idx.Decls {
+		if idx.Decls[i].Name != decl {
+			continue
+		}
+		for j := range idx.Decls[i].Directives {
+			if name, _ := splitDirective(idx.Decls[i].Directives[j].Directive); name == directive {
+				return &idx.Decls[i].Directives[j]
+			}
+		}
+	}
+	return nil
+}
+
+// generatedSymbols returns the top-level identifiers declared in the generated file at path, positioned in out
+func generatedSymbols(path, out string) ([]IndexSymbol, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	pos := func(p token.Pos) IndexPos {
+		position := fset.Position(p)
+		return IndexPos{File: out, Line: position.Line, Column: position.Column}
+	}
+	var symbols []IndexSymbol
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			sym := IndexSymbol{Name: d.Name.Name, Kind: "func", Pos: pos(d.Name.Pos())}
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				sym.Kind, sym.Receiver = "method", receiverName(d.Recv.List[0].Type)
+			}
+			symbols = append(symbols, sym)
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					symbols = append(symbols, IndexSymbol{Name: s.Name.Name, Kind: "type", Pos: pos(s.Name.Pos())})
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						if n.Name != "_" {
+							symbols = append(symbols, IndexSymbol{Name: n.Name, Kind: strings.ToLower(d.Tok.String()), Pos: pos(n.Pos())})
+						}
+					}
+				}
+			}
+		}
+	}
+	return symbols, nil
+}
+
+// receiverName returns the type name of a method receiver, e.g. "*Person" for `p *Person`
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return "*" + receiverName(e.X)
+	case *ast.IndexExpr:
+		return receiverName(e.X)
+	case *ast.IndexListExpr:
+		return receiverName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
+
+func indexPos(p token.Position) IndexPos {
+	return IndexPos{File: p.Filename, Line: p.Line, Column: p.Column}
+}
+
+// WriteJSON writes the index as indented JSON
+func (idx *Index) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(idx)
+}
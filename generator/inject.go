@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// provider is a //gofn:provide function as the generated Container sees it
+type provider struct {
+	fn       parser.FuncInfo
+	accessor string // Container method returning the value, e.g. DB for NewDB
+	once     string // Container field memoizing the provider, e.g. onceDB
+	typ      string // provided type
+	fallible bool   // the provider also returns an error
+}
+
+// providers returns the //gofn:provide functions of funcs with their types qualified by imports, failing on
+// functions of another shape and on two functions providing the same type or accessor
+func providers(funcs []parser.FuncInfo, imports *fileImports) ([]provider, error) {
+	var ps []provider
+	for _, f := range funcs {
+		if name, _ := splitDirective(f.Directive); name != "provide" {
+			continue
+		}
+		f = imports.qualifyFunc(f)
+		p := provider{fn: f}
+		switch {
+		case f.Receiver != "":
+			return nil, fmt.Errorf("provider %s: methods cannot be providers", f.Name)
+		case len(f.Results) == 1 && f.Results[0].Type != "error":
+		case len(f.Results) == 2 && f.Results[1].Type == "error":
+			p.fallible = true
+		default:
+			return nil, fmt.Errorf("provider %s: must return a value, or a value and an error", f.Name)
+		}
+		p.typ = f.Results[0].Type
+		accessor := f.Name
+		for _, prefix := range []string{"Provide", "provide", "New", "new"} {
+			if rest, ok := strings.CutPrefix(accessor, prefix); ok && rest != "" {
+				accessor = rest
+				break
+			}
+		}
+		p.accessor = exportName(accessor)
+		p.once = "once" + p.accessor
+		for _, other := range ps {
+			switch {
+			case other.typ == p.typ:
+				return nil, fmt.Errorf("providers %s and %s both provide %s", other.fn.Name, f.Name, p.typ)
+			case other.accessor == p.accessor:
+				return nil, fmt.Errorf("providers %s and %s would both be resolved by Container.%s; rename one", other.fn.Name, f.Name, p.accessor)
+			}
+		}
+		ps = append(ps, p)
+	}
+	return ps, nil
+}
+
+// providerFor returns the provider of typ
+func providerFor(ps []provider, typ string) (provider, bool) {
+	i := slices.IndexFunc(ps, func(p provider) bool { return p.typ == typ })
+	if i < 0 {
+		return provider{}, false
+	}
+	return ps[i], true
+}
+
+// checkProviders fails on a provider parameter no provider makes, other than a context.Context, and on cycles,
+// which the generated code would otherwise only reveal at run time
+func checkProviders(ps []provider) error {
+	for _, p := range ps {
+		for _, param := range p.fn.Params {
+			if _, ok := providerFor(ps, param.Type); !ok && param.Type != "context.Context" {
+				return fmt.Errorf("provider %s: no provider for parameter %s %s", p.fn.Name, param.Name, param.Type)
+			}
+		}
+	}
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := map[string]int{}
+	var path []string
+	var visit func(p provider) error
+	visit = func(p provider) error {
+		switch state[p.typ] {
+		case visiting:
+			start := slices.Index(path, p.fn.Name)
+			return fmt.Errorf("provider cycle: %s -> %s", strings.Join(path[start:], " -> "), p.fn.Name)
+		case done:
+			return nil
+		}
+		state[p.typ] = visiting
+		path = append(path, p.fn.Name)
+		for _, param := range p.fn.Params {
+			if dep, ok := providerFor(ps, param.Type); ok {
+				if err := visit(dep); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[p.typ] = done
+		return nil
+	}
+	for _, p := range ps {
+		if err := visit(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkInjection validates the provider graph of pkg and the fields of its //gofn:inject structs before any
+// of their files is written, so a broken graph leaves no file referring to a Container never generated
+func checkInjection(pkg parser.Package) error {
+	first := slices.IndexFunc(pkg.Funcs, func(f parser.FuncInfo) bool {
+		name, _ := splitDirective(f.Directive)
+		return name == "provide"
+	})
+	imports := newFileImports()
+	ps, err := providers(pkg.Funcs, imports)
+	if err == nil && first >= 0 {
+		err = checkProviders(ps)
+	}
+	if err != nil {
+		return fmt.Errorf("generating provide code for %s: %w", pkg.Funcs[first].Name, err)
+	}
+	for _, st := range pkg.Structs {
+		if name, _ := splitDirective(st.Directive); name != "inject" {
+			continue
+		}
+		var discard bytes.Buffer
+		if err := generateInjectCode(&discard, imports.qualifyStruct(st), pkg.Funcs, imports); err != nil {
+			return fmt.Errorf("generating inject code for %s: %w", st.Name, err)
+		}
+	}
+	return nil
+}
+
+// generateProvideCode generates the Container method resolving the provider f; the package's first provider
+// also declares Container and its constructor, after checking every provider's dependencies
+func generateProvideCode(buf *bytes.Buffer, f parser.FuncInfo, funcs []parser.FuncInfo, imports *fileImports, naming Naming) error {
+	ps, err := providers(funcs, imports)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(ps, func(p provider) bool { return p.fn.Name == f.Name })
+	p := ps[i]
+
+	if i == 0 {
+		if err := checkProviders(ps); err != nil {
+			return err
+		}
+		buf.WriteString("import (\n\t\"context\"\n\t\"sync\"\n)\n\n")
+		buf.WriteString("// Container resolves the values of the package's //gofn:provide functions, calling each provider at most once\n")
+		buf.WriteString("// and only when a value needs it; create one per request, or per scope the values should live for\n")
+		buf.WriteString("type Container struct {\n\tctx context.Context\n")
+		for _, p := range ps {
+			buf.WriteString(fmt.Sprintf("\t%s func() (%s, error)\n", p.once, p.typ))
+		}
+		buf.WriteString("}\n\n")
+		ctor := naming.constructor("Container")
+		buf.WriteString(fmt.Sprintf("// %s returns an empty Container; providers taking a context.Context get ctx\n", ctor))
+		buf.WriteString(fmt.Sprintf("func %s(ctx context.Context) *Container {\n\tc := &Container{ctx: ctx}\n", ctor))
+		for _, p := range ps {
+			buf.WriteString(fmt.Sprintf("\tc.%s = sync.OnceValues(c.resolve%s)\n", p.once, p.accessor))
+		}
+		buf.WriteString("\treturn c\n}\n\n")
+	}
+
+	buf.WriteString(fmt.Sprintf("// %s returns the %s made by %s, calling it on first use\n", p.accessor, p.typ, f.Name))
+	buf.WriteString(fmt.Sprintf("func (c *Container) %s() (%s, error) {\n\treturn c.%s()\n}\n\n", p.accessor, p.typ, p.once))
+
+	buf.WriteString(fmt.Sprintf("// resolve%s calls %s with its dependencies from c\n", p.accessor, f.Name))
+	buf.WriteString(fmt.Sprintf("func (c *Container) resolve%s() (%s, error) {\n", p.accessor, p.typ))
+	args := make([]string, len(p.fn.Params))
+	for j, param := range p.fn.Params {
+		if param.Type == "context.Context" {
+			args[j] = "c.ctx"
+			continue
+		}
+		dep, _ := providerFor(ps, param.Type)
+		args[j] = fmt.Sprintf("a%d", j+1)
+		buf.WriteString(fmt.Sprintf("\t%s, err := c.%s()\n\tif err != nil {\n\t\tvar zero %s\n\t\treturn zero, err\n\t}\n", args[j], dep.accessor, p.typ))
+	}
+	call := fmt.Sprintf("%s(%s)", f.Name, strings.Join(args, ", "))
+	if p.fallible {
+		buf.WriteString(fmt.Sprintf("\treturn %s\n}\n", call))
+	} else {
+		buf.WriteString(fmt.Sprintf("\treturn %s, nil\n}\n", call))
+	}
+	return nil
+}
+
+// generateInjectCode generates `Inject<Name>`, building s with each field resolved from a Container by its type
+// Fields tagged `inject:"-"` are left zero
+func generateInjectCode(buf *bytes.Buffer, s parser.StructInfo, funcs []parser.FuncInfo, imports *fileImports) error {
+	ps, err := providers(funcs, imports)
+	if err != nil {
+		return err
+	}
+	name := "Inject" + exportName(s.Name)
+	buf.WriteString(fmt.Sprintf("// %s returns a %s value with its fields resolved from c, failing with the first provider error\n", name, s.Name))
+	buf.WriteString(fmt.Sprintf("func %s(c *Container) (%s, error) {\n", name, s.Name))
+	var values []string
+	for i, f := range s.Fields {
+		if f.Name == "" || reflect.StructTag(f.Tag).Get("inject") == "-" {
+			continue
+		}
+		dep, ok := providerFor(ps, f.Type)
+		if !ok {
+			return fmt.Errorf("no provider for field %s %s; add a //gofn:provide function or tag it `inject:\"-\"`", f.Name, f.Type)
+		}
+		v := fmt.Sprintf("v%d", i+1)
+		buf.WriteString(fmt.Sprintf("\t%s, err := c.%s()\n\tif err != nil {\n\t\treturn %s{}, err\n\t}\n", v, dep.accessor, s.Name))
+		values = append(values, fmt.Sprintf("%s: %s", f.Name, v))
+	}
+	buf.WriteString(fmt.Sprintf("\treturn %s{%s}, nil\n}\n", s.Name, strings.Join(values, ", ")))
+	return nil
+}
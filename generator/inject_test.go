@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInjectionErrorsWriteNoFiles(t *testing.T) {
+	cases := map[string]struct {
+		src  string
+		want string
+	}{
+		"cycle": {
+			src: `package fixture
+
+type A struct{}
+type B struct{}
+
+//gofn:provide
+func NewA(b *B) *A { return &A{} }
+
+//gofn:provide
+func NewB(a *A) *B { return &B{} }
+
+//gofn:inject
+type Server struct {
+	A *A
+}
+`,
+			want: "provider cycle: NewA -> NewB -> NewA",
+		},
+		"missing provider": {
+			src: `package fixture
+
+type A struct{}
+type B struct{}
+
+//gofn:provide
+func NewA() *A { return &A{} }
+
+//gofn:inject
+type Server struct {
+	A *A
+	B *B
+}
+`,
+			want: "no provider for field B *B",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := writePackage(t, map[string]string{"wire.go": c.src})
+			err := generateDir(t, dir, Options{})
+			if err == nil || !strings.Contains(err.Error(), c.want) {
+				t.Fatalf("Expected an error containing %q, got %v", c.want, err)
+			}
+			generated, _ := filepath.Glob(filepath.Join(dir, "*_gen.go"))
+			if len(generated) > 0 {
+				t.Errorf("A broken provider graph should write no files, wrote %v", generated)
+			}
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateIteratorCode generates range-over-func iterators over the slice or map field of s: All, plus Keys and
+// Values for a map, a lazy Filter and Collect returning a copy of the elements
+// The field is the struct's only slice or map field, or the one named by the `field` argument; named slice and
+// map types are looked through
+func generateIteratorCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, args directiveArgs) error {
+	var field parser.FieldInfo
+	var collection string
+	var candidates []string
+	for _, f := range s.Fields {
+		if f.Name == "" {
+			continue
+		}
+		t, ok := types.collectionType(f.Type)
+		if !ok {
+			if f.Name == args.get("field", "") {
+				return fmt.Errorf("field %s is of type %s, not a slice or map", f.Name, f.Type)
+			}
+			continue
+		}
+		candidates = append(candidates, f.Name)
+		if f.Name == args.get("field", f.Name) {
+			field, collection = f, t
+		}
+	}
+	switch {
+	case len(candidates) == 0:
+		return fmt.Errorf("%s has no slice or map field to iterate", s.Name)
+	case field.Name == "":
+		return fmt.Errorf("%s has no field %s", s.Name, args.get("field", ""))
+	case !args.has("field") && len(candidates) > 1:
+		return fmt.Errorf("%s has several slice and map fields (%s); choose one with field=<name>", s.Name, strings.Join(candidates, ", "))
+	}
+
+	methods := []string{"All", "Filter", "Collect"}
+	isMap := strings.HasPrefix(collection, "map[")
+	if isMap {
+		methods = []string{"All", "Keys", "Values", "Filter", "Collect"}
+	}
+	declared := declaredMethods(types.funcs, s.Name)
+	for _, m := range methods {
+		if slices.Contains(declared, m) {
+			return fmt.Errorf("%s already declares %s", s.Name, m)
+		}
+		if slices.ContainsFunc(s.Fields, func(f parser.FieldInfo) bool { return f.Name == m }) {
+			return fmt.Errorf("%s has a field named %s, which the iterator method would collide with", s.Name, m)
+		}
+	}
+
+	name, items := s.Name, "v."+field.Name
+	if !isMap {
+		elem := strings.TrimPrefix(collection, "[]")
+		buf.WriteString("import (\n\t\"iter\"\n\t\"slices\"\n)\n\n")
+		buf.WriteString(fmt.Sprintf("// All returns the elements of %s in order\n", field.Name))
+		buf.WriteString(fmt.Sprintf("func (v %s) All() iter.Seq[%s] {\n\treturn slices.Values(%s)\n}\n\n", name, elem, items))
+		buf.WriteString(fmt.Sprintf("// Filter returns the elements of %s keep returns true for, in order; keep runs as the sequence is ranged over\n", field.Name))
+		buf.WriteString(fmt.Sprintf("func (v %s) Filter(keep func(%s) bool) iter.Seq[%s] {\n", name, elem, elem))
+		buf.WriteString(fmt.Sprintf("\treturn func(yield func(%s) bool) {\n\t\tfor _, e := range %s {\n", elem, items))
+		buf.WriteString("\t\t\tif keep(e) && !yield(e) {\n\t\t\t\treturn\n\t\t\t}\n\t\t}\n\t}\n}\n\n")
+		buf.WriteString(fmt.Sprintf("// Collect returns a copy of the elements of %s\n", field.Name))
+		buf.WriteString(fmt.Sprintf("func (v %s) Collect() []%s {\n\treturn slices.Clone(%s)\n}\n\n", name, elem, items))
+		return nil
+	}
+
+	key, elem := splitMapType(collection)
+	buf.WriteString("import (\n\t\"iter\"\n\t\"maps\"\n)\n\n")
+	buf.WriteString(fmt.Sprintf("// All returns the entries of %s in no particular order\n", field.Name))
+	buf.WriteString(fmt.Sprintf("func (v %s) All() iter.Seq2[%s, %s] {\n\treturn maps.All(%s)\n}\n\n", name, key, elem, items))
+	buf.WriteString(fmt.Sprintf("// Keys returns the keys of %s in no particular order\n", field.Name))
+	buf.WriteString(fmt.Sprintf("func (v %s) Keys() iter.Seq[%s] {\n\treturn maps.Keys(%s)\n}\n\n", name, key, items))
+	buf.WriteString(fmt.Sprintf("// Values returns the values of %s in no particular order\n", field.Name))
+	buf.WriteString(fmt.Sprintf("func (v %s) Values() iter.Seq[%s] {\n\treturn maps.Values(%s)\n}\n\n", name, elem, items))
+	buf.WriteString(fmt.Sprintf("// Filter returns the entries of %s keep returns true for; keep runs as the sequence is ranged over\n", field.Name))
+	buf.WriteString(fmt.Sprintf("func (v %s) Filter(keep func(%s, %s) bool) iter.Seq2[%s, %s] {\n", name, key, elem, key, elem))
+	buf.WriteString(fmt.Sprintf("\treturn func(yield func(%s, %s) bool) {\n\t\tfor k, e := range %s {\n", key, elem, items))
+	buf.WriteString("\t\t\tif keep(k, e) && !yield(k, e) {\n\t\t\t\treturn\n\t\t\t}\n\t\t}\n\t}\n}\n\n")
+	buf.WriteString(fmt.Sprintf("// Collect returns a copy of %s\n", field.Name))
+	buf.WriteString(fmt.Sprintf("func (v %s) Collect() map[%s]%s {\n\treturn maps.Clone(%s)\n}\n\n", name, key, elem, items))
+	return nil
+}
@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// jsonSchemaDialect is the JSON Schema version of the documents written for //gofn:schema
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaBuilder derives JSON Schemas from struct declarations, collecting the package structs they
+// reference under refPrefix ("#/$defs/" for a JSON Schema, "#/components/schemas/" for OpenAPI)
+type schemaBuilder struct {
+	types     typeIndex
+	refPrefix string
+	defs      map[string]any
+}
+
+// generateSchemaCode writes `<Name>JSONSchema`, the JSON Schema of s, and the schema documents next to the code:
+// `<Name>.schema.json` always and `<Name>.openapi.json` with the `openapi` directive argument
+// Field names and omission follow the json tags, and go-playground style validate tags add constraints
+func generateSchemaCode(buf *bytes.Buffer, outDir string, s parser.StructInfo, args directiveArgs, types typeIndex, opts Options) error {
+	b := &schemaBuilder{types: types, refPrefix: "#/$defs/", defs: map[string]any{}}
+	doc := b.object(s)
+	doc["$schema"] = jsonSchemaDialect
+	doc["title"] = s.Name
+	if len(b.defs) > 0 {
+		doc["$defs"] = b.defs
+	}
+	schema, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	literal := "`" + string(schema) + "`"
+	if strings.Contains(string(schema), "`") {
+		literal = strconv.Quote(string(schema))
+	}
+	name := exportName(s.Name) + "JSONSchema"
+	buf.WriteString(fmt.Sprintf("// %s is the JSON Schema of %s, derived from its json and validate tags\n", name, s.Name))
+	buf.WriteString(fmt.Sprintf("const %s = %s\n\n", name, literal))
+
+	if err := writeSchemaDoc(outDir, s, "schema", append(schema, '\n'), opts); err != nil {
+		return err
+	}
+	if !args.has("openapi") {
+		return nil
+	}
+
+	b = &schemaBuilder{types: types, refPrefix: "#/components/schemas/", defs: map[string]any{}}
+	b.defs[s.Name] = b.object(s)
+	openapi, err := json.MarshalIndent(map[string]any{
+		"openapi":    "3.1.0",
+		"info":       map[string]any{"title": s.Name, "version": args.get("version", "1.0.0")},
+		"paths":      map[string]any{},
+		"components": map[string]any{"schemas": b.defs},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeSchemaDoc(outDir, s, "openapi", append(openapi, '\n'), opts)
+}
+
+// writeSchemaDoc writes a schema document for s as <Name>.<kind>.json
+func writeSchemaDoc(outDir string, s parser.StructInfo, kind string, content []byte, opts Options) error {
+	out := filepath.Join(outDir, fmt.Sprintf("%s.%s.json", s.Name, kind))
+	if err := os.WriteFile(out, content, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("gofn: generated %s\n", out)
+	opts.Report.generated(s.Name, "schema", out, content)
+	return nil
+}
+
+// object returns the object schema of s
+// A field is required when its validate tag says so, or when encoding/json always emits it:
+// it has no omitempty and is neither a pointer nor a monad.Option
+func (b *schemaBuilder) object(s parser.StructInfo) map[string]any {
+	props := map[string]any{}
+	required := []string{}
+	for _, f := range s.Fields {
+		if f.Name == "" || !ast.IsExported(f.Name) {
+			continue
+		}
+		tag := reflect.StructTag(f.Tag)
+		name, opts, _ := strings.Cut(tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		elem, optional := optionElem(f.Type)
+		if !optional {
+			elem = f.Type
+		}
+		prop := b.schemaFor(elem)
+		validate := strings.Split(tag.Get("validate"), ",")
+		applyValidate(prop, validate)
+		props[name] = prop
+
+		omitempty := strings.Contains(","+opts+",", ",omitempty,") || strings.Contains(","+opts+",", ",omitzero,")
+		if containsWord(validate, "required") || !omitempty && !optional && !strings.HasPrefix(f.Type, "*") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// schemaFor returns the schema of a Go type expression
+// Structs of the package become references to definitions; types the generator cannot see accept any value
+func (b *schemaBuilder) schemaFor(t string) map[string]any {
+	t = strings.TrimSpace(t)
+	switch t {
+	case "string":
+		return map[string]any{"type": "string"}
+	case "bool":
+		return map[string]any{"type": "boolean"}
+	case "float32", "float64":
+		return map[string]any{"type": "number"}
+	case "time.Time":
+		return map[string]any{"type": "string", "format": "date-time"}
+	case "[]byte":
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case "any", "interface{}", "json.RawMessage":
+		return map[string]any{}
+	}
+	if isNumericType(t) || t == "time.Duration" {
+		return map[string]any{"type": "integer"}
+	}
+	if elem, ok := strings.CutPrefix(t, "*"); ok {
+		return b.schemaFor(elem)
+	}
+	if elem, ok := optionElem(t); ok {
+		return b.schemaFor(elem)
+	}
+	if elem, ok := strings.CutPrefix(t, "[]"); ok {
+		return map[string]any{"type": "array", "items": b.schemaFor(elem)}
+	}
+	if strings.HasPrefix(t, "[") {
+		_, elem, _ := strings.Cut(t, "]")
+		return map[string]any{"type": "array", "items": b.schemaFor(elem)}
+	}
+	if rest, ok := strings.CutPrefix(t, "map["); ok {
+		if key, elem, ok := strings.Cut(rest, "]"); ok && key == "string" {
+			return map[string]any{"type": "object", "additionalProperties": b.schemaFor(elem)}
+		}
+		return map[string]any{"type": "object"}
+	}
+
+	if s, ok := b.types.structs[t]; ok {
+		if _, done := b.defs[t]; !done {
+			b.defs[t] = nil // placeholder so recursive types terminate
+			b.defs[t] = b.object(s)
+		}
+		return map[string]any{"$ref": b.refPrefix + t}
+	}
+	if nt, ok := b.types.types[t]; ok && nt.Kind != parser.KindStruct && nt.Kind != parser.KindFunc && nt.Kind != parser.KindChan {
+		return b.schemaFor(nt.Underlying)
+	}
+	return map[string]any{}
+}
+
+// applyValidate adds the constraints of go-playground validate rules to schema
+// Length rules apply to the kind of value the schema describes: characters, items or the number itself
+func applyValidate(schema map[string]any, rules []string) {
+	bound := func(kind string) string {
+		switch schema["type"] {
+		case "string":
+			return kind + "Length"
+		case "array":
+			return kind + "Items"
+		case "object":
+			return kind + "Properties"
+		}
+		if kind == "min" {
+			return "minimum"
+		}
+		return "maximum"
+	}
+	number := func(v string) any {
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+		return v
+	}
+
+	for _, rule := range rules {
+		name, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch name {
+		case "min", "gte":
+			schema[bound("min")] = number(value)
+		case "max", "lte":
+			schema[bound("max")] = number(value)
+		case "gt":
+			schema["exclusiveMinimum"] = number(value)
+		case "lt":
+			schema["exclusiveMaximum"] = number(value)
+		case "len":
+			schema[bound("min")] = number(value)
+			schema[bound("max")] = number(value)
+		case "oneof":
+			var enum []any
+			for _, v := range strings.Fields(value) {
+				if schema["type"] == "string" {
+					enum = append(enum, v)
+				} else {
+					enum = append(enum, number(v))
+				}
+			}
+			schema["enum"] = enum
+		case "email":
+			schema["format"] = "email"
+		case "url", "uri":
+			schema["format"] = "uri"
+		case "uuid", "uuid4":
+			schema["format"] = "uuid"
+		case "ipv4", "ipv6", "hostname":
+			schema["format"] = name
+		case "datetime":
+			schema["format"] = "date-time"
+		}
+	}
+}
+
+// containsWord reports whether words contains w
+func containsWord(words []string, w string) bool {
+	for _, x := range words {
+		if strings.TrimSpace(x) == w {
+			return true
+		}
+	}
+	return false
+}
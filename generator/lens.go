@@ -0,0 +1,32 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateLensCode generates `<Name><Field>Lens`, an optics.Lens per named field of s
+// Setting through a lens copies the struct, so lenses of nested structs compose with optics.Compose
+// into immutable updates of deep fields
+func generateLensCode(buf *bytes.Buffer, s parser.StructInfo) error {
+	buf.WriteString("import \"github.com/snowmerak/gofn/monad/optics\"\n\n")
+	buf.WriteString("var (\n")
+	for i, f := range s.Fields {
+		if f.Name == "" {
+			continue
+		}
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		name := exportName(s.Name) + exportName(f.Name) + "Lens"
+		buf.WriteString(fmt.Sprintf("\t// %s focuses on the %s field of %s\n", name, f.Name, s.Name))
+		buf.WriteString(fmt.Sprintf("\t%s = optics.New(\n", name))
+		buf.WriteString(fmt.Sprintf("\t\tfunc(v %s) %s { return v.%s },\n", s.Name, f.Type, f.Name))
+		buf.WriteString(fmt.Sprintf("\t\tfunc(v %s, x %s) %s { v.%s = x; return v },\n", s.Name, f.Type, s.Name, f.Name))
+		buf.WriteString("\t)\n")
+	}
+	buf.WriteString(")\n")
+	return nil
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+)
+
+// Limits bounds the size of generated code so pathological declarations fail with a clear error
+// instead of producing unreadable or uncompilable output; zero fields select the defaults
+type Limits struct {
+	// MaxCurriedParams caps the parameters of a function getting curried wrappers, which nest one closure per parameter (default 12)
+	MaxCurriedParams int
+	// MaxMatchFields caps the fields of a match struct, each of which becomes a parameter of every pattern method (default 16)
+	MaxMatchFields int
+}
+
+// withDefaults fills zero fields with the default limits
+func (l Limits) withDefaults() Limits {
+	if l.MaxCurriedParams == 0 {
+		l.MaxCurriedParams = 12
+	}
+	if l.MaxMatchFields == 0 {
+		l.MaxMatchFields = 16
+	}
+	return l
+}
+
+// checkCurried reports an error when a function has too many parameters to curry
+func (l Limits) checkCurried(pos token.Position, params int) error {
+	if params > l.MaxCurriedParams {
+		return limitError(pos, fmt.Sprintf("function has %d params; currying limited to %d", params, l.MaxCurriedParams), "-max-curried-params")
+	}
+	return nil
+}
+
+// checkMatch reports an error when a struct has too many fields to generate matchers for
+func (l Limits) checkMatch(pos token.Position, fields int) error {
+	if fields > l.MaxMatchFields {
+		return limitError(pos, fmt.Sprintf("struct has %d fields; match limited to %d", fields, l.MaxMatchFields), "-max-match-fields")
+	}
+	return nil
+}
+
+func limitError(pos token.Position, msg, flag string) error {
+	if pos.IsValid() {
+		msg = pos.String() + ": " + msg
+	}
+	return fmt.Errorf("%s (split the declaration or raise the limit with %s)", msg, flag)
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateMatchTable generates `<Name>Table[T]`, a reusable matcher built once with the same When/WhenGuard/Default
+// calls as the fluent matcher; cases whose patterns are all Some values go into a monad.MatchTable map keyed by the
+// struct itself, so matching stays O(1) as the number of cases grows
+// It is only generated when every field is comparable and none is an Option, since those need structural matching
+func generateMatchTable(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, naming Naming) {
+	for _, f := range s.Fields {
+		if _, ok := optionElem(f.Type); ok || !types.isComparable(f.Type) {
+			return
+		}
+	}
+
+	tableName := exportName(s.Name) + "Table"
+	ctorName := naming.constructor(tableName)
+	matchName := fieldParamName(exportName(s.Name), 0) + "TableMatch"
+	params := make([]string, len(s.Fields))
+	names := make([]string, len(s.Fields))
+	somes := make([]string, len(s.Fields))
+	nones := make([]string, len(s.Fields))
+	key := make([]string, len(s.Fields))
+	conds := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		p := strings.ToLower(f.Name)
+		params[i] = fmt.Sprintf("%s monad.Option[%s]", p, f.Type)
+		names[i] = p
+		somes[i] = p + ".IsSome()"
+		nones[i] = p + ".IsNone()"
+		key[i] = fmt.Sprintf("%s: %s.Unwrap()", f.Name, p)
+		conds[i] = fmt.Sprintf("(%s.IsWildcard() || %s.IsSome() && %s.Unwrap() == v.%s)", p, p, p, f.Name)
+	}
+	paramList := strings.Join(params, ", ")
+	nameList := strings.Join(names, ", ")
+
+	buf.WriteString(fmt.Sprintf("// %s is a reusable matcher over %s: register cases once, then Match values against it\n", tableName, s.Name))
+	buf.WriteString("// Cases whose patterns are all Some values are dispatched through a map in O(1); other cases are tried in order,\n")
+	buf.WriteString("// and the first registered case matching a value wins either way. Match is safe for concurrent use once built\n")
+	buf.WriteString(fmt.Sprintf("type %s[T any] struct {\n", tableName))
+	buf.WriteString(fmt.Sprintf("\ttable    *monad.MatchTable[%s, %s, T]\n", s.Name, s.Name))
+	buf.WriteString(fmt.Sprintf("\tfallback func(%s) T\n", s.Name))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s creates an empty %s\n", ctorName, tableName))
+	buf.WriteString(fmt.Sprintf("func %s[T any]() *%s[T] {\n", ctorName, tableName))
+	buf.WriteString(fmt.Sprintf("\treturn &%s[T]{table: monad.NewMatchTable[%s, %s, T]()}\n", tableName, s.Name, s.Name))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// When adds a case; a case with a None pattern never matches and is dropped\n")
+	buf.WriteString(fmt.Sprintf("func (t *%s[T]) When(%s, handler func(%s) T) *%s[T] {\n", tableName, paramList, s.Name, tableName))
+	buf.WriteString(fmt.Sprintf("\tif %s {\n", strings.Join(nones, " || ")))
+	buf.WriteString("\t\treturn t\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\tif %s {\n", strings.Join(somes, " && ")))
+	buf.WriteString(fmt.Sprintf("\t\tt.table.AddExact(%s{%s}, handler)\n", s.Name, strings.Join(key, ", ")))
+	buf.WriteString("\t\treturn t\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\tt.table.Add(func(v %s) bool { return %s(%s, v) }, handler)\n", s.Name, matchName, nameList))
+	buf.WriteString("\treturn t\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// WhenGuard adds a case that also requires guard to accept the value\n")
+	buf.WriteString(fmt.Sprintf("func (t *%s[T]) WhenGuard(%s, guard func(%s) bool, handler func(%s) T) *%s[T] {\n", tableName, paramList, s.Name, s.Name, tableName))
+	buf.WriteString(fmt.Sprintf("\tt.table.Add(func(v %s) bool { return %s(%s, v) && guard(v) }, handler)\n", s.Name, matchName, nameList))
+	buf.WriteString("\treturn t\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Default sets the handler for values matching no case\n")
+	buf.WriteString(fmt.Sprintf("func (t *%s[T]) Default(handler func(%s) T) *%s[T] {\n", tableName, s.Name, tableName))
+	buf.WriteString("\tt.fallback = handler\n")
+	buf.WriteString("\treturn t\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Match returns the result of the first case matching v, else of the Default handler, else the zero T\n")
+	buf.WriteString(fmt.Sprintf("func (t *%s[T]) Match(v %s) T {\n", tableName, s.Name))
+	buf.WriteString("\tif r, ok := t.table.Dispatch(v, v); ok {\n")
+	buf.WriteString("\t\treturn r\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif t.fallback != nil {\n")
+	buf.WriteString("\t\treturn t.fallback(v)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tvar zero T\n")
+	buf.WriteString("\treturn zero\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s reports whether v matches every pattern\n", matchName))
+	buf.WriteString(fmt.Sprintf("func %s(%s, v %s) bool {\n", matchName, paramList, s.Name))
+	buf.WriteString("\treturn " + strings.Join(conds, " &&\n\t\t") + "\n")
+	buf.WriteString("}\n\n")
+}
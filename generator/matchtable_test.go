@@ -0,0 +1,28 @@
+package generator
+
+import "testing"
+
+func TestMatchTableConstructorFollowsNaming(t *testing.T) {
+	cases := map[string]struct {
+		src  string
+		opts Options
+	}{
+		"constructor prefix": {
+			src:  "type Point struct {\n\tX, Y int\n}\n\nvar _ = MakePointTable[string]()\n",
+			opts: Options{Naming: Naming{ConstructorPrefix: "Make"}},
+		},
+		"unexported flag": {
+			src:  "type point struct {\n\tx, y int\n}\n\nvar _ = newPointTable[string]()\n",
+			opts: Options{Naming: Naming{Unexported: true}},
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := writePackage(t, map[string]string{"point.go": "package fixture\n\n//gofn:match\n" + c.src})
+			if err := generateDir(t, dir, c.opts); err != nil {
+				t.Fatal(err)
+			}
+			goVet(t, dir)
+		})
+	}
+}
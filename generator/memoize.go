@@ -0,0 +1,345 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// memoParam is a parameter of a memoized function and how it is keyed
+type memoParam struct {
+	name    string
+	typ     string
+	hashed  bool   // keyed by its Hash() and confirmed with Equal on a hit
+	equalOf string // argument expression passed to Equal, e.g. "from" or "&from"
+	context bool   // a leading context.Context, forwarded but not keyed
+}
+
+// memoizeRequires enables //gofn:equal hash on the package structs a memoized function takes by value that are
+// neither comparable nor hashable yet, so they can key its cache; an external store keys by its own function
+func memoizeRequires(f parser.FuncInfo, types typeIndex) []requirement {
+	if _, args := splitDirective(f.Directive); args.get("store", "memory") != "memory" {
+		return nil
+	}
+	var reqs []requirement
+	for i, p := range f.Params {
+		if _, ok := types.structs[p.Type]; ok && !types.isHashable(p.Type) && !types.isComparable(p.Type) {
+			reqs = append(reqs, requirement{decl: p.Type, directive: "equal hash", reason: fmt.Sprintf("parameter %s of %s", paramName(p, i), f.Name)})
+		}
+	}
+	return reqs
+}
+
+// generateMemoizeCode generates a caching wrapper for a function of the form func(params) (V, error) or func(params) V
+// Comparable parameters are used as cache keys directly; structs declaring Hash() uint64 and Equal, or given them
+// by memoizeRequires, are keyed by their hash and compared with Equal on a hit; any other parameter fails generation
+func generateMemoizeCode(buf *bytes.Buffer, f parser.FuncInfo, args directiveArgs, types typeIndex, naming Naming) error {
+	if f.Receiver != "" {
+		return fmt.Errorf("memoize is only supported on functions, not methods")
+	}
+	withErr := len(f.Results) == 2 && f.Results[1].Type == "error"
+	if !withErr && len(f.Results) != 1 {
+		return fmt.Errorf("memoize requires a signature of the form func(...) (V, error) or func(...) V")
+	}
+
+	size, err := strconv.Atoi(args.get("size", "128"))
+	if err != nil || size < 0 {
+		return fmt.Errorf("invalid memoize size %q", args.get("size", ""))
+	}
+	var ttl time.Duration
+	if args.has("ttl") {
+		ttl, err = time.ParseDuration(args.get("ttl", ""))
+		if err != nil || ttl < 0 {
+			return fmt.Errorf("invalid memoize ttl %q", args.get("ttl", ""))
+		}
+	}
+	switch args.get("store", "memory") {
+	case "memory":
+	case "external":
+		return generateMemoizeStoreCode(buf, f, args, ttl, withErr, naming)
+	default:
+		return fmt.Errorf("invalid memoize store %q (want memory or external)", args.get("store", ""))
+	}
+
+	params := make([]memoParam, len(f.Params))
+	hasCtx, hasHashed := false, false
+	for i, p := range f.Params {
+		mp := memoParam{name: paramName(p, i), typ: p.Type}
+		switch {
+		case i == 0 && p.Type == "context.Context":
+			mp.context, hasCtx = true, true
+		case types.isHashable(p.Type):
+			mp.hashed, hasHashed = true, true
+			mp.equalOf = mp.name
+			if types.equalTakesPointer(p.Type) {
+				mp.equalOf = "&" + mp.name
+			}
+		case !types.isComparable(p.Type):
+			return fmt.Errorf("no cache key strategy for parameter %s of type %s: "+
+				"it is not comparable; declare Hash() uint64 and Equal(%s) bool methods on it, or generate them with //gofn:equal hash", mp.name, p.Type, p.Type)
+		}
+		params[i] = mp
+	}
+
+	valueType := f.Results[0].Type
+	base := exportName(f.Name)
+	memoName := base + "Memo"
+	keyName := fieldParamName(base, 0) + "MemoKey"
+	entryName := fieldParamName(base, 0) + "MemoEntry"
+	call := fmt.Sprintf("%s(%s)", f.Name, callArgs(f.Params))
+
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"context\"\n")
+	if ttl > 0 {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString("\n\t\"github.com/snowmerak/gofn/monad\"\n")
+	buf.WriteString(")\n\n")
+
+	bound := fmt.Sprintf("up to %d entries", size)
+	if size == 0 {
+		bound = "an unbounded number of entries"
+	}
+	if ttl > 0 {
+		bound += fmt.Sprintf(", each kept for %s", ttl)
+	}
+	buf.WriteString(fmt.Sprintf("// %s caches %s results in an LRU of %s\n", memoName, f.Name, bound))
+	if withErr {
+		buf.WriteString(fmt.Sprintf("// Concurrent calls with equal arguments share a single %s call; errors are not cached\n", f.Name))
+	} else {
+		buf.WriteString(fmt.Sprintf("// Concurrent calls with equal arguments share a single %s call\n", f.Name))
+	}
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", memoName))
+	buf.WriteString(fmt.Sprintf("\tcache *monad.Cache[%s, %s]\n", keyName, entryName))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s identifies a %s call; hashed parameters are stored as their Hash()\n", keyName, f.Name))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", keyName))
+	for _, p := range params {
+		switch {
+		case p.context:
+		case p.hashed:
+			buf.WriteString(fmt.Sprintf("\t%s uint64\n", p.name))
+		default:
+			buf.WriteString(fmt.Sprintf("\t%s %s\n", p.name, p.typ))
+		}
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s is a cached result with the hashed arguments it was computed for\n", entryName))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", entryName))
+	for _, p := range params {
+		if p.hashed {
+			buf.WriteString(fmt.Sprintf("\t%s %s\n", p.name, p.typ))
+		}
+	}
+	buf.WriteString(fmt.Sprintf("\tvalue %s\n", valueType))
+	buf.WriteString("}\n\n")
+
+	ctorName := naming.constructor(memoName)
+	buf.WriteString(fmt.Sprintf("// %s creates an empty %s\n", ctorName, memoName))
+	buf.WriteString(fmt.Sprintf("func %s() *%s {\n", ctorName, memoName))
+	ttlLit := "0"
+	if ttl > 0 {
+		ttlLit = durationLiteral(ttl)
+	}
+	buf.WriteString(fmt.Sprintf("\treturn &%s{cache: monad.NewCache[%s, %s](%d, %s)}\n", memoName, keyName, entryName, size, ttlLit))
+	buf.WriteString("}\n\n")
+
+	results := valueType
+	if withErr {
+		results = "(" + valueType + ", error)"
+	}
+	ctxExpr := "context.Background()"
+	if hasCtx {
+		ctxExpr = params[0].name
+	}
+
+	keyFields := []string{}
+	entryFields := []string{}
+	equals := []string{}
+	for _, p := range params {
+		switch {
+		case p.context:
+		case p.hashed:
+			keyFields = append(keyFields, fmt.Sprintf("%s: %s.Hash()", p.name, p.name))
+			entryFields = append(entryFields, fmt.Sprintf("%s: %s", p.name, p.name))
+			equals = append(equals, fmt.Sprintf("!entry.%s.Equal(%s)", p.name, p.equalOf))
+		default:
+			keyFields = append(keyFields, fmt.Sprintf("%s: %s", p.name, p.name))
+		}
+	}
+	entryFields = append(entryFields, "value: value")
+
+	buf.WriteString(fmt.Sprintf("// Call returns the cached result of %s, calling it on a miss\n", call))
+	if hasHashed {
+		buf.WriteString("// A Hash collision between unequal arguments bypasses the cache\n")
+	}
+	buf.WriteString(fmt.Sprintf("func (m *%s) Call(%s) %s {\n", memoName, paramList(f.Params), results))
+	buf.WriteString(fmt.Sprintf("\tkey := %s{%s}\n", keyName, strings.Join(keyFields, ", ")))
+	buf.WriteString(fmt.Sprintf("\tentry, err := m.cache.GetOrLoad(%s, key, func(context.Context) monad.Result[%s] {\n", ctxExpr, entryName))
+	if withErr {
+		buf.WriteString(fmt.Sprintf("\t\tvalue, err := %s\n", call))
+		buf.WriteString("\t\tif err != nil {\n")
+		buf.WriteString(fmt.Sprintf("\t\t\treturn monad.Err[%s](err)\n", entryName))
+		buf.WriteString("\t\t}\n")
+	} else {
+		buf.WriteString(fmt.Sprintf("\t\tvalue := %s\n", call))
+	}
+	buf.WriteString(fmt.Sprintf("\t\treturn monad.Ok(%s{%s})\n", entryName, strings.Join(entryFields, ", ")))
+	buf.WriteString("\t}).Unwrap()\n")
+	if withErr {
+		buf.WriteString("\tif err != nil {\n")
+		buf.WriteString("\t\treturn entry.value, err\n")
+		buf.WriteString("\t}\n")
+	} else {
+		// only ctx ending while another caller's load is shared can fail it; compute directly then
+		buf.WriteString("\tif err != nil {\n")
+		buf.WriteString(fmt.Sprintf("\t\treturn %s\n", call))
+		buf.WriteString("\t}\n")
+	}
+	if hasHashed {
+		buf.WriteString(fmt.Sprintf("\tif %s {\n", strings.Join(equals, " || ")))
+		buf.WriteString(fmt.Sprintf("\t\treturn %s\n", call))
+		buf.WriteString("\t}\n")
+	}
+	if withErr {
+		buf.WriteString("\treturn entry.value, nil\n")
+	} else {
+		buf.WriteString("\treturn entry.value\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Metrics returns a snapshot of the cache's counters\n")
+	buf.WriteString(fmt.Sprintf("func (m *%s) Metrics() monad.CacheMetrics {\n", memoName))
+	buf.WriteString("\treturn m.cache.Metrics()\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Purge drops every cached result\n")
+	buf.WriteString(fmt.Sprintf("func (m *%s) Purge() {\n", memoName))
+	buf.WriteString("\tm.cache.Purge()\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
+
+// generateMemoizeStoreCode generates a caching wrapper keeping results in a monad.KVStore, for `store=external`
+// Results are shared by every process using the store, so arguments are keyed by their JSON encoding and results
+// are serialized as JSON with their expiry; switching the store, e.g. to Redis, only changes the constructor call
+func generateMemoizeStoreCode(buf *bytes.Buffer, f parser.FuncInfo, args directiveArgs, ttl time.Duration, withErr bool, naming Naming) error {
+	hasCtx := len(f.Params) > 0 && f.Params[0].Type == "context.Context"
+	// the key covers every parameter but a leading context; anonymous ones keep the names they have in Call
+	var keyParams, keyArgs, keyCall []string
+	for i, p := range f.Params {
+		if i == 0 && hasCtx {
+			continue
+		}
+		name := paramName(p, i)
+		keyParams = append(keyParams, name+" "+p.Type)
+		keyArgs = append(keyArgs, name)
+		if strings.HasPrefix(p.Type, "...") {
+			name += "..."
+		}
+		keyCall = append(keyCall, name)
+	}
+
+	valueType := f.Results[0].Type
+	base := exportName(f.Name)
+	memoName := base + "Memo"
+	keyName := base + "MemoKey"
+	prefix := args.get("prefix", f.Name)
+	call := fmt.Sprintf("%s(%s)", f.Name, callArgs(f.Params))
+	ctxExpr := "context.Background()"
+	if hasCtx {
+		ctxExpr = paramName(f.Params[0], 0)
+	}
+
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"context\"\n")
+	if ttl > 0 {
+		buf.WriteString("\t\"time\"\n")
+	}
+	buf.WriteString("\n\t\"github.com/snowmerak/gofn/monad\"\n")
+	buf.WriteString(")\n\n")
+
+	kept := ""
+	if ttl > 0 {
+		kept = fmt.Sprintf(", each kept for %s", ttl)
+	}
+	buf.WriteString(fmt.Sprintf("// %s caches %s results in a monad.KVStore under keys prefixed with %q%s\n", memoName, f.Name, prefix, kept))
+	buf.WriteString("// Results are stored as JSON with their expiry, so they are shared by every process using the store;\n")
+	if withErr {
+		buf.WriteString(fmt.Sprintf("// concurrent calls with equal arguments in one process share a single %s call, and errors are not cached\n", f.Name))
+	} else {
+		buf.WriteString(fmt.Sprintf("// concurrent calls with equal arguments in one process share a single %s call\n", f.Name))
+	}
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", memoName))
+	buf.WriteString(fmt.Sprintf("\tcache *monad.KVCache[%s]\n", valueType))
+	buf.WriteString("}\n\n")
+
+	ctorName := naming.constructor(memoName)
+	ttlLit := "0"
+	if ttl > 0 {
+		ttlLit = durationLiteral(ttl)
+	}
+	buf.WriteString(fmt.Sprintf("// %s creates a %s over store, e.g. a Redis adapter; nil keeps the results in process memory\n", ctorName, memoName))
+	buf.WriteString(fmt.Sprintf("func %s(store monad.KVStore) *%s {\n", ctorName, memoName))
+	buf.WriteString(fmt.Sprintf("\treturn &%s{cache: monad.NewKVCache[%s](store, %q, %s)}\n", memoName, valueType, prefix, ttlLit))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s returns the key of a %s call within the %q prefix; it fails for arguments JSON cannot encode\n", keyName, f.Name, prefix))
+	buf.WriteString(fmt.Sprintf("func %s(%s) monad.Result[string] {\n", keyName, strings.Join(keyParams, ", ")))
+	buf.WriteString(fmt.Sprintf("\treturn monad.KVKey(%s)\n", strings.Join(keyArgs, ", ")))
+	buf.WriteString("}\n\n")
+
+	results := valueType
+	if withErr {
+		results = "(" + valueType + ", error)"
+	}
+	buf.WriteString(fmt.Sprintf("// Call returns the cached result of %s, calling it on a miss\n", call))
+	buf.WriteString("// Arguments without a key bypass the cache\n")
+	buf.WriteString(fmt.Sprintf("func (m *%s) Call(%s) %s {\n", memoName, paramList(f.Params), results))
+	buf.WriteString(fmt.Sprintf("\tkey, err := %s(%s).Unwrap()\n", keyName, strings.Join(keyCall, ", ")))
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn %s\n", call))
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\tvalue, err := m.cache.GetOrLoad(%s, key, func(context.Context) monad.Result[%s] {\n", ctxExpr, valueType))
+	if withErr {
+		buf.WriteString(fmt.Sprintf("\t\tvalue, err := %s\n", call))
+		buf.WriteString("\t\tif err != nil {\n")
+		buf.WriteString(fmt.Sprintf("\t\t\treturn monad.Err[%s](err)\n", valueType))
+		buf.WriteString("\t\t}\n")
+		buf.WriteString("\t\treturn monad.Ok(value)\n")
+		buf.WriteString("\t}).Unwrap()\n")
+		buf.WriteString("\treturn value, err\n")
+	} else {
+		buf.WriteString(fmt.Sprintf("\t\treturn monad.Ok(%s)\n", call))
+		buf.WriteString("\t}).Unwrap()\n")
+		// only ctx ending while another caller's load is shared can fail it; compute directly then
+		buf.WriteString("\tif err != nil {\n")
+		buf.WriteString(fmt.Sprintf("\t\treturn %s\n", call))
+		buf.WriteString("\t}\n")
+		buf.WriteString("\treturn value\n")
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// Forget removes the cached result of %s\n", call))
+	buf.WriteString(fmt.Sprintf("func (m *%s) Forget(%s) monad.Result[monad.Unit] {\n", memoName, paramList(f.Params)))
+	buf.WriteString(fmt.Sprintf("\tkey, err := %s(%s).Unwrap()\n", keyName, strings.Join(keyCall, ", ")))
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString("\t\treturn monad.Err[monad.Unit](err)\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\treturn m.cache.Delete(%s, key)\n", ctxExpr))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("// Metrics returns a snapshot of the cache's counters\n")
+	buf.WriteString(fmt.Sprintf("func (m *%s) Metrics() monad.KVCacheMetrics {\n", memoName))
+	buf.WriteString("\treturn m.cache.Metrics()\n")
+	buf.WriteString("}\n\n")
+
+	return nil
+}
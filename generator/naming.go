@@ -0,0 +1,51 @@
+package generator
+
+// Naming controls the prefixes and suffixes of generated identifiers so output can match a house style
+// Empty fields select the defaults; GetterPrefix defaults to no prefix (record getters are Name(), not GetName())
+type Naming struct {
+	// ConstructorPrefix starts every generated constructor, e.g. NewPerson (default "New")
+	ConstructorPrefix string
+	// GetterPrefix starts record getters and the matching fake methods, e.g. "Get" for GetName()
+	GetterPrefix string
+	// OptionPrefix starts functional option functions, e.g. WithHost (default "With")
+	OptionPrefix string
+	// CurriedSuffix ends curried wrappers, e.g. AddCurried (default "Curried")
+	CurriedSuffix string
+	// Unexported keeps the package-level identifiers generated for an unexported source unexported,
+	// e.g. newPerson instead of NewPerson; a single declaration can opt in with the `unexported` directive argument
+	Unexported bool
+}
+
+// withDefaults fills empty fields with the default naming
+func (n Naming) withDefaults() Naming {
+	if n.ConstructorPrefix == "" {
+		n.ConstructorPrefix = "New"
+	}
+	if n.OptionPrefix == "" {
+		n.OptionPrefix = "With"
+	}
+	if n.CurriedSuffix == "" {
+		n.CurriedSuffix = "Curried"
+	}
+	return n
+}
+
+// constructor returns the constructor name for a type name
+func (n Naming) constructor(name string) string {
+	return n.ConstructorPrefix + exportName(name)
+}
+
+// getter returns the record getter name for a field
+func (n Naming) getter(field string) string {
+	return n.GetterPrefix + exportName(field)
+}
+
+// option returns the functional option name for a field
+func (n Naming) option(field string) string {
+	return n.OptionPrefix + exportName(field)
+}
+
+// curried returns the curried wrapper name for a function
+func (n Naming) curried(fn string) string {
+	return exportName(fn) + n.CurriedSuffix
+}
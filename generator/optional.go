@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateOptionalCode generates functional options for a struct plus `<New><Name>FromOptions`, which takes one
+// monad.Option per field: Some values are applied and None fields keep their default
+// Defaults come from `default:"..."` field tags and apply to both constructors
+func generateOptionalCode(buf *bytes.Buffer, s parser.StructInfo, naming Naming) error {
+	defaults := []string{}
+	usesTime := false
+	for _, f := range s.Fields {
+		value, ok := reflect.StructTag(f.Tag).Lookup("default")
+		if !ok {
+			continue
+		}
+		lit, err := defaultLiteral(f.Type, value)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		usesTime = usesTime || f.Type == "time.Duration"
+		defaults = append(defaults, fmt.Sprintf("%s: %s", f.Name, lit))
+	}
+
+	buf.WriteString("import (\n")
+	if usesTime {
+		buf.WriteString("\t\"time\"\n\n")
+	}
+	buf.WriteString("\t\"github.com/snowmerak/gofn/monad\"\n")
+	buf.WriteString(")\n\n")
+
+	optTypeName := exportName(s.Name) + "Option"
+	buf.WriteString(fmt.Sprintf("type %s func(*%s)\n\n", optTypeName, s.Name))
+	for i, f := range s.Fields {
+		pname := fieldParamName(f.Name, i)
+		buf.WriteString(fmt.Sprintf("func %s(%s %s) %s {\n    return func(r *%s) { r.%s = %s }\n}\n\n",
+			naming.option(f.Name), pname, f.Type, optTypeName, s.Name, f.Name, pname))
+	}
+	initial := fmt.Sprintf("%s{%s}", s.Name, strings.Join(defaults, ", "))
+	buf.WriteString(fmt.Sprintf("func %sWithOptions(opts ...%s) %s {\n    r := %s\n    for _, o := range opts { o(&r) }\n    return r\n}\n\n",
+		naming.constructor(s.Name), optTypeName, s.Name, initial))
+
+	fromName := naming.constructor(s.Name) + "FromOptions"
+	params := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		params[i] = fmt.Sprintf("%s monad.Option[%s]", fieldParamName(f.Name, i), f.Type)
+	}
+	buf.WriteString(fmt.Sprintf("// %s creates a %s from partially populated input: Some values are applied,\n", fromName, s.Name))
+	buf.WriteString("// None (or Wildcard) fields keep their default tag value or the zero value\n")
+	buf.WriteString(fmt.Sprintf("func %s(%s) %s {\n", fromName, strings.Join(params, ", "), s.Name))
+	buf.WriteString(fmt.Sprintf("\tr := %s\n", initial))
+	for i, f := range s.Fields {
+		buf.WriteString(fmt.Sprintf("\tr.%s = %s.UnwrapOr(r.%s)\n", f.Name, fieldParamName(f.Name, i), f.Name))
+	}
+	buf.WriteString("\treturn r\n")
+	buf.WriteString("}\n\n")
+	return nil
+}
+
+// defaultLiteral renders the `default` tag value of a field of type t as a Go literal
+// Only predeclared string, bool and numeric types and time.Duration are supported
+func defaultLiteral(t, value string) (string, error) {
+	var err error
+	switch {
+	case t == "string":
+		return strconv.Quote(value), nil
+	case t == "bool":
+		_, err = strconv.ParseBool(value)
+	case t == "float32" || t == "float64":
+		_, err = strconv.ParseFloat(value, 64)
+	case strings.HasPrefix(t, "uint") || t == "byte":
+		_, err = strconv.ParseUint(value, 0, 64)
+	case isNumericType(t):
+		_, err = strconv.ParseInt(value, 0, 64)
+	case t == "time.Duration":
+		d, perr := time.ParseDuration(value)
+		if perr != nil {
+			return "", fmt.Errorf("invalid default %q for time.Duration: %w", value, perr)
+		}
+		return durationLiteral(d), nil
+	default:
+		return "", fmt.Errorf("default tags are not supported for type %s", t)
+	}
+	if err != nil {
+		return "", fmt.Errorf("invalid default %q for %s", value, t)
+	}
+	return value, nil
+}
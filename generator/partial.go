@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generatePartialCode generates `<Name>Partial1` … `<Name>Partial<N-1>` for a function of N parameters, each fixing
+// the first k arguments and returning a plain function of the rest; a variadic tail is never fixed, so it stays
+// variadic in every returned function
+func generatePartialCode(buf *bytes.Buffer, f parser.FuncInfo) error {
+	if f.Receiver != "" {
+		return fmt.Errorf("partial is only supported on functions, not methods")
+	}
+	if len(f.Params) < 2 {
+		return fmt.Errorf("partial requires at least two parameters")
+	}
+	params := namedParams(f.Params)
+	results := curriedType(nil, f.Results)
+	ret := "return "
+	if len(f.Results) == 0 {
+		ret = ""
+	}
+	call := fmt.Sprintf("%s(%s)", f.Name, callArgs(params))
+
+	for k := 1; k < len(params); k++ {
+		fixed, rest := params[:k], params[k:]
+		names := make([]string, len(fixed))
+		for i, p := range fixed {
+			names[i] = p.Name
+		}
+		name := fmt.Sprintf("%sPartial%d", exportName(f.Name), k)
+		fnType := strings.TrimSpace("func(" + paramList(rest) + ") " + results)
+		buf.WriteString(fmt.Sprintf("// %s fixes %s of %s and returns a function of the remaining arguments\n", name, strings.Join(names, ", "), f.Name))
+		buf.WriteString(fmt.Sprintf("func %s(%s) %s {\n", name, paramList(fixed), fnType))
+		buf.WriteString(fmt.Sprintf("\treturn %s {\n\t\t%s%s\n\t}\n}\n\n", fnType, ret, call))
+	}
+	return nil
+}
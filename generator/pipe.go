@@ -0,0 +1,145 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// pipeStage is one function of a //gofn:pipe list
+type pipeStage struct {
+	name    string
+	in, out string
+	fails   bool // returns (out, error)
+}
+
+// generatePipeCode generates the composition of the functions listed in a var, e.g.
+// `var parseAge = []any{trim, atoi, checkAge}`, as `ParseAgePipe` plus the Task variant `ParseAgePipeTask`
+// Every stage must be a function of this package taking one argument and returning T or (T, error),
+// and each stage's T must be the next stage's argument type
+func generatePipeCode(buf *bytes.Buffer, v parser.VarInfo, funcs []parser.FuncInfo) error {
+	if len(v.Elems) == 0 {
+		return fmt.Errorf("pipe requires a composite literal listing functions, e.g. []any{parse, validate}")
+	}
+
+	stages := make([]pipeStage, len(v.Elems))
+	for i, elem := range v.Elems {
+		f, ok := findFunc(funcs, elem)
+		if !ok {
+			return fmt.Errorf("stage %d (%s) is not a function declared in this package", i+1, elem)
+		}
+		stage, err := nextStage(stages[:i], f)
+		if err != nil {
+			return err
+		}
+		stages[i] = stage
+	}
+	writeComposition(buf, exportName(v.Name)+"Pipe", stages)
+	return nil
+}
+
+// nextStage checks that f can follow prev, taking one argument of the type the last stage of prev returns,
+// and returns it as a stage
+func nextStage(prev []pipeStage, f parser.FuncInfo) (pipeStage, error) {
+	i := len(prev)
+	if len(f.Params) != 1 || strings.HasPrefix(f.Params[0].Type, "...") {
+		return pipeStage{}, fmt.Errorf("stage %d (%s) must take exactly one argument", i+1, f.Name)
+	}
+	stage := pipeStage{name: f.Name, in: f.Params[0].Type}
+	switch {
+	case len(f.Results) == 1 && f.Results[0].Type != "error":
+		stage.out = f.Results[0].Type
+	case len(f.Results) == 2 && f.Results[1].Type == "error":
+		stage.out, stage.fails = f.Results[0].Type, true
+	default:
+		return pipeStage{}, fmt.Errorf("stage %d (%s) must return T or (T, error)", i+1, f.Name)
+	}
+	if i > 0 && prev[i-1].out != stage.in {
+		last := prev[i-1]
+		return pipeStage{}, fmt.Errorf("stage %d (%s) returns %s but stage %d (%s) takes %s", i, last.name, last.out, i+1, stage.name, stage.in)
+	}
+	return stage, nil
+}
+
+// writeComposition writes pipeName, running stages in turn, and its Task variant pipeName + "Task"
+func writeComposition(buf *bytes.Buffer, pipeName string, stages []pipeStage) {
+	anyFails := false
+	for _, s := range stages {
+		anyFails = anyFails || s.fails
+	}
+	in, out := stages[0].in, stages[len(stages)-1].out
+	taskName := pipeName + "Task"
+	names := make([]string, len(stages))
+	for i, s := range stages {
+		names[i] = s.name
+	}
+	chain := strings.Join(names, ", ")
+
+	buf.WriteString("import (\n")
+	buf.WriteString("\t\"context\"\n\n")
+	buf.WriteString("\t\"github.com/snowmerak/gofn/monad\"\n")
+	buf.WriteString(")\n\n")
+
+	if anyFails {
+		buf.WriteString(fmt.Sprintf("// %s runs %s in turn, stopping at the first error\n", pipeName, chain))
+		buf.WriteString(fmt.Sprintf("func %s(input %s) (%s, error) {\n", pipeName, in, out))
+		buf.WriteString(fmt.Sprintf("\tvar zero %s\n", out))
+	} else {
+		buf.WriteString(fmt.Sprintf("// %s runs %s in turn\n", pipeName, chain))
+		buf.WriteString(fmt.Sprintf("func %s(input %s) %s {\n", pipeName, in, out))
+	}
+	prev := "input"
+	for i, s := range stages {
+		cur := fmt.Sprintf("v%d", i+1)
+		if s.fails {
+			buf.WriteString(fmt.Sprintf("\t%s, err := %s(%s)\n", cur, s.name, prev))
+			buf.WriteString("\tif err != nil {\n")
+			buf.WriteString("\t\treturn zero, err\n")
+			buf.WriteString("\t}\n")
+		} else {
+			buf.WriteString(fmt.Sprintf("\t%s := %s(%s)\n", cur, s.name, prev))
+		}
+		prev = cur
+	}
+	if anyFails {
+		buf.WriteString(fmt.Sprintf("\treturn %s, nil\n", prev))
+	} else {
+		buf.WriteString(fmt.Sprintf("\treturn %s\n", prev))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s is %s as a Task that stops before the next stage once ctx is done\n", taskName, pipeName))
+	buf.WriteString(fmt.Sprintf("func %s(input %s) monad.Task[%s] {\n", taskName, in, out))
+	buf.WriteString(fmt.Sprintf("\treturn func(ctx context.Context) monad.Result[%s] {\n", out))
+	prev = "input"
+	for i, s := range stages {
+		cur := fmt.Sprintf("v%d", i+1)
+		buf.WriteString("\t\tif err := ctx.Err(); err != nil {\n")
+		buf.WriteString(fmt.Sprintf("\t\t\treturn monad.Err[%s](err)\n", out))
+		buf.WriteString("\t\t}\n")
+		if s.fails {
+			buf.WriteString(fmt.Sprintf("\t\t%s, err := %s(%s)\n", cur, s.name, prev))
+			buf.WriteString("\t\tif err != nil {\n")
+			buf.WriteString(fmt.Sprintf("\t\t\treturn monad.Err[%s](err)\n", out))
+			buf.WriteString("\t\t}\n")
+		} else {
+			buf.WriteString(fmt.Sprintf("\t\t%s := %s(%s)\n", cur, s.name, prev))
+		}
+		prev = cur
+	}
+	buf.WriteString(fmt.Sprintf("\t\treturn monad.Ok(%s)\n", prev))
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+}
+
+// findFunc returns the package-level function named name
+func findFunc(funcs []parser.FuncInfo, name string) (parser.FuncInfo, bool) {
+	for _, f := range funcs {
+		if f.Receiver == "" && f.Name == name {
+			return f, true
+		}
+	}
+	return parser.FuncInfo{}, false
+}
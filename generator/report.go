@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Report records what generation runs did: files written with their size and declarations skipped with the reason
+// Set Options.Report to collect one; a Report may accumulate several GeneratePackage calls
+type Report struct {
+	Files   []ReportFile    `json:"files"`
+	Skipped []ReportSkip    `json:"skipped"`
+	Enabled []ReportEnabled `json:"enabled"`
+}
+
+// ReportFile is a file written by the generator
+type ReportFile struct {
+	Path      string `json:"path"`
+	Decl      string `json:"decl"`
+	Directive string `json:"directive"`
+	Lines     int    `json:"lines"`
+}
+
+// ReportSkip is a declaration whose directive produced no file
+type ReportSkip struct {
+	Decl      string `json:"decl"`
+	Directive string `json:"directive"`
+	Reason    string `json:"reason"`
+}
+
+// ReportEnabled is a directive gofn added to a declaration because another directive depends on it
+type ReportEnabled struct {
+	Decl       string `json:"decl"`
+	Directive  string `json:"directive"`
+	RequiredBy string `json:"required_by"`
+}
+
+// DirectiveStats aggregates a Report per directive
+type DirectiveStats struct {
+	Directive string `json:"directive"`
+	Files     int    `json:"files"`
+	Lines     int    `json:"lines"`
+	Skipped   int    `json:"skipped"`
+}
+
+func (r *Report) generated(decl, directive, path string, content []byte) {
+	if r == nil {
+		return
+	}
+	r.Files = append(r.Files, ReportFile{Path: path, Decl: decl, Directive: directive, Lines: bytes.Count(content, []byte("\n"))})
+}
+
+func (r *Report) skipped(decl, directive, reason string) {
+	if r == nil {
+		return
+	}
+	r.Skipped = append(r.Skipped, ReportSkip{Decl: decl, Directive: directive, Reason: reason})
+}
+
+func (r *Report) enabled(decl, directive, requiredBy string) {
+	if r == nil {
+		return
+	}
+	r.Enabled = append(r.Enabled, ReportEnabled{Decl: decl, Directive: directive, RequiredBy: requiredBy})
+}
+
+// Stats returns per-directive totals sorted by directive name
+func (r *Report) Stats() []DirectiveStats {
+	byName := map[string]*DirectiveStats{}
+	get := func(name string) *DirectiveStats {
+		if byName[name] == nil {
+			byName[name] = &DirectiveStats{Directive: name}
+		}
+		return byName[name]
+	}
+	for _, f := range r.Files {
+		s := get(f.Directive)
+		s.Files++
+		s.Lines += f.Lines
+	}
+	for _, sk := range r.Skipped {
+		get(sk.Directive).Skipped++
+	}
+
+	stats := make([]DirectiveStats, 0, len(byName))
+	for _, s := range byName {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b DirectiveStats) int { return cmp.Compare(a.Directive, b.Directive) })
+	return stats
+}
+
+// Lines returns the number of lines written across all files
+func (r *Report) Lines() int {
+	n := 0
+	for _, f := range r.Files {
+		n += f.Lines
+	}
+	return n
+}
+
+// WriteJSON writes the report with its per-directive totals as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Directives []DirectiveStats `json:"directives"`
+		Lines      int              `json:"lines"`
+		*Report
+	}{r.Stats(), r.Lines(), r})
+}
+
+// WriteMarkdown writes the report as markdown tables, e.g. for a CI job summary
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	var b bytes.Buffer
+	b.WriteString("# gofn generation report\n\n")
+	b.WriteString(fmt.Sprintf("%d files, %d lines generated, %d declarations skipped\n\n", len(r.Files), r.Lines(), len(r.Skipped)))
+
+	b.WriteString("| Directive | Files | Lines | Skipped |\n|---|---:|---:|---:|\n")
+	for _, s := range r.Stats() {
+		b.WriteString(fmt.Sprintf("| %s | %d | %d | %d |\n", s.Directive, s.Files, s.Lines, s.Skipped))
+	}
+
+	if len(r.Files) > 0 {
+		b.WriteString("\n## Files\n\n| File | Declaration | Directive | Lines |\n|---|---|---|---:|\n")
+		for _, f := range r.Files {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %d |\n", f.Path, f.Decl, f.Directive, f.Lines))
+		}
+	}
+	if len(r.Skipped) > 0 {
+		b.WriteString("\n## Skipped\n\n| Declaration | Directive | Reason |\n|---|---|---|\n")
+		for _, s := range r.Skipped {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.Decl, s.Directive, s.Reason))
+		}
+	}
+	if len(r.Enabled) > 0 {
+		b.WriteString("\n## Enabled\n\n| Declaration | Directive | Required by |\n|---|---|---|\n")
+		for _, e := range r.Enabled {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", e.Decl, e.Directive, e.RequiredBy))
+		}
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
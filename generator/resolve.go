@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// directiveRule relates a struct directive to the directives it cannot be combined with and those it needs
+type directiveRule struct {
+	// conflicts maps directives that cannot document the same struct to why, and what to do instead
+	conflicts map[string]string
+	// requires returns the directives other structs need for the code generated for s to compile
+	requires func(s parser.StructInfo, types typeIndex) []requirement
+}
+
+// requirement is a directive a struct must carry because another directive depends on it
+type requirement struct {
+	decl      string
+	directive string // with any arguments it needs, e.g. "equal hash"
+	reason    string
+}
+
+// structRules are the relations between struct directives; conflicts are checked in both directions
+var structRules = map[string]directiveRule{
+	"record": {
+		conflicts: map[string]string{
+			"optional": "record keeps %[1]s immutable behind its constructor and getters, while optional generates options that set its fields; " +
+				"drop //gofn:optional, or drop //gofn:record and export the struct",
+			"ref": "record keeps %[1]s immutable, while ref shares a pointer whose SetValue overwrites it in place; " +
+				"drop //gofn:ref and pass the record by value",
+		},
+	},
+	"immutable": {
+		conflicts: map[string]string{
+			"optional": "immutable only changes %[1]s through copies, while optional generates options that set its fields; " +
+				"drop //gofn:optional and start from the immutable constructor",
+			"ref": "immutable only changes %[1]s through copies, while ref shares a pointer whose SetValue overwrites it in place; " +
+				"drop //gofn:ref and pass the value, replacing it with its With copies",
+		},
+	},
+	"accessors": {
+		conflicts: map[string]string{
+			"record": "record generates getters for %[1]s behind an immutable interface, while accessors generates getters and setters on the struct; " +
+				"drop one of them",
+			"immutable": "immutable only changes %[1]s through copies, while accessors generates setters changing it in place; " +
+				"drop //gofn:accessors and use the With copies, or drop //gofn:immutable",
+		},
+	},
+	"binary": {requires: binaryRequires},
+	"equal":  {requires: equalRequires},
+}
+
+// funcRequires returns, by function directive, the directives package structs need for the code generated for
+// the function to compile
+var funcRequires = map[string]func(f parser.FuncInfo, types typeIndex) []requirement{
+	"memoize": memoizeRequires,
+}
+
+// binaryRequires enables //gofn:binary on the package structs a binary struct nests, which are encoded
+// with their own MarshalBinary
+func binaryRequires(s parser.StructInfo, types typeIndex) []requirement {
+	var reqs []requirement
+	for _, f := range s.Fields {
+		if f.Name == "" || reflect.StructTag(f.Tag).Get("binary") == "-" {
+			continue
+		}
+		elem := strings.TrimPrefix(strings.TrimPrefix(f.Type, "*"), "[]")
+		if _, ok := types.structs[elem]; ok {
+			reqs = append(reqs, requirement{decl: elem, directive: "binary", reason: fmt.Sprintf("field %s of %s", f.Name, s.Name)})
+		}
+	}
+	return reqs
+}
+
+// checkConflicts fails when a struct carries two directives that cannot be combined, naming both and the fix
+func checkConflicts(structs []parser.StructInfo) error {
+	byName := map[string][]string{}
+	var order []string
+	for _, s := range structs {
+		name, _ := splitDirective(s.Directive)
+		if name == "" || slices.Contains(byName[s.Name], name) {
+			continue
+		}
+		if byName[s.Name] == nil {
+			order = append(order, s.Name)
+		}
+		byName[s.Name] = append(byName[s.Name], name)
+	}
+	for _, decl := range order {
+		dirs := byName[decl]
+		for i, a := range dirs {
+			for _, b := range dirs[i+1:] {
+				reason, ok := structRules[a].conflicts[b]
+				if !ok {
+					reason, ok = structRules[b].conflicts[a]
+				}
+				if ok {
+					return fmt.Errorf("%s: //gofn:%s conflicts with //gofn:%s: %s", decl, a, b, fmt.Sprintf(reason, decl))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveRequirements extends the structs to generate, and pkg with them, with the directives they and the
+// functions to generate depend on
+// Prerequisites are enabled transitively, each with a notice naming the directive that needed it; structs of
+// other packages cannot be annotated and are left to fail in their generator
+func resolveRequirements(structs []parser.StructInfo, funcs []parser.FuncInfo, pkg parser.Package, opts Options) ([]parser.StructInfo, parser.Package, error) {
+	pkg.Structs = slices.Clone(pkg.Structs)
+	types := newTypeIndex(pkg)
+	queue := slices.Clone(structs)
+	enable := func(name string, reqs []requirement) {
+		for _, req := range reqs {
+			reqName, _ := splitDirective(req.directive)
+			if types.hasDirective(req.decl, reqName) {
+				continue
+			}
+			target := types.structs[req.decl]
+			target.Directive = req.directive
+			fmt.Printf("gofn: enabling //gofn:%s on %s, required by //gofn:%s on %s\n", req.directive, req.decl, name, req.reason)
+			opts.Report.enabled(req.decl, req.directive, fmt.Sprintf("//gofn:%s on %s", name, req.reason))
+
+			types.directives[req.decl] = append(types.directives[req.decl], reqName)
+			pkg.Structs = append(pkg.Structs, target)
+			structs = append(structs, target)
+			queue = append(queue, target)
+		}
+	}
+	for _, f := range funcs {
+		name, _ := splitDirective(f.Directive)
+		if requires := funcRequires[name]; requires != nil {
+			enable(name, requires(f, types))
+		}
+	}
+	for len(queue) > 0 {
+		s := queue[0]
+		queue = queue[1:]
+		name, _ := splitDirective(s.Directive)
+		if requires := structRules[name].requires; requires != nil {
+			enable(name, requires(s, types))
+		}
+	}
+	if err := checkConflicts(pkg.Structs); err != nil {
+		return nil, pkg, err
+	}
+	return structs, pkg, nil
+}
@@ -0,0 +1,51 @@
+package generator
+
+import "testing"
+
+func TestMemoizeEnablesEqualHashOnStructParams(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"find.go": `package fixture
+
+type Query struct {
+	Terms []string
+	Limit int
+}
+
+type Page struct{ N int }
+
+var calls int
+
+//gofn:memoize
+func Find(q Query, p Page) []string {
+	calls++
+	return q.Terms[:min(q.Limit, len(q.Terms))]
+}
+`,
+		"find_test.go": `package fixture
+
+import "testing"
+
+func TestFindMemo(t *testing.T) {
+	m := NewFindMemo()
+	for range 2 {
+		if got := m.Call(Query{Terms: []string{"a", "b"}, Limit: 1}, Page{}); len(got) != 1 {
+			t.Fatalf("got %v", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected one call through the cache, got %d", calls)
+	}
+}
+`,
+	})
+	report := &Report{}
+	if err := generateDir(t, dir, Options{Report: report}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := ReportEnabled{Decl: "Query", Directive: "equal hash", RequiredBy: "//gofn:memoize on parameter q of Find"}
+	if len(report.Enabled) != 1 || report.Enabled[0] != want {
+		t.Errorf("Expected only %+v enabled, got %+v", want, report.Enabled)
+	}
+	goTest(t, dir)
+}
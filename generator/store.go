@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// storeAction is one action of a generated store: a reducer method of the state, or a field setter from a tag
+type storeAction struct {
+	name   string   // dispatch method name, e.g. AddItem
+	doc    string   // what the action does, e.g. "sets Cart.Total"
+	fields []string // action struct fields, e.g. "Name string"
+	params []string // dispatch method parameters, e.g. "name string"
+	values []string // action struct values built from the parameters, e.g. "Name: name"
+	reduce string   // reducer case body, with the action as a and the state as state
+}
+
+// generateStoreCode generates a Redux-style store over the state struct s: an action type per value-receiver
+// method returning s, which reduces the state, and per field tagged `store:"set"`, a sealed `<Name>Action`
+// interface, `Reduce<Name>`, and `<Name>Store`, a monad.Store with a dispatch method per action
+// Selectors and middleware come from the monad package: monad.Select and monad.Middleware
+func generateStoreCode(buf *bytes.Buffer, s parser.StructInfo, methods []parser.FuncInfo, naming Naming) error {
+	name := exportName(s.Name)
+	actionIface := name + "Action"
+	marker := "is" + actionIface
+	storeName := name + "Store"
+
+	var actions []storeAction
+	for _, m := range methods {
+		if strings.HasPrefix(m.Receiver, "*") || len(m.Results) != 1 || m.Results[0].Type != s.Name {
+			continue
+		}
+		a := storeAction{name: m.Name, doc: fmt.Sprintf("reduces the state with %s.%s", s.Name, m.Name)}
+		args := make([]string, len(m.Params))
+		for i, p := range m.Params {
+			field := exportName(p.Name)
+			param := p.Name
+			if p.Name == "" || p.Name == "_" {
+				field, param = fmt.Sprintf("Arg%d", i+1), fmt.Sprintf("arg%d", i+1)
+			}
+			typ, variadic := strings.CutPrefix(p.Type, "...")
+			if variadic {
+				typ = "[]" + typ
+			}
+			a.fields = append(a.fields, field+" "+typ)
+			a.params = append(a.params, param+" "+p.Type)
+			a.values = append(a.values, field+": "+param)
+			args[i] = "a." + field
+			if variadic {
+				args[i] += "..."
+			}
+		}
+		a.reduce = fmt.Sprintf("return state.%s(%s)", m.Name, strings.Join(args, ", "))
+		actions = append(actions, a)
+	}
+	for _, f := range s.Fields {
+		if f.Name == "" || reflect.StructTag(f.Tag).Get("store") != "set" {
+			continue
+		}
+		actions = append(actions, storeAction{
+			name:   "Set" + exportName(f.Name),
+			doc:    fmt.Sprintf("sets %s.%s", s.Name, f.Name),
+			fields: []string{"Value " + f.Type},
+			params: []string{"v " + f.Type},
+			values: []string{"Value: v"},
+			reduce: fmt.Sprintf("state.%s = a.Value\n\t\treturn state", f.Name),
+		})
+	}
+	if len(actions) == 0 {
+		return fmt.Errorf("no actions: add methods with a value receiver returning %s, or `store:\"set\"` field tags", s.Name)
+	}
+	var seen []string
+	for _, a := range actions {
+		switch {
+		case a.name == "Dispatch" || a.name == "State" || a.name == "Reactive":
+			return fmt.Errorf("action %s would collide with the monad.Store method", a.name)
+		case slices.Contains(seen, a.name):
+			return fmt.Errorf("action %s is declared by both a method and a store tag", a.name)
+		}
+		seen = append(seen, a.name)
+	}
+
+	buf.WriteString("import \"github.com/snowmerak/gofn/monad\"\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s is an action of a %s; only the action types below implement it\n", actionIface, storeName))
+	buf.WriteString(fmt.Sprintf("type %s interface {\n\t%s()\n}\n\n", actionIface, marker))
+	for _, a := range actions {
+		typ := name + a.name
+		buf.WriteString(fmt.Sprintf("// %s %s\n", typ, a.doc))
+		if len(a.fields) == 0 {
+			buf.WriteString(fmt.Sprintf("type %s struct{}\n\n", typ))
+		} else {
+			buf.WriteString(fmt.Sprintf("type %s struct {\n\t%s\n}\n\n", typ, strings.Join(a.fields, "\n\t")))
+		}
+		buf.WriteString(fmt.Sprintf("func (%s) %s() {}\n\n", typ, marker))
+	}
+
+	reduce := "Reduce" + name
+	buf.WriteString(fmt.Sprintf("// %s returns state with action applied, the reducer of %s\n", reduce, storeName))
+	buf.WriteString(fmt.Sprintf("func %s(state %s, action %s) %s {\n", reduce, s.Name, actionIface, s.Name))
+	buf.WriteString("\tswitch a := action.(type) {\n")
+	for _, a := range actions {
+		buf.WriteString(fmt.Sprintf("\tcase %s:\n\t\t%s\n", name+a.name, a.reduce))
+	}
+	buf.WriteString("\t}\n\treturn state\n}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s is a monad.Store of %s with a dispatch method per action\n", storeName, s.Name))
+	buf.WriteString("// Derive values with monad.Select(store.Store, selector)\n")
+	buf.WriteString(fmt.Sprintf("type %s struct {\n\t*monad.Store[%s, %s]\n}\n\n", storeName, s.Name, actionIface))
+
+	ctor := naming.constructor(storeName)
+	buf.WriteString(fmt.Sprintf("// %s creates a %s starting at initial; the first middleware sees an action first\n", ctor, storeName))
+	buf.WriteString(fmt.Sprintf("func %s(initial %s, middleware ...monad.Middleware[%s, %s]) *%s {\n", ctor, s.Name, s.Name, actionIface, storeName))
+	buf.WriteString(fmt.Sprintf("\treturn &%s{monad.NewStore(initial, %s, middleware...)}\n}\n\n", storeName, reduce))
+
+	for _, a := range actions {
+		recv := "s"
+		for _, p := range a.params {
+			if strings.HasPrefix(p, "s ") {
+				recv = "store"
+			}
+		}
+		buf.WriteString(fmt.Sprintf("// %s dispatches a %s\n", a.name, name+a.name))
+		buf.WriteString(fmt.Sprintf("func (%s *%s) %s(%s) {\n", recv, storeName, a.name, strings.Join(a.params, ", ")))
+		buf.WriteString(fmt.Sprintf("\t%s.Dispatch(%s{%s})\n}\n\n", recv, name+a.name, strings.Join(a.values, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"os"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// Stream generates code for declarations as they are parsed, one file at a time, instead of waiting for the
+// whole package: feed it with parser.StreamPackage and call Close once every file was added
+// Directives that only look at their own declaration are generated on Add; those that consult the rest of the
+// package (methods, named types, other structs, or every package name when unexporting) wait for Close
+type Stream struct {
+	outDir  string
+	opts    Options
+	naming  Naming
+	helpers *helperTypes
+	seen    parser.Package // every declaration added so far
+	pending parser.Package // declarations generated on Close
+}
+
+// NewStream creates a Stream writing to outDir, creating the directory if needed
+func NewStream(outDir string, opts Options) (*Stream, error) {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &Stream{outDir: outDir, opts: opts, naming: opts.Naming.withDefaults(), helpers: newHelperTypes()}, nil
+}
+
+// Add generates the self-contained declarations of decl, typically one parsed file, and keeps the rest for Close
+// Conflicting directives on a struct fail here, since all directives of a struct are declared in one file
+func (s *Stream) Add(decl parser.Package) error {
+	if err := checkConflicts(decl.Structs); err != nil {
+		return err
+	}
+	var now parser.Package
+	for _, st := range decl.Structs {
+		if s.needsPackage(st.Name, st.Directive) {
+			s.pending.Structs = append(s.pending.Structs, st)
+		} else {
+			now.Structs = append(now.Structs, st)
+		}
+	}
+	for _, f := range decl.Funcs {
+		if s.needsPackage(f.Name, f.Directive) {
+			s.pending.Funcs = append(s.pending.Funcs, f)
+		} else {
+			now.Funcs = append(now.Funcs, f)
+		}
+	}
+	// pipes resolve the functions they list, which may be declared in any file
+	s.pending.Vars = append(s.pending.Vars, decl.Vars...)
+
+	s.seen.Structs = append(s.seen.Structs, decl.Structs...)
+	s.seen.Funcs = append(s.seen.Funcs, decl.Funcs...)
+	s.seen.Types = append(s.seen.Types, decl.Types...)
+	s.seen.Vars = append(s.seen.Vars, decl.Vars...)
+
+	if err := generateStructs(s.outDir, now.Structs, decl, s.opts); err != nil {
+		return err
+	}
+	return generateFuncs(s.outDir, now.Funcs, decl, s.helpers, s.opts)
+}
+
+// Close generates the declarations that needed the whole package and writes the shared helper types
+// Directives the pending declarations depend on are enabled first
+func (s *Stream) Close() error {
+	structs, seen, err := resolveRequirements(s.pending.Structs, s.pending.Funcs, s.seen, s.opts)
+	if err != nil {
+		return err
+	}
+	s.pending.Structs, s.seen = structs, seen
+	if err := checkInjection(s.seen); err != nil {
+		return err
+	}
+	if err := generateStructs(s.outDir, s.pending.Structs, s.seen, s.opts); err != nil {
+		return err
+	}
+	if err := generateTypes(s.outDir, s.seen.Types, s.seen, s.opts); err != nil {
+		return err
+	}
+	if err := generateFuncs(s.outDir, s.pending.Funcs, s.seen, s.helpers, s.opts); err != nil {
+		return err
+	}
+	if err := generateVars(s.outDir, s.pending.Vars, s.seen, s.opts); err != nil {
+		return err
+	}
+	return s.helpers.write(s.outDir, s.opts)
+}
+
+// needsPackage reports whether generating the directive of the declaration name consults other declarations
+func (s *Stream) needsPackage(name, directive string) bool {
+	dir, args := splitDirective(directive)
+	if s.naming.keepUnexported(name, args) {
+		return true
+	}
+	switch dir {
+	case "match", "actor", "binary", "schema", "memoize", "compose", "builder", "accessors", "stringer", "csv", "equal", "iterator", "store", "visitor", "handler", "inject", "provide":
+		return true
+	}
+	return false
+}
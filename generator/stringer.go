@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// defaultMask replaces the value of a redacted field in a generated String
+const defaultMask = "[REDACTED]"
+
+// generateStringerCode generates a String method formatting every field of s as `Name{field: value, ...}`,
+// unexported fields included; strings are quoted and other values use %v
+// Fields tagged `gofn:"secret"`, and those listed by `redact=a,b`, are printed as the `mask` argument,
+// [REDACTED] by default, so logging a value does not leak them
+func generateStringerCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex, args directiveArgs) error {
+	if slices.Contains(declaredMethods(types.funcs, s.Name), "String") {
+		return fmt.Errorf("%s already declares String", s.Name)
+	}
+	mask := args.get("mask", defaultMask)
+	var redact []string
+	if list := args.get("redact", ""); list != "" {
+		redact = strings.Split(list, ",")
+	}
+
+	// parts are the Sprintf format of each field, plain their text when every field is redacted
+	var parts, plain, values, redacted []string
+	for _, f := range s.Fields {
+		name := f.Name
+		if name == "" {
+			// an embedded field is named after its type
+			name = strings.TrimPrefix(f.Type, "*")
+			name = name[strings.LastIndex(name, ".")+1:]
+			name, _, _ = strings.Cut(name, "[")
+		}
+		if slices.Contains(redact, name) || reflect.StructTag(f.Tag).Get("gofn") == "secret" {
+			parts = append(parts, name+": "+strings.ReplaceAll(mask, "%", "%%"))
+			plain = append(plain, name+": "+mask)
+			redacted = append(redacted, name)
+			continue
+		}
+		verb := "%v"
+		if validateKind(f.Type, types) == "string" {
+			verb = "%q"
+		}
+		parts = append(parts, name+": "+verb)
+		values = append(values, "v."+name)
+	}
+	for _, name := range redact {
+		if !slices.Contains(redacted, name) {
+			return fmt.Errorf("redact lists %s, which is not a field of %s", name, s.Name)
+		}
+	}
+
+	doc := fmt.Sprintf("// String formats every field of %s, unexported ones included\n", s.Name)
+	if len(redacted) > 0 {
+		doc = fmt.Sprintf("// String formats every field of %s, unexported ones included, printing %s as %s\n", s.Name, strings.Join(redacted, ", "), mask)
+	}
+	if len(values) == 0 {
+		buf.WriteString(doc)
+		text := strconv.Quote(s.Name + "{" + strings.Join(plain, ", ") + "}")
+		buf.WriteString(fmt.Sprintf("func (v %s) String() string {\n\treturn %s\n}\n\n", s.Name, text))
+		return nil
+	}
+	format := strconv.Quote(s.Name + "{" + strings.Join(parts, ", ") + "}")
+	buf.WriteString("import \"fmt\"\n\n")
+	buf.WriteString(doc)
+	buf.WriteString(fmt.Sprintf("func (v %s) String() string {\n\treturn fmt.Sprintf(%s, %s)\n}\n\n", s.Name, format, strings.Join(values, ", ")))
+	return nil
+}
@@ -11,20 +11,29 @@ import (
 )
 
 // generateStructs generates code for structs based on directives
-func generateStructs(outDir string, structs []parser.StructInfo) error {
+// pkg holds the declarations known so far; directives that need the methods or named types a struct refers to consult it
+func generateStructs(outDir string, structs []parser.StructInfo, pkg parser.Package, opts Options) error {
+	naming := opts.Naming.withDefaults()
+	limits := opts.Limits.withDefaults()
+	reserved := packageNames(parser.Package{Structs: pkg.Structs, Funcs: pkg.Funcs})
+	types := newTypeIndex(pkg)
 	for _, s := range structs {
 		dir := strings.TrimSpace(s.Directive)
 		if dir == "" {
 			continue
 		}
 
+		imports := newFileImports()
+		s := imports.qualifyStruct(s)
+
 		var buf bytes.Buffer
-		hdr := fmt.Sprintf("// Code generated by gofn; DO NOT EDIT.\n// gofn: %s\n\n", dir)
+		hdr := fileHeader(dir)
 		buf.WriteString(hdr)
 		buf.WriteString("package " + s.Package + "\n\n")
 
-		// generation per-directive
-		switch dir {
+		// generation per-directive; arguments after the name are passed to generators that accept them
+		name, args := splitDirective(dir)
+		switch name {
 		case "pipeline":
 			// generate composer using monad.Result
 			buf.WriteString("import (\n\t\"github.com/snowmerak/gofn/monad\"\n)\n\n")
@@ -46,14 +55,12 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 				if n == 2 {
 					buf.WriteString("        return f1(t1)\n")
 				} else {
-					buf.WriteString("        v1, err := f1(t1).Unwrap()\n")
-					buf.WriteString("        if err != nil { return monad.Err[" + s.Fields[n-1].Type + "](err) }\n")
+					// monad.AndThen short-circuits on errors and carries warnings to the next stage
+					buf.WriteString("        r1 := f1(t1)\n")
 					for i := 2; i <= n-2; i++ {
-						prev := fmt.Sprintf("v%d", i-1)
-						buf.WriteString(fmt.Sprintf("        v%d, err := f%d(%s).Unwrap()\n", i, i, prev))
-						buf.WriteString(fmt.Sprintf("        if err != nil { return monad.Err[%s](err) }\n", s.Fields[n-1].Type))
+						buf.WriteString(fmt.Sprintf("        r%d := monad.AndThen(r%d, f%d)\n", i, i-1, i))
 					}
-					buf.WriteString(fmt.Sprintf("        return f%d(v%d)\n", n-1, n-2))
+					buf.WriteString(fmt.Sprintf("        return monad.AndThen(r%d, f%d)\n", n-2, n-1))
 				}
 				buf.WriteString("    }\n")
 				buf.WriteString("}\n\n")
@@ -73,27 +80,29 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 					buf.WriteString("        result := f1(t1)\n")
 					buf.WriteString("        if !result.IsOk() {\n")
 					buf.WriteString("            _, err := result.Unwrap()\n")
-					buf.WriteString("            return errorHandler(1, err)\n")
+					buf.WriteString("            return monad.CarryWarnings(errorHandler(1, err), result)\n")
 					buf.WriteString("        }\n")
 					buf.WriteString("        return result\n")
 				} else {
-					buf.WriteString("        v1, err := f1(t1).Unwrap()\n")
+					// each stage's result carries the warnings of the stages before it, recovered ones included
+					buf.WriteString("        r1 := f1(t1)\n")
+					buf.WriteString("        v1, err := r1.Unwrap()\n")
 					buf.WriteString("        if err != nil {\n")
-					buf.WriteString("            return errorHandler(1, err)\n")
+					buf.WriteString("            return monad.CarryWarnings(errorHandler(1, err), r1)\n")
 					buf.WriteString("        }\n")
 
 					for i := 2; i <= n-2; i++ {
-						prev := fmt.Sprintf("v%d", i-1)
-						buf.WriteString(fmt.Sprintf("        v%d, err := f%d(%s).Unwrap()\n", i, i, prev))
+						buf.WriteString(fmt.Sprintf("        r%d := monad.CarryWarnings(f%d(v%d), r%d)\n", i, i, i-1, i-1))
+						buf.WriteString(fmt.Sprintf("        v%d, err := r%d.Unwrap()\n", i, i))
 						buf.WriteString("        if err != nil {\n")
-						buf.WriteString(fmt.Sprintf("            return errorHandler(%d, err)\n", i))
+						buf.WriteString(fmt.Sprintf("            return monad.CarryWarnings(errorHandler(%d, err), r%d)\n", i, i))
 						buf.WriteString("        }\n")
 					}
 
-					buf.WriteString(fmt.Sprintf("        result := f%d(v%d)\n", n-1, n-2))
+					buf.WriteString(fmt.Sprintf("        result := monad.CarryWarnings(f%d(v%d), r%d)\n", n-1, n-2, n-2))
 					buf.WriteString("        if !result.IsOk() {\n")
 					buf.WriteString("            _, err := result.Unwrap()\n")
-					buf.WriteString(fmt.Sprintf("            return errorHandler(%d, err)\n", n-1))
+					buf.WriteString(fmt.Sprintf("            return monad.CarryWarnings(errorHandler(%d, err), result)\n", n-1))
 					buf.WriteString("        }\n")
 					buf.WriteString("        return result\n")
 				}
@@ -133,11 +142,17 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 				continue
 			}
 
-			ifaceName := exportName(s.Name)
+			ifaceName := recordInterface(s.Name, naming, args)
 			// interface
 			buf.WriteString(fmt.Sprintf("type %s interface {\n", ifaceName))
 			for _, f := range s.Fields {
-				buf.WriteString(fmt.Sprintf("    %s() %s\n", exportName(f.Name), f.Type))
+				buf.WriteString(fmt.Sprintf("    %s() %s\n", naming.getter(f.Name), f.Type))
+			}
+			if types.hasDirective(s.Name, "immutable") {
+				// the copy methods generated by //gofn:immutable
+				for _, f := range s.Fields {
+					buf.WriteString(fmt.Sprintf("    With%s(%s) %s\n", exportName(f.Name), f.Type, ifaceName))
+				}
 			}
 			buf.WriteString("}\n\n")
 
@@ -149,7 +164,7 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 				params = append(params, fmt.Sprintf("%s %s", pname, f.Type))
 				assigns = append(assigns, fmt.Sprintf("%s: %s", f.Name, pname))
 			}
-			ctorName := "New" + ifaceName
+			ctorName := naming.constructor(s.Name)
 			baseCtor := fmt.Sprintf("// Generated record constructor for %s\nfunc %s(%s) %s {\n    return %s{%s}\n}\n\n",
 				s.Name, ctorName, strings.Join(params, ", "), ifaceName, s.Name, strings.Join(assigns, ", "))
 			buf.WriteString(baseCtor)
@@ -157,31 +172,35 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 			// getters
 			recv := strings.ToLower(string(s.Name[0]))
 			for _, f := range s.Fields {
-				gname := exportName(f.Name)
+				gname := naming.getter(f.Name)
 				getter := fmt.Sprintf("func (%s %s) %s() %s {\n    return %s.%s\n}\n\n", recv, s.Name, gname, f.Type, recv, f.Name)
 				buf.WriteString(getter)
 			}
 
+			// settable fake implementing the interface, for tests
+			if args.has("iface-fakes") {
+				generateRecordFake(&buf, s, ifaceName, naming, types.hasDirective(s.Name, "immutable"))
+			}
+
 		case "optional":
-			optTypeName := exportName(s.Name) + "Option"
-			buf.WriteString(fmt.Sprintf("type %s func(*%s)\n\n", optTypeName, s.Name))
-			for i, f := range s.Fields {
-				pname := fieldParamName(f.Name, i)
-				buf.WriteString(fmt.Sprintf("func With%s(%s %s) %s {\n    return func(r *%s) { r.%s = %s }\n}\n\n",
-					exportName(f.Name), pname, f.Type, optTypeName, s.Name, f.Name, pname))
+			// Generate functional options and the Option-aware constructor
+			if err := generateOptionalCode(&buf, s, naming); err != nil {
+				return fmt.Errorf("generating optional code for %s: %w", s.Name, err)
 			}
-			buf.WriteString(fmt.Sprintf("func New%sWithOptions(opts ...%s) %s {\n    r := %s{}\n    for _, o := range opts { o(&r) }\n    return r\n}\n\n",
-				exportName(s.Name), optTypeName, s.Name, s.Name))
 
 		case "match":
 			// Generate pattern matching code
-			if err := generateMatchCode(&buf, s); err != nil {
+			if err := limits.checkMatch(s.Pos, len(s.Fields)); err != nil {
+				return fmt.Errorf("generating match code for %s: %w", s.Name, err)
+			}
+			if err := generateMatchCode(&buf, s, types); err != nil {
 				return fmt.Errorf("generating match code for %s: %w", s.Name, err)
 			}
+			generateMatchTable(&buf, s, types, naming)
 
 		case "reactive":
 			// Generate reactive wrapper code
-			if err := generateReactiveCode(&buf, s); err != nil {
+			if err := generateReactiveCode(&buf, s, args, naming); err != nil {
 				return fmt.Errorf("generating reactive code for %s: %w", s.Name, err)
 			}
 
@@ -191,16 +210,146 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 				return fmt.Errorf("generating ref code for %s: %w", s.Name, err)
 			}
 
+		case "arbitrary":
+			// Generate property-based testing helpers
+			if err := generateArbitraryCode(&buf, s, types); err != nil {
+				return fmt.Errorf("generating arbitrary code for %s: %w", s.Name, err)
+			}
+
+		case "actor":
+			// Generate mailbox-based actor facade over the struct's methods
+			if err := generateActorCode(&buf, s, methodsOf(pkg.Funcs, s.Name), naming); err != nil {
+				return fmt.Errorf("generating actor code for %s: %w", s.Name, err)
+			}
+
+		case "workflow":
+			// Generate saga orchestrator over the struct's step fields
+			if err := generateWorkflowCode(&buf, s); err != nil {
+				return fmt.Errorf("generating workflow code for %s: %w", s.Name, err)
+			}
+
+		case "binary":
+			// Generate versioned MarshalBinary/UnmarshalBinary keyed by field IDs
+			if err := generateBinaryCode(&buf, s, types); err != nil {
+				return fmt.Errorf("generating binary code for %s: %w", s.Name, err)
+			}
+
+		case "schema":
+			// Generate JSON Schema (and OpenAPI component) documents from the json and validate tags
+			if err := generateSchemaCode(&buf, outDir, s, args, types, opts); err != nil {
+				return fmt.Errorf("generating schema for %s: %w", s.Name, err)
+			}
+
+		case "builder":
+			// Generate a fluent builder with chainable setters, validated by the validate tags
+			if err := generateBuilderCode(&buf, s, types, naming); err != nil {
+				return fmt.Errorf("generating builder code for %s: %w", s.Name, err)
+			}
+
+		case "store":
+			// Generate a Redux-style store with an action per reducer method and store tag
+			methods := methodsOf(pkg.Funcs, s.Name)
+			for i, m := range methods {
+				methods[i] = imports.qualifyFunc(m)
+			}
+			if err := generateStoreCode(&buf, s, methods, naming); err != nil {
+				return fmt.Errorf("generating store code for %s: %w", s.Name, err)
+			}
+
+		case "accessors":
+			// Generate getters and setters for unexported fields, with an optional change hook
+			if err := generateAccessorsCode(&buf, s, types, naming, args); err != nil {
+				return fmt.Errorf("generating accessors code for %s: %w", s.Name, err)
+			}
+
+		case "csv":
+			// Generate header-aware CSV row codecs plus typed readers and writers
+			if err := generateCSVCode(&buf, s, types, naming, args); err != nil {
+				return fmt.Errorf("generating csv code for %s: %w", s.Name, err)
+			}
+
+		case "equal":
+			// Generate a deep Equal method, plus a Hash agreeing with it when asked for
+			if err := generateEqualCode(&buf, s, types, args); err != nil {
+				return fmt.Errorf("generating equal code for %s: %w", s.Name, err)
+			}
+
+		case "iterator":
+			// Generate range-over-func iterators over the struct's slice or map field
+			if err := generateIteratorCode(&buf, s, types, args); err != nil {
+				return fmt.Errorf("generating iterator code for %s: %w", s.Name, err)
+			}
+
+		case "stringer":
+			// Generate a String method over every field, redacting secret ones
+			if err := generateStringerCode(&buf, s, types, args); err != nil {
+				return fmt.Errorf("generating stringer code for %s: %w", s.Name, err)
+			}
+
+		case "immutable":
+			// Generate With methods returning modified copies, plus a constructor and getters unless record has them
+			if err := generateImmutableCode(&buf, s, types, naming, args); err != nil {
+				return fmt.Errorf("generating immutable code for %s: %w", s.Name, err)
+			}
+
+		case "visitor":
+			// Generate double dispatch over the structs sharing the visitor group
+			if err := generateVisitorCode(&buf, s, pkg.Structs); err != nil {
+				return fmt.Errorf("generating visitor code for %s: %w", s.Name, err)
+			}
+
+		case "handler":
+			// Generate a JSON http.Handler over the service's context-taking methods
+			methods := methodsOf(pkg.Funcs, s.Name)
+			for i, m := range methods {
+				methods[i] = imports.qualifyFunc(m)
+			}
+			if err := generateHandlerCode(&buf, s, methods, types, args, naming); err != nil {
+				return fmt.Errorf("generating handler code for %s: %w", s.Name, err)
+			}
+
+		case "inject":
+			// Generate a constructor resolving the fields from the Container of the //gofn:provide functions
+			if err := generateInjectCode(&buf, s, pkg.Funcs, imports); err != nil {
+				return fmt.Errorf("generating inject code for %s: %w", s.Name, err)
+			}
+
+		case "lens":
+			// Generate optics.Lens values for the fields, composable into updates of nested fields
+			if err := generateLensCode(&buf, s); err != nil {
+				return fmt.Errorf("generating lens code for %s: %w", s.Name, err)
+			}
+
 		default:
 			// fallback constructor
-			ctor := fmt.Sprintf("// Generated constructor for %s\nfunc New%s(%s) %s {\n    return %s{%s}\n}\n\n",
-				s.Name, s.Name, paramsForFields(s.Fields), s.Name, s.Name, valuesForFields(s.Fields))
+			ctor := fmt.Sprintf("// Generated constructor for %s\nfunc %s(%s) %s {\n    return %s{%s}\n}\n\n",
+				s.Name, naming.ConstructorPrefix+s.Name, paramsForFields(s.Fields), s.Name, s.Name, valuesForFields(s.Fields))
 			buf.WriteString(ctor)
 		}
 
-		fname := fmt.Sprintf("%s_%s_gen.go", s.Name, normalizeDirective(s.Directive))
+		src, err := imports.addTo(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("generating %s code for %s: %w", name, s.Name, err)
+		}
+		buf.Reset()
+		buf.Write(src)
+
+		if naming.keepUnexported(s.Name, args) {
+			src, err := unexportGenerated(buf.Bytes(), reserved)
+			if err != nil {
+				return fmt.Errorf("generating %s code for %s: %w", name, s.Name, err)
+			}
+			buf.Reset()
+			buf.Write(src)
+		}
+
+		fname := fmt.Sprintf("%s_%s_gen.go", s.Name, normalizeDirective(name))
 		out := filepath.Join(outDir, fname)
 
+		if err := writeChart(outDir, s, name, args, opts); err != nil {
+			return fmt.Errorf("writing chart for %s: %w", s.Name, err)
+		}
+
 		// try to find source path
 		srcPath := ""
 		if s.Pos.Filename != "" {
@@ -216,12 +365,17 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 			return err
 		}
 
+		migrate, err := checkSchema(out, opts)
+		if err != nil {
+			return err
+		}
 		doGen, reason, serr := shouldGenerate(srcPath, out)
 		if serr != nil {
 			fmt.Printf("gofn: check should-generate for %s: %v\n", fname, serr)
 		}
-		if !doGen {
+		if !doGen && !migrate {
 			fmt.Printf("gofn: skip %s - %s\n", fname, reason)
+			opts.Report.skipped(s.Name, name, reason)
 			continue
 		}
 
@@ -230,13 +384,53 @@ func generateStructs(outDir string, structs []parser.StructInfo) error {
 			return err
 		}
 		fmt.Printf("gofn: generated %s\n", out)
+		opts.Report.generated(s.Name, name, out, formatted)
 	}
 	return nil
 }
 
+// generateRecordFake generates Fake<Iface>, a struct with exported settable fields implementing the record interface
+// Tests can build collaborators from it without going through the record constructor
+func generateRecordFake(buf *bytes.Buffer, s parser.StructInfo, ifaceName string, naming Naming, immutable bool) {
+	fakeName := "Fake" + ifaceName
+
+	buf.WriteString(fmt.Sprintf("// %s is a test double implementing %s with settable values\n", fakeName, ifaceName))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", fakeName))
+	for _, f := range s.Fields {
+		buf.WriteString(fmt.Sprintf("\t%sValue %s\n", exportName(f.Name), f.Type))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("var _ %s = %s{}\n\n", ifaceName, fakeName))
+
+	for _, f := range s.Fields {
+		gname := naming.getter(f.Name)
+		field := exportName(f.Name) + "Value"
+		buf.WriteString(fmt.Sprintf("// %s returns %s\n", gname, field))
+		buf.WriteString(fmt.Sprintf("func (f %s) %s() %s {\n\treturn f.%s\n}\n\n", fakeName, gname, f.Type, field))
+	}
+
+	if !immutable {
+		return
+	}
+	for _, f := range s.Fields {
+		with := "With" + exportName(f.Name)
+		field := exportName(f.Name) + "Value"
+		buf.WriteString(fmt.Sprintf("// %s returns a copy of f with %s set to v\n", with, field))
+		buf.WriteString(fmt.Sprintf("func (f %s) %s(v %s) %s {\n\tf.%s = v\n\treturn f\n}\n\n", fakeName, with, f.Type, ifaceName, field))
+	}
+}
+
 // generateMatchCode generates pattern matching code for a struct
-func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
+// Slice and map fields, which == cannot compare, take a monad.Pattern of their underlying type instead of an Option
+func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo, types typeIndex) error {
 	structName := s.Name
+	patternType := func(t string) string {
+		if coll, ok := types.collectionType(t); ok {
+			return "monad.Pattern[" + coll + "]"
+		}
+		return "monad.Option[" + t + "]"
+	}
 	matcherName := exportName(structName) + "Matcher"
 	returnMatcherName := exportName(structName) + "MatcherWithReturn"
 
@@ -281,8 +475,7 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 
 	// Generate parameters for each field
 	for _, field := range s.Fields {
-		buf.WriteString(fmt.Sprintf("\t%s monad.Option[%s],\n",
-			strings.ToLower(field.Name), field.Type))
+		buf.WriteString(fmt.Sprintf("\t%s %s,\n", strings.ToLower(field.Name), patternType(field.Type)))
 	}
 	buf.WriteString(fmt.Sprintf("\thandler func(%s),\n", structName))
 	buf.WriteString(fmt.Sprintf(") *%s {\n", matcherName))
@@ -308,8 +501,7 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString(fmt.Sprintf("func (m *%s) WhenGuard(\n", matcherName))
 
 	for _, field := range s.Fields {
-		buf.WriteString(fmt.Sprintf("\t%s monad.Option[%s],\n",
-			strings.ToLower(field.Name), field.Type))
+		buf.WriteString(fmt.Sprintf("\t%s %s,\n", strings.ToLower(field.Name), patternType(field.Type)))
 	}
 	buf.WriteString(fmt.Sprintf("\tguard func(%s) bool,\n", structName))
 	buf.WriteString(fmt.Sprintf("\thandler func(%s),\n", structName))
@@ -338,8 +530,7 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString(fmt.Sprintf("func (m *%s[T]) When(\n", returnMatcherName))
 
 	for _, field := range s.Fields {
-		buf.WriteString(fmt.Sprintf("\t%s monad.Option[%s],\n",
-			strings.ToLower(field.Name), field.Type))
+		buf.WriteString(fmt.Sprintf("\t%s %s,\n", strings.ToLower(field.Name), patternType(field.Type)))
 	}
 	buf.WriteString(fmt.Sprintf("\thandler func(%s) T,\n", structName))
 	buf.WriteString(fmt.Sprintf(") *%s[T] {\n", returnMatcherName))
@@ -359,8 +550,7 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString(fmt.Sprintf("func (m *%s[T]) WhenGuard(\n", returnMatcherName))
 
 	for _, field := range s.Fields {
-		buf.WriteString(fmt.Sprintf("\t%s monad.Option[%s],\n",
-			strings.ToLower(field.Name), field.Type))
+		buf.WriteString(fmt.Sprintf("\t%s %s,\n", strings.ToLower(field.Name), patternType(field.Type)))
 	}
 	buf.WriteString(fmt.Sprintf("\tguard func(%s) bool,\n", structName))
 	buf.WriteString(fmt.Sprintf("\thandler func(%s) T,\n", structName))
@@ -397,8 +587,7 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString("// matchFields checks if all fields match the pattern\n")
 	buf.WriteString(fmt.Sprintf("func (m *%s) matchFields(\n", matcherName))
 	for _, field := range s.Fields {
-		buf.WriteString(fmt.Sprintf("\t%s monad.Option[%s],\n",
-			strings.ToLower(field.Name), field.Type))
+		buf.WriteString(fmt.Sprintf("\t%s %s,\n", strings.ToLower(field.Name), patternType(field.Type)))
 	}
 	buf.WriteString(") bool {\n")
 
@@ -406,7 +595,7 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	for i, field := range s.Fields {
 		fieldName := strings.ToLower(field.Name)
 		conditions[i] = fmt.Sprintf("m.match%sField(%s, m.value.%s)",
-			exportName(field.Type), fieldName, field.Name)
+			typeIdent(field.Type), fieldName, field.Name)
 	}
 
 	buf.WriteString("\treturn " + strings.Join(conditions, " &&\n\t\t   ") + "\n")
@@ -416,15 +605,14 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString("// matchFields checks if all fields match the pattern (for return matcher)\n")
 	buf.WriteString(fmt.Sprintf("func (m *%s[T]) matchFields(\n", returnMatcherName))
 	for _, field := range s.Fields {
-		buf.WriteString(fmt.Sprintf("\t%s monad.Option[%s],\n",
-			strings.ToLower(field.Name), field.Type))
+		buf.WriteString(fmt.Sprintf("\t%s %s,\n", strings.ToLower(field.Name), patternType(field.Type)))
 	}
 	buf.WriteString(") bool {\n")
 
 	for i, field := range s.Fields {
 		fieldName := strings.ToLower(field.Name)
 		conditions[i] = fmt.Sprintf("m.match%sField(%s, m.value.%s)",
-			exportName(field.Type), fieldName, field.Name)
+			typeIdent(field.Type), fieldName, field.Name)
 	}
 
 	buf.WriteString("\treturn " + strings.Join(conditions, " &&\n\t\t   ") + "\n")
@@ -438,7 +626,34 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 		}
 		typesSeen[field.Type] = true
 
-		typeName := exportName(field.Type)
+		typeName := typeIdent(field.Type)
+		// Option-typed fields take SomeP/NoneP patterns that look inside the field
+		if _, ok := optionElem(field.Type); ok {
+			for _, recv := range []string{matcherName, returnMatcherName + "[T]"} {
+				buf.WriteString(fmt.Sprintf("// match%sField checks if an Option field matches a SomeP, NoneP or wildcard pattern\n", typeName))
+				buf.WriteString(fmt.Sprintf("func (m *%s) match%sField(pattern monad.Option[%s], value %s) bool {\n",
+					recv, typeName, field.Type, field.Type))
+				buf.WriteString("\treturn monad.MatchOptionField(pattern, value)\n")
+				buf.WriteString("}\n\n")
+			}
+			continue
+		}
+		// slice and map fields are matched by their Pattern, as their underlying type
+		if coll, ok := types.collectionType(field.Type); ok {
+			value := "value"
+			if coll != field.Type {
+				value = fmt.Sprintf("%s(value)", coll)
+			}
+			for _, recv := range []string{matcherName, returnMatcherName + "[T]"} {
+				buf.WriteString(fmt.Sprintf("// match%sField checks if a slice or map field matches the pattern\n", typeName))
+				buf.WriteString(fmt.Sprintf("func (m *%s) match%sField(pattern monad.Pattern[%s], value %s) bool {\n",
+					recv, typeName, coll, field.Type))
+				buf.WriteString(fmt.Sprintf("\treturn pattern.Match(%s)\n", value))
+				buf.WriteString("}\n\n")
+			}
+			continue
+		}
+
 		buf.WriteString(fmt.Sprintf("// match%sField checks if a field matches the pattern\n", typeName))
 		buf.WriteString(fmt.Sprintf("func (m *%s) match%sField(pattern monad.Option[%s], value %s) bool {\n",
 			matcherName, typeName, field.Type, field.Type))
@@ -468,12 +683,32 @@ func generateMatchCode(buf *bytes.Buffer, s parser.StructInfo) error {
 }
 
 // generateReactiveCode generates reactive wrapper code for a struct
-func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo) error {
+// The `stream` argument additionally generates a server-sent events handler for changes,
+// and `persist` JSON snapshot/restore methods plus a debounced autosave to a file
+func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo, args directiveArgs, naming Naming) error {
 	structName := s.Name
 	reactiveTypeName := "Reactive" + exportName(structName)
+	subscriberTypeName := "reactive" + exportName(structName) + "Subscriber"
 
 	// Add import for monad package and sync
+	stream := args.has("stream")
+	persist := args.has("persist")
 	buf.WriteString("import (\n")
+	buf.WriteString("\t\"context\"\n")
+	if stream || persist {
+		buf.WriteString("\t\"encoding/json\"\n")
+	}
+	if persist {
+		buf.WriteString("\t\"errors\"\n")
+		buf.WriteString("\t\"io\"\n")
+		buf.WriteString("\t\"io/fs\"\n")
+		buf.WriteString("\t\"os\"\n")
+		buf.WriteString("\t\"time\"\n")
+	}
+	if stream {
+		buf.WriteString("\t\"fmt\"\n")
+		buf.WriteString("\t\"net/http\"\n")
+	}
 	buf.WriteString("\t\"sync\"\n")
 	buf.WriteString("\t\"sync/atomic\"\n")
 	buf.WriteString("\t\"github.com/snowmerak/gofn/monad\"\n")
@@ -483,17 +718,24 @@ func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString(fmt.Sprintf("// %s provides reactive capabilities for %s\n", reactiveTypeName, structName))
 	buf.WriteString(fmt.Sprintf("type %s struct {\n", reactiveTypeName))
 	buf.WriteString(fmt.Sprintf("\tvalue %s\n", structName))
-	buf.WriteString(fmt.Sprintf("\tsubscribers map[int]func(old %s, new %s)\n", structName, structName))
+	buf.WriteString(fmt.Sprintf("\tsubscribers atomic.Pointer[[]%s]\n", subscriberTypeName))
 	buf.WriteString("\tnextID int64\n")
 	buf.WriteString("\tmutex sync.RWMutex\n")
 	buf.WriteString("}\n\n")
 
+	// Generate subscriber entry; the subscriber slice is copy-on-write so Set/Update never copy it
+	buf.WriteString(fmt.Sprintf("// %s is a registered change callback of %s\n", subscriberTypeName, reactiveTypeName))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", subscriberTypeName))
+	buf.WriteString("\tid int\n")
+	buf.WriteString(fmt.Sprintf("\tcallback func(ctx context.Context, old %s, new %s)\n", structName, structName))
+	buf.WriteString("}\n\n")
+
 	// Generate constructor
-	buf.WriteString(fmt.Sprintf("// NewReactive%s creates a new reactive wrapper for %s\n", exportName(structName), structName))
-	buf.WriteString(fmt.Sprintf("func NewReactive%s(initial %s) *%s {\n", exportName(structName), structName, reactiveTypeName))
+	ctorName := naming.constructor(reactiveTypeName)
+	buf.WriteString(fmt.Sprintf("// %s creates a new reactive wrapper for %s\n", ctorName, structName))
+	buf.WriteString(fmt.Sprintf("func %s(initial %s) *%s {\n", ctorName, structName, reactiveTypeName))
 	buf.WriteString(fmt.Sprintf("\treturn &%s{\n", reactiveTypeName))
 	buf.WriteString("\t\tvalue: initial,\n")
-	buf.WriteString(fmt.Sprintf("\t\tsubscribers: make(map[int]func(old %s, new %s)),\n", structName, structName))
 	buf.WriteString("\t\tnextID: 0,\n")
 	buf.WriteString("\t}\n")
 	buf.WriteString("}\n\n")
@@ -509,41 +751,48 @@ func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	// Generate Set method
 	buf.WriteString(fmt.Sprintf("// Set updates the %s value and notifies all subscribers\n", structName))
 	buf.WriteString(fmt.Sprintf("func (r *%s) Set(newValue %s) {\n", reactiveTypeName, structName))
+	buf.WriteString("\tr.SetCtx(context.Background(), newValue)\n")
+	buf.WriteString("}\n\n")
+
+	// Generate SetCtx method
+	buf.WriteString("// SetCtx is Set passing ctx to callbacks registered with SubscribeCtx, e.g. for trace or log correlation\n")
+	buf.WriteString("// Callbacks run after SetCtx returns, so they receive ctx's values without its cancellation\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) SetCtx(ctx context.Context, newValue %s) {\n", reactiveTypeName, structName))
 	buf.WriteString("\tr.mutex.Lock()\n")
 	buf.WriteString("\toldValue := r.value\n")
 	buf.WriteString("\tr.value = newValue\n")
-	buf.WriteString("\t\n")
-	buf.WriteString("\t// Copy subscribers to avoid holding lock during notifications\n")
-	buf.WriteString(fmt.Sprintf("\tsubscribers := make(map[int]func(old %s, new %s))\n", structName, structName))
-	buf.WriteString("\tfor id, callback := range r.subscribers {\n")
-	buf.WriteString("\t\tsubscribers[id] = callback\n")
-	buf.WriteString("\t}\n")
+	buf.WriteString("\tsubscribers := r.snapshot()\n")
 	buf.WriteString("\tr.mutex.Unlock()\n")
 	buf.WriteString("\t\n")
-	buf.WriteString("\t// Notify subscribers outside of lock to prevent deadlocks\n")
-	buf.WriteString("\tfor _, callback := range subscribers {\n")
-	buf.WriteString("\t\tgo callback(oldValue, newValue)\n")
-	buf.WriteString("\t}\n")
+	buf.WriteString("\tr.notify(ctx, subscribers, oldValue, newValue)\n")
 	buf.WriteString("}\n\n")
 
 	// Generate Update method
 	buf.WriteString(fmt.Sprintf("// Update applies a function to the current %s value\n", structName))
 	buf.WriteString(fmt.Sprintf("func (r *%s) Update(fn func(%s) %s) {\n", reactiveTypeName, structName, structName))
+	buf.WriteString("\tr.UpdateCtx(context.Background(), fn)\n")
+	buf.WriteString("}\n\n")
+
+	// Generate UpdateCtx method
+	buf.WriteString("// UpdateCtx is Update passing ctx to callbacks registered with SubscribeCtx\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) UpdateCtx(ctx context.Context, fn func(%s) %s) {\n", reactiveTypeName, structName, structName))
 	buf.WriteString("\tr.mutex.Lock()\n")
 	buf.WriteString("\toldValue := r.value\n")
 	buf.WriteString("\tnewValue := fn(r.value)\n")
 	buf.WriteString("\tr.value = newValue\n")
-	buf.WriteString("\t\n")
-	buf.WriteString("\t// Copy subscribers to avoid holding lock during notifications\n")
-	buf.WriteString(fmt.Sprintf("\tsubscribers := make(map[int]func(old %s, new %s))\n", structName, structName))
-	buf.WriteString("\tfor id, callback := range r.subscribers {\n")
-	buf.WriteString("\t\tsubscribers[id] = callback\n")
-	buf.WriteString("\t}\n")
+	buf.WriteString("\tsubscribers := r.snapshot()\n")
 	buf.WriteString("\tr.mutex.Unlock()\n")
 	buf.WriteString("\t\n")
-	buf.WriteString("\t// Notify subscribers outside of lock to prevent deadlocks\n")
-	buf.WriteString("\tfor _, callback := range subscribers {\n")
-	buf.WriteString("\t\tgo callback(oldValue, newValue)\n")
+	buf.WriteString("\tr.notify(ctx, subscribers, oldValue, newValue)\n")
+	buf.WriteString("}\n\n")
+
+	// Generate notify helper
+	buf.WriteString("// notify runs every subscriber callback asynchronously, outside of the lock to prevent deadlocks\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) notify(ctx context.Context, subscribers []%s, oldValue, newValue %s) {\n", reactiveTypeName, subscriberTypeName, structName))
+	buf.WriteString("\tctx = context.WithoutCancel(ctx)\n")
+	buf.WriteString("\tfor _, sub := range subscribers {\n")
+	buf.WriteString("\t\tcallback := sub.callback\n")
+	buf.WriteString("\t\tmonad.Spawn(func() { callback(ctx, oldValue, newValue) })\n")
 	buf.WriteString("\t}\n")
 	buf.WriteString("}\n\n")
 
@@ -551,11 +800,22 @@ func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString("// Subscribe adds a callback for value changes\n")
 	buf.WriteString("// Returns subscription ID for unsubscribing\n")
 	buf.WriteString(fmt.Sprintf("func (r *%s) Subscribe(callback func(old %s, new %s)) int {\n", reactiveTypeName, structName, structName))
+	buf.WriteString(fmt.Sprintf("\treturn r.SubscribeCtx(func(_ context.Context, old, new %s) { callback(old, new) })\n", structName))
+	buf.WriteString("}\n\n")
+
+	// Generate SubscribeCtx method
+	buf.WriteString("// SubscribeCtx adds a callback for value changes that receives the ctx given to SetCtx or UpdateCtx\n")
+	buf.WriteString("// (context.Background() for Set and Update); returns subscription ID for unsubscribing\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) SubscribeCtx(callback func(ctx context.Context, old %s, new %s)) int {\n", reactiveTypeName, structName, structName))
 	buf.WriteString("\tr.mutex.Lock()\n")
 	buf.WriteString("\tdefer r.mutex.Unlock()\n")
 	buf.WriteString("\t\n")
 	buf.WriteString("\tid := int(atomic.AddInt64(&r.nextID, 1))\n")
-	buf.WriteString("\tr.subscribers[id] = callback\n")
+	buf.WriteString("\tcurrent := r.snapshot()\n")
+	buf.WriteString(fmt.Sprintf("\tnext := make([]%s, len(current), len(current)+1)\n", subscriberTypeName))
+	buf.WriteString("\tcopy(next, current)\n")
+	buf.WriteString(fmt.Sprintf("\tnext = append(next, %s{id: id, callback: callback})\n", subscriberTypeName))
+	buf.WriteString("\tr.subscribers.Store(&next)\n")
 	buf.WriteString("\treturn id\n")
 	buf.WriteString("}\n\n")
 
@@ -564,7 +824,24 @@ func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString(fmt.Sprintf("func (r *%s) Unsubscribe(id int) {\n", reactiveTypeName))
 	buf.WriteString("\tr.mutex.Lock()\n")
 	buf.WriteString("\tdefer r.mutex.Unlock()\n")
-	buf.WriteString("\tdelete(r.subscribers, id)\n")
+	buf.WriteString("\t\n")
+	buf.WriteString("\tcurrent := r.snapshot()\n")
+	buf.WriteString(fmt.Sprintf("\tnext := make([]%s, 0, len(current))\n", subscriberTypeName))
+	buf.WriteString("\tfor _, sub := range current {\n")
+	buf.WriteString("\t\tif sub.id != id {\n")
+	buf.WriteString("\t\t\tnext = append(next, sub)\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tr.subscribers.Store(&next)\n")
+	buf.WriteString("}\n\n")
+
+	// Generate snapshot helper
+	buf.WriteString("// snapshot returns the current immutable subscriber slice\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) snapshot() []%s {\n", reactiveTypeName, subscriberTypeName))
+	buf.WriteString("\tif subs := r.subscribers.Load(); subs != nil {\n")
+	buf.WriteString("\t\treturn *subs\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\treturn nil\n")
 	buf.WriteString("}\n\n")
 
 	// Generate field-specific setters that trigger reactivity
@@ -605,9 +882,128 @@ func generateReactiveCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	buf.WriteString("\treturn result\n")
 	buf.WriteString("}\n\n")
 
+	if stream {
+		generateReactiveStream(buf, structName, reactiveTypeName)
+	}
+	if persist {
+		generateReactivePersist(buf, structName, reactiveTypeName, ctorName)
+	}
+
 	return nil
 }
 
+// generateReactivePersist generates JSON snapshot/restore methods and a file autosave for a reactive
+func generateReactivePersist(buf *bytes.Buffer, structName, reactiveTypeName, ctorName string) {
+	restoreName := "Restore" + reactiveTypeName
+
+	buf.WriteString(fmt.Sprintf("// SnapshotTo writes the current %s to w as JSON\n", structName))
+	buf.WriteString(fmt.Sprintf("func (r *%s) SnapshotTo(w io.Writer) error {\n", reactiveTypeName))
+	buf.WriteString("\treturn json.NewEncoder(w).Encode(r.Get())\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// RestoreFrom replaces the %s with the JSON read from rd, notifying subscribers\n", structName))
+	buf.WriteString(fmt.Sprintf("func (r *%s) RestoreFrom(rd io.Reader) error {\n", reactiveTypeName))
+	buf.WriteString(fmt.Sprintf("\tvar value %s\n", structName))
+	buf.WriteString("\tif err := json.NewDecoder(rd).Decode(&value); err != nil {\n")
+	buf.WriteString("\t\treturn err\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tr.Set(value)\n")
+	buf.WriteString("\treturn nil\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s creates a %s from a JSON snapshot written by SnapshotTo\n", restoreName, reactiveTypeName))
+	buf.WriteString(fmt.Sprintf("func %s(rd io.Reader) monad.Result[*%s] {\n", restoreName, reactiveTypeName))
+	buf.WriteString(fmt.Sprintf("\tvar value %s\n", structName))
+	buf.WriteString("\tif err := json.NewDecoder(rd).Decode(&value); err != nil {\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn monad.Err[*%s](err)\n", reactiveTypeName))
+	buf.WriteString("\t}\n")
+	buf.WriteString(fmt.Sprintf("\treturn monad.Ok(%s(value))\n", ctorName))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %sFile creates a %s from the snapshot at path, starting from initial if the file does not exist yet\n", restoreName, reactiveTypeName))
+	buf.WriteString(fmt.Sprintf("func %sFile(path string, initial %s) monad.Result[*%s] {\n", restoreName, structName, reactiveTypeName))
+	buf.WriteString("\tf, err := os.Open(path)\n")
+	buf.WriteString("\tif errors.Is(err, fs.ErrNotExist) {\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn monad.Ok(%s(initial))\n", ctorName))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tif err != nil {\n")
+	buf.WriteString(fmt.Sprintf("\t\treturn monad.Err[*%s](err)\n", reactiveTypeName))
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tdefer f.Close()\n")
+	buf.WriteString(fmt.Sprintf("\treturn %s(f)\n", restoreName))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// AutosaveFile snapshots the %s to path once it has stopped changing for debounce\n", structName))
+	buf.WriteString("// Call Stop on the returned Autosave to write any pending change and detach it\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) AutosaveFile(path string, debounce time.Duration) *monad.Autosave {\n", reactiveTypeName))
+	buf.WriteString("\tsave := monad.NewAutosave(debounce, func() error {\n")
+	buf.WriteString("\t\treturn monad.WriteFileAtomic(path, r.SnapshotTo)\n")
+	buf.WriteString("\t})\n")
+	buf.WriteString(fmt.Sprintf("\tid := r.Subscribe(func(_, _ %s) { save.Changed() })\n", structName))
+	buf.WriteString("\tsave.OnStop(func() { r.Unsubscribe(id) })\n")
+	buf.WriteString("\treturn save\n")
+	buf.WriteString("}\n\n")
+}
+
+// generateReactiveStream generates a server-sent events bridge streaming the changes of a reactive
+func generateReactiveStream(buf *bytes.Buffer, structName, reactiveTypeName string) {
+	eventTypeName := exportName(structName) + "ChangeEvent"
+	streamName := "Stream" + exportName(structName) + "Changes"
+
+	buf.WriteString(fmt.Sprintf("// %s is the payload of a change streamed by %s\n", eventTypeName, streamName))
+	buf.WriteString(fmt.Sprintf("type %s struct {\n", eventTypeName))
+	buf.WriteString(fmt.Sprintf("\tOld %s `json:\"old\"`\n", structName))
+	buf.WriteString(fmt.Sprintf("\tNew %s `json:\"new\"`\n", structName))
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// %s streams %s changes to the client as server-sent events\n", streamName, structName))
+	buf.WriteString("// The current value is sent first as a \"snapshot\" event, then every change as a \"change\" event;\n")
+	buf.WriteString("// the subscription is removed when the client disconnects\n")
+	buf.WriteString(fmt.Sprintf("func (r *%s) %s(w http.ResponseWriter, req *http.Request) {\n", reactiveTypeName, streamName))
+	buf.WriteString("\tflusher, ok := w.(http.Flusher)\n")
+	buf.WriteString("\tif !ok {\n")
+	buf.WriteString("\t\thttp.Error(w, \"streaming unsupported\", http.StatusInternalServerError)\n")
+	buf.WriteString("\t\treturn\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tw.Header().Set(\"Content-Type\", \"text/event-stream\")\n")
+	buf.WriteString("\tw.Header().Set(\"Cache-Control\", \"no-cache\")\n")
+	buf.WriteString("\tw.Header().Set(\"Connection\", \"keep-alive\")\n\n")
+	buf.WriteString("\tctx := req.Context()\n")
+	buf.WriteString(fmt.Sprintf("\tchanges := make(chan %s, 16)\n", eventTypeName))
+	buf.WriteString(fmt.Sprintf("\tid := r.Subscribe(func(old, new %s) {\n", structName))
+	buf.WriteString("\t\tselect {\n")
+	buf.WriteString(fmt.Sprintf("\t\tcase changes <- %s{Old: old, New: new}:\n", eventTypeName))
+	buf.WriteString("\t\tcase <-ctx.Done():\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t})\n")
+	buf.WriteString("\tdefer r.Unsubscribe(id)\n\n")
+	buf.WriteString("\twrite := func(event string, payload any) bool {\n")
+	buf.WriteString("\t\tdata, err := json.Marshal(payload)\n")
+	buf.WriteString("\t\tif err != nil {\n")
+	buf.WriteString("\t\t\treturn false\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tif _, err := fmt.Fprintf(w, \"event: %s\\ndata: %s\\n\\n\", event, data); err != nil {\n")
+	buf.WriteString("\t\t\treturn false\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t\tflusher.Flush()\n")
+	buf.WriteString("\t\treturn true\n")
+	buf.WriteString("\t}\n\n")
+	buf.WriteString("\tif !write(\"snapshot\", r.Get()) {\n")
+	buf.WriteString("\t\treturn\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("\tfor {\n")
+	buf.WriteString("\t\tselect {\n")
+	buf.WriteString("\t\tcase change := <-changes:\n")
+	buf.WriteString("\t\t\tif !write(\"change\", change) {\n")
+	buf.WriteString("\t\t\t\treturn\n")
+	buf.WriteString("\t\t\t}\n")
+	buf.WriteString("\t\tcase <-ctx.Done():\n")
+	buf.WriteString("\t\t\treturn\n")
+	buf.WriteString("\t\t}\n")
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+}
+
 // generateRefCode generates reference wrapper code for a struct
 func generateRefCode(buf *bytes.Buffer, s parser.StructInfo) error {
 	structName := s.Name
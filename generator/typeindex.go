@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// typeIndex looks up the package's own declarations to answer questions about parameter and field types
+type typeIndex struct {
+	structs    map[string]parser.StructInfo
+	types      map[string]parser.TypeInfo
+	funcs      []parser.FuncInfo
+	directives map[string][]string                 // directive names of each struct, which may carry several
+	args       map[string]map[string]directiveArgs // arguments of each struct's directives, by directive name
+	hashed     map[string]bool                     // structs whose Hash and Equal //gofn:equal hash generates
+}
+
+// newTypeIndex indexes the structs, named types and methods of pkg
+func newTypeIndex(pkg parser.Package) typeIndex {
+	x := typeIndex{
+		structs:    map[string]parser.StructInfo{},
+		types:      map[string]parser.TypeInfo{},
+		funcs:      pkg.Funcs,
+		directives: map[string][]string{},
+		args:       map[string]map[string]directiveArgs{},
+		hashed:     map[string]bool{},
+	}
+	for _, s := range pkg.Structs {
+		x.structs[s.Name] = s
+		name, args := splitDirective(s.Directive)
+		if name != "" {
+			x.directives[s.Name] = append(x.directives[s.Name], name)
+			if x.args[s.Name] == nil {
+				x.args[s.Name] = map[string]directiveArgs{}
+			}
+			x.args[s.Name][name] = args
+		}
+		if name == "equal" && args.has("hash") {
+			x.hashed[s.Name] = true
+		}
+	}
+	for _, t := range pkg.Types {
+		x.types[t.Name] = t
+	}
+	return x
+}
+
+// hasDirective reports whether the struct named name carries the directive
+func (x typeIndex) hasDirective(name, directive string) bool {
+	return slices.Contains(x.directives[name], directive)
+}
+
+// argsOf returns the arguments the struct named name gives the directive, nil when it lacks it
+func (x typeIndex) argsOf(name, directive string) directiveArgs {
+	return x.args[name][directive]
+}
+
+// isComparable reports whether values of type t can be compared with == without panicking
+// Types from other packages cannot be inspected and are assumed comparable; the compiler still checks them
+func (x typeIndex) isComparable(t string) bool {
+	return x.comparable(strings.TrimSpace(t), map[string]bool{})
+}
+
+func (x typeIndex) comparable(t string, seen map[string]bool) bool {
+	switch {
+	case strings.HasPrefix(t, "..."), strings.HasPrefix(t, "[]"), strings.HasPrefix(t, "map["),
+		strings.HasPrefix(t, "func("), strings.HasPrefix(t, "func "):
+		return false
+	case strings.HasPrefix(t, "*"), strings.HasPrefix(t, "chan"), strings.HasPrefix(t, "<-chan"):
+		return true
+	case strings.HasPrefix(t, "["):
+		_, elem, _ := strings.Cut(t, "]")
+		return x.comparable(elem, seen)
+	case t == "any", t == "error", strings.HasPrefix(t, "interface"):
+		// comparable at compile time, but == panics on dynamic values that are not
+		return false
+	case strings.Contains(t, "."):
+		return true
+	}
+
+	name, _, _ := strings.Cut(t, "[") // instantiated generic types are judged by their declaration
+	if seen[name] {
+		return true
+	}
+	seen[name] = true
+	if s, ok := x.structs[name]; ok {
+		for _, f := range s.Fields {
+			if !x.comparable(f.Type, seen) {
+				return false
+			}
+		}
+		return true
+	}
+	if nt, ok := x.types[name]; ok {
+		switch nt.Kind {
+		case parser.KindSlice, parser.KindMap, parser.KindFunc, parser.KindInterface:
+			return false
+		case parser.KindBasic, parser.KindPointer, parser.KindChan:
+			return true
+		default:
+			return x.comparable(nt.Underlying, seen)
+		}
+	}
+	// predeclared types and anything declared outside the parsed package
+	return true
+}
+
+// collectionType returns the slice or map type literal t is or is declared as, e.g. "[]string" for Tags
+// declared as `type Tags []string`
+func (x typeIndex) collectionType(t string) (string, bool) {
+	t = strings.TrimSpace(t)
+	for range 8 { // bounds chains of named types
+		if strings.HasPrefix(t, "[]") || strings.HasPrefix(t, "map[") {
+			return t, true
+		}
+		nt, ok := x.types[t]
+		if !ok || nt.Kind != parser.KindSlice && nt.Kind != parser.KindMap && nt.Kind != parser.KindNamed {
+			return "", false
+		}
+		t = strings.TrimSpace(nt.Underlying)
+	}
+	return "", false
+}
+
+// isHashable reports whether t is a struct declaring Hash() uint64 and Equal(t) bool (or Equal(*t) bool),
+// or one //gofn:equal hash generates them for
+func (x typeIndex) isHashable(t string) bool {
+	if _, ok := x.structs[t]; !ok {
+		return false
+	}
+	if x.hashed[t] {
+		return true
+	}
+	hash, equal := false, false
+	for _, m := range methodsOf(x.funcs, t) {
+		switch {
+		case m.Name == "Hash" && len(m.Params) == 0 && len(m.Results) == 1 && m.Results[0].Type == "uint64":
+			hash = true
+		case m.Name == "Equal" && len(m.Params) == 1 && strings.TrimPrefix(m.Params[0].Type, "*") == t &&
+			len(m.Results) == 1 && m.Results[0].Type == "bool":
+			equal = true
+		}
+	}
+	return hash && equal
+}
+
+// equalTakesPointer reports whether the Equal method of t takes *t rather than t
+func (x typeIndex) equalTakesPointer(t string) bool {
+	for _, m := range methodsOf(x.funcs, t) {
+		if m.Name == "Equal" && len(m.Params) == 1 {
+			return strings.HasPrefix(m.Params[0].Type, "*")
+		}
+	}
+	return false
+}
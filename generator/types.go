@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateTypes generates code for named non-struct types based on directives, resolving the types they refer to in pkg
+func generateTypes(outDir string, types []parser.TypeInfo, pkg parser.Package, opts Options) error {
+	naming := opts.Naming.withDefaults()
+	reserved := packageNames(pkg)
+	for _, t := range types {
+		dir := strings.TrimSpace(t.Directive)
+		if dir == "" {
+			continue
+		}
+
+		var buf bytes.Buffer
+		buf.WriteString(fileHeader(dir))
+		buf.WriteString("package " + t.Package + "\n\n")
+
+		name, args := splitDirective(dir)
+		switch name {
+		case "enum":
+			// Seal the interface to the listed variants and generate an exhaustive matcher
+			if err := generateEnumCode(&buf, t, positionalArgs(dir), pkg); err != nil {
+				return fmt.Errorf("generating enum code for %s: %w", t.Name, err)
+			}
+		default:
+			fmt.Printf("gofn: skip %s - directive %q is not supported on %s types\n", t.Name, name, t.Kind)
+			opts.Report.skipped(t.Name, name, fmt.Sprintf("not supported on %s types", t.Kind))
+			continue
+		}
+
+		if naming.keepUnexported(t.Name, args) {
+			src, err := unexportGenerated(buf.Bytes(), reserved)
+			if err != nil {
+				return fmt.Errorf("generating %s code for %s: %w", name, t.Name, err)
+			}
+			buf.Reset()
+			buf.Write(src)
+		}
+
+		fname := fmt.Sprintf("%s_%s_gen.go", t.Name, normalizeDirective(name))
+		out := filepath.Join(outDir, fname)
+
+		formatted, err := formatSource(buf.Bytes())
+		if err != nil {
+			fmt.Printf("gofn: format failed for %s: %v\n", fname, err)
+			return err
+		}
+
+		migrate, err := checkSchema(out, opts)
+		if err != nil {
+			return err
+		}
+		doGen, reason, serr := shouldGenerate(t.Pos.Filename, out)
+		if serr != nil {
+			fmt.Printf("gofn: check should-generate for %s: %v\n", fname, serr)
+		}
+		if !doGen && !migrate {
+			fmt.Printf("gofn: skip %s - %s\n", fname, reason)
+			opts.Report.skipped(t.Name, name, reason)
+			continue
+		}
+
+		if err := os.WriteFile(out, formatted, 0o644); err != nil {
+			fmt.Printf("gofn: failed to write %s: %v\n", out, err)
+			return err
+		}
+		fmt.Printf("gofn: generated %s\n", out)
+		opts.Report.generated(t.Name, name, out, formatted)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	gofnparser "github.com/snowmerak/gofn/parser"
+)
+
+// keepUnexported reports whether identifiers generated for the source identifier src stay unexported:
+// src must be unexported and either Naming.Unexported or the `unexported` directive argument must be set
+func (n Naming) keepUnexported(src string, args directiveArgs) bool {
+	return !ast.IsExported(src) && (n.Unexported || args.has("unexported"))
+}
+
+// unexportName lowercases the first letter of name, e.g. NewPerson -> newPerson
+func unexportName(name string) string {
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
+
+// unexportGenerated rewrites generated source so its exported package-level identifiers become unexported,
+// following the same rule everywhere: the first letter is lowercased and every reference in the file is updated
+// Methods and fields keep their names, since they do not widen the package API of an unexported type
+// reserved holds the package's own identifiers; a rename colliding with one of them is an error
+func unexportGenerated(src []byte, reserved map[string]bool) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := map[*ast.Object]string{}
+	for name, obj := range file.Scope.Objects {
+		if !ast.IsExported(name) {
+			continue
+		}
+		lower := unexportName(name)
+		if reserved[lower] || file.Scope.Objects[lower] != nil {
+			return nil, fmt.Errorf("cannot unexport %s: %s is already declared", name, lower)
+		}
+		renames[obj] = lower
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && id.Obj != nil {
+			if lower, ok := renames[id.Obj]; ok {
+				id.Name = lower
+			}
+		}
+		return true
+	})
+	// doc comments conventionally start with the identifier they describe
+	for _, decl := range file.Decls {
+		var doc *ast.CommentGroup
+		var name string
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				doc, name = d.Doc, d.Name.Name
+			}
+		case *ast.GenDecl:
+			if len(d.Specs) == 1 {
+				if ts, ok := d.Specs[0].(*ast.TypeSpec); ok {
+					doc, name = d.Doc, ts.Name.Name
+				}
+			}
+		}
+		if doc == nil || ast.IsExported(name) {
+			continue
+		}
+		exported := "// " + exportName(name)
+		for _, c := range doc.List {
+			if rest, ok := strings.CutPrefix(c.Text, exported); ok {
+				c.Text = "// " + name + rest
+			}
+		}
+	}
+
+	var out bytes.Buffer
+	if err := format.Node(&out, fset, file); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// packageNames returns the package-level names declared by the parsed sources of a package
+func packageNames(pkg gofnparser.Package) map[string]bool {
+	names := map[string]bool{}
+	for _, s := range pkg.Structs {
+		names[s.Name] = true
+	}
+	for _, t := range pkg.Types {
+		names[t.Name] = true
+	}
+	for _, f := range pkg.Funcs {
+		if f.Receiver == "" {
+			names[f.Name] = true
+		}
+	}
+	for _, v := range pkg.Vars {
+		names[v.Name] = true
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateVars generates code for package-level variables based on directives, resolving the functions they list in pkg
+func generateVars(outDir string, vars []parser.VarInfo, pkg parser.Package, opts Options) error {
+	naming := opts.Naming.withDefaults()
+	reserved := packageNames(pkg)
+	for _, v := range vars {
+		var buf bytes.Buffer
+		buf.WriteString(fileHeader(v.Directive))
+		buf.WriteString("package " + v.Package + "\n\n")
+
+		name, args := splitDirective(v.Directive)
+		switch name {
+		case "pipe":
+			if err := generatePipeCode(&buf, v, pkg.Funcs); err != nil {
+				return fmt.Errorf("generating pipe code for %s: %w", v.Name, err)
+			}
+		default:
+			fmt.Printf("gofn: skip %s - directive %q is not supported on variables\n", v.Name, name)
+			opts.Report.skipped(v.Name, name, "not supported on variables")
+			continue
+		}
+
+		if naming.keepUnexported(v.Name, args) {
+			src, err := unexportGenerated(buf.Bytes(), reserved)
+			if err != nil {
+				return fmt.Errorf("generating %s code for %s: %w", name, v.Name, err)
+			}
+			buf.Reset()
+			buf.Write(src)
+		}
+
+		fname := fmt.Sprintf("%s_%s_gen.go", v.Name, normalizeDirective(name))
+		out := filepath.Join(outDir, fname)
+
+		formatted, err := formatSource(buf.Bytes())
+		if err != nil {
+			fmt.Printf("gofn: format failed for %s: %v\n", fname, err)
+			return err
+		}
+
+		migrate, err := checkSchema(out, opts)
+		if err != nil {
+			return err
+		}
+		doGen, reason, serr := shouldGenerate(v.Pos.Filename, out)
+		if serr != nil {
+			fmt.Printf("gofn: check should-generate for %s: %v\n", fname, serr)
+		}
+		if !doGen && !migrate {
+			fmt.Printf("gofn: skip %s - %s\n", fname, reason)
+			opts.Report.skipped(v.Name, name, reason)
+			continue
+		}
+
+		if err := os.WriteFile(out, formatted, 0o644); err != nil {
+			fmt.Printf("gofn: failed to write %s: %v\n", out, err)
+			return err
+		}
+		fmt.Printf("gofn: generated %s\n", out)
+		opts.Report.generated(v.Name, name, out, formatted)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// generateVisitorCode generates the Accept method of s for the visitor group named by its directive, e.g.
+// Expr for `//gofn:visitor Expr`; the first struct of the group also declares `<Group>Visitor`, with a
+// Visit method per struct of the group, and `<Group>Node`, the interface Accept implements
+func generateVisitorCode(buf *bytes.Buffer, s parser.StructInfo, structs []parser.StructInfo) error {
+	args := positionalArgs(s.Directive)
+	if len(args) != 1 {
+		return fmt.Errorf("name the one visitor group of %s, e.g. //gofn:visitor Expr", s.Name)
+	}
+	group := args[0]
+
+	var members []string
+	for _, other := range structs {
+		name, _ := splitDirective(other.Directive)
+		if name != "visitor" {
+			continue
+		}
+		otherArgs := positionalArgs(other.Directive)
+		switch {
+		case other.Name == s.Name && (len(otherArgs) != 1 || otherArgs[0] != group):
+			return fmt.Errorf("%s is in more than one visitor group; a struct has one Accept method", s.Name)
+		case len(otherArgs) == 1 && otherArgs[0] == group && !slices.Contains(members, other.Name):
+			members = append(members, other.Name)
+		}
+	}
+
+	visitor := exportName(group) + "Visitor"
+	node := exportName(group) + "Node"
+	if members[0] == s.Name {
+		buf.WriteString(fmt.Sprintf("// %s has a Visit method per struct of the %s group, called by their Accept methods\n", visitor, group))
+		buf.WriteString(fmt.Sprintf("type %s interface {\n", visitor))
+		for _, m := range members {
+			buf.WriteString(fmt.Sprintf("\tVisit%s(%s)\n", exportName(m), m))
+		}
+		buf.WriteString("}\n\n")
+		buf.WriteString(fmt.Sprintf("// %s is a struct of the %s group: %s\n", node, group, strings.Join(members, ", ")))
+		buf.WriteString(fmt.Sprintf("type %s interface {\n\tAccept(visitor %s)\n}\n\n", node, visitor))
+	}
+
+	recv := strings.ToLower(s.Name[:1])
+	buf.WriteString(fmt.Sprintf("// Accept calls visitor.Visit%s with %s\n", exportName(s.Name), recv))
+	buf.WriteString(fmt.Sprintf("func (%s %s) Accept(visitor %s) {\n\tvisitor.Visit%s(%s)\n}\n", recv, s.Name, visitor, exportName(s.Name), recv))
+	return nil
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/snowmerak/gofn/parser"
+)
+
+// workflowStepType is the required type of step and compensation fields
+const workflowStepType = "func(context.Context) error"
+
+// workflowStep is a step field resolved from its `step` and `compensate` tags
+type workflowStep struct {
+	name       string
+	field      string
+	compensate string
+}
+
+// workflowSteps resolves the step fields of a workflow struct in declaration order
+// A field is a step when it has a `step:"name"` tag; `compensate:"Field"` names the field undoing it
+func workflowSteps(s parser.StructInfo) ([]workflowStep, error) {
+	types := map[string]string{}
+	for _, f := range s.Fields {
+		types[f.Name] = f.Type
+	}
+
+	var steps []workflowStep
+	for _, f := range s.Fields {
+		tag := reflect.StructTag(f.Tag)
+		name, ok := tag.Lookup("step")
+		if !ok {
+			continue
+		}
+		if f.Type != workflowStepType {
+			return nil, fmt.Errorf("step field %s must have type %s, got %s", f.Name, workflowStepType, f.Type)
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		comp := tag.Get("compensate")
+		if comp != "" {
+			t, ok := types[comp]
+			if !ok {
+				return nil, fmt.Errorf("step field %s: compensation field %s not found", f.Name, comp)
+			}
+			if t != workflowStepType {
+				return nil, fmt.Errorf("compensation field %s must have type %s, got %s", comp, workflowStepType, t)
+			}
+		}
+		steps = append(steps, workflowStep{name: name, field: f.Name, compensate: comp})
+	}
+
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no fields tagged with step")
+	}
+	return steps, nil
+}
+
+// generateWorkflowCode generates an orchestrator Task running the struct's step fields as a saga
+func generateWorkflowCode(buf *bytes.Buffer, s parser.StructInfo) error {
+	steps, err := workflowSteps(s)
+	if err != nil {
+		return err
+	}
+	structName := s.Name
+
+	buf.WriteString("import \"github.com/snowmerak/gofn/monad\"\n\n")
+
+	buf.WriteString(fmt.Sprintf("// Steps returns the steps of %s in declaration order\n", structName))
+	buf.WriteString(fmt.Sprintf("func (w *%s) Steps() []monad.WorkflowStep {\n", structName))
+	buf.WriteString("\treturn []monad.WorkflowStep{\n")
+	for _, step := range steps {
+		if step.compensate != "" {
+			buf.WriteString(fmt.Sprintf("\t\t{Name: %q, Run: w.%s, Compensate: w.%s},\n", step.name, step.field, step.compensate))
+		} else {
+			buf.WriteString(fmt.Sprintf("\t\t{Name: %q, Run: w.%s},\n", step.name, step.field))
+		}
+	}
+	buf.WriteString("\t}\n")
+	buf.WriteString("}\n\n")
+
+	buf.WriteString(fmt.Sprintf("// Task returns a Task running the %s steps sequentially\n", structName))
+	buf.WriteString("// On failure the compensations of completed steps run in reverse order and the Task fails with a *monad.WorkflowError\n")
+	buf.WriteString(fmt.Sprintf("func (w *%s) Task() monad.Task[monad.WorkflowTrace] {\n", structName))
+	buf.WriteString(fmt.Sprintf("\treturn monad.WorkflowTask(%q, w.Steps())\n", structName))
+	buf.WriteString("}\n\n")
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package gofnbench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Result is the measurement of one benchmark
+type Result struct {
+	Name        string  `json:"name"`
+	NsPerOp     float64 `json:"nsPerOp"`
+	AllocsPerOp int64   `json:"allocsPerOp"`
+	BytesPerOp  int64   `json:"bytesPerOp"`
+}
+
+// Report is a run of the benchmarks, the JSON document `gofn bench` writes and compares
+type Report struct {
+	GoVersion string   `json:"goVersion"`
+	GOOS      string   `json:"goos"`
+	GOARCH    string   `json:"goarch"`
+	Results   []Result `json:"results"`
+}
+
+// Thresholds bound how much slower a benchmark may get before Compare reports it
+type Thresholds struct {
+	// TimeRatio is the largest current/baseline ratio of ns/op accepted, e.g. 1.10 for 10% slower
+	TimeRatio float64
+	// ExtraAllocs is how many more allocations per op are accepted; allocation counts are stable, so 0 is usual
+	ExtraAllocs int64
+}
+
+// DefaultThresholds accept 10% more time, to absorb noise, and no extra allocation
+var DefaultThresholds = Thresholds{TimeRatio: 1.10}
+
+// Regression is a benchmark that got slower or allocates more than the thresholds accept
+type Regression struct {
+	Name     string
+	Baseline Result
+	Current  Result
+}
+
+// String describes the regression, e.g. "Result/OkUnwrap: 2.1 -> 3.4 ns/op (+62%), 0 -> 1 allocs/op"
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %.1f -> %.1f ns/op (%+.0f%%), %d -> %d allocs/op", r.Name,
+		r.Baseline.NsPerOp, r.Current.NsPerOp, (r.Current.NsPerOp/r.Baseline.NsPerOp-1)*100,
+		r.Baseline.AllocsPerOp, r.Current.AllocsPerOp)
+}
+
+// Compare returns the benchmarks of current that regressed against baseline, in current's order
+// Benchmarks missing from either report are not compared
+func Compare(baseline, current Report, t Thresholds) []Regression {
+	old := map[string]Result{}
+	for _, r := range baseline.Results {
+		old[r.Name] = r
+	}
+	var regressions []Regression
+	for _, r := range current.Results {
+		base, ok := old[r.Name]
+		if !ok {
+			continue
+		}
+		slower := base.NsPerOp > 0 && r.NsPerOp > base.NsPerOp*t.TimeRatio
+		if slower || r.AllocsPerOp > base.AllocsPerOp+t.ExtraAllocs {
+			regressions = append(regressions, Regression{Name: r.Name, Baseline: base, Current: r})
+		}
+	}
+	return regressions
+}
+
+// ReadReport reads a Report written by WriteReport
+func ReadReport(path string) (Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Report{}, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return Report{}, fmt.Errorf("reading benchmark report %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// WriteReport writes r to path as indented JSON
+func WriteReport(path string, r Report) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
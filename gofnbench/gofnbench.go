@@ -0,0 +1,169 @@
+// Package gofnbench holds benchmarks of the hot paths of the monad package, runnable from go test and
+// from `gofn bench`, which records them as a Report and compares it with a baseline so redesigns of
+// Result, Option, Future or Reactive can be validated and regressions caught in CI
+package gofnbench
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"runtime"
+	"testing"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// Benchmark is a named benchmark of a monad hot path
+type Benchmark struct {
+	Name string
+	F    func(b *testing.B)
+}
+
+// inline runs spawned functions on the calling goroutine, so notification benchmarks measure the
+// notification path rather than the scheduler
+type inline struct{}
+
+func (inline) Go(fn func()) { fn() }
+
+var errBench = errors.New("bench")
+
+// sinks keep results alive so the compiler cannot drop the benchmarked work; they are typed, since storing
+// into an interface would allocate
+var (
+	sinkInt    int
+	sinkBool   bool
+	sinkResult monad.Result[int]
+	sinkOption monad.Option[int]
+)
+
+// Benchmarks returns every benchmark, in a stable order
+func Benchmarks() []Benchmark {
+	return []Benchmark{
+		{"Result/OkUnwrap", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sinkInt, _ = monad.Ok(i).Unwrap()
+			}
+		}},
+		{"Result/MapAndThen", func(b *testing.B) {
+			b.ReportAllocs()
+			double := func(v int) int { return v * 2 }
+			check := func(v int) monad.Result[int] {
+				if v < 0 {
+					return monad.Err[int](errBench)
+				}
+				return monad.Ok(v + 1)
+			}
+			for i := 0; i < b.N; i++ {
+				sinkResult = monad.AndThen(monad.Map(monad.Ok(i), double), check)
+			}
+		}},
+		{"Result/ErrPropagation", func(b *testing.B) {
+			b.ReportAllocs()
+			double := func(v int) int { return v * 2 }
+			for i := 0; i < b.N; i++ {
+				sinkResult = monad.Map(monad.Map(monad.Err[int](errBench), double), double)
+			}
+		}},
+		{"Option/SomeUnwrapOr", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				sinkInt = monad.Some(i).UnwrapOr(0) + monad.None[int]().UnwrapOr(1)
+			}
+		}},
+		{"Option/Match", func(b *testing.B) {
+			b.ReportAllocs()
+			pattern := monad.S("admin")
+			for i := 0; i < b.N; i++ {
+				sinkBool = pattern.Match("admin") && monad.W[string]().Match("user")
+			}
+		}},
+		{"Option/MapOption", func(b *testing.B) {
+			b.ReportAllocs()
+			inc := func(v int) int { return v + 1 }
+			for i := 0; i < b.N; i++ {
+				sinkOption = monad.MapOption(monad.Some(i), inc)
+			}
+		}},
+		{"Future/CompleteAwait", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				f := monad.NewFuture[int]()
+				f.Complete(i)
+				sinkResult = f.Await()
+			}
+		}},
+		{"Future/PooledCompleteAwait", func(b *testing.B) {
+			b.ReportAllocs()
+			pool := monad.NewFuturePool[int]()
+			for i := 0; i < b.N; i++ {
+				f := pool.Get()
+				f.Complete(i)
+				sinkResult = f.AwaitAndRelease()
+			}
+		}},
+		{"Future/MapFuture", func(b *testing.B) {
+			b.ReportAllocs()
+			defer monad.SetExecutor(inline{})()
+			inc := func(v int) int { return v + 1 }
+			for i := 0; i < b.N; i++ {
+				sinkResult = monad.MapFuture(monad.CompletedFuture(i), inc).Await()
+			}
+		}},
+		{"Task/Run", func(b *testing.B) {
+			b.ReportAllocs()
+			defer monad.SetExecutor(inline{})()
+			task := monad.Task[int](func(context.Context) monad.Result[int] { return monad.Ok(1) })
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				sinkResult = task.Run(ctx).Await()
+			}
+		}},
+		{"Reactive/SetNoSubscribers", func(b *testing.B) {
+			b.ReportAllocs()
+			r := monad.NewReactive(0)
+			for i := 0; i < b.N; i++ {
+				r.Set(i)
+			}
+		}},
+		{"Reactive/SetFourSubscribers", func(b *testing.B) {
+			b.ReportAllocs()
+			defer monad.SetExecutor(inline{})()
+			r := monad.NewReactive(0)
+			var total int
+			for range 4 {
+				r.Subscribe(func(_, new int) { total += new })
+			}
+			for i := 0; i < b.N; i++ {
+				r.Set(i)
+			}
+			sinkInt = total
+		}},
+	}
+}
+
+// Run runs the benchmarks as sub-benchmarks of b, e.g. from a BenchmarkGofn in a project's own tests
+func Run(b *testing.B) {
+	for _, bm := range Benchmarks() {
+		b.Run(bm.Name, bm.F)
+	}
+}
+
+// Measure runs the benchmarks whose name matches filter, or all of them when filter is nil, and reports
+// their results; it works outside go test, with testing's default benchmark time
+func Measure(filter *regexp.Regexp) Report {
+	report := Report{GoVersion: runtime.Version(), GOOS: runtime.GOOS, GOARCH: runtime.GOARCH}
+	for _, bm := range Benchmarks() {
+		if filter != nil && !filter.MatchString(bm.Name) {
+			continue
+		}
+		r := testing.Benchmark(bm.F)
+		report.Results = append(report.Results, Result{
+			Name:        bm.Name,
+			NsPerOp:     float64(r.T.Nanoseconds()) / float64(max(r.N, 1)),
+			AllocsPerOp: r.AllocsPerOp(),
+			BytesPerOp:  r.AllocedBytesPerOp(),
+		})
+	}
+	return report
+}
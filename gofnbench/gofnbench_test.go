@@ -0,0 +1,71 @@
+package gofnbench
+
+import (
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func BenchmarkGofn(b *testing.B) {
+	Run(b)
+}
+
+func TestBenchmarkNames(t *testing.T) {
+	var names []string
+	for _, bm := range Benchmarks() {
+		if slices.Contains(names, bm.Name) || !strings.Contains(bm.Name, "/") {
+			t.Errorf("Benchmark names must be unique Area/Case names, got %q", bm.Name)
+		}
+		names = append(names, bm.Name)
+	}
+}
+
+func TestMeasureFilter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("runs a benchmark")
+	}
+	report := Measure(regexp.MustCompile(`^Result/OkUnwrap$`))
+	if len(report.Results) != 1 || report.Results[0].NsPerOp <= 0 || report.GoVersion == "" {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := Report{Results: []Result{
+		{Name: "A", NsPerOp: 10, AllocsPerOp: 1},
+		{Name: "B", NsPerOp: 10, AllocsPerOp: 1},
+		{Name: "C", NsPerOp: 10, AllocsPerOp: 1},
+		{Name: "Gone", NsPerOp: 10},
+	}}
+	current := Report{Results: []Result{
+		{Name: "A", NsPerOp: 10.5, AllocsPerOp: 1}, // within the time ratio
+		{Name: "B", NsPerOp: 12, AllocsPerOp: 1},   // slower
+		{Name: "C", NsPerOp: 8, AllocsPerOp: 2},    // faster but allocates more
+		{Name: "New", NsPerOp: 100},
+	}}
+
+	var names []string
+	for _, r := range Compare(baseline, current, DefaultThresholds) {
+		names = append(names, r.Name)
+	}
+	if !slices.Equal(names, []string{"B", "C"}) {
+		t.Errorf("Expected B and C to regress, got %v", names)
+	}
+	if got := Compare(baseline, current, Thresholds{TimeRatio: 1.5, ExtraAllocs: 1}); len(got) != 0 {
+		t.Errorf("Looser thresholds should accept every change, got %v", got)
+	}
+}
+
+func TestReportRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bench.json")
+	want := Report{GoVersion: "go1.25", Results: []Result{{Name: "A", NsPerOp: 1.5, AllocsPerOp: 1, BytesPerOp: 8}}}
+	if err := WriteReport(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadReport(path)
+	if err != nil || got.GoVersion != want.GoVersion || !slices.Equal(got.Results, want.Results) {
+		t.Errorf("Expected %+v, got %+v, %v", want, got, err)
+	}
+}
@@ -0,0 +1,710 @@
+// Package lift rewrites Go-style (T, error) call sites of a selected function into Result chains,
+// so callers can be migrated to the monad layer one function at a time.
+//
+// A call site is lifted when it has the shape
+//
+//	v, err := F(args)
+//	if err != nil {
+//		return <zero>, err
+//	}
+//	<rest of the function>
+//
+// directly in the body of a function returning (U, error); the rest becomes the body of a
+// monad.Map (for a single `return expr, nil`) or monad.AndThen closure. Anything the rewrite
+// cannot prove equivalent is reported as skipped and left untouched.
+//
+// The package is type-checked before every rewrite, so F, err, nil and the monad functions are
+// matched by the objects they resolve to rather than by name: a shadowing local or a method named
+// F is not a call site, and a later err that shadows the checked one is not a read of it.
+package lift
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const monadPath = "github.com/snowmerak/gofn/monad"
+
+// Site is a call to the selected function that was lifted or skipped
+type Site struct {
+	File   string
+	Line   int
+	Caller string
+	Reason string // why the site was skipped; empty when lifted
+}
+
+// Report is the outcome of lifting one function's call sites in a directory
+type Report struct {
+	Lifted  []Site
+	Skipped []Site
+	files   map[string][]byte // rewritten sources by path
+}
+
+// edit replaces src[start:end] with text
+type edit struct {
+	start, end int
+	text       string
+	site       Site
+}
+
+// Lift computes the rewrite of every call site of fn in the package in dir; nothing is written until Apply
+// The package's files for the current build context are type-checked, and all but generated ones
+// (*_gen.go) are rewritten; test files are left alone. Its dependencies are loaded with `go list -export`,
+// so the go command must be available
+// Calls that only become liftable once an earlier call in the same body is rewritten are handled by
+// repeating the rewrite until no site changes; Site.Line refers to the source as of the pass that lifted it
+func Lift(dir, fn string) (Report, error) {
+	pkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return Report{}, err
+	}
+
+	sources := map[string][]byte{}
+	for _, name := range append(pkg.GoFiles, pkg.CgoFiles...) {
+		path := filepath.Join(dir, name)
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return Report{}, err
+		}
+		sources[path] = src
+	}
+
+	exports, err := exportData(dir)
+	if err != nil {
+		return Report{}, err
+	}
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "gc", func(path string) (io.ReadCloser, error) {
+		file, ok := exports[path]
+		if !ok {
+			return nil, fmt.Errorf("no export data for %s", path)
+		}
+		return os.Open(file)
+	})
+
+	report := Report{files: map[string][]byte{}}
+	for {
+		pass, err := liftPass(fset, imp, pkg.Name, sources, fn)
+		if err != nil {
+			return Report{}, err
+		}
+		report.Lifted = append(report.Lifted, pass.Lifted...)
+		report.Skipped = pass.Skipped
+		if len(pass.files) == 0 {
+			break
+		}
+		for path, src := range pass.files {
+			sources[path] = src
+			report.files[path] = src
+		}
+	}
+
+	sortSites(report.Lifted)
+	sortSites(report.Skipped)
+	return report, nil
+}
+
+// exportData builds the dependencies of the package in dir, and the monad package the rewrite imports, and
+// returns their export data files by import path
+func exportData(dir string) (map[string]string, error) {
+	cmd := exec.Command("go", "list", "-e", "-export", "-deps", "-f", "{{if .Export}}{{.ImportPath}}={{.Export}}{{end}}", ".", monadPath)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list: %w\n%s", err, stderr.Bytes())
+	}
+	exports := map[string]string{}
+	for _, line := range strings.Split(string(out), "\n") {
+		if path, file, ok := strings.Cut(line, "="); ok {
+			exports[path] = file
+		}
+	}
+	return exports, nil
+}
+
+// liftPass type-checks sources and rewrites the first liftable call of every function body once
+func liftPass(fset *token.FileSet, imp types.Importer, name string, sources map[string][]byte, fn string) (Report, error) {
+	paths := make([]string, 0, len(sources))
+	for path := range sources {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	files := make([]*ast.File, len(paths))
+	for i, path := range paths {
+		file, err := parser.ParseFile(fset, path, sources[path], parser.ParseComments)
+		if err != nil {
+			return Report{}, err
+		}
+		files[i] = file
+	}
+
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	conf := types.Config{Importer: imp, FakeImportC: true}
+	pkg, err := conf.Check(name, fset, files, info)
+	if err != nil {
+		return Report{}, fmt.Errorf("type-checking %s: %w", name, err)
+	}
+
+	target, err := liftedFunc(pkg, fn)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{files: map[string][]byte{}}
+	for i, path := range paths {
+		if strings.HasSuffix(path, "_gen.go") {
+			continue
+		}
+		file, src := files[i], sources[path]
+		l := &lifter{fset: fset, src: src, info: info, target: target, monad: monadName(file)}
+		l.valueType, l.unimported = typeIn(target.Type().(*types.Signature).Results().At(0).Type(), pkg, file)
+
+		var edits []edit
+		ast.Inspect(file, func(n ast.Node) bool {
+			var ftype *ast.FuncType
+			var sig types.Type
+			var body *ast.BlockStmt
+			caller := "func literal"
+			switch f := n.(type) {
+			case *ast.FuncDecl:
+				ftype, body, caller = f.Type, f.Body, f.Name.Name
+				sig = info.Defs[f.Name].Type()
+			case *ast.FuncLit:
+				ftype, body = f.Type, f.Body
+				sig = info.TypeOf(f)
+			default:
+				return true
+			}
+			if body == nil {
+				return true
+			}
+			e, site, ok := l.liftBody(enclosing{ftype, sig.(*types.Signature)}, body)
+			if !ok {
+				return true
+			}
+			site.File, site.Caller = path, caller
+			if site.Reason != "" {
+				report.Skipped = append(report.Skipped, site)
+				return true
+			}
+			e.site = site
+			edits = append(edits, e)
+			return true
+		})
+		if len(edits) == 0 {
+			continue
+		}
+
+		// edits nested inside another edit's range are left for the next pass
+		out, applied := applyEdits(src, edits)
+		for _, e := range applied {
+			report.Lifted = append(report.Lifted, e.site)
+		}
+		if l.monad == "" {
+			out = addImport(out, file, fset)
+		}
+		formatted, err := format.Source(out)
+		if err != nil {
+			return Report{}, fmt.Errorf("formatting %s: %w", path, err)
+		}
+		report.files[path] = formatted
+	}
+	return report, nil
+}
+
+// Apply writes the rewritten files of a report
+func Apply(report Report) error {
+	for path, src := range report.files {
+		if err := os.WriteFile(path, src, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Files returns the paths of the files Lift changed, sorted
+func (r Report) Files() []string {
+	paths := make([]string, 0, len(r.files))
+	for path := range r.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Source returns the rewritten content of path, if Lift changed it
+func (r Report) Source(path string) ([]byte, bool) {
+	src, ok := r.files[path]
+	return src, ok
+}
+
+// liftedFunc looks fn up among the package-level functions of pkg and checks it returns (T, error)
+func liftedFunc(pkg *types.Package, fn string) (*types.Func, error) {
+	f, ok := pkg.Scope().Lookup(fn).(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("function %s not found", fn)
+	}
+	sig := f.Type().(*types.Signature)
+	if sig.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("%s: generic functions are not supported", fn)
+	}
+	if sig.Results().Len() != 2 || !isError(sig.Results().At(1).Type()) {
+		return nil, fmt.Errorf("%s must return (T, error)", fn)
+	}
+	return f, nil
+}
+
+// typeIn returns the source of t as written in file, which may import its packages under other names, and the
+// path of a package t needs that file does not import, if any
+func typeIn(t types.Type, pkg *types.Package, file *ast.File) (string, string) {
+	unimported := ""
+	s := types.TypeString(t, func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		for _, imp := range file.Imports {
+			if path, _ := strconv.Unquote(imp.Path.Value); path == p.Path() {
+				if imp.Name == nil {
+					return p.Name()
+				}
+				if imp.Name.Name == "." {
+					return ""
+				}
+				return imp.Name.Name
+			}
+		}
+		unimported = p.Path()
+		return p.Name()
+	})
+	return s, unimported
+}
+
+// enclosing is the function whose body holds a call site, by syntax and by type
+type enclosing struct {
+	ftype *ast.FuncType
+	sig   *types.Signature
+}
+
+// lifter rewrites the call sites of one function within one file
+type lifter struct {
+	fset       *token.FileSet
+	src        []byte
+	info       *types.Info
+	target     *types.Func
+	valueType  string // the target's value result type as written in this file
+	unimported string // a package valueType needs that the file does not import
+	monad      string // local name of the monad import, empty if not imported
+}
+
+// liftBody looks for a liftable call among the top-level statements of body
+// It reports ok=false when body contains no call to the target at all
+func (l *lifter) liftBody(fn enclosing, body *ast.BlockStmt) (edit, Site, bool) {
+	for i, stmt := range body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || !l.callsTarget(assign) {
+			continue
+		}
+		site := Site{Line: l.fset.Position(assign.Pos()).Line}
+		if reason := l.check(fn, body.List, i); reason != "" {
+			site.Reason = reason
+			return edit{}, site, true
+		}
+		return l.rewrite(fn, body.List, i), site, true
+	}
+	return edit{}, Site{}, false
+}
+
+// callsTarget reports whether stmt is `x, y := F(...)` or `x, y = F(...)` with F the target function
+func (l *lifter) callsTarget(assign *ast.AssignStmt) bool {
+	if len(assign.Rhs) != 1 {
+		return false
+	}
+	call, ok := assign.Rhs[0].(*ast.CallExpr)
+	return ok && l.refersTo(call.Fun, l.target)
+}
+
+// check returns why the call at stmts[i] cannot be lifted, or "" if it can
+func (l *lifter) check(fn enclosing, stmts []ast.Stmt, i int) string {
+	assign := stmts[i].(*ast.AssignStmt)
+	if assign.Tok != token.DEFINE || len(assign.Lhs) != 2 {
+		return "call result is not bound with v, err :="
+	}
+	errName, ok := assign.Lhs[1].(*ast.Ident)
+	if !ok || errName.Name == "_" {
+		return "error result is discarded"
+	}
+	errVar := l.info.ObjectOf(errName)
+
+	_, chained, ok := l.callerResult(fn)
+	if !ok {
+		return "caller does not return unnamed (U, error) or monad.Result[U]"
+	}
+	if l.unimported != "" {
+		return "file does not import " + l.unimported + ", which the value type needs"
+	}
+
+	if i+2 >= len(stmts) {
+		return "no statements follow the error check"
+	}
+	guard, ok := stmts[i+1].(*ast.IfStmt)
+	if !ok || guard.Init != nil || guard.Else != nil || !l.isErrCheck(guard.Cond, errVar) {
+		return "call is not followed by if err != nil"
+	}
+	if len(guard.Body.List) != 1 {
+		return "error branch does more than return"
+	}
+	ret, ok := guard.Body.List[0].(*ast.ReturnStmt)
+	if !ok || !l.returnsErr(ret, errVar, chained) {
+		return "error branch does not return the zero value and err unchanged"
+	}
+
+	rest := stmts[i+2:]
+	if _, ok := rest[len(rest)-1].(*ast.ReturnStmt); !ok {
+		return "function does not end in a return"
+	}
+	results := 2
+	if chained {
+		results = 1
+	}
+	return l.restHazard(rest, errVar, results)
+}
+
+// callerResult returns the value type U of a caller returning (U, error), or of one returning
+// monad.Result[U], in which case chained is true and the rewrite nests into the existing chain
+func (l *lifter) callerResult(fn enclosing) (u ast.Expr, chained, ok bool) {
+	res := fn.sig.Results()
+	if res.Len() == 0 || res.At(0).Name() != "" {
+		return nil, false, false
+	}
+	results := flatten(fn.ftype.Results)
+	switch {
+	case res.Len() == 2 && isError(res.At(1).Type()):
+		return results[0], false, true
+	case res.Len() == 1 && isMonadType(res.At(0).Type(), "Result"):
+		if idx, ok := results[0].(*ast.IndexExpr); ok {
+			return idx.Index, true, true
+		}
+	}
+	return nil, false, false
+}
+
+// returnsErr reports whether ret propagates errVar unchanged: `return <zero>, err`, or for chained
+// callers `return monad.ResultOf(<zero>, err)` / `return monad.Err[U](err)`
+func (l *lifter) returnsErr(ret *ast.ReturnStmt, errVar types.Object, chained bool) bool {
+	if !chained {
+		return len(ret.Results) == 2 && l.refersTo(ret.Results[1], errVar) && isZero(ret.Results[0])
+	}
+	if len(ret.Results) != 1 {
+		return false
+	}
+	call, ok := ret.Results[0].(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	switch l.monadFunc(call.Fun) {
+	case "ResultOf":
+		return len(call.Args) == 2 && isZero(call.Args[0]) && l.refersTo(call.Args[1], errVar)
+	case "Err":
+		return len(call.Args) == 1 && l.refersTo(call.Args[0], errVar)
+	}
+	return false
+}
+
+// monadFunc returns the name of the monad package function e refers to, possibly instantiated, or ""
+func (l *lifter) monadFunc(e ast.Expr) string {
+	if idx, ok := e.(*ast.IndexExpr); ok {
+		e = idx.X
+	}
+	if sel, ok := e.(*ast.SelectorExpr); ok {
+		e = sel.Sel
+	}
+	id, ok := e.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	f, ok := l.info.Uses[id].(*types.Func)
+	if !ok || f.Pkg() == nil || f.Pkg().Path() != monadPath || f.Type().(*types.Signature).Recv() != nil {
+		return ""
+	}
+	return f.Name()
+}
+
+// isErrCheck reports whether cond is `err != nil` for errVar
+func (l *lifter) isErrCheck(cond ast.Expr, errVar types.Object) bool {
+	bin, ok := cond.(*ast.BinaryExpr)
+	return ok && bin.Op == token.NEQ && l.refersTo(bin.X, errVar) && l.isNil(bin.Y)
+}
+
+// refersTo reports whether e is an identifier denoting obj
+func (l *lifter) refersTo(e ast.Expr, obj types.Object) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && obj != nil && l.info.ObjectOf(id) == obj
+}
+
+// isNil reports whether e is the predeclared nil
+func (l *lifter) isNil(e ast.Expr) bool {
+	tv, ok := l.info.Types[e]
+	return ok && tv.IsNil()
+}
+
+// restHazard reports constructs in the remaining statements whose meaning changes inside a closure
+func (l *lifter) restHazard(rest []ast.Stmt, errVar types.Object, results int) string {
+	reason := ""
+	redeclared := false
+	reads := func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok && !redeclared && l.info.Uses[id] == errVar {
+			reason = "remaining statements read " + errVar.Name()
+		}
+		return reason == ""
+	}
+	for _, stmt := range rest {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			if reason != "" {
+				return false
+			}
+			switch x := n.(type) {
+			case *ast.FuncLit:
+				// a nested closure may return or defer as it likes, but err is gone from its scope too
+				ast.Inspect(x.Body, reads)
+				return false
+			case *ast.DeferStmt:
+				reason = "remaining statements defer calls"
+			case *ast.LabeledStmt, *ast.BranchStmt:
+				if b, ok := x.(*ast.BranchStmt); ok && b.Label == nil && b.Tok != token.GOTO {
+					return true
+				}
+				reason = "remaining statements use labels or goto"
+			case *ast.ReturnStmt:
+				if len(x.Results) != results {
+					reason = "remaining statements contain a bare or mismatched return"
+				}
+			case *ast.AssignStmt:
+				if x.Tok == token.DEFINE {
+					for _, rhs := range x.Rhs {
+						ast.Inspect(rhs, reads)
+					}
+					// inside the closure, redeclaring err with := declares a fresh one
+					for _, lhs := range x.Lhs {
+						if l.refersTo(lhs, errVar) {
+							redeclared = true
+						}
+					}
+					return false
+				}
+			default:
+				return reads(n)
+			}
+			return reason == ""
+		})
+		if reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// rewrite replaces the call, its error check and the rest of the body with a Result chain
+func (l *lifter) rewrite(fn enclosing, stmts []ast.Stmt, i int) edit {
+	assign := stmts[i].(*ast.AssignStmt)
+	monad := l.monad
+	if monad == "" {
+		monad = "monad"
+	}
+	u, chained, _ := l.callerResult(fn)
+	resultType := l.text(u)
+	param := l.text(assign.Lhs[0])
+	call := l.text(assign.Rhs[0])
+	rest := stmts[i+2:]
+	restStart, restEnd := l.offset(rest[0].Pos()), l.offset(rest[len(rest)-1].End())
+
+	var b strings.Builder
+	switch ret, single := rest[0].(*ast.ReturnStmt); {
+	case chained:
+		fmt.Fprintf(&b, "return %s.AndThen(%s.ResultOf(%s), func(%s %s) %s.Result[%s] {\n", monad, monad, call, param, l.valueType, monad, resultType)
+		b.Write(l.src[restStart:restEnd])
+		b.WriteString("\n})")
+	case single && len(rest) == 1 && l.isNil(ret.Results[1]):
+		fmt.Fprintf(&b, "return %s.Map(%s.ResultOf(%s), func(%s %s) %s {\n", monad, monad, call, param, l.valueType, resultType)
+		fmt.Fprintf(&b, "return %s\n", l.text(ret.Results[0]))
+		b.WriteString("}).Unwrap()")
+	default:
+		fmt.Fprintf(&b, "return %s.AndThen(%s.ResultOf(%s), func(%s %s) %s.Result[%s] {\n", monad, monad, call, param, l.valueType, monad, resultType)
+		b.WriteString(l.wrapReturns(rest, monad))
+		b.WriteString("\n}).Unwrap()")
+	}
+
+	return edit{start: l.offset(assign.Pos()), end: restEnd, text: b.String()}
+}
+
+// wrapReturns returns the source of stmts with every `return a, b` outside nested closures turned into
+// `return monad.ResultOf(a, b)`
+func (l *lifter) wrapReturns(stmts []ast.Stmt, monad string) string {
+	start, end := l.offset(stmts[0].Pos()), l.offset(stmts[len(stmts)-1].End())
+	var edits []edit
+	for _, stmt := range stmts {
+		ast.Inspect(stmt, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				edits = append(edits, edit{
+					start: l.offset(x.Pos()) - start,
+					end:   l.offset(x.End()) - start,
+					text:  fmt.Sprintf("return %s.ResultOf(%s, %s)", monad, l.text(x.Results[0]), l.text(x.Results[1])),
+				})
+			}
+			return true
+		})
+	}
+	out, _ := applyEdits(l.src[start:end], edits)
+	return string(out)
+}
+
+func (l *lifter) offset(p token.Pos) int { return l.fset.Position(p).Offset }
+
+func (l *lifter) text(n ast.Node) string { return nodeText(l.fset, l.src, n) }
+
+// applyEdits applies non-overlapping edits back to front, dropping any edit that overlaps a later one,
+// and returns the edits it applied
+func applyEdits(src []byte, edits []edit) ([]byte, []edit) {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	out := append([]byte(nil), src...)
+	var applied []edit
+	end := len(src) + 1
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		if e.end > end {
+			continue
+		}
+		out = append(out[:e.start], append([]byte(e.text), out[e.end:]...)...)
+		applied = append(applied, e)
+		end = e.start
+	}
+	return out, applied
+}
+
+// addImport adds the monad import to the file's first parenthesized import block, or as its own
+// declaration after the imports; in a block it joins the last group of non-standard imports, or starts a
+// group of its own after the standard library ones, and gofmt sorts it into place
+func addImport(src []byte, file *ast.File, fset *token.FileSet) []byte {
+	spec := strconv.Quote(monadPath)
+	at := fset.Position(file.Name.End()).Offset
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			break
+		}
+		if gd.Lparen.IsValid() {
+			at, spec = fset.Position(gd.Rparen).Offset, "\n\t"+spec+"\n"
+			for _, s := range gd.Specs {
+				path, _ := strconv.Unquote(s.(*ast.ImportSpec).Path.Value)
+				if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+					at, spec = fset.Position(s.End()).Offset, "\n\t"+strconv.Quote(monadPath)
+				}
+			}
+			break
+		}
+		at = fset.Position(gd.End()).Offset
+	}
+	if !strings.HasPrefix(spec, "\n") {
+		spec = "\n\nimport " + spec
+	}
+
+	var b bytes.Buffer
+	b.Write(src[:at])
+	b.WriteString(spec)
+	b.Write(src[at:])
+	return b.Bytes()
+}
+
+// monadName returns the local name the file imports the monad package under, or ""
+func monadName(file *ast.File) string {
+	for _, imp := range file.Imports {
+		if path, _ := strconv.Unquote(imp.Path.Value); path == monadPath {
+			if imp.Name != nil {
+				return imp.Name.Name
+			}
+			return "monad"
+		}
+	}
+	return ""
+}
+
+// flatten expands grouped fields, e.g. (a, b int), into one expression per value
+func flatten(fields *ast.FieldList) []ast.Expr {
+	if fields == nil {
+		return nil
+	}
+	var out []ast.Expr
+	for _, f := range fields.List {
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for range n {
+			out = append(out, f.Type)
+		}
+	}
+	return out
+}
+
+// isError reports whether t is the predeclared error type
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// isMonadType reports whether t is an instance of the named type of the monad package
+func isMonadType(t types.Type, name string) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == monadPath && named.Obj().Name() == name
+}
+
+// isZero reports whether e is a literal zero value: nil, false, 0, "", or an empty composite literal
+func isZero(e ast.Expr) bool {
+	switch x := e.(type) {
+	case *ast.Ident:
+		return x.Name == "nil" || x.Name == "false"
+	case *ast.BasicLit:
+		switch x.Value {
+		case "0", "0.0", `""`, "``", "'\\x00'":
+			return true
+		}
+	case *ast.CompositeLit:
+		return len(x.Elts) == 0
+	}
+	return false
+}
+
+func nodeText(fset *token.FileSet, src []byte, n ast.Node) string {
+	return string(src[fset.Position(n.Pos()).Offset:fset.Position(n.End()).Offset])
+}
+
+func sortSites(sites []Site) {
+	sort.Slice(sites, func(i, j int) bool {
+		if sites[i].File != sites[j].File {
+			return sites[i].File < sites[j].File
+		}
+		return sites[i].Line < sites[j].Line
+	})
+}
@@ -0,0 +1,373 @@
+package lift
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const userSrc = `package fixture
+
+import "errors"
+
+type User struct{ Name string }
+
+func LoadUser(id string) (User, error) {
+	if id == "" {
+		return User{}, errors.New("no id")
+	}
+	return User{Name: id}, nil
+}
+`
+
+func TestLift(t *testing.T) {
+	cases := map[string]struct {
+		src     string
+		lifted  []string // callers whose call site is lifted
+		skipped string   // reason the only remaining site is skipped, if any
+		want    []string // fragments of the rewritten caller file
+	}{
+		"single return becomes Map": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+`,
+			lifted: []string{"Name"},
+			want: []string{
+				`import "github.com/snowmerak/gofn/monad"`,
+				"return monad.Map(monad.ResultOf(LoadUser(id)), func(u User) string {\n\t\treturn u.Name\n\t}).Unwrap()",
+			},
+		},
+		"longer body becomes AndThen": {
+			src: `package fixture
+
+import "errors"
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	if u.Name == "root" {
+		return "", errors.New("reserved")
+	}
+	return u.Name, nil
+}
+`,
+			lifted: []string{"Name"},
+			want: []string{
+				"return monad.AndThen(monad.ResultOf(LoadUser(id)), func(u User) monad.Result[string] {",
+				`return monad.ResultOf("", errors.New("reserved"))`,
+				"return monad.ResultOf(u.Name, nil)\n\t}).Unwrap()",
+			},
+		},
+		"consecutive calls nest": {
+			src: `package fixture
+
+func Pair(a, b string) (string, error) {
+	u, err := LoadUser(a)
+	if err != nil {
+		return "", err
+	}
+	w, err := LoadUser(b)
+	if err != nil {
+		return "", err
+	}
+	return u.Name + w.Name, nil
+}
+`,
+			lifted: []string{"Pair", "func literal"},
+			want:   []string{"return monad.AndThen(monad.ResultOf(LoadUser(b)), func(w User) monad.Result[string] {"},
+		},
+		"result caller is chained": {
+			src: `package fixture
+
+import "github.com/snowmerak/gofn/monad"
+
+func Name(id string) monad.Result[string] {
+	u, err := LoadUser(id)
+	if err != nil {
+		return monad.Err[string](err)
+	}
+	return monad.Ok(u.Name)
+}
+`,
+			lifted: []string{"Name"},
+			want:   []string{"return monad.AndThen(monad.ResultOf(LoadUser(id)), func(u User) monad.Result[string] {\n\t\treturn monad.Ok(u.Name)\n\t})\n}"},
+		},
+		"renamed monad import": {
+			src: `package fixture
+
+import m "github.com/snowmerak/gofn/monad"
+
+var _ m.Option[int]
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+`,
+			lifted: []string{"Name"},
+			want:   []string{"return m.Map(m.ResultOf(LoadUser(id)), func(u User) string {"},
+		},
+		"err shadowed in a nested block": {
+			src: `package fixture
+
+import "strconv"
+
+func Age(id string) (int, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return 0, err
+	}
+	if u.Name != "" {
+		n, err := strconv.Atoi(u.Name)
+		if err != nil {
+			return 0, err
+		}
+		return n, nil
+	}
+	return 0, nil
+}
+`,
+			lifted: []string{"Age"},
+		},
+		"shadowing local is not the target": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	LoadUser := func(string) (User, error) { return User{}, nil }
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+`,
+		},
+		"method of the same name is not the target": {
+			src: `package fixture
+
+type Store struct{}
+
+func (Store) LoadUser(id string) (User, error) { return User{Name: id}, nil }
+
+func Name(s Store, id string) (string, error) {
+	u, err := s.LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+`,
+		},
+		"discarded error": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	u, _ := LoadUser(id)
+	return u.Name, nil
+}
+`,
+			skipped: "error result is discarded",
+		},
+		"named results": {
+			src: `package fixture
+
+func Name(id string) (name string, err error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, nil
+}
+`,
+			skipped: "caller does not return unnamed (U, error) or monad.Result[U]",
+		},
+		"no error check": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	println(u.Name)
+	return u.Name, err
+}
+`,
+			skipped: "call is not followed by if err != nil",
+		},
+		"wrapped error": {
+			src: `package fixture
+
+import "fmt"
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", fmt.Errorf("loading %s: %w", id, err)
+	}
+	return u.Name, nil
+}
+`,
+			skipped: "error branch does not return the zero value and err unchanged",
+		},
+		"deferred call": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	defer println("done")
+	return u.Name, nil
+}
+`,
+			skipped: "remaining statements defer calls",
+		},
+		"err read after the check": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	return u.Name, err
+}
+`,
+			skipped: "remaining statements read err",
+		},
+		"err read in a closure": {
+			src: `package fixture
+
+func Name(id string) (string, error) {
+	u, err := LoadUser(id)
+	if err != nil {
+		return "", err
+	}
+	check := func() bool { return err == nil }
+	if !check() {
+		return "", nil
+	}
+	return u.Name, nil
+}
+`,
+			skipped: "remaining statements read err",
+		},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := writePackage(t, map[string]string{"user.go": userSrc, "caller.go": c.src})
+			report, err := Lift(dir, "LoadUser")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var lifted []string
+			for _, s := range report.Lifted {
+				lifted = append(lifted, s.Caller)
+			}
+			if strings.Join(lifted, ",") != strings.Join(c.lifted, ",") {
+				t.Errorf("Expected lifted callers %v, got %v", c.lifted, lifted)
+			}
+			switch {
+			case c.skipped == "" && len(report.Skipped) > 0:
+				t.Errorf("Expected no skipped sites, got %+v", report.Skipped)
+			case c.skipped != "" && (len(report.Skipped) != 1 || report.Skipped[0].Reason != c.skipped):
+				t.Errorf("Expected one site skipped because %q, got %+v", c.skipped, report.Skipped)
+			}
+
+			caller := filepath.Join(dir, "caller.go")
+			src, changed := report.Source(caller)
+			if changed != (len(c.lifted) > 0) {
+				t.Fatalf("Expected caller.go changed to be %v, got %v", len(c.lifted) > 0, changed)
+			}
+			for _, want := range c.want {
+				if !strings.Contains(string(src), want) {
+					t.Errorf("Rewritten source lacks %q:\n%s", want, src)
+				}
+			}
+			if _, ok := report.Source(filepath.Join(dir, "user.go")); ok {
+				t.Error("Lift must not rewrite files without call sites")
+			}
+
+			if err := Apply(report); err != nil {
+				t.Fatal(err)
+			}
+			goVet(t, dir)
+		})
+	}
+}
+
+func TestLiftRejectsTargets(t *testing.T) {
+	cases := map[string]struct {
+		fn   string
+		want string
+	}{
+		"missing":       {"Missing", "function Missing not found"},
+		"variable":      {"Loader", "function Loader not found"},
+		"single result": {"Count", "Count must return (T, error)"},
+		"generic":       {"First", "First: generic functions are not supported"},
+	}
+	dir := writePackage(t, map[string]string{"targets.go": `package fixture
+
+var Loader = func() (int, error) { return 0, nil }
+
+func Count() int { return 0 }
+
+func First[T any](xs []T) (T, error) { return xs[0], nil }
+`})
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Lift(dir, c.fn)
+			if err == nil || err.Error() != c.want {
+				t.Errorf("Expected %q, got %v", c.want, err)
+			}
+		})
+	}
+}
+
+// writePackage writes files into a new module requiring this one and returns its directory, skipping when
+// the go command Lift loads dependencies with is unavailable
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("loads dependencies with the go command")
+	}
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go command not found")
+	}
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/fixture\n\ngo 1.25.0\n\nrequire github.com/snowmerak/gofn v0.0.0\n\nreplace github.com/snowmerak/gofn => " + root + "\n"
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// goVet type-checks the rewritten module in dir
+func goVet(t *testing.T, dir string) {
+	t.Helper()
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go vet in the rewritten package: %v\n%s", err, out)
+	}
+}
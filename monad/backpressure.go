@@ -0,0 +1,236 @@
+package monad
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// overflowPolicy is what a full backpressure buffer does with the next value
+type overflowPolicy int
+
+const (
+	overflowBlock overflowPolicy = iota
+	overflowDropOldest
+	overflowDropNewest
+)
+
+// BackpressureStrategy bounds the values buffered between a producer and a slower consumer
+type BackpressureStrategy struct {
+	size   int
+	policy overflowPolicy
+}
+
+// Buffer buffers up to n values (at least one), then makes the producer wait for the consumer
+func Buffer(n int) BackpressureStrategy {
+	return BackpressureStrategy{size: max(n, 1), policy: overflowBlock}
+}
+
+// Block makes the producer wait whenever the consumer has not taken the previous value yet; it is Buffer(1)
+func Block() BackpressureStrategy {
+	return Buffer(1)
+}
+
+// DropOldest buffers up to n values (at least one), discarding the oldest buffered value to make room for a new one
+// Use it when only recent values matter, e.g. progress or price updates
+func DropOldest(n int) BackpressureStrategy {
+	return BackpressureStrategy{size: max(n, 1), policy: overflowDropOldest}
+}
+
+// DropNewest buffers up to n values (at least one), discarding new values while the buffer is full
+func DropNewest(n int) BackpressureStrategy {
+	return BackpressureStrategy{size: max(n, 1), policy: overflowDropNewest}
+}
+
+// BackpressureMetrics is a snapshot of a Backpressured's counters, summed over its subscriptions
+type BackpressureMetrics struct {
+	Delivered   int64
+	Dropped     int64 // values discarded by DropOldest or DropNewest
+	Blocked     int64 // values the producer had to wait to buffer
+	Buffered    int64 // values currently waiting for a consumer
+	MaxBuffered int64 // the most values a single subscription had waiting at once
+}
+
+// Backpressured decouples an Observable from its subscribers with a bounded buffer per subscription,
+// so a slow consumer, e.g. a WebSocket writer, cannot make memory grow without bound
+type Backpressured[T any] struct {
+	source     Observable[T]
+	strategy   BackpressureStrategy
+	onOverflow func(T)
+
+	delivered, dropped, blocked, buffered, maxBuffered atomic.Int64
+}
+
+// WithBackpressure wraps source so every subscription buffers its values according to strategy
+// The source runs on the package Executor and the subscriber consumes values at its own pace
+func WithBackpressure[T any](source Observable[T], strategy BackpressureStrategy) *Backpressured[T] {
+	return &Backpressured[T]{source: source, strategy: strategy}
+}
+
+// OnOverflow sets a callback receiving every value a drop strategy discards; it runs on the producer side
+func (b *Backpressured[T]) OnOverflow(fn func(dropped T)) *Backpressured[T] {
+	b.onOverflow = fn
+	return b
+}
+
+// Metrics returns a snapshot of the counters
+func (b *Backpressured[T]) Metrics() BackpressureMetrics {
+	return BackpressureMetrics{
+		Delivered:   b.delivered.Load(),
+		Dropped:     b.dropped.Load(),
+		Blocked:     b.blocked.Load(),
+		Buffered:    b.buffered.Load(),
+		MaxBuffered: b.maxBuffered.Load(),
+	}
+}
+
+// Observable returns the buffered stream
+// Values buffered when the source ends are still delivered before the stream ends with the source's result
+func (b *Backpressured[T]) Observable() Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		q := &overflowQueue[T]{owner: b, signal: make(chan struct{})}
+		done := make(chan error, 1)
+		Spawn(func() {
+			err := b.source(ctx, func(v T) { q.push(ctx, v) })
+			q.close()
+			done <- err
+		})
+
+		for {
+			v, ok := q.pop(ctx)
+			if !ok {
+				break
+			}
+			next(v)
+			b.delivered.Add(1)
+		}
+		if err := ctx.Err(); err != nil {
+			cancel()
+			<-done
+			b.buffered.Add(-int64(q.drain()))
+			return err
+		}
+		return <-done
+	}
+}
+
+// overflowQueue is the buffer of one subscription; signal is closed and replaced on every change
+type overflowQueue[T any] struct {
+	owner  *Backpressured[T]
+	mu     sync.Mutex
+	items  []T
+	closed bool
+	signal chan struct{}
+}
+
+// notify wakes the producer and consumer waiting on the queue; q.mu must be held
+func (q *overflowQueue[T]) notify() {
+	close(q.signal)
+	q.signal = make(chan struct{})
+}
+
+// push buffers v according to the strategy, waiting for room under Buffer until ctx is done
+func (q *overflowQueue[T]) push(ctx context.Context, v T) {
+	b := q.owner
+	waited := false
+	for {
+		q.mu.Lock()
+		if len(q.items) < b.strategy.size {
+			q.items = append(q.items, v)
+			n := int64(len(q.items))
+			q.notify()
+			q.mu.Unlock()
+
+			b.buffered.Add(1)
+			for {
+				peak := b.maxBuffered.Load()
+				if n <= peak || b.maxBuffered.CompareAndSwap(peak, n) {
+					return
+				}
+			}
+		}
+
+		switch b.strategy.policy {
+		case overflowDropNewest:
+			q.mu.Unlock()
+			q.overflow(v)
+			return
+		case overflowDropOldest:
+			oldest := q.items[0]
+			q.items = append(q.items[1:], v)
+			q.notify()
+			q.mu.Unlock()
+			q.overflow(oldest)
+			return
+		}
+
+		wait := q.signal
+		q.mu.Unlock()
+		if !waited {
+			waited = true
+			b.blocked.Add(1)
+		}
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// overflow counts a discarded value and reports it to the OnOverflow callback
+func (q *overflowQueue[T]) overflow(v T) {
+	q.owner.dropped.Add(1)
+	currentMetrics().backpressureDropped.Add(1)
+	if q.owner.onOverflow != nil {
+		q.owner.onOverflow(v)
+	}
+}
+
+// pop takes the oldest buffered value, waiting until one arrives; ok is false once the queue is closed
+// and empty or ctx is done
+func (q *overflowQueue[T]) pop(ctx context.Context) (v T, ok bool) {
+	for {
+		q.mu.Lock()
+		if len(q.items) > 0 {
+			v = q.items[0]
+			q.items = q.items[1:]
+			q.notify()
+			q.mu.Unlock()
+			q.owner.buffered.Add(-1)
+			return v, true
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return v, false
+		}
+		wait := q.signal
+		q.mu.Unlock()
+
+		select {
+		case <-wait:
+		case <-ctx.Done():
+			return v, false
+		}
+	}
+}
+
+// close marks the end of the source
+func (q *overflowQueue[T]) close() {
+	q.mu.Lock()
+	q.closed = true
+	q.notify()
+	q.mu.Unlock()
+}
+
+// drain discards the values left after the subscriber stopped and returns how many there were
+func (q *overflowQueue[T]) drain() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := len(q.items)
+	q.items = nil
+	return n
+}
@@ -0,0 +1,128 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// burst emits 1, waits until the consumer holds it, then emits 2..n while the consumer is stalled
+func burst(n int, held <-chan struct{}, produced chan<- struct{}) Observable[int] {
+	return func(ctx context.Context, next func(int)) error {
+		next(1)
+		<-held
+		for i := 2; i <= n; i++ {
+			next(i)
+		}
+		close(produced)
+		return nil
+	}
+}
+
+// stalledConsumer signals held on the first value and waits for produced before consuming further
+func stalledConsumer(got *[]int, held chan<- struct{}, produced <-chan struct{}) func(int) {
+	return func(v int) {
+		if v == 1 {
+			close(held)
+			<-produced
+		}
+		*got = append(*got, v)
+	}
+}
+
+func TestBackpressureDropNewest(t *testing.T) {
+	held, produced := make(chan struct{}), make(chan struct{})
+	var dropped []int
+	bp := WithBackpressure(burst(10, held, produced), DropNewest(3)).OnOverflow(func(v int) { dropped = append(dropped, v) })
+
+	var got []int
+	if err := bp.Observable()(context.Background(), stalledConsumer(&got, held, produced)); err != nil {
+		t.Fatalf("Expected the stream to complete, got %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 2, 3, 4}) || !reflect.DeepEqual(dropped, []int{5, 6, 7, 8, 9, 10}) {
+		t.Errorf("Expected [1 2 3 4] delivered and [5..10] dropped, got %v and %v", got, dropped)
+	}
+	m := bp.Metrics()
+	if m.Delivered != 4 || m.Dropped != 6 || m.Buffered != 0 || m.MaxBuffered != 3 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestBackpressureDropOldest(t *testing.T) {
+	held, produced := make(chan struct{}), make(chan struct{})
+	var dropped []int
+	bp := WithBackpressure(burst(10, held, produced), DropOldest(3)).OnOverflow(func(v int) { dropped = append(dropped, v) })
+
+	var got []int
+	if err := bp.Observable()(context.Background(), stalledConsumer(&got, held, produced)); err != nil {
+		t.Fatalf("Expected the stream to complete, got %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{1, 8, 9, 10}) || !reflect.DeepEqual(dropped, []int{2, 3, 4, 5, 6, 7}) {
+		t.Errorf("Expected [1 8 9 10] delivered and [2..7] dropped, got %v and %v", got, dropped)
+	}
+	if m := bp.Metrics(); m.Delivered != 4 || m.Dropped != 6 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestBackpressureBuffer(t *testing.T) {
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+	bp := WithBackpressure(ObservableOf(values...), Buffer(4))
+
+	got, err := bp.Observable().Collect(context.Background()).Unwrap()
+	if err != nil || !reflect.DeepEqual(got, values) {
+		t.Fatalf("Expected every value in order, got %v (%v)", got, err)
+	}
+	if m := bp.Metrics(); m.Delivered != 100 || m.Dropped != 0 || m.MaxBuffered > 4 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestBackpressureBlockCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	source := Observable[int](func(ctx context.Context, next func(int)) error {
+		for i := 0; ; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			next(i)
+		}
+	})
+	bp := WithBackpressure(source, Block())
+
+	var got []int
+	err := bp.Observable()(ctx, func(v int) {
+		got = append(got, v)
+		if v == 2 {
+			cancel()
+		}
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("Expected [0 1 2], got %v", got)
+	}
+	if m := bp.Metrics(); m.Buffered != 0 || m.Blocked == 0 {
+		t.Errorf("Expected a blocked producer and an empty buffer, got %+v", m)
+	}
+}
+
+func TestBackpressureSourceError(t *testing.T) {
+	errBoom := errors.New("boom")
+	source := Observable[int](func(ctx context.Context, next func(int)) error {
+		next(1)
+		next(2)
+		return errBoom
+	})
+
+	var got []int
+	err := WithBackpressure(source, Buffer(8)).Observable()(context.Background(), func(v int) { got = append(got, v) })
+	if !errors.Is(err, errBoom) || !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Errorf("Expected the buffered values then the source error, got %v and %v", got, err)
+	}
+}
@@ -0,0 +1,126 @@
+package monad
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Batcher coalesces concurrent single-key loads into batched fetches (the DataLoader pattern)
+// A batch is dispatched when it reaches size keys or window after its first key, whichever comes first.
+// Duplicate keys within a batch are fetched once and share the result.
+type Batcher[K comparable, V any] struct {
+	fetch  func(keys []K) ([]V, error)
+	size   int
+	window time.Duration
+
+	mu      sync.Mutex
+	keys    []K
+	waiters map[K][]*Future[V]
+	timer   Timer
+}
+
+// NewBatcher creates a Batcher calling fetch with each batch
+// fetch must return one value per key, in the order of keys
+func NewBatcher[K comparable, V any](size int, window time.Duration, fetch func(keys []K) ([]V, error)) *Batcher[K, V] {
+	if size < 1 {
+		size = 1
+	}
+	return &Batcher[K, V]{fetch: fetch, size: size, window: window}
+}
+
+// Load queues key for the next batch and returns a Future of its value
+func (b *Batcher[K, V]) Load(key K) *Future[V] {
+	future := NewFuture[V]()
+
+	b.mu.Lock()
+	if b.waiters == nil {
+		b.waiters = make(map[K][]*Future[V])
+	}
+	if _, queued := b.waiters[key]; !queued {
+		b.keys = append(b.keys, key)
+	}
+	b.waiters[key] = append(b.waiters[key], future)
+
+	if len(b.keys) >= b.size {
+		keys, waiters := b.take()
+		b.mu.Unlock()
+		Spawn(func() { b.dispatch(keys, waiters) })
+		return future
+	}
+	if b.timer == nil {
+		b.timer = currentClock().AfterFunc(b.window, b.Flush)
+	}
+	b.mu.Unlock()
+	return future
+}
+
+// LoadMany queues every key and returns a Future of all values in key order
+func (b *Batcher[K, V]) LoadMany(keys []K) *Future[[]V] {
+	futures := make([]*Future[V], len(keys))
+	for i, key := range keys {
+		futures[i] = b.Load(key)
+	}
+	return SequenceFutures(futures)
+}
+
+// Flush dispatches the pending batch immediately
+func (b *Batcher[K, V]) Flush() {
+	b.mu.Lock()
+	if len(b.keys) == 0 {
+		b.mu.Unlock()
+		return
+	}
+	keys, waiters := b.take()
+	b.mu.Unlock()
+
+	b.dispatch(keys, waiters)
+}
+
+// take detaches the pending batch; the caller must hold b.mu
+func (b *Batcher[K, V]) take() ([]K, map[K][]*Future[V]) {
+	keys, waiters := b.keys, b.waiters
+	b.keys, b.waiters = nil, nil
+	if b.timer != nil {
+		b.timer.Stop()
+		b.timer = nil
+	}
+	return keys, waiters
+}
+
+// dispatch fetches a batch and completes the waiting Futures
+// If fetch, or a continuation of a completed Future, panics, the Futures still waiting fail with a
+// PanicError before the panic goes on
+func (b *Batcher[K, V]) dispatch(keys []K, waiters map[K][]*Future[V]) {
+	dispatched := false
+	defer func() {
+		if dispatched {
+			return
+		}
+		r := recover()
+		failed := Err[V](&PanicError{Value: r})
+		for _, key := range keys {
+			for _, future := range waiters[key] {
+				future.complete(failed)
+			}
+		}
+		if r != nil {
+			panic(r)
+		}
+	}()
+	values, err := b.fetch(keys)
+	if err == nil && len(values) != len(keys) {
+		err = fmt.Errorf("monad: batch fetch returned %d values for %d keys", len(values), len(keys))
+	}
+
+	for i, key := range keys {
+		result := Err[V](err)
+		if err == nil {
+			result = Ok(values[i])
+		}
+		for _, future := range waiters[key] {
+			future.complete(result)
+		}
+	}
+	dispatched = true
+}
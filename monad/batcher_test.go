@@ -0,0 +1,106 @@
+package monad
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatcherDispatchesFullBatch(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]int
+	b := NewBatcher(3, time.Hour, func(keys []int) ([]string, error) {
+		mu.Lock()
+		batches = append(batches, keys)
+		mu.Unlock()
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			values[i] = string(rune('a' + k))
+		}
+		return values, nil
+	})
+
+	values, err := b.LoadMany([]int{0, 1, 2}).AwaitWithTimeout(time.Second).Unwrap()
+	if err != nil || len(values) != 3 || values[2] != "c" {
+		t.Fatalf("Expected [a b c], got %v (%v)", values, err)
+	}
+	if len(batches) != 1 || len(batches[0]) != 3 {
+		t.Errorf("Expected a single batch of 3, got %v", batches)
+	}
+}
+
+func TestBatcherWindow(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	calls := 0
+	b := NewBatcher(100, 5*time.Millisecond, func(keys []string) ([]int, error) {
+		calls++
+		values := make([]int, len(keys))
+		for i, k := range keys {
+			values[i] = len(k)
+		}
+		return values, nil
+	})
+
+	first := b.Load("a")
+	second := b.Load("bbb")
+	duplicate := b.Load("a")
+	if first.IsDone() {
+		t.Fatal("Batch should wait for the window")
+	}
+
+	clock.Advance(5 * time.Millisecond)
+
+	for _, c := range []struct {
+		future *Future[int]
+		want   int
+	}{{first, 1}, {second, 3}, {duplicate, 1}} {
+		if val, err := c.future.AwaitWithTimeout(time.Second).Unwrap(); err != nil || val != c.want {
+			t.Errorf("Expected %d, got %d (%v)", c.want, val, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected one fetch, got %d", calls)
+	}
+}
+
+func TestBatcherErrors(t *testing.T) {
+	testErr := errors.New("backend down")
+	failing := NewBatcher(1, time.Hour, func(keys []int) ([]int, error) { return nil, testErr })
+	if _, err := failing.Load(1).AwaitWithTimeout(time.Second).Unwrap(); err != testErr {
+		t.Errorf("Expected %v, got %v", testErr, err)
+	}
+
+	short := NewBatcher(2, time.Hour, func(keys []int) ([]int, error) { return []int{1}, nil })
+	if _, err := short.LoadMany([]int{1, 2}).AwaitWithTimeout(time.Second).Unwrap(); err == nil {
+		t.Error("Mismatched value count should fail the batch")
+	}
+}
+
+func TestBatcherFlush(t *testing.T) {
+	b := NewBatcher(100, time.Hour, func(keys []int) ([]int, error) { return keys, nil })
+	future := b.Load(7)
+	b.Flush()
+	if val, err := future.AwaitWithTimeout(time.Second).Unwrap(); err != nil || val != 7 {
+		t.Errorf("Expected 7, got %d (%v)", val, err)
+	}
+}
+
+func TestBatcherPanickingFetch(t *testing.T) {
+	exec := newRecoveringExecutor()
+	defer SetExecutor(exec)()
+	b := NewBatcher(2, time.Hour, func(keys []int) ([]int, error) { panic("fetch failed") })
+
+	first, second := b.Load(1), b.Load(2)
+	if r := <-exec.panics; r != "fetch failed" {
+		t.Errorf("The panic should propagate, got %v", r)
+	}
+	var panicErr *PanicError
+	for _, future := range []*Future[int]{first, second} {
+		if _, err := future.AwaitWithTimeout(time.Second).Unwrap(); !errors.As(err, &panicErr) {
+			t.Errorf("Every key of the batch should fail with a PanicError, got %v", err)
+		}
+	}
+}
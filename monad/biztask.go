@@ -0,0 +1,155 @@
+package monad
+
+import (
+	"context"
+	"time"
+)
+
+// BizTask is a Task whose outcome distinguishes typed business errors from infrastructure failures
+// A business error E is carried as Left, a value T as Right, while transport and context errors
+// stay in the surrounding Result's Go error
+type BizTask[E, T any] func(context.Context) Result[Either[E, T]]
+
+// NewBizTask creates a BizTask from a function
+func NewBizTask[E, T any](f func(context.Context) Result[Either[E, T]]) BizTask[E, T] {
+	return f
+}
+
+// BizSucceed creates a BizTask that completes with value
+func BizSucceed[E, T any](value T) BizTask[E, T] {
+	return func(ctx context.Context) Result[Either[E, T]] {
+		return Ok(Right[E](value))
+	}
+}
+
+// BizFail creates a BizTask that completes with the business error e
+func BizFail[E, T any](e E) BizTask[E, T] {
+	return func(ctx context.Context) Result[Either[E, T]] {
+		return Ok(Left[E, T](e))
+	}
+}
+
+// BizTaskFromTask lifts a Task into a BizTask; its errors remain infrastructure errors
+func BizTaskFromTask[E, T any](task Task[T]) BizTask[E, T] {
+	return func(ctx context.Context) Result[Either[E, T]] {
+		return Map(task(ctx), Right[E, T])
+	}
+}
+
+// Task returns the BizTask as a Task of Either so that generic Task combinators apply
+func (b BizTask[E, T]) Task() Task[Either[E, T]] {
+	return Task[Either[E, T]](b)
+}
+
+// Run executes the BizTask asynchronously
+func (b BizTask[E, T]) Run(ctx context.Context) *Future[Either[E, T]] {
+	return b.Task().Run(ctx)
+}
+
+// ToTask folds business errors into Go errors using toErr
+func (b BizTask[E, T]) ToTask(toErr func(E) error) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		return AndThen(b(ctx), func(e Either[E, T]) Result[T] {
+			if e.IsLeft() {
+				return Err[T](toErr(e.UnwrapLeft()))
+			}
+			return Ok(e.UnwrapRight())
+		})
+	}
+}
+
+// MapBizTask transforms the value of a successful BizTask
+func MapBizTask[E, T, U any](b BizTask[E, T], f func(T) U) BizTask[E, U] {
+	return func(ctx context.Context) Result[Either[E, U]] {
+		return Map(b(ctx), func(e Either[E, T]) Either[E, U] {
+			return MapRight(e, f)
+		})
+	}
+}
+
+// MapBizError transforms the business error of a BizTask
+func MapBizError[E, F, T any](b BizTask[E, T], f func(E) F) BizTask[F, T] {
+	return func(ctx context.Context) Result[Either[F, T]] {
+		return Map(b(ctx), func(e Either[E, T]) Either[F, T] {
+			return MapLeft(e, f)
+		})
+	}
+}
+
+// AndThenBizTask chains a BizTask on the value of another, short-circuiting on either kind of error
+func AndThenBizTask[E, T, U any](b BizTask[E, T], f func(T) BizTask[E, U]) BizTask[E, U] {
+	return func(ctx context.Context) Result[Either[E, U]] {
+		result := b(ctx)
+		if !result.IsOk() {
+			return Err[Either[E, U]](result.err)
+		}
+		if result.val.IsLeft() {
+			return Ok(Left[E, U](result.val.UnwrapLeft()))
+		}
+		return f(result.val.UnwrapRight())(ctx)
+	}
+}
+
+// RecoverBizTask handles a business error by running the BizTask returned by f
+func RecoverBizTask[E, T any](b BizTask[E, T], f func(E) BizTask[E, T]) BizTask[E, T] {
+	return func(ctx context.Context) Result[Either[E, T]] {
+		result := b(ctx)
+		if !result.IsOk() || result.val.IsRight() {
+			return result
+		}
+		return f(result.val.UnwrapLeft())(ctx)
+	}
+}
+
+// SequenceBizTasks executes BizTasks sequentially, stopping at the first error of either kind
+func SequenceBizTasks[E, T any](tasks []BizTask[E, T]) BizTask[E, []T] {
+	return func(ctx context.Context) Result[Either[E, []T]] {
+		values := make([]T, 0, len(tasks))
+		for _, task := range tasks {
+			if err := ctx.Err(); err != nil {
+				return Err[Either[E, []T]](err)
+			}
+			result := task(ctx)
+			if !result.IsOk() {
+				return Err[Either[E, []T]](result.err)
+			}
+			if result.val.IsLeft() {
+				return Ok(Left[E, []T](result.val.UnwrapLeft()))
+			}
+			values = append(values, result.val.UnwrapRight())
+		}
+		return Ok(Right[E](values))
+	}
+}
+
+// ParallelBizTasks executes BizTasks in parallel
+// The first error by index is reported; remaining tasks are cancelled when it returns
+func ParallelBizTasks[E, T any](tasks []BizTask[E, T]) BizTask[E, []T] {
+	return func(ctx context.Context) Result[Either[E, []T]] {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		futures := make([]*Future[Either[E, T]], len(tasks))
+		for i, task := range tasks {
+			futures[i] = task.Run(ctx)
+		}
+
+		values := make([]T, len(tasks))
+		for i, future := range futures {
+			result := future.AwaitWithContext(ctx)
+			if !result.IsOk() {
+				return Err[Either[E, []T]](result.err)
+			}
+			if result.val.IsLeft() {
+				return Ok(Left[E, []T](result.val.UnwrapLeft()))
+			}
+			values[i] = result.val.UnwrapRight()
+		}
+		return Ok(Right[E](values))
+	}
+}
+
+// WithBizTimeout bounds a BizTask like WithTimeout; a timeout is an infrastructure error
+func WithBizTimeout[E, T any](b BizTask[E, T], d time.Duration) BizTask[E, T] {
+	return BizTask[E, T](WithTimeout(b.Task(), d))
+}
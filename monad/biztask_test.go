@@ -0,0 +1,92 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type bizError struct {
+	Code string
+}
+
+func TestBizTaskOutcomes(t *testing.T) {
+	ctx := context.Background()
+
+	e, err := BizSucceed[bizError](5)(ctx).Unwrap()
+	if err != nil || !e.IsRight() || e.UnwrapRight() != 5 {
+		t.Errorf("Expected Right(5), got %v (%v)", e, err)
+	}
+
+	e, err = BizFail[bizError, int](bizError{"out_of_stock"})(ctx).Unwrap()
+	if err != nil || !e.IsLeft() || e.UnwrapLeft().Code != "out_of_stock" {
+		t.Errorf("Expected business error, got %v (%v)", e, err)
+	}
+
+	testErr := errors.New("connection reset")
+	if _, err := BizTaskFromTask[bizError](NewTaskFromError[int](testErr))(ctx).Unwrap(); err != testErr {
+		t.Errorf("Expected transport error, got %v", err)
+	}
+}
+
+func TestBizTaskToTask(t *testing.T) {
+	toErr := func(e bizError) error { return errors.New(e.Code) }
+	ctx := context.Background()
+
+	if val, err := BizSucceed[bizError](1).ToTask(toErr)(ctx).Unwrap(); err != nil || val != 1 {
+		t.Errorf("Expected 1, got %d (%v)", val, err)
+	}
+	if _, err := BizFail[bizError, int](bizError{"denied"}).ToTask(toErr)(ctx).Unwrap(); err == nil || err.Error() != "denied" {
+		t.Errorf("Expected denied, got %v", err)
+	}
+}
+
+func TestMapAndThenBizTask(t *testing.T) {
+	ctx := context.Background()
+	doubled := MapBizTask(BizSucceed[bizError](2), func(v int) int { return v * 2 })
+	chained := AndThenBizTask(doubled, func(v int) BizTask[bizError, string] {
+		if v > 3 {
+			return BizFail[bizError, string](bizError{"too_large"})
+		}
+		return BizSucceed[bizError]("ok")
+	})
+
+	e, _ := chained(ctx).Unwrap()
+	if !e.IsLeft() || e.UnwrapLeft().Code != "too_large" {
+		t.Errorf("Expected too_large, got %v", e)
+	}
+
+	mapped := MapBizError(chained, func(e bizError) string { return e.Code })
+	if e, _ := mapped(ctx).Unwrap(); e.UnwrapLeft() != "too_large" {
+		t.Errorf("Expected mapped business error, got %v", e)
+	}
+}
+
+func TestRecoverBizTask(t *testing.T) {
+	recovered := RecoverBizTask(BizFail[bizError, int](bizError{"cache_miss"}), func(e bizError) BizTask[bizError, int] {
+		return BizSucceed[bizError](7)
+	})
+	if e, _ := recovered(context.Background()).Unwrap(); !e.IsRight() || e.UnwrapRight() != 7 {
+		t.Errorf("Expected recovery to 7, got %v", e)
+	}
+}
+
+func TestSequenceAndParallelBizTasks(t *testing.T) {
+	ctx := context.Background()
+	ok := []BizTask[bizError, int]{BizSucceed[bizError](1), BizSucceed[bizError](2)}
+
+	for _, combined := range []BizTask[bizError, []int]{SequenceBizTasks(ok), ParallelBizTasks(ok)} {
+		e, err := combined(ctx).Unwrap()
+		if err != nil || !e.IsRight() || len(e.UnwrapRight()) != 2 {
+			t.Errorf("Expected [1 2], got %v (%v)", e, err)
+		}
+	}
+
+	failing := append(ok, BizFail[bizError, int](bizError{"invalid"}))
+	for _, combined := range []BizTask[bizError, []int]{SequenceBizTasks(failing), ParallelBizTasks(failing)} {
+		e, err := combined(ctx).Unwrap()
+		if err != nil || !e.IsLeft() || e.UnwrapLeft().Code != "invalid" {
+			t.Errorf("Expected invalid, got %v (%v)", e, err)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package monad
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CacheMetrics is a snapshot of a Cache's counters
+type CacheMetrics struct {
+	Hits       int64
+	Misses     int64
+	Loads      int64 // loader runs; concurrent misses for one key share a single load
+	LoadErrors int64
+	Evictions  int64 // entries dropped for the size bound or because they expired
+}
+
+// Cache is a concurrency-safe LRU cache with optional TTL whose loads are deduplicated per key
+// Expiry is measured by the package Clock; failed loads are not cached
+type Cache[K comparable, V any] struct {
+	maxEntries int
+	ttl        time.Duration
+
+	mu       sync.Mutex
+	entries  map[K]*list.Element
+	lru      *list.List // front is most recently used
+	inflight map[K]*Future[V]
+
+	hits, misses, loads, loadErrors, evictions atomic.Int64
+}
+
+// cacheEntry is an element of the LRU list
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // zero when the cache has no TTL
+}
+
+// NewCache creates a Cache holding at most maxEntries values (0 for unbounded) for ttl each (0 for no expiry)
+func NewCache[K comparable, V any](maxEntries int, ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    map[K]*list.Element{},
+		lru:        list.New(),
+		inflight:   map[K]*Future[V]{},
+	}
+}
+
+// Get returns the cached value for key, or None if it is absent or expired
+func (c *Cache[K, V]) Get(key K) Option[V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if value, ok := c.lookup(key); ok {
+		c.hits.Add(1)
+		currentMetrics().cacheHits.Add(1)
+		return Some(value)
+	}
+	c.misses.Add(1)
+	currentMetrics().cacheMisses.Add(1)
+	return None[V]()
+}
+
+// Set stores value under key, evicting the least recently used entry when full
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(key, value)
+}
+
+// Delete removes key from the cache
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// Purge removes every entry
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[K]*list.Element{}
+	c.lru.Init()
+}
+
+// Len returns the number of entries, including expired ones not yet evicted
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// GetOrLoad returns the cached value for key, or runs load and caches its value
+// Concurrent misses for the same key share one load, run with the first caller's context;
+// the other callers stop waiting when their own ctx ends
+// If load panics, the callers sharing it fail with a PanicError and the panic propagates to the caller running it
+func (c *Cache[K, V]) GetOrLoad(ctx context.Context, key K, load Task[V]) Result[V] {
+	c.mu.Lock()
+	if value, ok := c.lookup(key); ok {
+		c.mu.Unlock()
+		c.hits.Add(1)
+		currentMetrics().cacheHits.Add(1)
+		return Ok(value)
+	}
+	c.misses.Add(1)
+	currentMetrics().cacheMisses.Add(1)
+	if pending, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		return pending.AwaitWithContext(ctx)
+	}
+	pending := NewFuture[V]()
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	c.loads.Add(1)
+	m := currentMetrics()
+	m.cacheLoads.Add(1)
+	start := currentClock().Now()
+	loaded := false
+	defer func() {
+		if loaded {
+			return
+		}
+		// load panicked: fail the callers sharing it and let the next miss load again before the panic goes on
+		r := recover()
+		c.mu.Lock()
+		delete(c.inflight, key)
+		c.mu.Unlock()
+		c.loadErrors.Add(1)
+		m.cacheLoadErrors.Add(1)
+		pending.complete(Err[V](&PanicError{Value: r}))
+		if r != nil {
+			panic(r)
+		}
+	}()
+	result := load(ctx)
+	loaded = true
+	m.cacheLoadSeconds.Observe(currentClock().Now().Sub(start).Seconds())
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if value, err := result.Unwrap(); err == nil {
+		c.store(key, value)
+	} else {
+		c.loadErrors.Add(1)
+		m.cacheLoadErrors.Add(1)
+	}
+	c.mu.Unlock()
+
+	pending.complete(result)
+	return result
+}
+
+// Metrics returns a snapshot of the cache's counters
+func (c *Cache[K, V]) Metrics() CacheMetrics {
+	return CacheMetrics{
+		Hits:       c.hits.Load(),
+		Misses:     c.misses.Load(),
+		Loads:      c.loads.Load(),
+		LoadErrors: c.loadErrors.Load(),
+		Evictions:  c.evictions.Load(),
+	}
+}
+
+// lookup returns a live entry, marking it recently used and evicting it if expired; c.mu must be held
+func (c *Cache[K, V]) lookup(key K) (V, bool) {
+	var zero V
+	el, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := el.Value.(*cacheEntry[K, V])
+	if !entry.expires.IsZero() && !currentClock().Now().Before(entry.expires) {
+		c.lru.Remove(el)
+		delete(c.entries, key)
+		c.evictions.Add(1)
+		currentMetrics().cacheEvictions.Add(1)
+		return zero, false
+	}
+	c.lru.MoveToFront(el)
+	return entry.value, true
+}
+
+// store inserts or refreshes an entry and enforces the size bound; c.mu must be held
+func (c *Cache[K, V]) store(key K, value V) {
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = currentClock().Now().Add(c.ttl)
+	}
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*cacheEntry[K, V])
+		entry.value, entry.expires = value, expires
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&cacheEntry[K, V]{key: key, value: value, expires: expires})
+	for c.maxEntries > 0 && c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry[K, V]).key)
+		c.evictions.Add(1)
+		currentMetrics().cacheEvictions.Add(1)
+	}
+}
+
+// MemoizeTask returns a Task serving key from cache, running task only on a miss
+func MemoizeTask[K comparable, V any](cache *Cache[K, V], key K, task Task[V]) Task[V] {
+	return func(ctx context.Context) Result[V] {
+		return cache.GetOrLoad(ctx, key, task)
+	}
+}
@@ -0,0 +1,160 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheLRU(t *testing.T) {
+	cache := NewCache[string, int](2, 0)
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a") // a is now most recently used
+	cache.Set("c", 3)
+
+	if cache.Get("b").IsSome() {
+		t.Error("b should be evicted as least recently used")
+	}
+	if v := cache.Get("a"); !v.IsSome() || v.Unwrap() != 1 {
+		t.Errorf("Expected a=1, got %v", v)
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+	if m := cache.Metrics(); m.Evictions != 1 || m.Hits != 2 || m.Misses != 1 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+
+	cache.Delete("a")
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Error("Purge should remove every entry")
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	cache := NewCache[string, int](0, time.Minute)
+	cache.Set("a", 1)
+	clock.Advance(59 * time.Second)
+	if !cache.Get("a").IsSome() {
+		t.Error("Entry should be live before its TTL")
+	}
+	clock.Advance(time.Second)
+	if cache.Get("a").IsSome() {
+		t.Error("Entry should expire after its TTL")
+	}
+}
+
+func TestCacheGetOrLoadSingleflight(t *testing.T) {
+	cache := NewCache[int, string](0, 0)
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := Task[string](func(ctx context.Context) Result[string] {
+		loads.Add(1)
+		<-release
+		return Ok("loaded")
+	})
+
+	var wg sync.WaitGroup
+	results := make([]Result[string], 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = cache.GetOrLoad(context.Background(), 1, load)
+		}()
+	}
+	// let every caller reach the cache before the load finishes
+	for cache.Metrics().Misses < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("Expected a single load, got %d", n)
+	}
+	for _, r := range results {
+		if v, err := r.Unwrap(); err != nil || v != "loaded" {
+			t.Errorf("Unexpected result (%s, %v)", v, err)
+		}
+	}
+	if v, _ := cache.GetOrLoad(context.Background(), 1, load).Unwrap(); v != "loaded" || loads.Load() != 1 {
+		t.Error("The loaded value should be served from the cache")
+	}
+}
+
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	cache := NewCache[int, int](0, 0)
+	boom := errors.New("boom")
+	if _, err := cache.GetOrLoad(context.Background(), 1, NewTaskFromError[int](boom)).Unwrap(); err != boom {
+		t.Errorf("Expected boom, got %v", err)
+	}
+	if v, err := cache.GetOrLoad(context.Background(), 1, NewTaskFromValue(7)).Unwrap(); err != nil || v != 7 {
+		t.Errorf("A failed load should not be cached, got (%d, %v)", v, err)
+	}
+	if m := cache.Metrics(); m.Loads != 2 || m.LoadErrors != 1 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestCacheGetOrLoadPanickingLoader(t *testing.T) {
+	cache := NewCache[int, string](0, 0)
+	started, release := make(chan struct{}), make(chan struct{})
+	panicking := Task[string](func(ctx context.Context) Result[string] {
+		close(started)
+		<-release
+		panic("loader failed")
+	})
+
+	recovered := make(chan any)
+	go func() {
+		defer func() { recovered <- recover() }()
+		cache.GetOrLoad(context.Background(), 1, panicking)
+	}()
+	<-started
+	waiter := make(chan Result[string])
+	go func() { waiter <- cache.GetOrLoad(context.Background(), 1, NewTaskFromValue("unused")) }()
+	for cache.Metrics().Misses < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	if r := <-recovered; r != "loader failed" {
+		t.Errorf("The panic should reach the caller running the load, got %v", r)
+	}
+	var panicErr *PanicError
+	if _, err := (<-waiter).Unwrap(); !errors.As(err, &panicErr) || panicErr.Value != "loader failed" {
+		t.Errorf("A caller sharing the load should fail with a PanicError, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if v, err := cache.GetOrLoad(ctx, 1, NewTaskFromValue("loaded")).Unwrap(); err != nil || v != "loaded" {
+		t.Errorf("The key should load again after a panic, got (%s, %v)", v, err)
+	}
+}
+
+func TestMemoizeTask(t *testing.T) {
+	cache := NewCache[string, int](0, 0)
+	var runs atomic.Int32
+	task := MemoizeTask(cache, "answer", Task[int](func(ctx context.Context) Result[int] {
+		runs.Add(1)
+		return Ok(42)
+	}))
+
+	for range 3 {
+		if v, _ := task(context.Background()).Unwrap(); v != 42 {
+			t.Errorf("Expected 42, got %d", v)
+		}
+	}
+	if runs.Load() != 1 {
+		t.Errorf("Expected the task to run once, got %d", runs.Load())
+	}
+}
@@ -0,0 +1,36 @@
+package monad
+
+import (
+	"context"
+	"runtime"
+)
+
+// checkpointEvery is how many iterations ChunkedLoop runs between checkpoints
+const checkpointEvery = 1024
+
+// Checkpoint returns ctx's error once it is done, and otherwise yields the processor so other goroutines,
+// such as the timer of WithTimeout, get to run; CPU-bound Tasks call it between units of work so the
+// timeout and cancellation combinators can actually stop them
+func Checkpoint(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	runtime.Gosched()
+	return nil
+}
+
+// ChunkedLoop calls body for i from 0 to n-1, passing a Checkpoint every 1024 iterations
+// It stops at the first error of body, or with ctx's error once ctx is done
+func ChunkedLoop(ctx context.Context, n int, body func(i int) error) error {
+	for i := 0; i < n; i++ {
+		if i%checkpointEvery == 0 {
+			if err := Checkpoint(ctx); err != nil {
+				return err
+			}
+		}
+		if err := body(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
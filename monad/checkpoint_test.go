@@ -0,0 +1,70 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCheckpoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := Checkpoint(ctx); err != nil {
+		t.Errorf("Expected no error before cancellation, got %v", err)
+	}
+	cancel()
+	if err := Checkpoint(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestChunkedLoop(t *testing.T) {
+	sum := 0
+	err := ChunkedLoop(context.Background(), 5000, func(i int) error {
+		sum += i
+		return nil
+	})
+	if err != nil || sum != 5000*4999/2 {
+		t.Errorf("Expected every iteration to run, got %d, %v", sum, err)
+	}
+
+	boom := errors.New("boom")
+	calls := 0
+	err = ChunkedLoop(context.Background(), 10, func(i int) error {
+		calls++
+		if i == 3 {
+			return boom
+		}
+		return nil
+	})
+	if !errors.Is(err, boom) || calls != 4 {
+		t.Errorf("Expected the loop to stop at the body's error, got %d calls, %v", calls, err)
+	}
+}
+
+func TestChunkedLoopStopsUnderTimeout(t *testing.T) {
+	var stopped atomic.Bool
+	var runs atomic.Int32
+	spin := Task[int](func(ctx context.Context) Result[int] {
+		runs.Add(1)
+		err := ChunkedLoop(ctx, 1<<62, func(int) error { return nil })
+		stopped.Store(true)
+		return Err[int](err)
+	})
+
+	result := RetryTask(WithTimeout(spin, 20*time.Millisecond), 3, nil)(context.Background())
+	if _, err := result.Unwrap(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if runs.Load() != 3 {
+		t.Errorf("Each timed out attempt should be retried, got %d runs", runs.Load())
+	}
+	deadline := time.Now().Add(time.Second)
+	for !stopped.Load() && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !stopped.Load() {
+		t.Error("The loop should stop once the timeout cancels its context")
+	}
+}
@@ -0,0 +1,227 @@
+package monad
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers to the time-based APIs of the package
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+	AfterFunc(d time.Duration, fn func()) Timer
+}
+
+// Timer is a single-shot timer created by a Clock
+type Timer interface {
+	// C returns the channel the fire time is delivered on (nil for AfterFunc timers)
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, reporting whether it was still pending
+	Stop() bool
+	// Reset reschedules the timer to fire after d, reporting whether it was still pending
+	Reset(d time.Duration) bool
+}
+
+// realClock is the Clock backed by the time package
+type realClock struct{}
+
+// realTimer adapts *time.Timer to Timer
+type realTimer struct {
+	t *time.Timer
+}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{t: time.NewTimer(d)} }
+func (realClock) AfterFunc(d time.Duration, fn func()) Timer {
+	return realTimer{t: time.AfterFunc(d, fn)}
+}
+
+func (t realTimer) C() <-chan time.Time        { return t.t.C }
+func (t realTimer) Stop() bool                 { return t.t.Stop() }
+func (t realTimer) Reset(d time.Duration) bool { return t.t.Reset(d) }
+
+// RealClock returns the Clock backed by the time package
+func RealClock() Clock {
+	return realClock{}
+}
+
+var (
+	clockMu sync.RWMutex
+	clock   Clock = realClock{}
+)
+
+// SetClock replaces the Clock used by the package and returns a function restoring the previous one
+// Passing nil restores the real clock
+func SetClock(c Clock) (restore func()) {
+	if c == nil {
+		c = realClock{}
+	}
+	clockMu.Lock()
+	prev := clock
+	clock = c
+	clockMu.Unlock()
+
+	return func() {
+		clockMu.Lock()
+		clock = prev
+		clockMu.Unlock()
+	}
+}
+
+// currentClock returns the Clock used by the package
+func currentClock() Clock {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return clock
+}
+
+// FakeClock is a Clock whose time only moves when Advance or Set is called
+type FakeClock struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// fakeTimer is a pending timer of a FakeClock
+type fakeTimer struct {
+	clock    *FakeClock
+	deadline time.Time
+	ch       chan time.Time
+	fn       func()
+}
+
+// NewFakeClock creates a FakeClock starting at the given time
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the fake current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel that receives the fake time once the clock is advanced by d
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// NewTimer creates a Timer that fires once the clock is advanced by d
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: c, ch: make(chan time.Time, 1)}
+	c.schedule(t, d)
+	return t
+}
+
+// AfterFunc creates a Timer that calls fn through Spawn once the clock is advanced by d
+func (c *FakeClock) AfterFunc(d time.Duration, fn func()) Timer {
+	t := &fakeTimer{clock: c, fn: fn}
+	c.schedule(t, d)
+	return t
+}
+
+// schedule registers t to fire d after the current fake time, firing immediately if d <= 0
+func (c *FakeClock) schedule(t *fakeTimer, d time.Duration) {
+	c.mu.Lock()
+	t.deadline = c.now.Add(d)
+	if d <= 0 {
+		now := c.now
+		c.mu.Unlock()
+		t.fire(now)
+		return
+	}
+	c.timers = append(c.timers, t)
+	sort.SliceStable(c.timers, func(i, j int) bool { return c.timers[i].deadline.Before(c.timers[j].deadline) })
+	c.cond.Broadcast()
+	c.mu.Unlock()
+}
+
+// remove unregisters t, reporting whether it was pending; c.mu must be held
+func (c *FakeClock) remove(t *fakeTimer) bool {
+	for i, pending := range c.timers {
+		if pending == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// PendingTimers returns the number of timers that have not fired yet
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.timers)
+}
+
+// BlockUntilTimers blocks until at least n timers are pending
+// Use it to wait for a goroutine under test to start waiting before advancing the clock
+func (c *FakeClock) BlockUntilTimers(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.timers) < n {
+		c.cond.Wait()
+	}
+}
+
+// Advance moves the fake clock forward by d and fires every timer that became due, in deadline order
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	c.Set(target)
+}
+
+// Set moves the fake clock to t and fires every timer that became due, in deadline order
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	due := 0
+	for due < len(c.timers) && !c.timers[due].deadline.After(t) {
+		due++
+	}
+	fired := append([]*fakeTimer(nil), c.timers[:due]...)
+	c.timers = append([]*fakeTimer(nil), c.timers[due:]...)
+	c.mu.Unlock()
+
+	for _, timer := range fired {
+		timer.fire(t)
+	}
+}
+
+// fire delivers the fire time on the channel or runs the AfterFunc callback
+func (t *fakeTimer) fire(now time.Time) {
+	if t.fn != nil {
+		Spawn(t.fn)
+		return
+	}
+	select {
+	case t.ch <- now:
+	default:
+	}
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	return t.clock.remove(t)
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	pending := t.clock.remove(t)
+	t.clock.mu.Unlock()
+	t.clock.schedule(t, d)
+	return pending
+}
@@ -0,0 +1,112 @@
+package monad
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockTimers(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	early := clock.NewTimer(time.Second)
+	late := clock.After(3 * time.Second)
+
+	if clock.PendingTimers() != 2 {
+		t.Errorf("Expected 2 pending timers, got %d", clock.PendingTimers())
+	}
+
+	clock.Advance(time.Second)
+
+	select {
+	case fired := <-early.C():
+		if !fired.Equal(start.Add(time.Second)) {
+			t.Errorf("Expected fire time %v, got %v", start.Add(time.Second), fired)
+		}
+	default:
+		t.Error("Timer should fire once its deadline is reached")
+	}
+
+	select {
+	case <-late:
+		t.Error("Later timer should not fire yet")
+	default:
+	}
+
+	clock.Advance(2 * time.Second)
+	select {
+	case <-late:
+	default:
+		t.Error("Later timer should fire after advancing past its deadline")
+	}
+
+	if !clock.Now().Equal(start.Add(3 * time.Second)) {
+		t.Errorf("Expected %v, got %v", start.Add(3*time.Second), clock.Now())
+	}
+}
+
+func TestFakeClockStopAndReset(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+
+	timer := clock.NewTimer(time.Second)
+	if !timer.Stop() {
+		t.Error("Stop should report a pending timer")
+	}
+	if timer.Stop() {
+		t.Error("Second Stop should report the timer was no longer pending")
+	}
+
+	clock.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Error("Stopped timer should not fire")
+	default:
+	}
+
+	timer.Reset(2 * time.Second)
+	clock.Advance(time.Second)
+	select {
+	case <-timer.C():
+		t.Error("Reset timer should not fire before its new deadline")
+	default:
+	}
+	clock.Advance(time.Second)
+	select {
+	case <-timer.C():
+	default:
+		t.Error("Reset timer should fire at its new deadline")
+	}
+}
+
+func TestFakeClockAfterFunc(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	calls := 0
+	s.AfterFunc(time.Minute, func() { calls++ })
+
+	s.Advance(59 * time.Second)
+	if calls != 0 {
+		t.Error("AfterFunc should not run before its deadline")
+	}
+
+	s.Advance(time.Second)
+	if calls != 1 {
+		t.Errorf("Expected AfterFunc to run once, got %d", calls)
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	fake := NewFakeClock(time.Unix(100, 0))
+	restore := SetClock(fake)
+
+	if !currentClock().Now().Equal(time.Unix(100, 0)) {
+		t.Error("Package clock should be the fake clock")
+	}
+
+	restore()
+
+	if _, ok := currentClock().(realClock); !ok {
+		t.Error("Restore should bring back the real clock")
+	}
+}
@@ -0,0 +1,172 @@
+package monad
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// CSVMarshaler is implemented by the types //gofn:csv generates codecs for: a row of cells per value,
+// under a header naming each column
+type CSVMarshaler interface {
+	CSVHeader() []string
+	MarshalCSVRow() []string
+}
+
+// CSVCodec is the constraint on the pointer of a //gofn:csv type: CSVColumns matches a header to the
+// type's columns once, so UnmarshalCSVColumns decodes every row without looking names up again
+type CSVCodec[T any] interface {
+	*T
+	CSVMarshaler
+	CSVColumns(header []string) ([]int, error)
+	UnmarshalCSVColumns(cols []int, row []string) error
+}
+
+// CSVReader decodes values of T from CSV rows, matching columns to fields by the header on the first line
+// Columns may come in any order and unknown ones are ignored; a missing column fails the first Read
+// unless its field is a monad.Option, which is then None
+type CSVReader[T any, P CSVCodec[T]] struct {
+	r    *csv.Reader
+	cols []int
+	err  error // sticky header error
+}
+
+// NewCSVReader returns a CSVReader reading r with the given field separator, ',' for CSV or '\t' for TSV
+func NewCSVReader[T any, P CSVCodec[T]](r io.Reader, comma rune) *CSVReader[T, P] {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.ReuseRecord = true
+	return &CSVReader[T, P]{r: cr}
+}
+
+// Read returns the next value, or io.EOF once the input is exhausted
+// Errors name the line of the offending row
+func (r *CSVReader[T, P]) Read() (T, error) {
+	var v T
+	if r.cols == nil && r.err == nil {
+		header, err := r.r.Read()
+		switch {
+		case errors.Is(err, io.EOF):
+			r.err = errors.New("csv: missing header")
+		case err != nil:
+			r.err = err
+		default:
+			r.cols, r.err = P(&v).CSVColumns(header)
+		}
+	}
+	if r.err != nil {
+		return v, r.err
+	}
+
+	row, err := r.r.Read()
+	if err != nil {
+		return v, err
+	}
+	if err := P(&v).UnmarshalCSVColumns(r.cols, row); err != nil {
+		line, _ := r.r.FieldPos(0)
+		return v, fmt.Errorf("csv line %d: %w", line, err)
+	}
+	return v, nil
+}
+
+// ReadAll reads every remaining value
+func (r *CSVReader[T, P]) ReadAll() ([]T, error) {
+	var values []T
+	for {
+		v, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+		values = append(values, v)
+	}
+}
+
+// Observable streams the remaining values, stopping at the first error or once ctx is done
+// The underlying reader is consumed, so the Observable can be subscribed to once
+func (r *CSVReader[T, P]) Observable() Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		for {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			v, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			next(v)
+		}
+	}
+}
+
+// CSVWriter encodes values of T as CSV rows, preceded by T's header
+type CSVWriter[T CSVMarshaler] struct {
+	w      *csv.Writer
+	header bool
+}
+
+// NewCSVWriter returns a CSVWriter writing to w with the given field separator, ',' for CSV or '\t' for TSV
+// Rows are buffered; call Flush, or use WriteAll or WriteObservable, which flush when done
+func NewCSVWriter[T CSVMarshaler](w io.Writer, comma rune) *CSVWriter[T] {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	return &CSVWriter[T]{w: cw}
+}
+
+// writeHeader writes the header before the first row
+func (w *CSVWriter[T]) writeHeader() error {
+	if w.header {
+		return nil
+	}
+	w.header = true
+	var zero T
+	return w.w.Write(zero.CSVHeader())
+}
+
+// Write writes v as one row, writing the header first if this is the first row
+func (w *CSVWriter[T]) Write(v T) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	return w.w.Write(v.MarshalCSVRow())
+}
+
+// WriteAll writes every value and flushes; with no values it still writes the header
+func (w *CSVWriter[T]) WriteAll(values []T) error {
+	return w.WriteObservable(context.Background(), ObservableOf(values...))
+}
+
+// WriteObservable writes every value obs emits and flushes once it ends, returning the first write
+// error or the error that ended the stream
+func (w *CSVWriter[T]) WriteObservable(ctx context.Context, obs Observable[T]) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	err := obs(ctx, func(v T) {
+		if err := w.w.Write(v.MarshalCSVRow()); err != nil {
+			cancel(err)
+		}
+	})
+	if cause := context.Cause(ctx); cause != nil && !errors.Is(cause, context.Canceled) {
+		err = cause
+	}
+	if ferr := w.Flush(); err == nil {
+		err = ferr
+	}
+	return err
+}
+
+// Flush writes any buffered rows to the underlying writer
+func (w *CSVWriter[T]) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
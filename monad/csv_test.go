@@ -0,0 +1,115 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// csvPoint is a hand-written CSVCodec, shaped like the code //gofn:csv generates
+type csvPoint struct {
+	name  string
+	score Option[int]
+}
+
+func (csvPoint) CSVHeader() []string { return []string{"name", "score"} }
+
+func (p csvPoint) MarshalCSVRow() []string {
+	row := []string{p.name, ""}
+	if p.score.IsSome() {
+		row[1] = strconv.Itoa(p.score.Unwrap())
+	}
+	return row
+}
+
+func (csvPoint) CSVColumns(header []string) ([]int, error) {
+	cols := []int{-1, -1}
+	for i, name := range header {
+		switch name {
+		case "name":
+			cols[0] = i
+		case "score":
+			cols[1] = i
+		}
+	}
+	if cols[0] < 0 {
+		return nil, errors.New(`missing column "name"`)
+	}
+	return cols, nil
+}
+
+func (p *csvPoint) UnmarshalCSVColumns(cols []int, row []string) error {
+	p.name, p.score = row[cols[0]], None[int]()
+	if i := cols[1]; i >= 0 && row[i] != "" {
+		n, err := strconv.Atoi(row[i])
+		if err != nil {
+			return err
+		}
+		p.score = Some(n)
+	}
+	return nil
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	points := []csvPoint{{name: "a", score: Some(1)}, {name: "b", score: None[int]()}}
+	var out strings.Builder
+	if err := NewCSVWriter[csvPoint](&out, '\t').WriteAll(points); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "name\tscore\na\t1\nb\t\n" {
+		t.Fatalf("Unexpected TSV %q", got)
+	}
+
+	read, err := NewCSVReader[csvPoint](strings.NewReader(out.String()), '\t').ReadAll()
+	if err != nil || len(read) != 2 || read[0].score.Unwrap() != 1 || read[1].score.IsSome() {
+		t.Errorf("Unexpected round trip %v, %v", read, err)
+	}
+}
+
+func TestCSVReaderHeader(t *testing.T) {
+	r := NewCSVReader[csvPoint](strings.NewReader("score,extra,name\n7,x,c\n"), ',')
+	if p, err := r.Read(); err != nil || p.name != "c" || p.score.Unwrap() != 7 {
+		t.Errorf("Columns should be matched by name, got %v, %v", p, err)
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected EOF, got %v", err)
+	}
+
+	missing := NewCSVReader[csvPoint](strings.NewReader("score\n1\n"), ',')
+	if _, err := missing.Read(); err == nil || !strings.Contains(err.Error(), `missing column "name"`) {
+		t.Errorf("Expected a missing column error, got %v", err)
+	}
+	if _, err := NewCSVReader[csvPoint](strings.NewReader(""), ',').Read(); err == nil {
+		t.Error("An empty input should miss its header")
+	}
+
+	bad := NewCSVReader[csvPoint](strings.NewReader("name,score\na,1\nb,x\n"), ',')
+	_, err := bad.ReadAll()
+	if err == nil || !strings.Contains(err.Error(), "csv line 3") {
+		t.Errorf("Expected the line of the bad row, got %v", err)
+	}
+}
+
+func TestCSVObservable(t *testing.T) {
+	r := NewCSVReader[csvPoint](strings.NewReader("name\na\nb\nc\n"), ',')
+	var out strings.Builder
+	w := NewCSVWriter[csvPoint](&out, ',')
+	if err := w.WriteObservable(context.Background(), r.Observable()); err != nil {
+		t.Fatal(err)
+	}
+	if got := out.String(); got != "name,score\na,\nb,\nc,\n" {
+		t.Errorf("Unexpected stream copy %q", got)
+	}
+
+	failing := Observable[csvPoint](func(ctx context.Context, next func(csvPoint)) error {
+		next(csvPoint{name: "a"})
+		return errors.New("source failed")
+	})
+	out.Reset()
+	if err := NewCSVWriter[csvPoint](&out, ',').WriteObservable(context.Background(), failing); err == nil || out.String() != "name,score\na,\n" {
+		t.Errorf("Expected the rows before the failure and its error, got %q, %v", out.String(), err)
+	}
+}
@@ -21,6 +21,15 @@ func Right[L, R any](right R) Either[L, R] {
 	return Either[L, R]{left: zeroLeft, right: right, isRight: true}
 }
 
+// EitherOf builds an Either from both candidate values and a tag selecting one, e.g. a decoded union
+// whose discriminator tells which field is set; the unselected value is dropped
+func EitherOf[L, R any](left L, right R, isRight bool) Either[L, R] {
+	if isRight {
+		return Right[L](right)
+	}
+	return Left[L, R](left)
+}
+
 // IsLeft returns true if this Either contains a Left value
 func (e Either[L, R]) IsLeft() bool {
 	return !e.isRight
@@ -36,20 +45,14 @@ func (e Either[L, R]) Unwrap() (L, R, bool) {
 	return e.left, e.right, e.isRight
 }
 
-// UnwrapLeft returns the Left value if present, panics otherwise
+// UnwrapLeft returns the Left value if present, panics otherwise (subject to the package PanicPolicy)
 func (e Either[L, R]) UnwrapLeft() L {
-	if e.isRight {
-		panic("called UnwrapLeft on Right value")
-	}
-	return e.left
+	return e.UnwrapLeftWith(currentPanicPolicy())
 }
 
-// UnwrapRight returns the Right value if present, panics otherwise
+// UnwrapRight returns the Right value if present, panics otherwise (subject to the package PanicPolicy)
 func (e Either[L, R]) UnwrapRight() R {
-	if !e.isRight {
-		panic("called UnwrapRight on Left value")
-	}
-	return e.right
+	return e.UnwrapRightWith(currentPanicPolicy())
 }
 
 // UnwrapLeftOr returns the Left value if present, otherwise returns the default
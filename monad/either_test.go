@@ -309,4 +309,16 @@ func TestEitherAliases(t *testing.T) {
 	if !right.IsRight() {
 		t.Error("R should create Right")
 	}
-}
\ No newline at end of file
+}
+
+func TestEitherOf(t *testing.T) {
+	right := EitherOf("unused", 42, true)
+	if !right.IsRight() || right.UnwrapRight() != 42 {
+		t.Errorf("Expected Right(42), got %v", right)
+	}
+
+	left := EitherOf("bad input", 0, false)
+	if !left.IsLeft() || left.UnwrapLeft() != "bad input" {
+		t.Errorf("Expected Left(bad input), got %v", left)
+	}
+}
@@ -0,0 +1,144 @@
+package monad
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors aggregates several failures into a single error
+// It implements Unwrap() []error, so errors.Is and errors.As inspect every cause
+type Errors []error
+
+// Error joins the messages of all aggregated errors
+func (e Errors) Error() string {
+	switch len(e) {
+	case 0:
+		return "no errors"
+	case 1:
+		return e[0].Error()
+	}
+
+	var b strings.Builder
+	b.WriteString(strconv.Itoa(len(e)))
+	b.WriteString(" errors: ")
+	for i, err := range e {
+		if i > 0 {
+			b.WriteString("; ")
+		}
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the aggregated errors
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// JoinErrors aggregates the non-nil errors into an Errors value
+// Returns nil when every error is nil, so the result can be compared against nil safely
+func JoinErrors(errs ...error) error {
+	var collected Errors
+	for _, err := range errs {
+		if err != nil {
+			collected = append(collected, err)
+		}
+	}
+	if len(collected) == 0 {
+		return nil
+	}
+	return collected
+}
+
+// PanicError fails the Futures a panicking callback would otherwise leave pending forever, such as the callers
+// sharing a Cache load; the panic itself still propagates in the goroutine that ran the callback
+type PanicError struct {
+	Value any // the value passed to panic
+}
+
+// Error reports the panic value
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("monad: panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value when it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// CollectErrors returns all values when every Result is Ok
+// Otherwise returns an Err holding every failure as Errors, in input order
+func CollectErrors[T any](results []Result[T]) Result[[]T] {
+	values := make([]T, len(results))
+	var errs Errors
+	for i, result := range results {
+		val, err := result.Unwrap()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		values[i] = val
+	}
+	if len(errs) > 0 {
+		return Err[[]T](errs)
+	}
+	return Ok(values)
+}
+
+// SequenceTasksAll executes Tasks sequentially, continuing past failures
+// Reports every failure as Errors instead of only the first
+func SequenceTasksAll[T any](tasks []Task[T]) Task[[]T] {
+	return func(ctx context.Context) Result[[]T] {
+		results := make([]Result[T], len(tasks))
+		for i, task := range tasks {
+			if err := ctx.Err(); err != nil {
+				results[i] = Err[T](err)
+				continue
+			}
+			results[i] = task(ctx)
+		}
+		return CollectErrors(results)
+	}
+}
+
+// ParallelTasksAll executes Tasks in parallel and waits for all of them
+// Unlike ParallelTasks a failure does not cancel the siblings, and every failure is reported as Errors
+func ParallelTasksAll[T any](tasks []Task[T]) Task[[]T] {
+	return func(ctx context.Context) Result[[]T] {
+		futures := make([]*Future[T], len(tasks))
+		for i, task := range tasks {
+			futures[i] = task.Run(ctx)
+		}
+
+		results := make([]Result[T], len(tasks))
+		for i, future := range futures {
+			results[i] = future.Await()
+		}
+		return CollectErrors(results)
+	}
+}
+
+// SequenceFuturesAll waits for every Future and collects their values
+// Reports every failure as Errors instead of only the first
+func SequenceFuturesAll[T any](futures []*Future[T]) *Future[[]T] {
+	resultFuture := NewFuture[[]T]()
+	results := make([]Result[T], len(futures))
+
+	var step func(i int)
+	step = func(i int) {
+		if i == len(futures) {
+			resultFuture.complete(CollectErrors(results))
+			return
+		}
+		futures[i].onComplete(func(result Result[T]) {
+			results[i] = result
+			step(i + 1)
+		})
+	}
+	step(0)
+
+	return resultFuture
+}
@@ -0,0 +1,120 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestJoinErrors(t *testing.T) {
+	if JoinErrors() != nil || JoinErrors(nil, nil) != nil {
+		t.Error("JoinErrors without failures should be nil")
+	}
+
+	errA := errors.New("a")
+	errB := errors.New("b")
+	err := JoinErrors(errA, nil, errB)
+	if err == nil {
+		t.Fatal("Expected an error")
+	}
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Error("errors.Is should see every aggregated error")
+	}
+	if err.Error() != "2 errors: a; b" {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Errorf("Expected Errors with 2 entries, got %v", errs)
+	}
+}
+
+func TestErrorsSingleMessage(t *testing.T) {
+	if msg := (Errors{errors.New("only")}).Error(); msg != "only" {
+		t.Errorf("Expected only, got %q", msg)
+	}
+}
+
+func TestPanicError(t *testing.T) {
+	cause := errors.New("cause")
+	err := error(&PanicError{Value: cause})
+	if !errors.Is(err, cause) || err.Error() != "monad: panic: cause" {
+		t.Errorf("Unexpected PanicError %q", err)
+	}
+	if errors.Unwrap(&PanicError{Value: 42}) != nil {
+		t.Error("A non-error panic value should not unwrap")
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	values, err := CollectErrors([]Result[int]{Ok(1), Ok(2)}).Unwrap()
+	if err != nil || len(values) != 2 || values[1] != 2 {
+		t.Errorf("Expected [1 2], got %v (%v)", values, err)
+	}
+
+	errA := errors.New("a")
+	errB := errors.New("b")
+	_, err = CollectErrors([]Result[int]{Err[int](errA), Ok(2), Err[int](errB)}).Unwrap()
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0] != errA || errs[1] != errB {
+		t.Errorf("Expected both failures in order, got %v", err)
+	}
+}
+
+func TestSequenceTasksAll(t *testing.T) {
+	errA := errors.New("a")
+	ran := 0
+	tasks := []Task[int]{
+		NewTaskFromError[int](errA),
+		func(ctx context.Context) Result[int] { ran++; return Ok(2) },
+	}
+
+	_, err := SequenceTasksAll(tasks)(context.Background()).Unwrap()
+	if !errors.Is(err, errA) {
+		t.Errorf("Expected a, got %v", err)
+	}
+	if ran != 1 {
+		t.Error("SequenceTasksAll should continue past failures")
+	}
+}
+
+func TestParallelTasksAll(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	tasks := []Task[int]{
+		NewTaskFromError[int](errA),
+		NewTaskFromValue(2),
+		NewTaskFromError[int](errB),
+	}
+
+	_, err := ParallelTasksAll(tasks)(context.Background()).Unwrap()
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Expected 2 failures, got %v", err)
+	}
+	if errs[0] != errA || errs[1] != errB {
+		t.Errorf("Expected failures in input order, got %v", errs)
+	}
+
+	values, err := ParallelTasksAll([]Task[int]{NewTaskFromValue(1), NewTaskFromValue(2)})(context.Background()).Unwrap()
+	if err != nil || values[0] != 1 || values[1] != 2 {
+		t.Errorf("Expected [1 2], got %v (%v)", values, err)
+	}
+}
+
+func TestSequenceFuturesAll(t *testing.T) {
+	errA := errors.New("a")
+	futures := []*Future[int]{FailedFuture[int](errA), CompletedFuture(2), FailedFuture[int](errA)}
+
+	_, err := SequenceFuturesAll(futures).Await().Unwrap()
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Errorf("Expected 2 failures, got %v", err)
+	}
+
+	values, err := SequenceFuturesAll([]*Future[int]{CompletedFuture(1)}).Await().Unwrap()
+	if err != nil || values[0] != 1 {
+		t.Errorf("Expected [1], got %v (%v)", values, err)
+	}
+}
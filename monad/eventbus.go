@@ -0,0 +1,268 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrTopicClosed is returned when publishing to a closed Topic or EventBus
+var ErrTopicClosed = errors.New("monad: topic closed")
+
+// EventBus is a registry of typed Topics looked up by name
+type EventBus struct {
+	mu     sync.Mutex
+	topics map[string]any
+	closed bool
+}
+
+// NewEventBus creates an empty EventBus
+func NewEventBus() *EventBus {
+	return &EventBus{topics: make(map[string]any)}
+}
+
+// TopicOf returns the Topic registered under name, creating it on first use
+// Fails if the name is already registered with a different event type or the bus is closed
+func TopicOf[T any](bus *EventBus, name string) Result[*Topic[T]] {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if bus.closed {
+		return Err[*Topic[T]](ErrTopicClosed)
+	}
+	if existing, ok := bus.topics[name]; ok {
+		topic, ok := existing.(*Topic[T])
+		if !ok {
+			return Err[*Topic[T]](fmt.Errorf("monad: topic %q registered with event type %T", name, existing))
+		}
+		return Ok(topic)
+	}
+
+	topic := NewTopic[T](name)
+	bus.topics[name] = topic
+	return Ok(topic)
+}
+
+// Close closes every Topic of the bus; later TopicOf calls fail with ErrTopicClosed
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	b.closed = true
+	topics := b.topics
+	b.topics = make(map[string]any)
+	b.mu.Unlock()
+
+	for _, topic := range topics {
+		topic.(interface{ Close() }).Close()
+	}
+}
+
+// Topic is a typed pub/sub channel
+// Subscribers are kept in a copy-on-write slice like Reactive, and each subscriber receives events
+// asynchronously on the package Executor, in publish order
+type Topic[T any] struct {
+	name        string
+	mu          sync.Mutex
+	subscribers atomic.Pointer[[]*Subscription[T]]
+	closed      bool
+}
+
+// NewTopic creates a standalone Topic that is not registered with any EventBus
+func NewTopic[T any](name string) *Topic[T] {
+	return &Topic[T]{name: name}
+}
+
+// Name returns the name of the Topic
+func (t *Topic[T]) Name() string {
+	return t.name
+}
+
+// Publish delivers event to every current subscriber without waiting for the handlers
+func (t *Topic[T]) Publish(event T) Result[Unit] {
+	t.mu.Lock()
+	closed := t.closed
+	t.mu.Unlock()
+	if closed {
+		return Err[Unit](ErrTopicClosed)
+	}
+
+	if subs := t.subscribers.Load(); subs != nil {
+		for _, sub := range *subs {
+			sub.enqueue(event)
+		}
+	}
+	return OkUnit()
+}
+
+// Subscribe registers handler for every event published until ctx is cancelled or the Subscription is cancelled
+func (t *Topic[T]) Subscribe(ctx context.Context, handler func(T)) *Subscription[T] {
+	sub := &Subscription[T]{topic: t, handler: handler, done: make(chan struct{})}
+
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+		sub.Cancel()
+		return sub
+	}
+	current := t.snapshot()
+	next := make([]*Subscription[T], len(current), len(current)+1)
+	copy(next, current)
+	next = append(next, sub)
+	t.subscribers.Store(&next)
+	t.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() { sub.Cancel() })
+	sub.mu.Lock()
+	sub.stop = stop
+	sub.mu.Unlock()
+	return sub
+}
+
+// Observable bridges the Topic to an Observable emitting published events until ctx is cancelled or the Topic closes
+func (t *Topic[T]) Observable() Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		sub := t.Subscribe(ctx, next)
+		<-sub.Done()
+		return ctx.Err()
+	}
+}
+
+// PublishAll subscribes to source and publishes every value it emits
+// The returned Future completes when the source ends, failing if the Topic is closed first
+func (t *Topic[T]) PublishAll(ctx context.Context, source Observable[T]) *Future[Unit] {
+	ctx, cancel := context.WithCancelCause(ctx)
+	future := NewFuture[Unit]()
+	Spawn(func() {
+		defer cancel(nil)
+		err := source(ctx, func(v T) {
+			if _, err := t.Publish(v).Unwrap(); err != nil {
+				cancel(err)
+			}
+		})
+		if cause := context.Cause(ctx); cause != nil && cause != context.Canceled {
+			err = cause
+		}
+		future.complete(UnitFrom(err))
+	})
+	return future
+}
+
+// Subscribers returns the number of active subscriptions
+func (t *Topic[T]) Subscribers() int {
+	return len(t.snapshot())
+}
+
+// Close cancels every subscription; later Publish calls fail with ErrTopicClosed
+func (t *Topic[T]) Close() {
+	t.mu.Lock()
+	t.closed = true
+	subs := t.snapshot()
+	t.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Cancel()
+	}
+}
+
+// snapshot returns the current immutable subscriber slice
+func (t *Topic[T]) snapshot() []*Subscription[T] {
+	if subs := t.subscribers.Load(); subs != nil {
+		return *subs
+	}
+	return nil
+}
+
+// remove drops sub from the subscriber slice
+func (t *Topic[T]) remove(sub *Subscription[T]) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	current := t.snapshot()
+	next := make([]*Subscription[T], 0, len(current))
+	for _, s := range current {
+		if s != sub {
+			next = append(next, s)
+		}
+	}
+	t.subscribers.Store(&next)
+}
+
+// Subscription is a handle to a Topic subscriber
+// Events are queued per subscription and drained by a single task at a time, preserving publish order
+type Subscription[T any] struct {
+	topic     *Topic[T]
+	handler   func(T)
+	stop      func() bool
+	mu        sync.Mutex
+	queue     []T
+	draining  bool
+	cancelled bool
+	done      chan struct{}
+}
+
+// Cancel stops delivery to this subscription; events already being handled finish first
+func (s *Subscription[T]) Cancel() {
+	s.mu.Lock()
+	if s.cancelled {
+		s.mu.Unlock()
+		return
+	}
+	s.cancelled = true
+	s.queue = nil
+	draining := s.draining
+	stop := s.stop
+	s.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+	s.topic.remove(s)
+	if !draining {
+		close(s.done)
+	}
+}
+
+// Done returns a channel closed once the subscription is cancelled and no handler is running
+func (s *Subscription[T]) Done() <-chan struct{} {
+	return s.done
+}
+
+// enqueue queues event and starts a drain task if none is running
+func (s *Subscription[T]) enqueue(event T) {
+	s.mu.Lock()
+	if s.cancelled {
+		s.mu.Unlock()
+		return
+	}
+	s.queue = append(s.queue, event)
+	if s.draining {
+		s.mu.Unlock()
+		return
+	}
+	s.draining = true
+	s.mu.Unlock()
+
+	Spawn(s.drain)
+}
+
+// drain delivers queued events until the queue is empty or the subscription is cancelled
+func (s *Subscription[T]) drain() {
+	for {
+		s.mu.Lock()
+		if s.cancelled || len(s.queue) == 0 {
+			s.draining = false
+			cancelled := s.cancelled
+			s.mu.Unlock()
+			if cancelled {
+				close(s.done)
+			}
+			return
+		}
+		event := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		s.handler(event)
+	}
+}
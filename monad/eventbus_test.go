@@ -0,0 +1,124 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBusTopicOf(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+
+	first, err := TopicOf[string](bus, "users").Unwrap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	second, _ := TopicOf[string](bus, "users").Unwrap()
+	if first != second {
+		t.Error("TopicOf should return the registered topic")
+	}
+
+	if _, err := TopicOf[int](bus, "users").Unwrap(); err == nil {
+		t.Error("TopicOf with a different event type should fail")
+	}
+}
+
+func TestTopicPublishOrder(t *testing.T) {
+	topic := NewTopic[int]("numbers")
+
+	var mu sync.Mutex
+	var got []int
+	var wg sync.WaitGroup
+	wg.Add(100)
+	topic.Subscribe(context.Background(), func(v int) {
+		mu.Lock()
+		got = append(got, v)
+		mu.Unlock()
+		wg.Done()
+	})
+
+	for i := 0; i < 100; i++ {
+		if !topic.Publish(i).IsOk() {
+			t.Fatal("Publish should succeed")
+		}
+	}
+	wg.Wait()
+
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("Expected events in publish order, got %v", got)
+		}
+	}
+}
+
+func TestTopicSubscriptionContext(t *testing.T) {
+	topic := NewTopic[int]("numbers")
+	ctx, cancel := context.WithCancel(context.Background())
+	sub := topic.Subscribe(ctx, func(int) {})
+
+	if topic.Subscribers() != 1 {
+		t.Fatal("Expected one subscriber")
+	}
+
+	cancel()
+	select {
+	case <-sub.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Cancelling the context should end the subscription")
+	}
+	if topic.Subscribers() != 0 {
+		t.Error("Cancelled subscription should be removed")
+	}
+}
+
+func TestTopicClose(t *testing.T) {
+	bus := NewEventBus()
+	topic, _ := TopicOf[int](bus, "numbers").Unwrap()
+	sub := topic.Subscribe(context.Background(), func(int) {})
+
+	bus.Close()
+
+	<-sub.Done()
+	if _, err := topic.Publish(1).Unwrap(); !errors.Is(err, ErrTopicClosed) {
+		t.Errorf("Expected ErrTopicClosed, got %v", err)
+	}
+	if _, err := TopicOf[int](bus, "numbers").Unwrap(); !errors.Is(err, ErrTopicClosed) {
+		t.Errorf("Expected ErrTopicClosed, got %v", err)
+	}
+}
+
+func TestTopicObservableBridge(t *testing.T) {
+	source := NewTopic[int]("source")
+	sink := NewTopic[int]("sink")
+
+	received := make(chan int, 3)
+	sink.Subscribe(context.Background(), func(v int) { received <- v })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	forwarding := sink.PublishAll(ctx, source.Observable())
+
+	for source.Subscribers() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	source.Publish(1)
+	source.Publish(2)
+
+	for _, want := range []int{1, 2} {
+		select {
+		case v := <-received:
+			if v != want {
+				t.Errorf("Expected %d, got %d", want, v)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for bridged event")
+		}
+	}
+
+	cancel()
+	if _, err := forwarding.AwaitWithTimeout(time.Second).Unwrap(); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package monad
+
+import "sync"
+
+// Executor runs functions asynchronously on behalf of Futures, Tasks and Reactives
+type Executor interface {
+	Go(fn func())
+}
+
+// goroutineExecutor is the default Executor, running every function in its own goroutine
+type goroutineExecutor struct{}
+
+func (goroutineExecutor) Go(fn func()) { go fn() }
+
+var (
+	executorMu sync.RWMutex
+	executor   Executor = goroutineExecutor{}
+)
+
+// SetExecutor replaces the Executor used by the package and returns a function restoring the previous one
+// Passing nil restores the default goroutine-per-call executor
+func SetExecutor(e Executor) (restore func()) {
+	if e == nil {
+		e = goroutineExecutor{}
+	}
+	executorMu.Lock()
+	prev := executor
+	executor = e
+	executorMu.Unlock()
+
+	return func() {
+		executorMu.Lock()
+		executor = prev
+		executorMu.Unlock()
+	}
+}
+
+// Spawn runs fn asynchronously using the package Executor
+// Generated code uses Spawn so that it honours the configured Executor as well
+func Spawn(fn func()) {
+	executorMu.RLock()
+	e := executor
+	executorMu.RUnlock()
+	e.Go(fn)
+}
@@ -0,0 +1,66 @@
+package monad
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// countingExecutor runs functions inline and counts them
+type countingExecutor struct {
+	calls int64
+}
+
+func (e *countingExecutor) Go(fn func()) {
+	atomic.AddInt64(&e.calls, 1)
+	fn()
+}
+
+// recoveringExecutor runs functions in goroutines and reports their panics instead of crashing the test
+type recoveringExecutor struct {
+	panics chan any
+}
+
+func newRecoveringExecutor() *recoveringExecutor {
+	return &recoveringExecutor{panics: make(chan any, 16)}
+}
+
+func (e *recoveringExecutor) Go(fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				e.panics <- r
+			}
+		}()
+		fn()
+	}()
+}
+
+func TestSetExecutor(t *testing.T) {
+	exec := &countingExecutor{}
+	restore := SetExecutor(exec)
+
+	future := RunAsync(func() Result[int] { return Ok(7) })
+	// The inline executor completes the future before RunAsync returns
+	if !future.IsDone() {
+		t.Error("Future should be done with an inline executor")
+	}
+	if atomic.LoadInt64(&exec.calls) != 1 {
+		t.Errorf("Expected 1 executor call, got %d", exec.calls)
+	}
+
+	restore()
+
+	Spawn(func() {})
+	if atomic.LoadInt64(&exec.calls) != 1 {
+		t.Error("Restored executor should no longer be used")
+	}
+}
+
+func TestSetExecutorNil(t *testing.T) {
+	restore := SetExecutor(nil)
+	defer restore()
+
+	done := make(chan struct{})
+	Spawn(func() { close(done) })
+	<-done
+}
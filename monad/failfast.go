@@ -0,0 +1,70 @@
+package monad
+
+import (
+	"context"
+	"sync"
+)
+
+// FailureMode selects how combinators over several inputs react when one of them fails
+type FailureMode int
+
+const (
+	// InOrder reports the first failure in input order, waiting for earlier inputs to complete first
+	InOrder FailureMode = iota
+	// FailFast reports the first failure as soon as it happens and cancels the inputs still pending,
+	// which cancels the context of work started by Task.Run or RunAsyncWithContext
+	FailFast
+)
+
+// SequenceFuturesCtx collects the values of futures like SequenceFutures, but also fails with ctx.Err()
+// once ctx is done; with FailFast the pending inputs are cancelled whenever the result fails
+func SequenceFuturesCtx[T any](ctx context.Context, futures []*Future[T], mode FailureMode) *Future[[]T] {
+	resultFuture := NewFuture[[]T]()
+	defer stopOnDone(ctx, resultFuture)
+	if mode != FailFast {
+		SequenceFutures(futures).onComplete(resultFuture.complete)
+		return resultFuture
+	}
+
+	resultFuture.onComplete(func(result Result[[]T]) {
+		if !result.IsOk() {
+			for _, future := range futures {
+				future.Cancel()
+			}
+		}
+	})
+
+	results := make([]T, len(futures))
+	if len(futures) == 0 {
+		resultFuture.Complete(results)
+		return resultFuture
+	}
+	var mu sync.Mutex
+	remaining := len(futures)
+	for i, future := range futures {
+		future.onComplete(func(result Result[T]) {
+			val, err := result.Unwrap()
+			if err != nil {
+				resultFuture.finish(Err[[]T](err), false)
+				return
+			}
+			mu.Lock()
+			results[i] = val
+			remaining--
+			done := remaining == 0
+			mu.Unlock()
+			if done {
+				resultFuture.finish(Ok(results), false)
+			}
+		})
+	}
+	return resultFuture
+}
+
+// stopOnDone fails future with ctx.Err() if ctx is done before the future completes
+func stopOnDone[T any](ctx context.Context, future *Future[T]) {
+	stop := context.AfterFunc(ctx, func() {
+		future.finish(Err[T](ctx.Err()), false)
+	})
+	future.onComplete(func(Result[T]) { stop() })
+}
@@ -0,0 +1,87 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSequenceFuturesCtxFailFast(t *testing.T) {
+	boom := errors.New("boom")
+	slowCancelled := make(chan struct{})
+	slow := RunAsyncWithContext(context.Background(), func(ctx context.Context) Result[int] {
+		<-ctx.Done()
+		close(slowCancelled)
+		return Err[int](ctx.Err())
+	})
+	failing := FailedFuture[int](boom)
+
+	_, err := SequenceFuturesCtx(context.Background(), []*Future[int]{slow, failing}, FailFast).Await().Unwrap()
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected the failure of the second input, got %v", err)
+	}
+	if !slow.IsCancelled() {
+		t.Error("The pending input should be cancelled")
+	}
+	select {
+	case <-slowCancelled:
+	case <-time.After(time.Second):
+		t.Error("Cancelling the pending input should cancel its context")
+	}
+}
+
+func TestSequenceFuturesCtxInOrder(t *testing.T) {
+	boom := errors.New("boom")
+	first := NewFuture[int]()
+	result := SequenceFuturesCtx(context.Background(), []*Future[int]{first, FailedFuture[int](boom)}, InOrder)
+
+	if _, done := result.Poll(); done {
+		t.Error("InOrder should wait for earlier inputs before reporting a later failure")
+	}
+	first.Complete(1)
+	if _, err := result.Await().Unwrap(); !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+	if first.IsCancelled() {
+		t.Error("InOrder should not cancel inputs")
+	}
+
+	values, err := SequenceFuturesCtx(context.Background(), []*Future[int]{CompletedFuture(1), CompletedFuture(2)}, InOrder).Await().Unwrap()
+	if err != nil || len(values) != 2 || values[1] != 2 {
+		t.Errorf("Expected [1 2], got %v, %v", values, err)
+	}
+}
+
+func TestSequenceFuturesCtxCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pending := NewFuture[int]()
+	result := SequenceFuturesCtx(ctx, []*Future[int]{pending}, FailFast)
+
+	cancel()
+	if _, err := result.Await().Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !pending.IsCancelled() {
+		t.Error("FailFast should cancel pending inputs when ctx is done")
+	}
+}
+
+func TestParallelTasksFailFast(t *testing.T) {
+	boom := errors.New("boom")
+	blocked := func(ctx context.Context) Result[int] {
+		<-ctx.Done()
+		return Err[int](ctx.Err())
+	}
+	failing := NewTaskFromError[int](boom)
+
+	_, err := ParallelTasks([]Task[int]{blocked, failing}, FailFast)(context.Background()).Unwrap()
+	if !errors.Is(err, boom) {
+		t.Errorf("Expected boom, got %v", err)
+	}
+
+	values, err := ParallelTasks([]Task[int]{NewTaskFromValue(1), NewTaskFromValue(2)}, FailFast)(context.Background()).Unwrap()
+	if err != nil || len(values) != 2 || values[0] != 1 {
+		t.Errorf("Expected [1 2], got %v, %v", values, err)
+	}
+}
@@ -0,0 +1,84 @@
+package monad
+
+// Identity returns its argument unchanged
+func Identity[T any](v T) T { return v }
+
+// Const returns a function that ignores its argument and always returns v
+func Const[T any, U any](v T) func(U) T {
+	return func(U) T { return v }
+}
+
+// Compose2 composes two functions left to right: Compose2(f, g)(x) == g(f(x))
+func Compose2[A, B, C any](f func(A) B, g func(B) C) func(A) C {
+	return func(a A) C { return g(f(a)) }
+}
+
+// Compose3 composes three functions left to right
+func Compose3[A, B, C, D any](f func(A) B, g func(B) C, h func(C) D) func(A) D {
+	return func(a A) D { return h(g(f(a))) }
+}
+
+// Compose4 composes four functions left to right
+func Compose4[A, B, C, D, E any](f func(A) B, g func(B) C, h func(C) D, i func(D) E) func(A) E {
+	return func(a A) E { return i(h(g(f(a)))) }
+}
+
+// Compose5 composes five functions left to right
+func Compose5[A, B, C, D, E, F any](f func(A) B, g func(B) C, h func(C) D, i func(D) E, j func(E) F) func(A) F {
+	return func(a A) F { return j(i(h(g(f(a))))) }
+}
+
+// Pipe threads v through functions of the same type, left to right
+func Pipe[T any](v T, fs ...func(T) T) T {
+	for _, f := range fs {
+		v = f(v)
+	}
+	return v
+}
+
+// Pipe2 threads v through two functions, inferring the types of each step
+func Pipe2[A, B, C any](v A, f func(A) B, g func(B) C) C {
+	return g(f(v))
+}
+
+// Pipe3 threads v through three functions, inferring the types of each step
+func Pipe3[A, B, C, D any](v A, f func(A) B, g func(B) C, h func(C) D) D {
+	return h(g(f(v)))
+}
+
+// Pipe4 threads v through four functions, inferring the types of each step
+func Pipe4[A, B, C, D, E any](v A, f func(A) B, g func(B) C, h func(C) D, i func(D) E) E {
+	return i(h(g(f(v))))
+}
+
+// Pipe5 threads v through five functions, inferring the types of each step
+func Pipe5[A, B, C, D, E, F any](v A, f func(A) B, g func(B) C, h func(C) D, i func(D) E, j func(E) F) F {
+	return j(i(h(g(f(v)))))
+}
+
+// Curry2 turns a two-argument function into a chain of single-argument functions
+// It is the runtime counterpart of the //gofn:curried directive
+func Curry2[A, B, C any](f func(A, B) C) func(A) func(B) C {
+	return func(a A) func(B) C {
+		return func(b B) C { return f(a, b) }
+	}
+}
+
+// Curry3 turns a three-argument function into a chain of single-argument functions
+func Curry3[A, B, C, D any](f func(A, B, C) D) func(A) func(B) func(C) D {
+	return func(a A) func(B) func(C) D {
+		return func(b B) func(C) D {
+			return func(c C) D { return f(a, b, c) }
+		}
+	}
+}
+
+// Uncurry2 turns a curried two-argument function back into a regular one
+func Uncurry2[A, B, C any](f func(A) func(B) C) func(A, B) C {
+	return func(a A, b B) C { return f(a)(b) }
+}
+
+// Uncurry3 turns a curried three-argument function back into a regular one
+func Uncurry3[A, B, C, D any](f func(A) func(B) func(C) D) func(A, B, C) D {
+	return func(a A, b B, c C) D { return f(a)(b)(c) }
+}
@@ -0,0 +1,84 @@
+package monad
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIdentityAndConst(t *testing.T) {
+	if Identity(5) != 5 {
+		t.Error("Identity should return its argument")
+	}
+
+	always := Const[string, int]("x")
+	if always(1) != "x" || always(2) != "x" {
+		t.Error("Const should ignore its argument")
+	}
+}
+
+func TestCompose(t *testing.T) {
+	double := func(x int) int { return x * 2 }
+	toString := strconv.Itoa
+	length := func(s string) int { return len(s) }
+	isEven := func(x int) bool { return x%2 == 0 }
+	negate := func(b bool) bool { return !b }
+
+	if Compose2(double, toString)(21) != "42" {
+		t.Error("Compose2 should apply functions left to right")
+	}
+	if Compose3(double, toString, length)(50) != 3 {
+		t.Error("Compose3 should apply functions left to right")
+	}
+	if !Compose4(double, toString, length, isEven)(5) {
+		t.Error("Compose4 should apply functions left to right")
+	}
+	if Compose5(double, toString, length, isEven, negate)(5) {
+		t.Error("Compose5 should apply functions left to right")
+	}
+}
+
+func TestPipe(t *testing.T) {
+	inc := func(x int) int { return x + 1 }
+	if Pipe(1, inc, inc, inc) != 4 {
+		t.Error("Pipe should apply every function")
+	}
+	if Pipe(7) != 7 {
+		t.Error("Pipe without functions should return the value")
+	}
+
+	result := Pipe3("  Hello ", strings.TrimSpace, strings.ToUpper, func(s string) int { return len(s) })
+	if result != 5 {
+		t.Errorf("Expected 5, got %d", result)
+	}
+	if Pipe2(3, inc, strconv.Itoa) != "4" {
+		t.Error("Pipe2 should infer step types")
+	}
+	if Pipe4(1, inc, inc, strconv.Itoa, strings.NewReader).Len() != 1 {
+		t.Error("Pipe4 should infer step types")
+	}
+	if Pipe5(1, inc, inc, inc, inc, strconv.Itoa) != "5" {
+		t.Error("Pipe5 should infer step types")
+	}
+}
+
+func TestCurryUncurry(t *testing.T) {
+	add := func(a, b int) int { return a + b }
+	curried := Curry2(add)
+	addFive := curried(5)
+	if addFive(3) != 8 {
+		t.Error("Curry2 should partially apply the first argument")
+	}
+	if Uncurry2(curried)(2, 2) != 4 {
+		t.Error("Uncurry2 should restore the original function")
+	}
+
+	join := func(a, b, c string) string { return a + b + c }
+	curried3 := Curry3(join)
+	if curried3("a")("b")("c") != "abc" {
+		t.Error("Curry3 should apply arguments in order")
+	}
+	if Uncurry3(curried3)("x", "y", "z") != "xyz" {
+		t.Error("Uncurry3 should restore the original function")
+	}
+}
@@ -0,0 +1,116 @@
+package monad
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// Format renders a Result as Ok(value) or Err(message)
+// The verb and flags apply to the inner value, so %x or %.2f work as on T itself;
+// %+v quotes strings and appends the error chain's types, %#v prints Go syntax
+func (r Result[T]) Format(f fmt.State, verb rune) {
+	if r.err != nil {
+		writeErr(f, verb, r.err)
+		return
+	}
+	writeWrapped(f, verb, "Ok", r.val)
+}
+
+// LogValue renders a Result for slog the way %+v does
+func (r Result[T]) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprintf("%+v", r))
+}
+
+// Format renders an Option as Some(value), None or Wildcard
+// The verb and flags apply to the inner value; %+v quotes strings, %#v prints Go syntax
+func (o Option[T]) Format(f fmt.State, verb rune) {
+	switch o.state {
+	case optionSome:
+		writeWrapped(f, verb, "Some", o.value)
+	case optionWildcard:
+		writeBare(f, verb, "Wildcard")
+	default:
+		writeBare(f, verb, "None")
+	}
+}
+
+// LogValue renders an Option for slog the way %+v does
+func (o Option[T]) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprintf("%+v", o))
+}
+
+// Format renders an Either as Left(value) or Right(value)
+// The verb and flags apply to the inner value; %+v quotes strings, %#v prints Go syntax
+func (e Either[L, R]) Format(f fmt.State, verb rune) {
+	if e.isRight {
+		writeWrapped(f, verb, "Right", e.right)
+		return
+	}
+	writeWrapped(f, verb, "Left", e.left)
+}
+
+// LogValue renders an Either for slog the way %+v does
+func (e Either[L, R]) LogValue() slog.Value {
+	return slog.StringValue(fmt.Sprintf("%+v", e))
+}
+
+// writeWrapped writes name(value), formatting value with the caller's verb and flags
+func writeWrapped(f fmt.State, verb rune, name string, value any) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "monad.%s(%#v)", name, value)
+		return
+	}
+	if s, ok := value.(string); ok && verb == 'v' && f.Flag('+') {
+		fmt.Fprintf(f, "%s(%q)", name, s)
+		return
+	}
+	fmt.Fprintf(f, "%s(", name)
+	fmt.Fprintf(f, fmt.FormatString(f, verb), value)
+	fmt.Fprint(f, ")")
+}
+
+// writeBare writes a value-less state name, or its Go syntax under %#v
+func writeBare(f fmt.State, verb rune, name string) {
+	if verb == 'v' && f.Flag('#') {
+		fmt.Fprintf(f, "monad.%s()", name)
+		return
+	}
+	fmt.Fprint(f, name)
+}
+
+// writeErr writes Err(message); %+v appends the types along the error chain,
+// e.g. Err(load: open a.txt: no such file [*fmt.wrapError > *fs.PathError > syscall.Errno])
+func writeErr(f fmt.State, verb rune, err error) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "monad.Err(%#v)", err)
+	case verb == 'v' && f.Flag('+'):
+		fmt.Fprintf(f, "Err(%s [%s])", err.Error(), errorChain(err))
+	default:
+		fmt.Fprintf(f, "Err(%s)", err.Error())
+	}
+}
+
+// errorChain lists the dynamic types of err and everything it wraps, depth first;
+// branches of joined errors are grouped in braces
+func errorChain(err error) string {
+	var b strings.Builder
+	for err != nil {
+		if b.Len() > 0 {
+			b.WriteString(" > ")
+		}
+		fmt.Fprintf(&b, "%T", err)
+		if multi, ok := err.(interface{ Unwrap() []error }); ok {
+			parts := make([]string, 0, len(multi.Unwrap()))
+			for _, e := range multi.Unwrap() {
+				parts = append(parts, errorChain(e))
+			}
+			b.WriteString(" {" + strings.Join(parts, ", ") + "}")
+			break
+		}
+		err = errors.Unwrap(err)
+	}
+	return b.String()
+}
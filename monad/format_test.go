@@ -0,0 +1,101 @@
+package monad
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestResultFormat(t *testing.T) {
+	cases := []struct {
+		format string
+		value  any
+		want   string
+	}{
+		{"%v", Ok(42), "Ok(42)"},
+		{"%d", Ok(42), "Ok(42)"},
+		{"%x", Ok(255), "Ok(ff)"},
+		{"%.2f", Ok(3.14159), "Ok(3.14)"},
+		{"%v", Ok("x"), "Ok(x)"},
+		{"%+v", Ok("x"), `Ok("x")`},
+		{"%#v", Ok("x"), `monad.Ok("x")`},
+		{"%v", Err[int](io.EOF), "Err(EOF)"},
+		{"%s", Err[int](io.EOF), "Err(EOF)"},
+		{"%v", Ok(Some(1)), "Ok(Some(1))"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprintf(c.format, c.value); got != c.want {
+			t.Errorf("Sprintf(%q) = %s, want %s", c.format, got, c.want)
+		}
+	}
+}
+
+func TestResultFormatErrorChain(t *testing.T) {
+	err := fmt.Errorf("load: %w", &fs.PathError{Op: "open", Path: "a.txt", Err: fs.ErrNotExist})
+	got := fmt.Sprintf("%+v", Err[int](err))
+	want := "Err(load: open a.txt: file does not exist [*fmt.wrapError > *fs.PathError > *errors.errorString])"
+	if got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	joined := errors.Join(io.EOF, err)
+	got = fmt.Sprintf("%+v", Err[int](joined))
+	if !strings.Contains(got, "*errors.joinError {*errors.errorString, *fmt.wrapError > *fs.PathError > *errors.errorString}") {
+		t.Errorf("joined chain not rendered: %s", got)
+	}
+}
+
+func TestOptionFormat(t *testing.T) {
+	cases := []struct {
+		format string
+		value  any
+		want   string
+	}{
+		{"%v", Some("x"), "Some(x)"},
+		{"%+v", Some("x"), `Some("x")`},
+		{"%q", Some("x"), `Some("x")`},
+		{"%v", None[int](), "None"},
+		{"%v", Wildcard[int](), "Wildcard"},
+		{"%#v", Some(1), "monad.Some(1)"},
+		{"%#v", None[int](), "monad.None()"},
+	}
+	for _, c := range cases {
+		if got := fmt.Sprintf(c.format, c.value); got != c.want {
+			t.Errorf("Sprintf(%q) = %s, want %s", c.format, got, c.want)
+		}
+	}
+}
+
+func TestEitherFormat(t *testing.T) {
+	if got := fmt.Sprintf("%v", Left[string, int]("bad")); got != "Left(bad)" {
+		t.Errorf("got %s", got)
+	}
+	if got := fmt.Sprintf("%+v", Right[string, int](7)); got != "Right(7)" {
+		t.Errorf("got %s", got)
+	}
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	logger.Info("values", "result", Ok(42), "failed", Err[int](io.EOF), "name", Some("x"), "side", Left[string, int]("l"))
+
+	out := buf.String()
+	for _, want := range []string{"result=Ok(42)", `failed="Err(EOF [*errors.errorString])"`, `name="Some(\"x\")"`, `side="Left(\"l\")"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q missing %s", out, want)
+		}
+	}
+}
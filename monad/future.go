@@ -7,59 +7,130 @@ import (
 )
 
 // Future represents a computation that will complete in the future
-// Uses sync.Cond for efficient waiting instead of channels
+// Uses sync.Cond for efficient waiting, plus a channel closed on completion
+// so context-aware waits can select on it without spawning goroutines
 type Future[T any] struct {
-	mu     *sync.Mutex
-	cond   *sync.Cond
-	done   bool
-	result Result[T]
+	mu        *sync.Mutex
+	cond      *sync.Cond
+	done      bool
+	cancelled bool
+	doneCh    chan struct{}
+	result    Result[T]
+	callbacks []func(Result[T])
+	dbg       futureDebug    // misuse tracking; empty unless built with the gofndebug tag
+	pool      *FuturePool[T] // set for Futures from a FuturePool, which recycles them once released
+	refs      int32          // holders of a pooled Future that have not released it
 }
 
 // NewFuture creates a new Future
 func NewFuture[T any]() *Future[T] {
 	mu := &sync.Mutex{}
 	return &Future[T]{
-		mu:   mu,
-		cond: sync.NewCond(mu),
-		done: false,
+		mu:     mu,
+		cond:   sync.NewCond(mu),
+		done:   false,
+		doneCh: make(chan struct{}),
+		dbg:    newFutureDebug(),
 	}
 }
 
 // complete marks the Future as done with the given result
 func (f *Future[T]) complete(result Result[T]) {
+	f.finish(result, false)
+}
+
+// finish marks the Future as done, returning false if it had already completed
+func (f *Future[T]) finish(result Result[T], cancelled bool) bool {
+	f.dbg.live()
 	f.cond.L.Lock()
-	defer f.cond.L.Unlock()
 	
 	if f.done {
-		return // already completed
+		f.cond.L.Unlock()
+		return false // already completed
 	}
 	
 	f.result = result
 	f.done = true
+	f.cancelled = cancelled
+	close(f.doneCh)
+	callbacks := f.callbacks
+	f.callbacks = nil
 	f.cond.Broadcast() // wake up all waiting goroutines
+	recycle := f.pool != nil && f.refs == 0 // every holder released it while pending
+	f.cond.L.Unlock()
+	f.dbg.completed()
+	
+	// Run continuations outside of lock so they may touch the Future again
+	for _, cb := range callbacks {
+		cb(result)
+	}
+	if recycle {
+		f.pool.put(f)
+	}
+	return true
+}
+
+// onComplete registers cb to be called with the result once the Future completes
+// cb runs synchronously in the completing goroutine, or immediately if already done,
+// so it must not block; continuations running user code should hand off via Spawn
+func (f *Future[T]) onComplete(cb func(Result[T])) {
+	f.dbg.live()
+	f.cond.L.Lock()
+	if f.done {
+		result := f.result
+		f.cond.L.Unlock()
+		cb(result)
+		return
+	}
+	f.callbacks = append(f.callbacks, cb)
+	f.cond.L.Unlock()
 }
 
 // Complete manually completes the Future with a value
 func (f *Future[T]) Complete(value T) {
-	f.complete(Ok(value))
+	if !f.finish(Ok(value), false) {
+		f.dbg.completedTwice()
+	}
 }
 
 // CompleteWithError manually completes the Future with an error
 func (f *Future[T]) CompleteWithError(err error) {
-	f.complete(Err[T](err))
+	if !f.finish(Err[T](err), false) {
+		f.dbg.completedTwice()
+	}
+}
+
+// Cancel completes the Future with context.Canceled if it is still pending
+// Returns true if this call cancelled the Future
+func (f *Future[T]) Cancel() bool {
+	return f.finish(Err[T](context.Canceled), true)
 }
 
 // IsDone returns true if the Future has completed
 func (f *Future[T]) IsDone() bool {
 	f.cond.L.Lock()
 	defer f.cond.L.Unlock()
+	f.dbg.live()
 	return f.done
 }
 
+// IsCancelled returns true if the Future was completed by Cancel
+func (f *Future[T]) IsCancelled() bool {
+	f.cond.L.Lock()
+	defer f.cond.L.Unlock()
+	return f.done && f.cancelled
+}
+
+// Done returns a channel that is closed once the Future completes
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.doneCh
+}
+
 // Poll returns the result if available, without blocking
 func (f *Future[T]) Poll() (Result[T], bool) {
 	f.cond.L.Lock()
 	defer f.cond.L.Unlock()
+	f.dbg.live()
 	
 	if f.done {
 		return f.result, true
@@ -73,7 +144,12 @@ func (f *Future[T]) Poll() (Result[T], bool) {
 func (f *Future[T]) Await() Result[T] {
 	f.cond.L.Lock()
 	defer f.cond.L.Unlock()
+	f.dbg.live()
 	
+	if !f.done {
+		// debug builds panic here if the wait can never end
+		defer f.dbg.await()()
+	}
 	for !f.done {
 		f.cond.Wait()
 	}
@@ -82,26 +158,28 @@ func (f *Future[T]) Await() Result[T] {
 }
 
 // AwaitWithContext waits for the Future to complete or context to be cancelled
+// Waiting does not spawn goroutines, so abandoned waits leave nothing behind
 func (f *Future[T]) AwaitWithContext(ctx context.Context) Result[T] {
-	done := make(chan Result[T], 1)
-	
-	go func() {
-		done <- f.Await()
-	}()
-	
 	select {
-	case result := <-done:
-		return result
+	case <-f.doneCh:
+		return f.Await()
 	case <-ctx.Done():
 		return Err[T](ctx.Err())
 	}
 }
 
 // AwaitWithTimeout waits for the Future to complete or timeout
+// The timeout is measured by the package Clock, so it can be driven by a TestScheduler
 func (f *Future[T]) AwaitWithTimeout(timeout time.Duration) Result[T] {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-	return f.AwaitWithContext(ctx)
+	timer := currentClock().NewTimer(timeout)
+	defer timer.Stop()
+	
+	select {
+	case <-f.doneCh:
+		return f.Await()
+	case <-timer.C():
+		return Err[T](context.DeadlineExceeded)
+	}
 }
 
 
@@ -128,22 +206,27 @@ func FailedFuture[T any](err error) *Future[T] {
 func RunAsync[T any](f func() Result[T]) *Future[T] {
 	future := NewFuture[T]()
 	
-	go func() {
+	Spawn(func() {
+		future.dbg.own()
 		result := f()
 		future.complete(result)
-	}()
+	})
 	
 	return future
 }
 
 // RunAsyncWithContext executes a function asynchronously with context
+// Cancelling the Future cancels the context passed to f
 func RunAsyncWithContext[T any](ctx context.Context, f func(context.Context) Result[T]) *Future[T] {
 	future := NewFuture[T]()
+	ctx, cancel := context.WithCancel(ctx)
+	future.onComplete(func(Result[T]) { cancel() })
 	
-	go func() {
+	Spawn(func() {
+		future.dbg.own()
 		result := f(ctx)
 		future.complete(result)
-	}()
+	})
 	
 	return future
 }
@@ -152,11 +235,12 @@ func RunAsyncWithContext[T any](ctx context.Context, f func(context.Context) Res
 func MapFuture[T, U any](future *Future[T], fn func(T) U) *Future[U] {
 	newFuture := NewFuture[U]()
 	
-	go func() {
-		result := future.Await()
-		mappedResult := Map(result, fn)
-		newFuture.complete(mappedResult)
-	}()
+	future.onComplete(func(result Result[T]) {
+		Spawn(func() {
+			mappedResult := Map(result, fn)
+			newFuture.complete(mappedResult)
+		})
+	})
 	
 	return newFuture
 }
@@ -165,8 +249,7 @@ func MapFuture[T, U any](future *Future[T], fn func(T) U) *Future[U] {
 func AndThenFuture[T, U any](future *Future[T], fn func(T) *Future[U]) *Future[U] {
 	newFuture := NewFuture[U]()
 	
-	go func() {
-		result := future.Await()
+	future.onComplete(func(result Result[T]) {
 		if !result.IsOk() {
 			val, err := result.Unwrap()
 			_ = val // unused
@@ -174,11 +257,12 @@ func AndThenFuture[T, U any](future *Future[T], fn func(T) *Future[U]) *Future[U
 			return
 		}
 		
-		val, _ := result.Unwrap()
-		nextFuture := fn(val)
-		nextResult := nextFuture.Await()
-		newFuture.complete(nextResult)
-	}()
+		Spawn(func() {
+			val, _ := result.Unwrap()
+			nextFuture := fn(val)
+			nextFuture.onComplete(newFuture.complete)
+		})
+	})
 	
 	return newFuture
 }
@@ -188,11 +272,16 @@ func AndThenFuture[T, U any](future *Future[T], fn func(T) *Future[U]) *Future[U
 // SequenceFutures waits for all Futures to complete and collects results
 func SequenceFutures[T any](futures []*Future[T]) *Future[[]T] {
 	resultFuture := NewFuture[[]T]()
+	results := make([]T, len(futures))
 	
-	go func() {
-		results := make([]T, len(futures))
-		for i, future := range futures {
-			result := future.Await()
+	// Chain continuations in order so the first failure by index is reported
+	var step func(i int)
+	step = func(i int) {
+		if i == len(futures) {
+			resultFuture.Complete(results)
+			return
+		}
+		futures[i].onComplete(func(result Result[T]) {
 			if !result.IsOk() {
 				val, err := result.Unwrap()
 				_ = val // unused
@@ -201,9 +290,10 @@ func SequenceFutures[T any](futures []*Future[T]) *Future[[]T] {
 			}
 			val, _ := result.Unwrap()
 			results[i] = val
-		}
-		resultFuture.Complete(results)
-	}()
+			step(i + 1)
+		})
+	}
+	step(0)
 	
 	return resultFuture
 }
@@ -218,13 +308,12 @@ func RaceFutures[T any](futures []*Future[T]) *Future[T] {
 	}
 	
 	for _, future := range futures {
-		go func(f *Future[T]) {
-			result := f.Await()
+		future.onComplete(func(result Result[T]) {
 			if result.IsOk() {
 				val, _ := result.Unwrap()
 				resultFuture.Complete(val)
 			}
-		}(future)
+		})
 	}
 	
 	return resultFuture
@@ -245,10 +334,7 @@ func FirstCompleted[T any](futures []*Future[T]) *Future[T] {
 	}
 	
 	for _, future := range futures {
-		go func(f *Future[T]) {
-			result := f.Await()
-			resultFuture.complete(result)
-		}(future)
+		future.onComplete(resultFuture.complete)
 	}
 	
 	return resultFuture
@@ -3,6 +3,7 @@ package monad
 import (
 	"context"
 	"errors"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -437,4 +438,28 @@ func TestFirstCompleted(t *testing.T) {
 	if err.Error() != "fast error" {
 		t.Errorf("Expected 'fast error', got %s", err.Error())
 	}
-}
\ No newline at end of file
+}
+
+func TestAwaitWithContextDoesNotLeakGoroutines(t *testing.T) {
+	future := NewFuture[int]()
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 100; i++ {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result := future.AwaitWithContext(ctx)
+		if _, err := result.Unwrap(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected Canceled, got %v", err)
+		}
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Cancelled awaits leaked goroutines: %d before, %d after", before, after)
+	}
+
+	// The future can still be awaited normally afterwards
+	future.Complete(3)
+	if val, err := future.AwaitWithContext(context.Background()).Unwrap(); err != nil || val != 3 {
+		t.Errorf("Expected 3, got %d (%v)", val, err)
+	}
+}
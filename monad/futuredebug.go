@@ -0,0 +1,42 @@
+package monad
+
+import "fmt"
+
+// Builds with the gofndebug tag track how Futures are awaited and completed:
+//
+//	go test -tags gofndebug ./...
+//
+// Await panics with a diagnostic when the wait can never end, because the awaiting goroutine is the one
+// expected to complete the Future (directly or through a cycle of other waits), and FutureTracker
+// reports Futures completed twice or never completed. Without the tag the tracking compiles away.
+
+// FutureIssue is a misuse of a Future recorded by builds with the gofndebug tag
+type FutureIssue struct {
+	Kind    string // "completed twice" or "never completed"
+	Created string // file:line of the code that created the Future
+	Detail  string // where the offending completion happened, if any
+}
+
+func (i FutureIssue) String() string {
+	if i.Detail == "" {
+		return fmt.Sprintf("future created at %s %s", i.Created, i.Kind)
+	}
+	return fmt.Sprintf("future created at %s %s (%s)", i.Created, i.Kind, i.Detail)
+}
+
+// FutureTracker collects misuse of the Futures created after TrackFutures
+type FutureTracker struct {
+	from uint64
+}
+
+// TrackFutures starts tracking Futures created from now on; call Issues at test teardown
+// Futures created by parallel tests are tracked too, so use it in tests that do not run in parallel
+func TrackFutures() *FutureTracker {
+	return &FutureTracker{from: nextFutureID()}
+}
+
+// Issues returns the tracked Futures completed twice so far and those still pending
+// It is always empty without the gofndebug build tag
+func (t *FutureTracker) Issues() []FutureIssue {
+	return futureIssuesSince(t.from)
+}
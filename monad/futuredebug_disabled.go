@@ -0,0 +1,24 @@
+//go:build !gofndebug
+
+package monad
+
+// futureDebugBuild reports whether Futures are tracked, as with the gofndebug tag
+const futureDebugBuild = false
+
+// futureDebug is empty in regular builds, so the tracking hooks compile to nothing
+type futureDebug struct{}
+
+func newFutureDebug() futureDebug { return futureDebug{} }
+
+func (futureDebug) own()            {}
+func (futureDebug) completed()      {}
+func (futureDebug) completedTwice() {}
+func (futureDebug) await() func()   { return noWait }
+func (futureDebug) live()           {}
+func (futureDebug) release() bool   { return true }
+
+func noWait() {}
+
+func nextFutureID() uint64 { return 0 }
+
+func futureIssuesSince(uint64) []FutureIssue { return nil }
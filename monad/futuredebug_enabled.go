@@ -0,0 +1,210 @@
+//go:build gofndebug
+
+package monad
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// futureDebugBuild reports whether Futures are tracked, as with the gofndebug tag
+const futureDebugBuild = true
+
+// futureDebug identifies a Future in the debug registry
+type futureDebug struct {
+	id      uint64
+	created string
+}
+
+// futureRecord is the registry entry of a pending Future
+type futureRecord struct {
+	created string
+	owner   int64 // goroutine expected to complete the Future; 0 when unknown
+}
+
+// futureRegistry tracks pending Futures, which goroutine will complete them and which Future each goroutine awaits
+var futureRegistry = struct {
+	sync.Mutex
+	lastID   uint64
+	pending  map[uint64]*futureRecord
+	waiting  map[int64]uint64
+	twice    map[uint64][]FutureIssue
+	released map[uint64]bool // pooled Futures released by every holder, poisoned instead of recycled
+}{
+	pending:  map[uint64]*futureRecord{},
+	waiting:  map[int64]uint64{},
+	twice:    map[uint64][]FutureIssue{},
+	released: map[uint64]bool{},
+}
+
+// packageDir is the directory of the monad sources; frames from non-test files there are skipped in reports
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+func newFutureDebug() futureDebug {
+	created := callerOutsidePackage()
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	futureRegistry.lastID++
+	id := futureRegistry.lastID
+	futureRegistry.pending[id] = &futureRecord{created: created}
+	return futureDebug{id: id, created: created}
+}
+
+// own records the calling goroutine as the one that will complete the Future
+func (d futureDebug) own() {
+	g := goroutineID()
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	if rec, ok := futureRegistry.pending[d.id]; ok {
+		rec.owner = g
+	}
+}
+
+func (d futureDebug) completed() {
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	delete(futureRegistry.pending, d.id)
+}
+
+// completedTwice records a Complete call on a finished Future
+// Combinators in this package complete racing Futures on purpose, so only callers outside it are reported
+func (d futureDebug) completedTwice() {
+	if inPackage(callerFile(3)) {
+		return
+	}
+	issue := FutureIssue{Kind: "completed twice", Created: d.created, Detail: "again at " + callerOutsidePackage()}
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	futureRegistry.twice[d.id] = append(futureRegistry.twice[d.id], issue)
+}
+
+// await registers the calling goroutine as waiting for the Future, panicking if that wait closes a cycle
+// The returned function unregisters it
+func (d futureDebug) await() func() {
+	g := goroutineID()
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+
+	var chain []string
+	seen := map[uint64]bool{}
+	for id := d.id; !seen[id]; {
+		seen[id] = true
+		rec, ok := futureRegistry.pending[id]
+		if !ok || rec.owner == 0 {
+			break
+		}
+		chain = append(chain, fmt.Sprintf("future created at %s (completed by goroutine %d)", rec.created, rec.owner))
+		if rec.owner == g {
+			panic(fmt.Sprintf("monad: deadlock: goroutine %d awaits a %s", g, strings.Join(chain, ", which awaits a ")))
+		}
+		next, waiting := futureRegistry.waiting[rec.owner]
+		if !waiting {
+			break
+		}
+		id = next
+	}
+
+	futureRegistry.waiting[g] = d.id
+	return func() {
+		futureRegistry.Lock()
+		defer futureRegistry.Unlock()
+		delete(futureRegistry.waiting, g)
+	}
+}
+
+// live panics when the Future came from a FuturePool and every holder has released it, since in regular builds
+// it may already be recycled for another computation
+func (d futureDebug) live() {
+	futureRegistry.Lock()
+	released := futureRegistry.released[d.id]
+	futureRegistry.Unlock()
+	if released {
+		panic(fmt.Sprintf("monad: use of a pooled future created at %s after every holder released it", d.created))
+	}
+}
+
+// release poisons a pooled Future that every holder released and reports that it must not be recycled
+func (d futureDebug) release() bool {
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	futureRegistry.released[d.id] = true
+	return false
+}
+
+func nextFutureID() uint64 {
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	return futureRegistry.lastID + 1
+}
+
+func futureIssuesSince(from uint64) []FutureIssue {
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	var ids []uint64
+	for id := range futureRegistry.pending {
+		if id >= from {
+			ids = append(ids, id)
+		}
+	}
+	for id := range futureRegistry.twice {
+		if id >= from {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	var issues []FutureIssue
+	for _, id := range ids {
+		issues = append(issues, futureRegistry.twice[id]...)
+		if rec, ok := futureRegistry.pending[id]; ok {
+			issues = append(issues, FutureIssue{Kind: "never completed", Created: rec.created})
+		}
+	}
+	return issues
+}
+
+// goroutineID parses the current goroutine's id from its stack header, e.g. "goroutine 42 [running]:"
+func goroutineID() int64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, []byte("goroutine "))
+	b, _, _ = bytes.Cut(b, []byte(" "))
+	id, _ := strconv.ParseInt(string(b), 10, 64)
+	return id
+}
+
+// inPackage reports whether file is a non-test source of this package
+func inPackage(file string) bool {
+	return filepath.Dir(file) == packageDir && !strings.HasSuffix(file, "_test.go")
+}
+
+// callerFile returns the file of the frame skip levels above its caller
+func callerFile(skip int) string {
+	_, file, _, _ := runtime.Caller(skip)
+	return file
+}
+
+// callerOutsidePackage returns file:line of the innermost caller outside this package
+func callerOutsidePackage() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !inPackage(frame.File) {
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
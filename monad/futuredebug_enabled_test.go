@@ -0,0 +1,104 @@
+//go:build gofndebug
+
+package monad
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFutureDebugSelfDeadlock(t *testing.T) {
+	var future *Future[int]
+	ready := make(chan struct{})
+	recovered := make(chan any, 1)
+	future = RunAsync(func() (r Result[int]) {
+		<-ready
+		defer func() { recovered <- recover() }()
+		return future.Await()
+	})
+	close(ready)
+
+	msg, _ := (<-recovered).(string)
+	if !strings.Contains(msg, "monad: deadlock") || !strings.Contains(msg, "futuredebug_enabled_test.go") {
+		t.Errorf("panic = %q, want a deadlock diagnostic naming the creation site", msg)
+	}
+	future.Await()
+}
+
+func TestFutureDebugWaitCycle(t *testing.T) {
+	var a, b *Future[int]
+	start := make(chan struct{})
+	recovered := make(chan any, 2)
+	a = RunAsync(func() Result[int] {
+		<-start
+		return b.Await()
+	})
+	b = RunAsync(func() (r Result[int]) {
+		<-start
+		// wait until a's goroutine is blocked on b, so awaiting a closes the cycle
+		for !awaitedBySomeone(b) {
+			time.Sleep(time.Millisecond)
+		}
+		defer func() { recovered <- recover() }()
+		return a.Await()
+	})
+	close(start)
+
+	msg, _ := (<-recovered).(string)
+	if strings.Count(msg, "which awaits a future") != 1 {
+		t.Errorf("panic = %q, want a two-future cycle", msg)
+	}
+	a.Await()
+}
+
+func TestFutureDebugIssues(t *testing.T) {
+	tracker := TrackFutures()
+
+	twice := NewFuture[int]()
+	twice.Complete(1)
+	twice.CompleteWithError(errors.New("late"))
+	pending := NewFuture[string]()
+	_ = pending
+
+	issues := tracker.Issues()
+	if len(issues) != 2 {
+		t.Fatalf("issues = %v, want 2", issues)
+	}
+	if issues[0].Kind != "completed twice" || !strings.Contains(issues[0].Detail, "futuredebug_enabled_test.go") {
+		t.Errorf("first issue = %v, want completed twice", issues[0])
+	}
+	if issues[1].Kind != "never completed" {
+		t.Errorf("second issue = %v, want never completed", issues[1])
+	}
+}
+
+func TestFutureDebugUseAfterRelease(t *testing.T) {
+	pool := NewFuturePool[int]()
+	f := pool.RunAsync(func() Result[int] { return Ok(1) })
+	f.AwaitAndRelease()
+
+	defer func() {
+		msg, _ := recover().(string)
+		if !strings.Contains(msg, "after every holder released it") || !strings.Contains(msg, "futuredebug_enabled_test.go") {
+			t.Errorf("panic = %q, want a use-after-release diagnostic naming the creation site", msg)
+		}
+		if pool.Stats().Recycled != 0 {
+			t.Error("Debug builds should not recycle released Futures")
+		}
+	}()
+	f.Await()
+}
+
+// awaitedBySomeone reports whether any goroutine is blocked in Await on f
+func awaitedBySomeone[T any](f *Future[T]) bool {
+	futureRegistry.Lock()
+	defer futureRegistry.Unlock()
+	for _, id := range futureRegistry.waiting {
+		if id == f.dbg.id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,19 @@
+package monad
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFutureTrackerCleanUsage(t *testing.T) {
+	tracker := TrackFutures()
+
+	RunAsync(func() Result[int] { return Ok(1) }).Await()
+	MapFuture(CompletedFuture(2), func(x int) int { return x * 2 }).Await()
+	RaceFutures([]*Future[int]{CompletedFuture(1), CompletedFuture(2)}).Await()
+	FirstCompleted([]*Future[int]{FailedFuture[int](errors.New("a")), FailedFuture[int](errors.New("b"))}).Await()
+
+	if issues := tracker.Issues(); len(issues) != 0 {
+		t.Errorf("unexpected issues: %v", issues)
+	}
+}
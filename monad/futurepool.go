@@ -0,0 +1,118 @@
+package monad
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// FuturePoolStats is a snapshot of a FuturePool's counters
+type FuturePoolStats struct {
+	Gets      int64 // Futures handed out by Get and RunAsync
+	Allocated int64 // Futures the pool had to allocate because none was free
+	Recycled  int64 // released Futures returned to the pool
+}
+
+// FuturePool recycles the Futures of one result type, with their lock and condition variable, for servers
+// creating millions of short-lived Futures; the done channel cannot be reopened, so it is the one allocation a
+// recycled Future still makes
+//
+// Pooling is opt-in and explicit: a Future from the pool starts with one holder, its creator, and Retain adds
+// one for each other goroutine it is shared with. Each holder calls Release, or AwaitAndRelease, once it has
+// observed the result, and must not touch the Future after that. Once the Future is done and every holder has
+// released it, it is reset and returned to the pool. A Future that is never released is simply collected
+//
+// Builds with the gofndebug tag never recycle Futures: released ones are poisoned instead, so any later use,
+// which would otherwise observe a recycled Future, panics naming where the Future was created
+type FuturePool[T any] struct {
+	pool                      sync.Pool
+	gets, allocated, recycled atomic.Int64
+}
+
+// NewFuturePool returns an empty FuturePool
+func NewFuturePool[T any]() *FuturePool[T] {
+	p := &FuturePool[T]{}
+	p.pool.New = func() any {
+		p.allocated.Add(1)
+		mu := &sync.Mutex{}
+		return &Future[T]{mu: mu, cond: sync.NewCond(mu), pool: p}
+	}
+	return p
+}
+
+// Get returns a pending Future held once by the caller
+func (p *FuturePool[T]) Get() *Future[T] {
+	p.gets.Add(1)
+	f := p.pool.Get().(*Future[T])
+	f.doneCh = make(chan struct{})
+	f.refs = 1
+	f.dbg = newFutureDebug()
+	return f
+}
+
+// RunAsync is RunAsync with a Future from the pool, held once by the caller
+func (p *FuturePool[T]) RunAsync(fn func() Result[T]) *Future[T] {
+	future := p.Get()
+	Spawn(func() {
+		future.dbg.own()
+		future.complete(fn())
+	})
+	return future
+}
+
+// Stats returns a snapshot of the pool's counters
+func (p *FuturePool[T]) Stats() FuturePoolStats {
+	return FuturePoolStats{Gets: p.gets.Load(), Allocated: p.allocated.Load(), Recycled: p.recycled.Load()}
+}
+
+// put resets a released, completed Future and returns it to the pool
+func (p *FuturePool[T]) put(f *Future[T]) {
+	if !f.dbg.release() {
+		return
+	}
+	var zero Result[T]
+	f.done, f.cancelled, f.result, f.callbacks, f.doneCh = false, false, zero, nil, nil
+	p.recycled.Add(1)
+	p.pool.Put(f)
+}
+
+// Retain adds a holder to a Future from a FuturePool, before sharing it with another goroutine that will
+// Release it; it returns f so it can be passed along directly
+// It does nothing for other Futures
+func (f *Future[T]) Retain() *Future[T] {
+	if f.pool == nil {
+		return f
+	}
+	f.dbg.live()
+	f.cond.L.Lock()
+	f.refs++
+	f.cond.L.Unlock()
+	return f
+}
+
+// Release drops the caller's hold on a Future from a FuturePool; the Future returns to its pool once it is done
+// and released by every holder, so the caller must not use it afterwards
+// It does nothing for other Futures
+func (f *Future[T]) Release() {
+	if f.pool == nil {
+		return
+	}
+	f.dbg.live()
+	f.cond.L.Lock()
+	if f.refs <= 0 {
+		f.cond.L.Unlock()
+		panic("monad: Release of a Future with no holders left")
+	}
+	f.refs--
+	recycle := f.refs == 0 && f.done
+	f.cond.L.Unlock()
+	if recycle {
+		f.pool.put(f)
+	}
+}
+
+// AwaitAndRelease waits for the Future, releases the caller's hold on it and returns the result
+func (f *Future[T]) AwaitAndRelease() Result[T] {
+	result := f.Await()
+	f.Release()
+	return result
+}
@@ -0,0 +1,113 @@
+package monad
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// wantRecycled is n in regular builds; gofndebug builds poison released Futures instead of recycling them
+func wantRecycled(n int64) int64 {
+	if futureDebugBuild {
+		return 0
+	}
+	return n
+}
+
+func TestFuturePoolRecyclesReleasedFutures(t *testing.T) {
+	pool := NewFuturePool[int]()
+	for i := range 100 {
+		got := pool.RunAsync(func() Result[int] { return Ok(i) }).AwaitAndRelease()
+		if v, err := got.Unwrap(); err != nil || v != i {
+			t.Fatalf("run %d: got %d, %v", i, v, err)
+		}
+	}
+
+	stats := pool.Stats()
+	if stats.Gets != 100 || stats.Recycled != wantRecycled(100) {
+		t.Errorf("Unexpected stats %+v", stats)
+	}
+}
+
+func TestFuturePoolResetsRecycledFutures(t *testing.T) {
+	pool := NewFuturePool[string]()
+	first := pool.Get()
+	first.onComplete(func(Result[string]) {})
+	first.Cancel()
+	first.Release()
+
+	f := pool.Get()
+	if f.IsDone() || f.IsCancelled() {
+		t.Fatal("A Future from the pool should be pending")
+	}
+	select {
+	case <-f.Done():
+		t.Fatal("The done channel of a Future from the pool should be open")
+	default:
+	}
+	f.CompleteWithError(errors.New("boom"))
+	if r := f.AwaitAndRelease(); r.IsOk() {
+		t.Error("Expected the error the Future was completed with")
+	}
+}
+
+func TestFuturePoolWaitsForEveryHolder(t *testing.T) {
+	pool := NewFuturePool[int]()
+	f := pool.Get()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func(f *Future[int]) {
+			defer wg.Done()
+			if v, _ := f.AwaitAndRelease().Unwrap(); v != 7 {
+				t.Errorf("Expected 7, got %d", v)
+			}
+		}(f.Retain())
+	}
+	f.Complete(7)
+	wg.Wait()
+	if got := pool.Stats().Recycled; got != 0 {
+		t.Fatalf("The Future should stay out of the pool while the creator holds it, got %d recycled", got)
+	}
+	f.Release()
+	if got := pool.Stats().Recycled; got != wantRecycled(1) {
+		t.Errorf("Expected the Future to be recycled after the last Release, got %d", got)
+	}
+}
+
+func TestFuturePoolReleaseWhilePending(t *testing.T) {
+	pool := NewFuturePool[int]()
+	f := pool.Get()
+	mapped := MapFuture(f, func(x int) int { return x + 1 })
+	f.Release() // abandoned by its only holder; it is recycled once completed
+
+	if pool.Stats().Recycled != 0 {
+		t.Fatal("A pending Future must not be recycled")
+	}
+	f.Complete(1)
+	if v, _ := mapped.Await().Unwrap(); v != 2 {
+		t.Errorf("Continuations should see the result, got %d", v)
+	}
+	if got := pool.Stats().Recycled; got != wantRecycled(1) {
+		t.Errorf("Expected the Future to be recycled on completion, got %d", got)
+	}
+}
+
+func TestFutureReleaseWithoutPool(t *testing.T) {
+	f := CompletedFuture(1)
+	f.Retain().Release()
+	f.Release()
+	if v, _ := f.Await().Unwrap(); v != 1 {
+		t.Error("Release should do nothing for Futures not from a pool")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Releasing more often than held should panic")
+		}
+	}()
+	pooled := NewFuturePool[int]().Get()
+	pooled.Release()
+	pooled.Release()
+}
@@ -0,0 +1,102 @@
+package monad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// TaskMiddleware wraps the Task of each call served by ServeTask, e.g. with WithTimeout or RetryTask:
+// func(t Task[any]) Task[any] { return WithTimeout(t, time.Second) }
+type TaskMiddleware func(Task[any]) Task[any]
+
+// HandlerOptions configure ServeTask and the handlers //gofn:handler generates
+type HandlerOptions struct {
+	// Middleware wraps every call; the first one is the outermost
+	Middleware []TaskMiddleware
+	// StatusOf maps a failure to a status code; nil selects DefaultStatus
+	StatusOf func(error) int
+}
+
+// StatusError is a failure with the HTTP status ServeTask responds with
+type StatusError struct {
+	Status int
+	Err    error
+}
+
+// Error returns the message of the wrapped error
+func (e *StatusError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// DefaultStatus maps a *StatusError to its status, context.DeadlineExceeded to 504 and anything else to 500
+func DefaultStatus(err error) int {
+	var se *StatusError
+	switch {
+	case errors.As(err, &se):
+		return se.Status
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// ServeTask serves one JSON call: it decodes the request body into a Req, checks it with validate when not nil,
+// runs call as a Task through the middleware and encodes the Resp
+// An empty body decodes as the zero Req. Decoding fails with 400 and validation with 422; other failures get
+// the status of opts.StatusOf. Errors are sent as {"error": message}, with the message of a 500 hidden
+func ServeTask[Req, Resp any](w http.ResponseWriter, r *http.Request, opts HandlerOptions, validate func(Req) error, call func(context.Context, Req) (Resp, error)) {
+	var req Req
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, opts, &StatusError{Status: http.StatusBadRequest, Err: fmt.Errorf("decoding request: %w", err)})
+		return
+	}
+	if validate != nil {
+		if err := validate(req); err != nil {
+			writeError(w, opts, &StatusError{Status: http.StatusUnprocessableEntity, Err: err})
+			return
+		}
+	}
+
+	task := Task[any](func(ctx context.Context) Result[any] {
+		resp, err := call(ctx, req)
+		if err != nil {
+			return Err[any](err)
+		}
+		return Ok[any](resp)
+	})
+	for i := len(opts.Middleware) - 1; i >= 0; i-- {
+		task = opts.Middleware[i](task)
+	}
+	resp, err := task(r.Context()).Unwrap()
+	if err != nil {
+		writeError(w, opts, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// writeError sends err as a JSON error body with the status opts maps it to
+func writeError(w http.ResponseWriter, opts HandlerOptions, err error) {
+	statusOf := opts.StatusOf
+	if statusOf == nil {
+		statusOf = DefaultStatus
+	}
+	status := statusOf(err)
+	message := err.Error()
+	if status == http.StatusInternalServerError {
+		message = http.StatusText(status)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
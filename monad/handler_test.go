@@ -0,0 +1,98 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type greetRequest struct {
+	Name string `json:"name"`
+}
+
+type greetResponse struct {
+	Greeting string `json:"greeting"`
+}
+
+func greet(_ context.Context, req greetRequest) (greetResponse, error) {
+	switch req.Name {
+	case "ghost":
+		return greetResponse{}, &StatusError{Status: http.StatusNotFound, Err: errors.New("no such user")}
+	case "db":
+		return greetResponse{}, errors.New("connection refused to 10.0.0.7")
+	}
+	return greetResponse{Greeting: "hello " + req.Name}, nil
+}
+
+func serveGreet(body string, opts HandlerOptions) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	validate := func(req greetRequest) error {
+		if req.Name == "" {
+			return errors.New("name: is required")
+		}
+		return nil
+	}
+	ServeTask(rec, httptest.NewRequest("POST", "/Greet", strings.NewReader(body)), opts, validate, greet)
+	return rec
+}
+
+func TestServeTask(t *testing.T) {
+	tests := []struct {
+		body   string
+		status int
+		want   string
+	}{
+		{`{"name":"kim"}`, http.StatusOK, `{"greeting":"hello kim"}`},
+		{`{"name":`, http.StatusBadRequest, `decoding request`},
+		{``, http.StatusUnprocessableEntity, `{"error":"name: is required"}`},
+		{`{"name":"ghost"}`, http.StatusNotFound, `{"error":"no such user"}`},
+		{`{"name":"db"}`, http.StatusInternalServerError, `{"error":"Internal Server Error"}`},
+	}
+	for _, tt := range tests {
+		rec := serveGreet(tt.body, HandlerOptions{})
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.want) {
+			t.Errorf("%q: expected %d %s, got %d %s", tt.body, tt.status, tt.want, rec.Code, rec.Body.String())
+		}
+	}
+}
+
+func TestServeTaskMiddleware(t *testing.T) {
+	var order []string
+	trace := func(name string) TaskMiddleware {
+		return func(next Task[any]) Task[any] {
+			return func(ctx context.Context) Result[any] {
+				order = append(order, name)
+				return next(ctx)
+			}
+		}
+	}
+	deadline := func(next Task[any]) Task[any] {
+		return func(context.Context) Result[any] {
+			return Err[any](context.DeadlineExceeded)
+		}
+	}
+
+	rec := serveGreet(`{"name":"kim"}`, HandlerOptions{Middleware: []TaskMiddleware{trace("outer"), trace("inner")}})
+	if rec.Code != http.StatusOK || strings.Join(order, ",") != "outer,inner" {
+		t.Errorf("Middleware should wrap the call in order, got %d %v", rec.Code, order)
+	}
+	rec = serveGreet(`{"name":"kim"}`, HandlerOptions{Middleware: []TaskMiddleware{deadline}})
+	if rec.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected a timeout to map to 504, got %d", rec.Code)
+	}
+	rec = serveGreet(`{"name":"kim"}`, HandlerOptions{
+		Middleware: []TaskMiddleware{func(t Task[any]) Task[any] { return WithTimeout(t, time.Second) }},
+		StatusOf:   func(error) int { return http.StatusTeapot },
+	})
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected WithTimeout to pass a fast call through, got %d", rec.Code)
+	}
+	rec = serveGreet(`{"name":"db"}`, HandlerOptions{StatusOf: func(error) int { return http.StatusTeapot }})
+	if rec.Code != http.StatusTeapot || !strings.Contains(rec.Body.String(), "connection refused") {
+		t.Errorf("Expected the custom status and message, got %d %s", rec.Code, rec.Body.String())
+	}
+}
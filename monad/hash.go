@@ -0,0 +1,171 @@
+package monad
+
+import (
+	"iter"
+	"math"
+)
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hasher accumulates a 64-bit FNV-1a hash of the values written to it, for Hash methods that must agree with
+// an Equal method; sums do not depend on a seed, so they are stable across processes
+// The zero value is ready to use
+type Hasher struct {
+	// state is the running hash xored with the offset basis, so the zero value starts from the basis
+	state uint64
+}
+
+func (h *Hasher) byte(b byte) {
+	s := h.state ^ fnvOffset64
+	s ^= uint64(b)
+	s *= fnvPrime64
+	h.state = s ^ fnvOffset64
+}
+
+// WriteUint64 adds u to the hash
+func (h *Hasher) WriteUint64(u uint64) {
+	for i := range 8 {
+		h.byte(byte(u >> (8 * i)))
+	}
+}
+
+// WriteInt64 adds i to the hash
+func (h *Hasher) WriteInt64(i int64) {
+	h.WriteUint64(uint64(i))
+}
+
+// WriteFloat64 adds f to the hash; 0 and -0 hash alike since they are ==
+func (h *Hasher) WriteFloat64(f float64) {
+	if f == 0 {
+		f = 0
+	}
+	h.WriteUint64(math.Float64bits(f))
+}
+
+// WriteBool adds b to the hash
+func (h *Hasher) WriteBool(b bool) {
+	if b {
+		h.byte(1)
+	} else {
+		h.byte(0)
+	}
+}
+
+// WriteString adds s to the hash, prefixed by its length so consecutive strings cannot run together
+func (h *Hasher) WriteString(s string) {
+	h.WriteUint64(uint64(len(s)))
+	for i := 0; i < len(s); i++ {
+		h.byte(s[i])
+	}
+}
+
+// WriteBytes adds b to the hash like WriteString
+func (h *Hasher) WriteBytes(b []byte) {
+	h.WriteUint64(uint64(len(b)))
+	for _, c := range b {
+		h.byte(c)
+	}
+}
+
+// Sum64 returns the hash of everything written so far; the Hasher can keep being written
+func (h *Hasher) Sum64() uint64 {
+	return h.state ^ fnvOffset64
+}
+
+// Hashable is a value with a hash agreeing with its Equal method: Equal values must have equal hashes
+// Structs with //gofn:equal hash implement it
+type Hashable[K any] interface {
+	Hash() uint64
+	Equal(other K) bool
+}
+
+// HashMap is a map keyed by Hashable values, for keys that cannot be map keys because they hold slices, maps
+// or pointers compared by what they point to
+// Keys are bucketed by Hash and told apart by Equal; a key must not change while it is in the map
+// A HashMap is not safe for concurrent use, and the zero value is an empty map
+type HashMap[K Hashable[K], V any] struct {
+	buckets map[uint64][]hashEntry[K, V]
+	size    int
+}
+
+type hashEntry[K any, V any] struct {
+	key   K
+	value V
+}
+
+// NewHashMap returns an empty HashMap
+func NewHashMap[K Hashable[K], V any]() *HashMap[K, V] {
+	return &HashMap[K, V]{}
+}
+
+// Len returns the number of entries in m
+func (m *HashMap[K, V]) Len() int {
+	return m.size
+}
+
+// Get returns the value stored for key, or None
+func (m *HashMap[K, V]) Get(key K) Option[V] {
+	for _, e := range m.buckets[key.Hash()] {
+		if e.key.Equal(key) {
+			return Some(e.value)
+		}
+	}
+	return None[V]()
+}
+
+// Has reports whether m holds key
+func (m *HashMap[K, V]) Has(key K) bool {
+	return m.Get(key).IsSome()
+}
+
+// Set stores value for key, replacing the value of an Equal key
+func (m *HashMap[K, V]) Set(key K, value V) {
+	hash := key.Hash()
+	bucket := m.buckets[hash]
+	for i, e := range bucket {
+		if e.key.Equal(key) {
+			bucket[i].value = value
+			return
+		}
+	}
+	if m.buckets == nil {
+		m.buckets = map[uint64][]hashEntry[K, V]{}
+	}
+	m.buckets[hash] = append(bucket, hashEntry[K, V]{key: key, value: value})
+	m.size++
+}
+
+// Delete removes key and reports whether it was present
+func (m *HashMap[K, V]) Delete(key K) bool {
+	hash := key.Hash()
+	bucket := m.buckets[hash]
+	for i, e := range bucket {
+		if !e.key.Equal(key) {
+			continue
+		}
+		if len(bucket) == 1 {
+			delete(m.buckets, hash)
+		} else {
+			m.buckets[hash] = append(bucket[:i:i], bucket[i+1:]...)
+		}
+		m.size--
+		return true
+	}
+	return false
+}
+
+// All returns the entries of m in no particular order
+func (m *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for _, bucket := range m.buckets {
+			for _, e := range bucket {
+				if !yield(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
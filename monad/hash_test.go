@@ -0,0 +1,94 @@
+package monad
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// tagSet is a key that cannot be a Go map key; tags compare in order, and the hash collides on purpose
+// when coarse is set so HashMap has to tell keys apart with Equal
+type tagSet struct {
+	tags   []string
+	coarse bool
+}
+
+func (k tagSet) Equal(other tagSet) bool {
+	return slices.Equal(k.tags, other.tags)
+}
+
+func (k tagSet) Hash() uint64 {
+	if k.coarse {
+		return uint64(len(k.tags))
+	}
+	var h Hasher
+	for _, tag := range k.tags {
+		h.WriteString(tag)
+	}
+	return h.Sum64()
+}
+
+func TestHasherMatchesFNV(t *testing.T) {
+	var zero Hasher
+	if zero.Sum64() != fnvOffset64 {
+		t.Errorf("The zero Hasher should start from the FNV offset basis, got %d", zero.Sum64())
+	}
+
+	var a, b Hasher
+	a.WriteString("ab")
+	a.WriteString("c")
+	b.WriteString("a")
+	b.WriteString("bc")
+	if a.Sum64() == b.Sum64() {
+		t.Error("Strings should be length-prefixed so they cannot run together")
+	}
+
+	var pos, neg Hasher
+	pos.WriteFloat64(0)
+	neg.WriteFloat64(math.Copysign(0, -1))
+	if pos.Sum64() != neg.Sum64() {
+		t.Error("0 and -0 should hash alike")
+	}
+
+	var again Hasher
+	again.WriteString("ab")
+	again.WriteString("c")
+	if again.Sum64() != a.Sum64() {
+		t.Error("Sums should be deterministic")
+	}
+}
+
+func TestHashMap(t *testing.T) {
+	var m HashMap[tagSet, int]
+	m.Set(tagSet{tags: []string{"a", "b"}, coarse: true}, 1)
+	m.Set(tagSet{tags: []string{"b", "a"}, coarse: true}, 2)
+	m.Set(tagSet{tags: []string{"a", "b"}, coarse: true}, 3)
+
+	if m.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", m.Len())
+	}
+	if got := m.Get(tagSet{tags: []string{"a", "b"}, coarse: true}); got.Unwrap() != 3 {
+		t.Errorf("Set should replace the value of an Equal key, got %v", got)
+	}
+	if got := m.Get(tagSet{tags: []string{"b", "a"}, coarse: true}); got.Unwrap() != 2 {
+		t.Errorf("Colliding keys should be told apart by Equal, got %v", got)
+	}
+	if m.Has(tagSet{tags: []string{"c"}}) {
+		t.Error("A missing key should not be found")
+	}
+
+	if !m.Delete(tagSet{tags: []string{"a", "b"}, coarse: true}) || m.Delete(tagSet{tags: []string{"a", "b"}, coarse: true}) {
+		t.Error("Delete should report whether the key was present")
+	}
+	if m.Len() != 1 || m.Get(tagSet{tags: []string{"b", "a"}, coarse: true}).Unwrap() != 2 {
+		t.Error("Delete should keep the other keys of a bucket")
+	}
+
+	sum := 0
+	for _, v := range m.All() {
+		sum += v
+	}
+	if sum != 2 {
+		t.Errorf("All should yield every entry, got a sum of %d", sum)
+	}
+}
@@ -0,0 +1,141 @@
+package monad
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Status is the health of a single check or of a whole service
+type Status int
+
+const (
+	StatusUp Status = iota
+	StatusDegraded
+	StatusDown
+)
+
+// String returns the lower-case name of the Status
+func (s Status) String() string {
+	switch s {
+	case StatusUp:
+		return "up"
+	case StatusDegraded:
+		return "degraded"
+	default:
+		return "down"
+	}
+}
+
+// MarshalText encodes the Status by name so reports serialize readably
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// CheckReport is the outcome of one named check
+type CheckReport struct {
+	Name     string        `json:"name"`
+	Status   Status        `json:"status"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// HealthReport aggregates all checks; Status is the worst Status of any check
+type HealthReport struct {
+	Status Status        `json:"status"`
+	Checks []CheckReport `json:"checks"`
+}
+
+type healthEntry struct {
+	name    string
+	check   Task[Status]
+	timeout time.Duration
+}
+
+// HealthCheck runs named Task[Status] checks in parallel, each bounded by a timeout
+// A check that fails or times out is reported as StatusDown
+type HealthCheck struct {
+	timeout time.Duration
+	entries []healthEntry
+}
+
+// NewHealthCheck creates an empty HealthCheck whose checks default to the given timeout
+func NewHealthCheck(timeout time.Duration) *HealthCheck {
+	return &HealthCheck{timeout: timeout}
+}
+
+// Add registers a check using the default timeout
+func (h *HealthCheck) Add(name string, check Task[Status]) *HealthCheck {
+	return h.AddWithTimeout(name, check, h.timeout)
+}
+
+// AddWithTimeout registers a check with its own timeout; zero or negative disables the timeout
+func (h *HealthCheck) AddWithTimeout(name string, check Task[Status], timeout time.Duration) *HealthCheck {
+	h.entries = append(h.entries, healthEntry{name: name, check: check, timeout: timeout})
+	return h
+}
+
+// Run executes every check in parallel and aggregates a HealthReport
+// Fails only if ctx is cancelled; individual check failures are part of the report
+func (h *HealthCheck) Run(ctx context.Context) Result[HealthReport] {
+	clock := currentClock()
+	futures := make([]*Future[Status], len(h.entries))
+	starts := make([]time.Time, len(h.entries))
+
+	for i, entry := range h.entries {
+		check := entry.check
+		if entry.timeout > 0 {
+			check = WithTimeout(check, entry.timeout)
+		}
+		starts[i] = clock.Now()
+		futures[i] = check.Run(ctx)
+	}
+
+	report := HealthReport{Status: StatusUp, Checks: make([]CheckReport, len(h.entries))}
+	for i, future := range futures {
+		status, err := future.AwaitWithContext(ctx).Unwrap()
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return Err[HealthReport](ctxErr)
+		}
+
+		checkReport := CheckReport{
+			Name:     h.entries[i].name,
+			Status:   status,
+			Duration: clock.Now().Sub(starts[i]),
+		}
+		if err != nil {
+			checkReport.Status = StatusDown
+			checkReport.Error = err.Error()
+		}
+		if checkReport.Status > report.Status {
+			report.Status = checkReport.Status
+		}
+		report.Checks[i] = checkReport
+	}
+
+	return Ok(report)
+}
+
+// Task returns the HealthCheck as a Task so it composes with other combinators
+func (h *HealthCheck) Task() Task[HealthReport] {
+	return h.Run
+}
+
+// ServeHTTP serves the report as JSON for /healthz style endpoints
+// Responds 200 while the service is up or degraded and 503 when it is down
+func (h *HealthCheck) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report, err := h.Run(r.Context()).Unwrap()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	code := http.StatusOK
+	if report.Status == StatusDown {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,88 @@
+package monad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHealthCheckAggregates(t *testing.T) {
+	health := NewHealthCheck(time.Second).
+		Add("db", NewTaskFromValue(StatusUp)).
+		Add("cache", NewTaskFromValue(StatusDegraded))
+
+	report, err := health.Run(context.Background()).Unwrap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Status != StatusDegraded {
+		t.Errorf("Expected degraded, got %v", report.Status)
+	}
+	if len(report.Checks) != 2 || report.Checks[0].Name != "db" || report.Checks[1].Status != StatusDegraded {
+		t.Errorf("Unexpected checks %+v", report.Checks)
+	}
+}
+
+func TestHealthCheckFailureIsDown(t *testing.T) {
+	health := NewHealthCheck(time.Second).
+		Add("db", NewTaskFromError[Status](errors.New("connection refused"))).
+		Add("cache", NewTaskFromValue(StatusUp))
+
+	report, _ := health.Run(context.Background()).Unwrap()
+	if report.Status != StatusDown {
+		t.Errorf("Expected down, got %v", report.Status)
+	}
+	if report.Checks[0].Error != "connection refused" {
+		t.Errorf("Expected error to be reported, got %q", report.Checks[0].Error)
+	}
+	if report.Checks[1].Status != StatusUp {
+		t.Error("A failing check should not affect its siblings")
+	}
+}
+
+func TestHealthCheckTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	health := NewHealthCheck(time.Second).Add("slow", blockingTask[Status]())
+	results := make(chan Result[HealthReport], 1)
+	go func() {
+		results <- health.Run(context.Background())
+	}()
+
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Second)
+
+	report, err := (<-results).Unwrap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if report.Status != StatusDown || report.Checks[0].Error != context.DeadlineExceeded.Error() {
+		t.Errorf("Expected timed out check, got %+v", report.Checks[0])
+	}
+}
+
+func TestHealthCheckServeHTTP(t *testing.T) {
+	up := NewHealthCheck(time.Second).Add("db", NewTaskFromValue(StatusUp))
+	rec := httptest.NewRecorder()
+	up.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200, got %d", rec.Code)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["status"] != "up" {
+		t.Errorf("Unexpected body %s (%v)", rec.Body.String(), err)
+	}
+
+	down := NewHealthCheck(time.Second).Add("db", NewTaskFromValue(StatusDown))
+	rec = httptest.NewRecorder()
+	down.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503, got %d", rec.Code)
+	}
+}
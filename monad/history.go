@@ -0,0 +1,144 @@
+package monad
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HistoryEntry is one action dispatched to a Store and the state it produced
+type HistoryEntry[S, A any] struct {
+	Index  int       `json:"index"` // 1 for the first action the History saw
+	At     time.Time `json:"at"`
+	Type   string    `json:"type"` // the action's Go type, e.g. main.CartAddItem
+	Action A         `json:"action"`
+	State  S         `json:"state"`
+}
+
+// HistoryExport is the JSON document Export writes, for viewers of a Store's history
+type HistoryExport[S, A any] struct {
+	Base      int                  `json:"base"` // index of baseState; older entries were dropped
+	BaseState S                    `json:"baseState"`
+	Current   int                  `json:"current"` // index of the state the store holds, moved by ReplayTo
+	Entries   []HistoryEntry[S, A] `json:"entries"`
+}
+
+// History records the actions dispatched to a Store and the state after each, for time-travel debugging in
+// development builds: ReplayTo moves the store back or forward to any recorded state
+// Only the last limit entries are kept, 0 keeping all of them. Timestamps come from the package Clock
+type History[S, A any] struct {
+	limit       int
+	dispatching sync.Mutex // serializes recorded dispatches and replays, so each entry has its own state
+
+	mu        sync.Mutex
+	store     *Store[S, A]
+	base      int
+	baseState S
+	current   int
+	entries   []HistoryEntry[S, A]
+}
+
+// NewHistory creates a History keeping the last limit actions; attach it with Middleware
+func NewHistory[S, A any](limit int) *History[S, A] {
+	return &History[S, A]{limit: limit}
+}
+
+// Middleware returns the middleware recording into h; pass it last to NewStore, so it records the actions the
+// reducer gets, and to a single store
+// The state the store starts with is the base state, index 0
+func (h *History[S, A]) Middleware() Middleware[S, A] {
+	return func(store *Store[S, A], next func(A)) func(A) {
+		h.mu.Lock()
+		h.store, h.baseState = store, store.State()
+		h.mu.Unlock()
+		return func(action A) {
+			h.dispatching.Lock()
+			defer h.dispatching.Unlock()
+			next(action)
+			h.record(action, store.State())
+		}
+	}
+}
+
+// record appends an entry after the current one, dropping entries a ReplayTo went back over and those
+// beyond the limit
+func (h *History[S, A]) record(action A, state S) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.entries = h.entries[:h.current-h.base]
+	h.current++
+	h.entries = append(h.entries, HistoryEntry[S, A]{
+		Index:  h.current,
+		At:     currentClock().Now(),
+		Type:   fmt.Sprintf("%T", action),
+		Action: action,
+		State:  state,
+	})
+	if h.limit > 0 && len(h.entries) > h.limit {
+		dropped := h.entries[len(h.entries)-h.limit-1]
+		h.base, h.baseState = dropped.Index, dropped.State
+		h.entries = h.entries[len(h.entries)-h.limit:]
+	}
+}
+
+// Entries returns the recorded entries, oldest first
+func (h *History[S, A]) Entries() []HistoryEntry[S, A] {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]HistoryEntry[S, A](nil), h.entries...)
+}
+
+// ReplayTo sets the store's state to the one after action n, or the base state for the oldest index kept,
+// notifying its subscribers and selectors. Later entries are kept, so ReplayTo can move forward again until
+// the next dispatch, which drops them like an undo would
+// It fails when n was dropped by the limit or not dispatched yet
+func (h *History[S, A]) ReplayTo(n int) Result[S] {
+	h.dispatching.Lock()
+	defer h.dispatching.Unlock()
+
+	h.mu.Lock()
+	store := h.store
+	if store == nil {
+		h.mu.Unlock()
+		return Err[S](errors.New("history is not attached to a store"))
+	}
+	last := h.base + len(h.entries)
+	if n < h.base || n > last {
+		h.mu.Unlock()
+		return Err[S](fmt.Errorf("history holds states %d to %d, not %d", h.base, last, n))
+	}
+	state := h.baseState
+	if n > h.base {
+		state = h.entries[n-h.base-1].State
+	}
+	h.current = n
+	h.mu.Unlock()
+
+	store.Reactive().Set(state)
+	return Ok(state)
+}
+
+// Export writes the history as a JSON HistoryExport
+func (h *History[S, A]) Export(w io.Writer) error {
+	h.mu.Lock()
+	export := HistoryExport[S, A]{
+		Base:      h.base,
+		BaseState: h.baseState,
+		Current:   h.current,
+		Entries:   append([]HistoryEntry[S, A]{}, h.entries...),
+	}
+	h.mu.Unlock()
+	return json.NewEncoder(w).Encode(export)
+}
+
+// ServeHTTP serves Export, so a viewer can poll the history of a running development build
+func (h *History[S, A]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := h.Export(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,86 @@
+package monad
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestHistoryRecordsAndReplays(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer SetClock(clock)()
+	history := NewHistory[int, counterAction](0)
+	store := NewStore(10, reduceCounter, history.Middleware())
+
+	for _, d := range []int{1, 2, 3} {
+		clock.Advance(time.Second)
+		store.Dispatch(counterAction{delta: d})
+	}
+	entries := history.Entries()
+	if len(entries) != 3 || entries[2].Index != 3 || entries[2].State != 16 || entries[0].At.Second() != 1 {
+		t.Fatalf("Unexpected entries %+v", entries)
+	}
+	if entries[0].Type != "monad.counterAction" {
+		t.Errorf("Expected the action type, got %q", entries[0].Type)
+	}
+
+	if state, err := history.ReplayTo(1).Unwrap(); err != nil || state != 11 || store.State() != 11 {
+		t.Errorf("Expected to go back to 11, got %d (store %d), %v", state, store.State(), err)
+	}
+	if state, _ := history.ReplayTo(0).Unwrap(); state != 10 {
+		t.Errorf("Expected the initial state, got %d", state)
+	}
+	if state, _ := history.ReplayTo(3).Unwrap(); state != 16 {
+		t.Errorf("Expected to move forward again, got %d", state)
+	}
+	if history.ReplayTo(4).IsOk() {
+		t.Error("Replaying past the last action should fail")
+	}
+
+	history.ReplayTo(1)
+	store.Dispatch(counterAction{delta: 100})
+	entries = history.Entries()
+	if len(entries) != 2 || entries[1].Index != 2 || entries[1].State != 111 {
+		t.Errorf("Dispatching after a replay should drop the later entries, got %+v", entries)
+	}
+}
+
+func TestHistoryLimit(t *testing.T) {
+	history := NewHistory[int, counterAction](2)
+	store := NewStore(0, reduceCounter, history.Middleware())
+	for range 5 {
+		store.Dispatch(counterAction{delta: 1})
+	}
+
+	entries := history.Entries()
+	if len(entries) != 2 || entries[0].Index != 4 {
+		t.Fatalf("Expected the last two entries, got %+v", entries)
+	}
+	if state, err := history.ReplayTo(3).Unwrap(); err != nil || state != 3 {
+		t.Errorf("The state before the oldest entry should be kept, got %d, %v", state, err)
+	}
+	if history.ReplayTo(2).IsOk() {
+		t.Error("Replaying to a dropped state should fail")
+	}
+}
+
+func TestHistoryExport(t *testing.T) {
+	history := NewHistory[int, counterAction](0)
+	if history.ReplayTo(0).IsOk() {
+		t.Error("A detached history cannot replay")
+	}
+	store := NewStore(0, reduceCounter, history.Middleware())
+	store.Dispatch(counterAction{delta: 5})
+
+	rec := httptest.NewRecorder()
+	history.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/store", nil))
+	var export HistoryExport[int, counterAction]
+	if err := json.NewDecoder(bytes.NewReader(rec.Body.Bytes())).Decode(&export); err != nil {
+		t.Fatal(err)
+	}
+	if export.Current != 1 || len(export.Entries) != 1 || export.Entries[0].State != 5 || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected export %+v", export)
+	}
+}
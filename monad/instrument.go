@@ -0,0 +1,76 @@
+package monad
+
+import (
+	"sync/atomic"
+
+	"github.com/snowmerak/gofn/monad/metrics"
+)
+
+// runtimeMetrics holds the instruments the runtime reports through, resolved once per SetMetrics
+type runtimeMetrics struct {
+	executorQueued    metrics.Gauge
+	executorExecuted  metrics.Counter
+	executorShed      metrics.Counter
+	executorQueueWait metrics.Histogram
+
+	cacheHits        metrics.Counter
+	cacheMisses      metrics.Counter
+	cacheLoads       metrics.Counter
+	cacheLoadErrors  metrics.Counter
+	cacheEvictions   metrics.Counter
+	cacheLoadSeconds metrics.Histogram
+	cacheStaleHits   metrics.Counter
+	cacheRefreshes   metrics.Counter
+
+	reactiveUpdates     metrics.Counter
+	reactiveSubscribers metrics.Gauge
+
+	backpressureDropped metrics.Counter
+}
+
+var instruments atomic.Pointer[runtimeMetrics]
+
+func init() {
+	instruments.Store(newRuntimeMetrics(metrics.Noop()))
+}
+
+// newRuntimeMetrics creates the runtime instruments from p
+// The totals are summed over every PriorityExecutor, Cache, Reactive and Backpressured of the process;
+// use their Metrics methods for per-instance numbers
+func newRuntimeMetrics(p metrics.Provider) *runtimeMetrics {
+	return &runtimeMetrics{
+		executorQueued:    p.Gauge("gofn_executor_queued", "Work waiting for a PriorityExecutor worker"),
+		executorExecuted:  p.Counter("gofn_executor_executed_total", "Work started by PriorityExecutor workers"),
+		executorShed:      p.Counter("gofn_executor_shed_total", "Work shed because its context ended before it started"),
+		executorQueueWait: p.Histogram("gofn_executor_queue_wait_seconds", "Time work waited in a PriorityExecutor queue"),
+
+		cacheHits:        p.Counter("gofn_cache_hits_total", "Cache lookups finding a live entry"),
+		cacheMisses:      p.Counter("gofn_cache_misses_total", "Cache lookups finding no live entry"),
+		cacheLoads:       p.Counter("gofn_cache_loads_total", "Cache loader runs"),
+		cacheLoadErrors:  p.Counter("gofn_cache_load_errors_total", "Cache loader runs that failed"),
+		cacheEvictions:   p.Counter("gofn_cache_evictions_total", "Cache entries dropped for the size bound or expiry"),
+		cacheLoadSeconds: p.Histogram("gofn_cache_load_seconds", "Duration of cache loader runs"),
+		cacheStaleHits:   p.Counter("gofn_cache_stale_hits_total", "StaleWhileRevalidate lookups served a stale value"),
+		cacheRefreshes:   p.Counter("gofn_cache_refreshes_total", "StaleWhileRevalidate background refreshes"),
+
+		reactiveUpdates:     p.Counter("gofn_reactive_updates_total", "Reactive Set and Update calls"),
+		reactiveSubscribers: p.Gauge("gofn_reactive_subscribers", "Registered Reactive subscribers"),
+
+		backpressureDropped: p.Counter("gofn_backpressure_dropped_total", "Values discarded by backpressure drop strategies"),
+	}
+}
+
+// SetMetrics makes the runtime report through p and returns a function restoring the previous Provider
+// Passing nil restores the no-op default
+func SetMetrics(p metrics.Provider) (restore func()) {
+	if p == nil {
+		p = metrics.Noop()
+	}
+	prev := instruments.Swap(newRuntimeMetrics(p))
+	return func() { instruments.Store(prev) }
+}
+
+// currentMetrics returns the instruments of the runtime
+func currentMetrics() *runtimeMetrics {
+	return instruments.Load()
+}
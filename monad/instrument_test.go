@@ -0,0 +1,91 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/snowmerak/gofn/monad/metrics"
+)
+
+func TestSetMetricsCache(t *testing.T) {
+	m := metrics.NewMemory()
+	defer SetMetrics(m)()
+
+	cache := NewCache[string, int](1, 0)
+	cache.GetOrLoad(context.Background(), "a", NewTaskFromValue(1))
+	cache.Get("a")
+	cache.GetOrLoad(context.Background(), "b", NewTaskFromError[int](errors.New("boom")))
+	cache.Set("c", 3)
+
+	for name, want := range map[string]int64{
+		"gofn_cache_hits_total":        1,
+		"gofn_cache_misses_total":      2,
+		"gofn_cache_loads_total":       2,
+		"gofn_cache_load_errors_total": 1,
+		"gofn_cache_evictions_total":   1,
+	} {
+		if got := m.CounterValue(name); got != want {
+			t.Errorf("%s: expected %d, got %d", name, want, got)
+		}
+	}
+	if got := m.HistogramValue("gofn_cache_load_seconds").Count; got != 2 {
+		t.Errorf("Expected 2 load durations, got %d", got)
+	}
+}
+
+func TestSetMetricsExecutorAndReactive(t *testing.T) {
+	m := metrics.NewMemory()
+	defer SetMetrics(m)()
+
+	e := NewPriorityExecutor(1)
+	release := blockWorker(e)
+	ctx, cancel := context.WithCancel(context.Background())
+	shed := e.Submit(ctx, func() {})
+	if got := m.GaugeValue("gofn_executor_queued"); got != 1 {
+		t.Errorf("Expected 1 queued, got %v", got)
+	}
+	cancel()
+	release()
+	shed.Await()
+	e.Close()
+
+	if got := m.CounterValue("gofn_executor_executed_total"); got != 1 {
+		t.Errorf("Expected 1 executed, got %d", got)
+	}
+	if got := m.CounterValue("gofn_executor_shed_total"); got != 1 {
+		t.Errorf("Expected 1 shed, got %d", got)
+	}
+	if got := m.GaugeValue("gofn_executor_queued"); got != 0 {
+		t.Errorf("Expected an empty queue, got %v", got)
+	}
+
+	s := NewTestScheduler()
+	defer s.Install()()
+	reactive := NewReactive(0)
+	id := reactive.Subscribe(func(_, _ int) {})
+	reactive.Subscribe(func(_, _ int) {})
+	reactive.Set(1)
+	reactive.Update(func(v int) int { return v + 1 })
+	reactive.Unsubscribe(id)
+	reactive.Unsubscribe(id)
+	s.RunUntilIdle()
+
+	if got := m.CounterValue("gofn_reactive_updates_total"); got != 2 {
+		t.Errorf("Expected 2 updates, got %d", got)
+	}
+	if got := m.GaugeValue("gofn_reactive_subscribers"); got != 1 {
+		t.Errorf("Expected 1 subscriber, got %v", got)
+	}
+}
+
+func TestSetMetricsRestore(t *testing.T) {
+	m := metrics.NewMemory()
+	restore := SetMetrics(m)
+	restore()
+
+	NewCache[int, int](0, 0).Get(1)
+	if got := m.CounterValue("gofn_cache_misses_total"); got != 0 {
+		t.Errorf("Expected no measurements after restore, got %d", got)
+	}
+}
@@ -0,0 +1,58 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// ErrChannelClosed is returned by TaskFromChan when the channel closes before delivering a value
+var ErrChannelClosed = errors.New("monad: channel closed")
+
+// ToFunc converts the Task into a conventional Go function
+func (t Task[T]) ToFunc() func(context.Context) (T, error) {
+	return func(ctx context.Context) (T, error) {
+		return t(ctx).Unwrap()
+	}
+}
+
+// TaskFromHTTPHandler creates a Task serving req with handler in-process
+// The request runs with the Task's context and the recorded response is returned
+func TaskFromHTTPHandler(handler http.Handler, req *http.Request) Task[*http.Response] {
+	return func(ctx context.Context) Result[*http.Response] {
+		if err := ctx.Err(); err != nil {
+			return Err[*http.Response](err)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req.WithContext(ctx))
+		return Ok(recorder.Result())
+	}
+}
+
+// FutureFromWaitGroup returns a Future completing once wg's counter reaches zero
+func FutureFromWaitGroup(wg *sync.WaitGroup) *Future[Unit] {
+	future := NewFuture[Unit]()
+	Spawn(func() {
+		wg.Wait()
+		future.Complete(Unit{})
+	})
+	return future
+}
+
+// TaskFromChan creates a Task receiving a single value from ch
+// Fails with ErrChannelClosed if ch is closed, or with the context error if cancelled first
+func TaskFromChan[T any](ch <-chan T) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		select {
+		case val, ok := <-ch:
+			if !ok {
+				return Err[T](ErrChannelClosed)
+			}
+			return Ok(val)
+		case <-ctx.Done():
+			return Err[T](ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTaskToFunc(t *testing.T) {
+	fn := NewTaskFromValue(42).ToFunc()
+	val, err := fn(context.Background())
+	if err != nil || val != 42 {
+		t.Errorf("Expected 42, got %d (%v)", val, err)
+	}
+
+	testErr := errors.New("failed")
+	if _, err := NewTaskFromError[int](testErr).ToFunc()(context.Background()); err != testErr {
+		t.Errorf("Expected %v, got %v", testErr, err)
+	}
+}
+
+func TestTaskFromHTTPHandler(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		io.WriteString(w, "hello "+r.URL.Query().Get("name"))
+	})
+	req := httptest.NewRequest(http.MethodGet, "/?name=gofn", nil)
+
+	resp, err := TaskFromHTTPHandler(handler, req)(context.Background()).Unwrap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusTeapot || string(body) != "hello gofn" {
+		t.Errorf("Unexpected response %d %q", resp.StatusCode, body)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := TaskFromHTTPHandler(handler, req)(ctx).Unwrap(); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestFutureFromWaitGroup(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	future := FutureFromWaitGroup(&wg)
+
+	wg.Done()
+	if future.IsDone() {
+		t.Error("Future should wait for the whole group")
+	}
+	wg.Done()
+
+	if result := future.AwaitWithTimeout(time.Second); !result.IsOk() {
+		t.Errorf("Expected completion, got %v", result)
+	}
+}
+
+func TestTaskFromChan(t *testing.T) {
+	ch := make(chan int, 1)
+	ch <- 7
+	if val, err := TaskFromChan(ch)(context.Background()).Unwrap(); err != nil || val != 7 {
+		t.Errorf("Expected 7, got %d (%v)", val, err)
+	}
+
+	close(ch)
+	if _, err := TaskFromChan(ch)(context.Background()).Unwrap(); err != ErrChannelClosed {
+		t.Errorf("Expected ErrChannelClosed, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := TaskFromChan(make(chan int))(ctx).Unwrap(); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package monad
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// KVStore is an external key-value store holding serialized cache entries, such as Redis or memcached
+// Implementations honor ttl when they can; entries written by KVCache also carry their expiry, so a
+// store without native expiry only wastes space on stale entries
+type KVStore interface {
+	// Get returns the value stored under key, or None if there is none
+	Get(ctx context.Context, key string) Result[Option[[]byte]]
+	// Set stores value under key for ttl; 0 keeps it until it is deleted
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) Result[Unit]
+	// Delete removes key; deleting a missing key succeeds
+	Delete(ctx context.Context, key string) Result[Unit]
+}
+
+// MemoryKVStore is an in-process KVStore, the default of generated external caches and a stand-in for tests
+// Expiry is measured by the package Clock
+type MemoryKVStore struct {
+	mu      sync.Mutex
+	entries map[string]memoryKVEntry
+}
+
+// memoryKVEntry is a value of a MemoryKVStore
+type memoryKVEntry struct {
+	value   []byte
+	expires time.Time // zero when the entry does not expire
+}
+
+// NewMemoryKVStore creates an empty MemoryKVStore
+func NewMemoryKVStore() *MemoryKVStore {
+	return &MemoryKVStore{entries: map[string]memoryKVEntry{}}
+}
+
+// Get returns a copy of the live value stored under key
+func (s *MemoryKVStore) Get(_ context.Context, key string) Result[Option[[]byte]] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return Ok(None[[]byte]())
+	}
+	if !entry.expires.IsZero() && !currentClock().Now().Before(entry.expires) {
+		delete(s.entries, key)
+		return Ok(None[[]byte]())
+	}
+	return Ok(Some(append([]byte(nil), entry.value...)))
+}
+
+// Set stores a copy of value under key for ttl
+func (s *MemoryKVStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) Result[Unit] {
+	entry := memoryKVEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expires = currentClock().Now().Add(ttl)
+	}
+	s.mu.Lock()
+	s.entries[key] = entry
+	s.mu.Unlock()
+	return OkUnit()
+}
+
+// Delete removes key
+func (s *MemoryKVStore) Delete(_ context.Context, key string) Result[Unit] {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return OkUnit()
+}
+
+// Len returns the number of stored entries, including expired ones not read since they expired
+func (s *MemoryKVStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// KVKey builds a cache key from arguments, each encoded as JSON and separated by colons, e.g. KVKey(42, "en")
+// is `42:"en"`; JSON quoting keeps keys of different arguments distinct even when they contain colons
+// Arguments that cannot be encoded as JSON fail the call with the encoding error
+func KVKey(args ...any) Result[string] {
+	var b strings.Builder
+	for i, arg := range args {
+		enc, err := json.Marshal(arg)
+		if err != nil {
+			return Err[string](err)
+		}
+		if i > 0 {
+			b.WriteByte(':')
+		}
+		b.Write(enc)
+	}
+	return Ok(b.String())
+}
+
+// kvEnvelope is the serialized form of a KVCache entry
+type kvEnvelope[V any] struct {
+	Value   V          `json:"value"`
+	Expires *time.Time `json:"expires,omitempty"`
+}
+
+// EncodeKVEntry serializes value as JSON together with its expiry, ttl from now on the package Clock
+// A ttl of 0 writes an entry that never expires
+func EncodeKVEntry[V any](value V, ttl time.Duration) Result[[]byte] {
+	env := kvEnvelope[V]{Value: value}
+	if ttl > 0 {
+		expires := currentClock().Now().Add(ttl)
+		env.Expires = &expires
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return Err[[]byte](err)
+	}
+	return Ok(data)
+}
+
+// DecodeKVEntry deserializes an entry written by EncodeKVEntry, returning None once it has expired
+func DecodeKVEntry[V any](data []byte) Result[Option[V]] {
+	var env kvEnvelope[V]
+	if err := json.Unmarshal(data, &env); err != nil {
+		return Err[Option[V]](err)
+	}
+	if env.Expires != nil && !currentClock().Now().Before(*env.Expires) {
+		return Ok(None[V]())
+	}
+	return Ok(Some(env.Value))
+}
+
+// KVCacheMetrics is a snapshot of a KVCache's counters
+type KVCacheMetrics struct {
+	Hits        int64
+	Misses      int64
+	Loads       int64 // loader runs; concurrent misses for one key in this process share a single load
+	LoadErrors  int64
+	StoreErrors int64 // failed reads and writes of the store, and entries that could not be decoded
+}
+
+// KVCache caches values of type V in a KVStore under prefixed keys, serialized with EncodeKVEntry
+// The store is an optimization: when it fails the value is loaded and returned as if it were missing
+type KVCache[V any] struct {
+	store  KVStore
+	prefix string
+	ttl    time.Duration
+
+	mu       sync.Mutex
+	inflight map[string]*Future[V]
+
+	hits, misses, loads, loadErrors, storeErrors atomic.Int64
+}
+
+// NewKVCache creates a KVCache keeping values in store for ttl (0 for no expiry) under prefix+":"+key;
+// a nil store uses a new MemoryKVStore
+func NewKVCache[V any](store KVStore, prefix string, ttl time.Duration) *KVCache[V] {
+	if store == nil {
+		store = NewMemoryKVStore()
+	}
+	return &KVCache[V]{store: store, prefix: prefix, ttl: ttl, inflight: map[string]*Future[V]{}}
+}
+
+// Get returns the value cached under key, or None if it is absent, expired or unreadable
+func (c *KVCache[V]) Get(ctx context.Context, key string) Option[V] {
+	data, err := c.store.Get(ctx, c.key(key)).Unwrap()
+	if err != nil {
+		c.storeErrors.Add(1)
+		return None[V]()
+	}
+	if !data.IsSome() {
+		return None[V]()
+	}
+	value, err := DecodeKVEntry[V](data.Unwrap()).Unwrap()
+	if err != nil {
+		c.storeErrors.Add(1)
+		return None[V]()
+	}
+	return value
+}
+
+// Set caches value under key
+func (c *KVCache[V]) Set(ctx context.Context, key string, value V) Result[Unit] {
+	data, err := EncodeKVEntry(value, c.ttl).Unwrap()
+	if err != nil {
+		return Err[Unit](err)
+	}
+	return c.store.Set(ctx, c.key(key), data, c.ttl)
+}
+
+// Delete removes the value cached under key
+func (c *KVCache[V]) Delete(ctx context.Context, key string) Result[Unit] {
+	return c.store.Delete(ctx, c.key(key))
+}
+
+// GetOrLoad returns the value cached under key, or runs load and caches its value
+// Concurrent misses for the same key in this process share one load, run with the first caller's context;
+// failed loads are not cached and a failed write is only counted
+func (c *KVCache[V]) GetOrLoad(ctx context.Context, key string, load Task[V]) Result[V] {
+	m := currentMetrics()
+	if value := c.Get(ctx, key); value.IsSome() {
+		c.hits.Add(1)
+		m.cacheHits.Add(1)
+		return Ok(value.Unwrap())
+	}
+
+	c.mu.Lock()
+	c.misses.Add(1)
+	m.cacheMisses.Add(1)
+	if pending, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		return pending.AwaitWithContext(ctx)
+	}
+	pending := NewFuture[V]()
+	c.inflight[key] = pending
+	c.mu.Unlock()
+
+	c.loads.Add(1)
+	m.cacheLoads.Add(1)
+	start := currentClock().Now()
+	result := load(ctx)
+	m.cacheLoadSeconds.Observe(currentClock().Now().Sub(start).Seconds())
+	if value, err := result.Unwrap(); err == nil {
+		if !c.Set(ctx, key, value).IsOk() {
+			c.storeErrors.Add(1)
+		}
+	} else {
+		c.loadErrors.Add(1)
+		m.cacheLoadErrors.Add(1)
+	}
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	c.mu.Unlock()
+	pending.complete(result)
+	return result
+}
+
+// Metrics returns a snapshot of the cache's counters
+func (c *KVCache[V]) Metrics() KVCacheMetrics {
+	return KVCacheMetrics{
+		Hits:        c.hits.Load(),
+		Misses:      c.misses.Load(),
+		Loads:       c.loads.Load(),
+		LoadErrors:  c.loadErrors.Load(),
+		StoreErrors: c.storeErrors.Load(),
+	}
+}
+
+// key returns the store key of a cache key
+func (c *KVCache[V]) key(key string) string {
+	if c.prefix == "" {
+		return key
+	}
+	return c.prefix + ":" + key
+}
@@ -0,0 +1,175 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryKVStoreTTL(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+	ctx := context.Background()
+
+	store := NewMemoryKVStore()
+	value := []byte("v")
+	store.Set(ctx, "a", value, time.Minute)
+	store.Set(ctx, "b", []byte("w"), 0)
+	value[0] = 'x' // the store keeps its own copy
+
+	if got, _ := store.Get(ctx, "a").Unwrap(); !got.IsSome() || string(got.Unwrap()) != "v" {
+		t.Errorf("Expected a=v, got %v", got)
+	}
+	clock.Advance(time.Minute)
+	if got, _ := store.Get(ctx, "a").Unwrap(); got.IsSome() {
+		t.Error("a should expire after its ttl")
+	}
+	if got, _ := store.Get(ctx, "b").Unwrap(); !got.IsSome() {
+		t.Error("b has no ttl and should not expire")
+	}
+
+	store.Delete(ctx, "b")
+	if store.Len() != 0 {
+		t.Errorf("Expected an empty store, got %d entries", store.Len())
+	}
+}
+
+func TestKVKey(t *testing.T) {
+	key, err := KVKey(42, "a:b", []string{"x"}).Unwrap()
+	if err != nil || key != `42:"a:b":["x"]` {
+		t.Errorf("Unexpected key %q, %v", key, err)
+	}
+	if a, b := KVKey("a", "b:c"), KVKey("a:b", "c"); a == b {
+		t.Error("Keys of different arguments should differ")
+	}
+	if KVKey(func() {}).IsOk() {
+		t.Error("Arguments JSON cannot encode should fail")
+	}
+}
+
+func TestKVEntryCarriesExpiry(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	data, err := EncodeKVEntry("v", time.Second).Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := DecodeKVEntry[string](data).Unwrap(); !got.IsSome() || got.Unwrap() != "v" {
+		t.Errorf("Expected v, got %v", got)
+	}
+	clock.Advance(time.Second)
+	if got, _ := DecodeKVEntry[string](data).Unwrap(); got.IsSome() {
+		t.Error("The entry should expire even if the store keeps it")
+	}
+
+	forever, _ := EncodeKVEntry(1, 0).Unwrap()
+	clock.Advance(time.Hour)
+	if got, _ := DecodeKVEntry[int](forever).Unwrap(); !got.IsSome() {
+		t.Error("An entry without ttl should not expire")
+	}
+	if DecodeKVEntry[int]([]byte("{")).IsOk() {
+		t.Error("Malformed entries should fail to decode")
+	}
+}
+
+func TestKVCacheGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKVStore()
+	cache := NewKVCache[int](store, "sq", 0)
+
+	var loads atomic.Int32
+	load := func(n int) Task[int] {
+		return func(context.Context) Result[int] {
+			loads.Add(1)
+			return Ok(n * n)
+		}
+	}
+	for range 2 {
+		if v, err := cache.GetOrLoad(ctx, "3", load(3)).Unwrap(); err != nil || v != 9 {
+			t.Fatalf("Expected 9, got %d, %v", v, err)
+		}
+	}
+	if loads.Load() != 1 {
+		t.Errorf("Expected 1 load, got %d", loads.Load())
+	}
+	if got, _ := store.Get(ctx, "sq:3").Unwrap(); !got.IsSome() {
+		t.Error("The value should be stored under the prefixed key")
+	}
+
+	// another cache over the same store, e.g. in another process, sees the value
+	other := NewKVCache[int](store, "sq", 0)
+	if v := other.Get(ctx, "3"); !v.IsSome() || v.Unwrap() != 9 {
+		t.Errorf("Expected the shared value 9, got %v", v)
+	}
+
+	failed := errors.New("boom")
+	cache.GetOrLoad(ctx, "4", func(context.Context) Result[int] { return Err[int](failed) })
+	if cache.Get(ctx, "4").IsSome() {
+		t.Error("Failed loads should not be cached")
+	}
+	cache.Delete(ctx, "3")
+	if cache.Get(ctx, "3").IsSome() {
+		t.Error("Delete should remove the value")
+	}
+	if m := cache.Metrics(); m.Hits != 1 || m.Misses != 2 || m.Loads != 2 || m.LoadErrors != 1 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestKVCacheSingleflight(t *testing.T) {
+	cache := NewKVCache[string](nil, "", 0)
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := Task[string](func(context.Context) Result[string] {
+		loads.Add(1)
+		<-release
+		return Ok("v")
+	})
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.GetOrLoad(context.Background(), "k", load)
+		}()
+	}
+	for cache.Metrics().Misses < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if loads.Load() != 1 {
+		t.Errorf("Concurrent misses should share one load, got %d", loads.Load())
+	}
+}
+
+// failingKVStore fails every operation
+type failingKVStore struct{}
+
+func (failingKVStore) Get(context.Context, string) Result[Option[[]byte]] {
+	return Err[Option[[]byte]](errors.New("down"))
+}
+
+func (failingKVStore) Set(context.Context, string, []byte, time.Duration) Result[Unit] {
+	return Err[Unit](errors.New("down"))
+}
+
+func (failingKVStore) Delete(context.Context, string) Result[Unit] {
+	return Err[Unit](errors.New("down"))
+}
+
+func TestKVCacheStoreFailureLoads(t *testing.T) {
+	cache := NewKVCache[int](failingKVStore{}, "x", 0)
+	v, err := cache.GetOrLoad(context.Background(), "k", NewTaskFromValue(7)).Unwrap()
+	if err != nil || v != 7 {
+		t.Errorf("A failing store should fall back to loading, got %d, %v", v, err)
+	}
+	if m := cache.Metrics(); m.StoreErrors != 2 {
+		t.Errorf("Expected a failed read and write, got %+v", m)
+	}
+}
@@ -0,0 +1,127 @@
+package monad
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Lazy is a thunk evaluating its supplier at most once, on first access
+// Concurrent callers of Get block until the single evaluation has finished
+type Lazy[T any] struct {
+	once      sync.Once
+	supplier  func() T
+	value     T
+	evaluated atomic.Bool
+}
+
+// NewLazy creates a Lazy value computed by supplier on first access
+func NewLazy[T any](supplier func() T) *Lazy[T] {
+	return &Lazy[T]{supplier: supplier}
+}
+
+// Get returns the value, evaluating the supplier on the first call
+func (l *Lazy[T]) Get() T {
+	l.once.Do(func() {
+		l.value = l.supplier()
+		l.supplier = nil // release captured state
+		l.evaluated.Store(true)
+	})
+	return l.value
+}
+
+// IsEvaluated returns true once the supplier has run
+func (l *Lazy[T]) IsEvaluated() bool {
+	return l.evaluated.Load()
+}
+
+// LazyPolicy controls how a LazyResult treats a failed evaluation
+type LazyPolicy int
+
+const (
+	// CacheFailures keeps the first Result, Ok or Err, forever
+	CacheFailures LazyPolicy = iota
+	// RetryFailures caches only Ok Results; an Err is returned and the supplier runs again on the next Get
+	RetryFailures
+)
+
+// LazyResult is a thunk for fallible computations such as connections or configuration loading
+type LazyResult[T any] struct {
+	mu        sync.Mutex
+	supplier  func() Result[T]
+	policy    LazyPolicy
+	result    Result[T]
+	evaluated atomic.Bool
+}
+
+// NewLazyResult creates a LazyResult computed by supplier on first access, caching failures according to policy
+func NewLazyResult[T any](supplier func() Result[T], policy LazyPolicy) *LazyResult[T] {
+	return &LazyResult[T]{supplier: supplier, policy: policy}
+}
+
+// Get returns the cached Result or evaluates the supplier
+// Concurrent callers wait for an in-flight evaluation instead of starting their own
+func (l *LazyResult[T]) Get() Result[T] {
+	if l.evaluated.Load() {
+		return l.result
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.evaluated.Load() {
+		return l.result
+	}
+
+	result := l.supplier()
+	if result.IsOk() || l.policy == CacheFailures {
+		l.result = result
+		l.supplier = nil // release captured state
+		l.evaluated.Store(true)
+	}
+	return result
+}
+
+// IsEvaluated returns true once a Result has been cached
+func (l *LazyResult[T]) IsEvaluated() bool {
+	return l.evaluated.Load()
+}
+
+// ForceAll evaluates every Lazy concurrently and returns their values in order
+func ForceAll[T any](lazies ...*Lazy[T]) []T {
+	values := make([]T, len(lazies))
+	var wg sync.WaitGroup
+	for i, l := range lazies {
+		wg.Add(1)
+		Spawn(func() {
+			defer wg.Done()
+			values[i] = l.Get()
+		})
+	}
+	wg.Wait()
+	return values
+}
+
+// ForceAllResults evaluates every LazyResult concurrently
+// Returns the values in order, or the first error by index
+func ForceAllResults[T any](lazies ...*LazyResult[T]) Result[[]T] {
+	results := make([]Result[T], len(lazies))
+	var wg sync.WaitGroup
+	for i, l := range lazies {
+		wg.Add(1)
+		Spawn(func() {
+			defer wg.Done()
+			results[i] = l.Get()
+		})
+	}
+	wg.Wait()
+
+	values := make([]T, len(lazies))
+	for i, result := range results {
+		val, err := result.Unwrap()
+		if err != nil {
+			return Err[[]T](err)
+		}
+		values[i] = val
+	}
+	return Ok(values)
+}
@@ -0,0 +1,125 @@
+package monad
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLazyEvaluatesOnce(t *testing.T) {
+	var calls int64
+	lazy := NewLazy(func() int {
+		atomic.AddInt64(&calls, 1)
+		return 42
+	})
+
+	if lazy.IsEvaluated() {
+		t.Error("Lazy should not evaluate before Get")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if lazy.Get() != 42 {
+				t.Error("Expected 42")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("Expected supplier to run once, ran %d times", calls)
+	}
+	if !lazy.IsEvaluated() {
+		t.Error("Lazy should report evaluation")
+	}
+}
+
+func TestLazyResultCacheFailures(t *testing.T) {
+	calls := 0
+	testErr := errors.New("unavailable")
+	lazy := NewLazyResult(func() Result[string] {
+		calls++
+		return Err[string](testErr)
+	}, CacheFailures)
+
+	for i := 0; i < 3; i++ {
+		if _, err := lazy.Get().Unwrap(); err != testErr {
+			t.Errorf("Expected cached error, got %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected supplier to run once, ran %d times", calls)
+	}
+	if !lazy.IsEvaluated() {
+		t.Error("Cached failure should count as evaluated")
+	}
+}
+
+func TestLazyResultRetryFailures(t *testing.T) {
+	calls := 0
+	lazy := NewLazyResult(func() Result[string] {
+		calls++
+		if calls < 3 {
+			return Err[string](errors.New("not yet"))
+		}
+		return Ok("connected")
+	}, RetryFailures)
+
+	if lazy.Get().IsOk() || lazy.Get().IsOk() {
+		t.Error("First two evaluations should fail")
+	}
+	if lazy.IsEvaluated() {
+		t.Error("Failures should not be cached with RetryFailures")
+	}
+
+	for i := 0; i < 2; i++ {
+		if val, err := lazy.Get().Unwrap(); err != nil || val != "connected" {
+			t.Errorf("Expected connected, got %q (%v)", val, err)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 supplier calls, got %d", calls)
+	}
+}
+
+func TestForceAll(t *testing.T) {
+	lazies := []*Lazy[int]{
+		NewLazy(func() int { return 1 }),
+		NewLazy(func() int { return 2 }),
+		NewLazy(func() int { return 3 }),
+	}
+
+	values := ForceAll(lazies...)
+	for i, v := range values {
+		if v != i+1 {
+			t.Errorf("Expected %d at index %d, got %d", i+1, i, v)
+		}
+		if !lazies[i].IsEvaluated() {
+			t.Errorf("Lazy %d should be evaluated", i)
+		}
+	}
+}
+
+func TestForceAllResults(t *testing.T) {
+	ok := ForceAllResults(
+		NewLazyResult(func() Result[int] { return Ok(1) }, CacheFailures),
+		NewLazyResult(func() Result[int] { return Ok(2) }, CacheFailures),
+	)
+	values, err := ok.Unwrap()
+	if err != nil || len(values) != 2 || values[1] != 2 {
+		t.Errorf("Expected [1 2], got %v (%v)", values, err)
+	}
+
+	testErr := errors.New("boom")
+	failed := ForceAllResults(
+		NewLazyResult(func() Result[int] { return Ok(1) }, CacheFailures),
+		NewLazyResult(func() Result[int] { return Err[int](testErr) }, CacheFailures),
+	)
+	if _, err := failed.Unwrap(); err != testErr {
+		t.Errorf("Expected boom, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// checkNoLeaks runs fn and fails the test if goroutines started inside this package outlive it
+// It is a small internal take on goleak: goroutines are compared by count and stacks are reported on failure
+func checkNoLeaks(t *testing.T, fn func()) {
+	t.Helper()
+	before := runtime.NumGoroutine()
+
+	fn()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		if runtime.NumGoroutine() <= before {
+			return
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	buf := make([]byte, 1<<20)
+	stacks := string(buf[:runtime.Stack(buf, true)])
+	var leaked []string
+	for _, g := range strings.Split(stacks, "\n\n") {
+		if strings.Contains(g, "gofn/monad.") && !strings.Contains(g, "checkNoLeaks") {
+			leaked = append(leaked, g)
+		}
+	}
+	t.Errorf("goroutines leaked: %d before, %d after\n%s", before, runtime.NumGoroutine(), strings.Join(leaked, "\n\n"))
+}
+
+// blockingTask blocks until its context is cancelled
+func blockingTask[T any]() Task[T] {
+	return func(ctx context.Context) Result[T] {
+		<-ctx.Done()
+		return Err[T](ctx.Err())
+	}
+}
+
+func TestFutureCancel(t *testing.T) {
+	future := NewFuture[int]()
+	if future.IsCancelled() {
+		t.Error("Pending future should not be cancelled")
+	}
+
+	if !future.Cancel() {
+		t.Error("Cancel should succeed on a pending future")
+	}
+	if future.Cancel() {
+		t.Error("Cancel should fail on a completed future")
+	}
+	if !future.IsCancelled() {
+		t.Error("Future should report cancellation")
+	}
+
+	select {
+	case <-future.Done():
+	default:
+		t.Error("Done channel should be closed after Cancel")
+	}
+
+	if _, err := future.Await().Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Canceled, got %v", err)
+	}
+
+	completed := CompletedFuture(1)
+	if completed.Cancel() || completed.IsCancelled() {
+		t.Error("Completed future should not be cancellable")
+	}
+}
+
+func TestFutureDoneChannel(t *testing.T) {
+	future := NewFuture[string]()
+	select {
+	case <-future.Done():
+		t.Error("Done should not be closed before completion")
+	default:
+	}
+
+	future.Complete("x")
+	<-future.Done()
+}
+
+func TestFutureCombinatorsDoNotLeak(t *testing.T) {
+	checkNoLeaks(t, func() {
+		source := RunAsync(func() Result[int] { return Ok(1) })
+		mapped := MapFuture(source, func(x int) int { return x + 1 })
+		chained := AndThenFuture(mapped, func(x int) *Future[int] { return CompletedFuture(x * 2) })
+		chained.Await()
+
+		SequenceFutures([]*Future[int]{CompletedFuture(1), FailedFuture[int](errors.New("fail"))}).Await()
+		RaceFutures([]*Future[int]{CompletedFuture(1), NewFuture[int]()}).Await()
+		FirstCompleted([]*Future[int]{NewFuture[int](), FailedFuture[int](errors.New("fail"))}).Await()
+	})
+}
+
+func TestPendingCombinatorsDoNotHoldGoroutines(t *testing.T) {
+	checkNoLeaks(t, func() {
+		pending := NewFuture[int]()
+		MapFuture(pending, func(x int) int { return x })
+		AndThenFuture(pending, func(x int) *Future[int] { return CompletedFuture(x) })
+		SequenceFutures([]*Future[int]{pending})
+		RaceFutures([]*Future[int]{pending})
+		FirstCompleted([]*Future[int]{pending})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		pending.AwaitWithContext(ctx)
+		pending.AwaitWithTimeout(time.Millisecond)
+		pending.Cancel()
+	})
+}
+
+func TestTaskCombinatorsDoNotLeak(t *testing.T) {
+	checkNoLeaks(t, func() {
+		ctx := context.Background()
+
+		// A failing task cancels its blocking siblings once ParallelTasks returns
+		failing := NewTaskFromError[int](errors.New("fail"))
+		if ParallelTasks([]Task[int]{failing, blockingTask[int]()})(ctx).IsOk() {
+			t.Error("ParallelTasks should fail")
+		}
+
+		// The winner cancels the losers
+		if !RaceTasks([]Task[int]{blockingTask[int](), NewTaskFromValue(1)})(ctx).IsOk() {
+			t.Error("RaceTasks should succeed")
+		}
+
+		// A timed out task is cancelled
+		WithTimeout(blockingTask[int](), time.Millisecond)(ctx)
+	})
+}
@@ -0,0 +1,163 @@
+package monad
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrMailboxStopped is returned for messages sent to, or discarded by, a stopped Mailbox
+var ErrMailboxStopped = errors.New("monad: mailbox stopped")
+
+// mailboxMessage is a queued unit of work; cancel is called instead of run when the message is discarded
+type mailboxMessage struct {
+	run    func()
+	cancel func()
+}
+
+// Mailbox runs submitted functions one at a time in submission order
+// It is the runtime behind generated actors: no goroutine is held while the mailbox is idle,
+// and processing is started on the package Executor whenever messages arrive
+type Mailbox struct {
+	mu       sync.Mutex
+	idle     *sync.Cond
+	queue    []mailboxMessage
+	running  bool
+	stopped  bool
+	stopDone chan struct{}
+}
+
+// NewMailbox creates an empty, running Mailbox
+func NewMailbox() *Mailbox {
+	m := &Mailbox{stopDone: make(chan struct{})}
+	m.idle = sync.NewCond(&m.mu)
+	return m
+}
+
+// Send queues fn, returning false if the mailbox has been stopped
+func (m *Mailbox) Send(fn func()) bool {
+	return m.send(mailboxMessage{run: fn})
+}
+
+// send queues a message and starts processing if the mailbox is idle
+func (m *Mailbox) send(msg mailboxMessage) bool {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		return false
+	}
+	m.queue = append(m.queue, msg)
+	if m.running {
+		m.mu.Unlock()
+		return true
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	Spawn(m.process)
+	return true
+}
+
+// process runs queued messages until the queue is empty
+// A panicking message hands the rest of the queue to a new processor before the panic goes on, so the
+// mailbox keeps running
+func (m *Mailbox) process() {
+	returned := false
+	defer func() {
+		if returned {
+			return
+		}
+		m.mu.Lock()
+		if len(m.queue) == 0 {
+			m.running = false
+			m.idle.Broadcast()
+			m.mu.Unlock()
+			return
+		}
+		m.mu.Unlock()
+		Spawn(m.process)
+	}()
+	for {
+		m.mu.Lock()
+		if len(m.queue) == 0 {
+			m.running = false
+			m.idle.Broadcast()
+			m.mu.Unlock()
+			returned = true
+			return
+		}
+		msg := m.queue[0]
+		m.queue = m.queue[1:]
+		m.mu.Unlock()
+
+		msg.run()
+	}
+}
+
+// Stop rejects new messages and discards the queued ones; the message being processed finishes
+func (m *Mailbox) Stop() {
+	m.mu.Lock()
+	dropped := m.queue
+	m.queue = nil
+	first := !m.stopped
+	m.stopped = true
+	m.mu.Unlock()
+
+	if first {
+		close(m.stopDone)
+	}
+	for _, msg := range dropped {
+		if msg.cancel != nil {
+			msg.cancel()
+		}
+	}
+}
+
+// Drain rejects new messages and blocks until every queued message has been processed
+// Must not be called from inside a message, which would wait on itself
+func (m *Mailbox) Drain() {
+	m.mu.Lock()
+	first := !m.stopped
+	m.stopped = true
+	for m.running || len(m.queue) > 0 {
+		m.idle.Wait()
+	}
+	m.mu.Unlock()
+
+	if first {
+		close(m.stopDone)
+	}
+}
+
+// Stopped returns a channel closed once Stop or Drain has been called
+func (m *Mailbox) Stopped() <-chan struct{} {
+	return m.stopDone
+}
+
+// Ask queues fn on the mailbox and returns a Future of its Result
+// The Future fails with ErrMailboxStopped if the mailbox is stopped before fn runs, and with a PanicError
+// if fn panics
+func Ask[T any](m *Mailbox, fn func() Result[T]) *Future[T] {
+	future := NewFuture[T]()
+	sent := m.send(mailboxMessage{
+		run: func() {
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				r := recover()
+				future.complete(Err[T](&PanicError{Value: r}))
+				if r != nil {
+					panic(r)
+				}
+			}()
+			future.complete(fn())
+			completed = true
+		},
+		cancel: func() { future.complete(Err[T](ErrMailboxStopped)) },
+	})
+	if !sent {
+		future.complete(Err[T](ErrMailboxStopped))
+	}
+	return future
+}
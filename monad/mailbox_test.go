@@ -0,0 +1,132 @@
+package monad
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMailboxSerializesInOrder(t *testing.T) {
+	m := NewMailbox()
+
+	var got []int
+	var wg sync.WaitGroup
+	wg.Add(100)
+	for i := 0; i < 100; i++ {
+		m.Send(func() {
+			got = append(got, i) // safe: messages never run concurrently
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("Expected messages in order, got %v", got)
+		}
+	}
+}
+
+func TestAsk(t *testing.T) {
+	m := NewMailbox()
+	counter := 0
+
+	futures := make([]*Future[int], 10)
+	for i := range futures {
+		futures[i] = Ask(m, func() Result[int] {
+			counter++
+			return Ok(counter)
+		})
+	}
+
+	for i, f := range futures {
+		if val, err := f.AwaitWithTimeout(time.Second).Unwrap(); err != nil || val != i+1 {
+			t.Errorf("Expected %d, got %d (%v)", i+1, val, err)
+		}
+	}
+}
+
+func TestMailboxDrain(t *testing.T) {
+	m := NewMailbox()
+	processed := 0
+	for i := 0; i < 5; i++ {
+		m.Send(func() {
+			time.Sleep(time.Millisecond)
+			processed++
+		})
+	}
+
+	m.Drain()
+	if processed != 5 {
+		t.Errorf("Drain should process queued messages, processed %d", processed)
+	}
+	if m.Send(func() {}) {
+		t.Error("Send after Drain should be rejected")
+	}
+	select {
+	case <-m.Stopped():
+	default:
+		t.Error("Stopped should be closed after Drain")
+	}
+}
+
+func TestMailboxStopDiscards(t *testing.T) {
+	m := NewMailbox()
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	first := Ask(m, func() Result[int] {
+		close(started)
+		<-release
+		return Ok(1)
+	})
+	second := Ask(m, func() Result[int] { return Ok(2) })
+
+	<-started
+	m.Stop()
+	close(release)
+
+	if val, err := first.AwaitWithTimeout(time.Second).Unwrap(); err != nil || val != 1 {
+		t.Errorf("In-flight message should finish, got %d (%v)", val, err)
+	}
+	if _, err := second.AwaitWithTimeout(time.Second).Unwrap(); err != ErrMailboxStopped {
+		t.Errorf("Queued message should be discarded, got %v", err)
+	}
+	if _, err := Ask(m, func() Result[int] { return Ok(3) }).Await().Unwrap(); err != ErrMailboxStopped {
+		t.Errorf("Ask after Stop should fail, got %v", err)
+	}
+}
+
+func TestMailboxSurvivesPanickingMessage(t *testing.T) {
+	exec := newRecoveringExecutor()
+	defer SetExecutor(exec)()
+	m := NewMailbox()
+
+	failed := Ask(m, func() Result[int] { panic("message failed") })
+	next := Ask(m, func() Result[int] { return Ok(2) })
+
+	var panicErr *PanicError
+	if _, err := failed.AwaitWithTimeout(time.Second).Unwrap(); !errors.As(err, &panicErr) || panicErr.Value != "message failed" {
+		t.Errorf("A panicking message should fail its Future with a PanicError, got %v", err)
+	}
+	if r := <-exec.panics; r != "message failed" {
+		t.Errorf("The panic should propagate, got %v", r)
+	}
+	if val, err := next.AwaitWithTimeout(time.Second).Unwrap(); err != nil || val != 2 {
+		t.Errorf("Messages queued behind a panic should still run, got %d (%v)", val, err)
+	}
+
+	m.Send(func() { panic("last message failed") })
+	<-exec.panics
+	done := make(chan struct{})
+	go func() {
+		m.Drain()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Drain should return after the last message panicked")
+	}
+}
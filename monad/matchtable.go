@@ -0,0 +1,65 @@
+package monad
+
+// MatchTable dispatches values to the handler of the first case they match; it backs the generated <Type>Table matchers
+// Cases whose pattern is an exact key are looked up in a map, so dispatch stays O(1) however many of them there are;
+// other cases (wildcards, guards) are tried in registration order, and first-match order is kept across both kinds
+// Build a table once and reuse it: registering cases is not safe concurrently with Dispatch, dispatching is
+type MatchTable[K comparable, V, R any] struct {
+	exact    map[K]int
+	cases    []matchCase[V, R]
+	fallback []int // indexes of cases that are not exact keys, in registration order
+}
+
+// matchCase is a registered case; match is nil for exact cases
+type matchCase[V, R any] struct {
+	match   func(V) bool
+	handler func(V) R
+}
+
+// NewMatchTable creates an empty MatchTable
+func NewMatchTable[K comparable, V, R any]() *MatchTable[K, V, R] {
+	return &MatchTable[K, V, R]{exact: map[K]int{}}
+}
+
+// AddExact registers a case matching values whose key equals key
+// A later case with the same key is unreachable and ignored
+func (t *MatchTable[K, V, R]) AddExact(key K, handler func(V) R) {
+	if _, ok := t.exact[key]; ok {
+		return
+	}
+	t.exact[key] = len(t.cases)
+	t.cases = append(t.cases, matchCase[V, R]{handler: handler})
+}
+
+// Add registers a case matching the values match accepts
+func (t *MatchTable[K, V, R]) Add(match func(V) bool, handler func(V) R) {
+	t.fallback = append(t.fallback, len(t.cases))
+	t.cases = append(t.cases, matchCase[V, R]{match: match, handler: handler})
+}
+
+// Len returns the number of registered cases
+func (t *MatchTable[K, V, R]) Len() int {
+	return len(t.cases)
+}
+
+// Dispatch runs the handler of the first case matching value, whose key is key, reporting whether one matched
+func (t *MatchTable[K, V, R]) Dispatch(key K, value V) (R, bool) {
+	first, exact := t.exact[key]
+	if !exact {
+		first = len(t.cases)
+	}
+	// only fallback cases registered before the exact hit can take precedence over it
+	for _, i := range t.fallback {
+		if i > first {
+			break
+		}
+		if t.cases[i].match(value) {
+			return t.cases[i].handler(value), true
+		}
+	}
+	if exact {
+		return t.cases[first].handler(value), true
+	}
+	var zero R
+	return zero, false
+}
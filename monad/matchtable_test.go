@@ -0,0 +1,79 @@
+package monad
+
+import (
+	"fmt"
+	"testing"
+)
+
+type matchKey struct {
+	kind string
+	code int
+}
+
+func TestMatchTableExact(t *testing.T) {
+	table := NewMatchTable[matchKey, matchKey, string]()
+	table.AddExact(matchKey{"a", 1}, func(matchKey) string { return "a1" })
+	table.AddExact(matchKey{"b", 2}, func(matchKey) string { return "b2" })
+	table.AddExact(matchKey{"a", 1}, func(matchKey) string { return "unreachable" })
+
+	if got, ok := table.Dispatch(matchKey{"a", 1}, matchKey{"a", 1}); !ok || got != "a1" {
+		t.Errorf("Expected the first a1 case, got %q, %v", got, ok)
+	}
+	if _, ok := table.Dispatch(matchKey{"c", 3}, matchKey{"c", 3}); ok {
+		t.Error("An unknown key should not match")
+	}
+	if table.Len() != 2 {
+		t.Errorf("Expected the duplicate key to be ignored, got %d cases", table.Len())
+	}
+}
+
+func TestMatchTableKeepsRegistrationOrder(t *testing.T) {
+	table := NewMatchTable[matchKey, matchKey, string]()
+	table.AddExact(matchKey{"a", 1}, func(matchKey) string { return "exact a1" })
+	table.Add(func(v matchKey) bool { return v.kind == "a" }, func(matchKey) string { return "any a" })
+	table.AddExact(matchKey{"a", 2}, func(matchKey) string { return "exact a2" })
+
+	cases := map[matchKey]string{
+		{"a", 1}: "exact a1", // exact case registered before the fallback
+		{"a", 2}: "any a",    // fallback registered before the exact case
+		{"a", 3}: "any a",
+	}
+	for key, want := range cases {
+		if got, ok := table.Dispatch(key, key); !ok || got != want {
+			t.Errorf("Dispatch(%v): expected %q, got %q, %v", key, want, got, ok)
+		}
+	}
+}
+
+// linearMatch mirrors the fluent matchers, testing every case in order
+func linearMatch(cases []matchKey, v matchKey) int {
+	for i, c := range cases {
+		if c == v {
+			return i
+		}
+	}
+	return -1
+}
+
+func BenchmarkMatchTable(b *testing.B) {
+	for _, n := range []int{4, 64, 1024} {
+		cases := make([]matchKey, n)
+		table := NewMatchTable[matchKey, matchKey, int]()
+		for i := range cases {
+			cases[i] = matchKey{"kind", i}
+			table.AddExact(cases[i], func(matchKey) int { return i })
+		}
+		last := cases[n-1]
+
+		b.Run(fmt.Sprintf("linear/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				linearMatch(cases, last)
+			}
+		})
+		b.Run(fmt.Sprintf("table/%d", n), func(b *testing.B) {
+			for b.Loop() {
+				table.Dispatch(last, last)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+)
+
+// Expvar is a Provider publishing instruments as expvar variables, served on /debug/vars
+// Counters become expvar.Int, gauges expvar.Float and histograms an expvar.Map with count and sum
+type Expvar struct {
+	prefix string
+	mu     sync.Mutex
+}
+
+// NewExpvar creates a Provider publishing every instrument under prefix+name
+func NewExpvar(prefix string) *Expvar {
+	return &Expvar{prefix: prefix}
+}
+
+// Counter publishes or reuses the expvar.Int named prefix+name
+func (e *Expvar) Counter(name, _ string) Counter {
+	return publish(e, name, func() *expvar.Int { return new(expvar.Int) })
+}
+
+// Gauge publishes or reuses the expvar.Float named prefix+name
+func (e *Expvar) Gauge(name, _ string) Gauge {
+	return publish(e, name, func() *expvar.Float { return new(expvar.Float) })
+}
+
+// Histogram publishes or reuses the expvar.Map named prefix+name
+func (e *Expvar) Histogram(name, _ string) Histogram {
+	return expvarHistogram{publish(e, name, func() *expvar.Map { return new(expvar.Map).Init() })}
+}
+
+// publish returns the variable already published under the name, or publishes a new one
+// expvar panics on duplicate names, so a name taken by a variable of another type is an error
+func publish[V expvar.Var](e *Expvar, name string, create func() V) V {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	full := e.prefix + name
+	if existing := expvar.Get(full); existing != nil {
+		v, ok := existing.(V)
+		if !ok {
+			panic(fmt.Sprintf("metrics: expvar %q already published as %T", full, existing))
+		}
+		return v
+	}
+	v := create()
+	expvar.Publish(full, v)
+	return v
+}
+
+// expvarHistogram keeps the count and sum of observations
+type expvarHistogram struct{ m *expvar.Map }
+
+func (h expvarHistogram) Observe(value float64) {
+	h.m.Add("count", 1)
+	h.m.AddFloat("sum", value)
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestExpvar(t *testing.T) {
+	p := NewExpvar("expvar_test_")
+	p.Counter("hits_total", "").Add(2)
+	p.Counter("hits_total", "").Add(3)
+	p.Gauge("queued", "").Set(4)
+	p.Histogram("wait_seconds", "").Observe(0.5)
+
+	if got := expvar.Get("expvar_test_hits_total").(*expvar.Int).Value(); got != 5 {
+		t.Errorf("Expected the counter to be reused, got %d", got)
+	}
+	if got := expvar.Get("expvar_test_queued").(*expvar.Float).Value(); got != 4 {
+		t.Errorf("Expected 4, got %v", got)
+	}
+	hist := expvar.Get("expvar_test_wait_seconds").(*expvar.Map)
+	if got := hist.Get("count").(*expvar.Int).Value(); got != 1 {
+		t.Errorf("Expected 1 observation, got %d", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Reusing a name for another instrument type should panic")
+		}
+	}()
+	p.Gauge("hits_total", "")
+}
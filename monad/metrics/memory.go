@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// Memory is a Provider keeping every instrument in memory, for tests and for custom exporters
+type Memory struct {
+	mu         sync.Mutex
+	counters   map[string]*memoryCounter
+	gauges     map[string]*memoryGauge
+	histograms map[string]*memoryHistogram
+}
+
+// HistogramSnapshot summarizes the observations of a histogram
+type HistogramSnapshot struct {
+	Count int64
+	Sum   float64
+}
+
+// NewMemory creates an empty Memory provider
+func NewMemory() *Memory {
+	return &Memory{
+		counters:   map[string]*memoryCounter{},
+		gauges:     map[string]*memoryGauge{},
+		histograms: map[string]*memoryHistogram{},
+	}
+}
+
+// Counter returns the counter registered under name, creating it on first use
+func (m *Memory) Counter(name, _ string) Counter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counters[name] == nil {
+		m.counters[name] = &memoryCounter{}
+	}
+	return m.counters[name]
+}
+
+// Gauge returns the gauge registered under name, creating it on first use
+func (m *Memory) Gauge(name, _ string) Gauge {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.gauges[name] == nil {
+		m.gauges[name] = &memoryGauge{}
+	}
+	return m.gauges[name]
+}
+
+// Histogram returns the histogram registered under name, creating it on first use
+func (m *Memory) Histogram(name, _ string) Histogram {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.histograms[name] == nil {
+		m.histograms[name] = &memoryHistogram{}
+	}
+	return m.histograms[name]
+}
+
+// CounterValue returns the value of a counter, 0 if it was never created
+func (m *Memory) CounterValue(name string) int64 {
+	m.mu.Lock()
+	c := m.counters[name]
+	m.mu.Unlock()
+	if c == nil {
+		return 0
+	}
+	return c.n.Load()
+}
+
+// GaugeValue returns the value of a gauge, 0 if it was never created
+func (m *Memory) GaugeValue(name string) float64 {
+	m.mu.Lock()
+	g := m.gauges[name]
+	m.mu.Unlock()
+	if g == nil {
+		return 0
+	}
+	return math.Float64frombits(g.bits.Load())
+}
+
+// HistogramValue returns the summary of a histogram, empty if it was never created
+func (m *Memory) HistogramValue(name string) HistogramSnapshot {
+	m.mu.Lock()
+	h := m.histograms[name]
+	m.mu.Unlock()
+	if h == nil {
+		return HistogramSnapshot{}
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.snapshot
+}
+
+type memoryCounter struct{ n atomic.Int64 }
+
+func (c *memoryCounter) Add(delta int64) { c.n.Add(delta) }
+
+type memoryGauge struct{ bits atomic.Uint64 }
+
+func (g *memoryGauge) Set(value float64) { g.bits.Store(math.Float64bits(value)) }
+
+func (g *memoryGauge) Add(delta float64) {
+	for {
+		old := g.bits.Load()
+		if g.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+type memoryHistogram struct {
+	mu       sync.Mutex
+	snapshot HistogramSnapshot
+}
+
+func (h *memoryHistogram) Observe(value float64) {
+	h.mu.Lock()
+	h.snapshot.Count++
+	h.snapshot.Sum += value
+	h.mu.Unlock()
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				m.Counter("requests_total", "").Add(1)
+				m.Gauge("in_flight", "").Add(0.5)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := m.CounterValue("requests_total"); got != 800 {
+		t.Errorf("Expected 800, got %d", got)
+	}
+	if got := m.GaugeValue("in_flight"); got != 400 {
+		t.Errorf("Expected 400, got %v", got)
+	}
+	m.Gauge("in_flight", "").Set(3)
+	if got := m.GaugeValue("in_flight"); got != 3 {
+		t.Errorf("Expected 3 after Set, got %v", got)
+	}
+
+	m.Histogram("latency_seconds", "").Observe(0.25)
+	m.Histogram("latency_seconds", "").Observe(0.75)
+	if got := m.HistogramValue("latency_seconds"); got.Count != 2 || got.Sum != 1 {
+		t.Errorf("Expected 2 observations summing to 1, got %+v", got)
+	}
+
+	if m.CounterValue("missing") != 0 || m.GaugeValue("missing") != 0 || m.HistogramValue("missing").Count != 0 {
+		t.Error("Missing instruments should read as zero")
+	}
+}
@@ -0,0 +1,48 @@
+// Package metrics defines the instruments the monad runtime reports through, with a no-op default
+// and adapters for expvar and for in-memory inspection.
+// Other backends, such as a Prometheus registry, plug in by implementing Provider; instrument names
+// follow Prometheus conventions (gofn_cache_hits_total, gofn_executor_queue_wait_seconds).
+package metrics
+
+// Counter is a monotonically increasing count
+type Counter interface {
+	Add(delta int64)
+}
+
+// Gauge is a value that goes up and down
+type Gauge interface {
+	Add(delta float64)
+	Set(value float64)
+}
+
+// Histogram records a distribution of observations, e.g. durations in seconds
+type Histogram interface {
+	Observe(value float64)
+}
+
+// Provider creates named instruments; asking twice for the same name returns the same instrument
+type Provider interface {
+	Counter(name, help string) Counter
+	Gauge(name, help string) Gauge
+	Histogram(name, help string) Histogram
+}
+
+// noop discards every measurement
+type noop struct{}
+
+func (noop) Add(int64)                          {}
+func (noop) Observe(float64)                    {}
+func (noop) Counter(string, string) Counter     { return noop{} }
+func (noop) Gauge(string, string) Gauge         { return noopGauge{} }
+func (noop) Histogram(string, string) Histogram { return noop{} }
+
+// noopGauge is the Gauge of the no-op Provider; it is separate from noop because Add differs in type
+type noopGauge struct{}
+
+func (noopGauge) Add(float64) {}
+func (noopGauge) Set(float64) {}
+
+// Noop returns the Provider discarding every measurement; it is the default of the monad package
+func Noop() Provider {
+	return noop{}
+}
@@ -0,0 +1,11 @@
+package metrics
+
+import "testing"
+
+func TestNoop(t *testing.T) {
+	p := Noop()
+	p.Counter("c", "").Add(1)
+	p.Gauge("g", "").Add(1)
+	p.Gauge("g", "").Set(2)
+	p.Histogram("h", "").Observe(3)
+}
@@ -0,0 +1,158 @@
+package monad
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// ErrMisuse matches every MisuseError
+var ErrMisuse = errors.New("monad: misuse")
+
+// MisuseError describes an accessor called on the wrong variant, e.g. Option.Unwrap on None
+type MisuseError struct {
+	Op  string // the accessor, e.g. "Option.Unwrap"
+	Msg string // the message a panic would carry
+}
+
+func (e *MisuseError) Error() string { return "monad: " + e.Msg }
+
+// Is makes errors.Is(err, ErrMisuse) hold for every MisuseError
+func (e *MisuseError) Is(target error) bool { return target == ErrMisuse }
+
+// PanicPolicy controls what Unwrap-style accessors do when called on the wrong variant
+type PanicPolicy int
+
+const (
+	// PanicOnMisuse panics, the default; keep it in tests so misuse fails loudly
+	PanicOnMisuse PanicPolicy = iota
+	// ZeroOnMisuse silently returns the zero value
+	ZeroOnMisuse
+	// LogOnMisuse logs the MisuseError with slog.Default and returns the zero value
+	LogOnMisuse
+	// HookOnMisuse passes the MisuseError to the hook set by SetPanicHook and returns the zero value;
+	// without a hook it panics
+	HookOnMisuse
+)
+
+var (
+	policyMu    sync.RWMutex
+	panicPolicy = PanicOnMisuse
+	panicHook   func(error)
+)
+
+// SetPanicPolicy replaces the package-wide PanicPolicy and returns a function restoring the previous one
+// Services can opt into a non-panicking policy in production while tests keep the default
+func SetPanicPolicy(p PanicPolicy) (restore func()) {
+	policyMu.Lock()
+	prev := panicPolicy
+	panicPolicy = p
+	policyMu.Unlock()
+
+	return func() {
+		policyMu.Lock()
+		panicPolicy = prev
+		policyMu.Unlock()
+	}
+}
+
+// SetPanicHook sets the hook called under HookOnMisuse and returns a function restoring the previous one
+func SetPanicHook(hook func(error)) (restore func()) {
+	policyMu.Lock()
+	prev := panicHook
+	panicHook = hook
+	policyMu.Unlock()
+
+	return func() {
+		policyMu.Lock()
+		panicHook = prev
+		policyMu.Unlock()
+	}
+}
+
+// currentPanicPolicy returns the package-wide PanicPolicy
+func currentPanicPolicy() PanicPolicy {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+	return panicPolicy
+}
+
+// misuse applies policy to a misused accessor, returning the zero value when it does not panic
+func misuse[T any](policy PanicPolicy, op, msg string) T {
+	err := &MisuseError{Op: op, Msg: msg}
+	switch policy {
+	case ZeroOnMisuse:
+	case LogOnMisuse:
+		slog.Error("monad: misuse", "op", op, "error", err)
+	case HookOnMisuse:
+		policyMu.RLock()
+		hook := panicHook
+		policyMu.RUnlock()
+		if hook == nil {
+			panic(msg)
+		}
+		hook(err)
+	default:
+		panic(msg)
+	}
+	var zero T
+	return zero
+}
+
+// UnwrapWith returns the contained value, applying policy instead of the package-wide one if it is None or Wildcard
+func (o Option[T]) UnwrapWith(policy PanicPolicy) T {
+	switch o.state {
+	case optionWildcard:
+		return misuse[T](policy, "Option.Unwrap", "called Unwrap on Wildcard value")
+	case optionNone:
+		return misuse[T](policy, "Option.Unwrap", "called Unwrap on None value")
+	}
+	return o.value
+}
+
+// TryUnwrap returns the contained value, or the zero value and a MisuseError if it is None or Wildcard
+func (o Option[T]) TryUnwrap() (T, error) {
+	if o.state == optionSome {
+		return o.value, nil
+	}
+	msg := "called Unwrap on None value"
+	if o.state == optionWildcard {
+		msg = "called Unwrap on Wildcard value"
+	}
+	var zero T
+	return zero, &MisuseError{Op: "Option.Unwrap", Msg: msg}
+}
+
+// UnwrapLeftWith returns the Left value, applying policy instead of the package-wide one if it is Right
+func (e Either[L, R]) UnwrapLeftWith(policy PanicPolicy) L {
+	if e.isRight {
+		return misuse[L](policy, "Either.UnwrapLeft", "called UnwrapLeft on Right value")
+	}
+	return e.left
+}
+
+// UnwrapRightWith returns the Right value, applying policy instead of the package-wide one if it is Left
+func (e Either[L, R]) UnwrapRightWith(policy PanicPolicy) R {
+	if !e.isRight {
+		return misuse[R](policy, "Either.UnwrapRight", "called UnwrapRight on Left value")
+	}
+	return e.right
+}
+
+// TryLeft returns the Left value, or the zero value and a MisuseError if it is Right
+func (e Either[L, R]) TryLeft() (L, error) {
+	if e.isRight {
+		var zero L
+		return zero, &MisuseError{Op: "Either.UnwrapLeft", Msg: "called UnwrapLeft on Right value"}
+	}
+	return e.left, nil
+}
+
+// TryRight returns the Right value, or the zero value and a MisuseError if it is Left
+func (e Either[L, R]) TryRight() (R, error) {
+	if !e.isRight {
+		var zero R
+		return zero, &MisuseError{Op: "Either.UnwrapRight", Msg: "called UnwrapRight on Left value"}
+	}
+	return e.right, nil
+}
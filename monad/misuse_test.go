@@ -0,0 +1,87 @@
+package monad
+
+import (
+	"errors"
+	"testing"
+)
+
+func mustPanic(t *testing.T, want string, fn func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		if got := recover(); got != want {
+			t.Fatalf("panic = %v, want %q", got, want)
+		}
+	}()
+	fn()
+}
+
+func TestPanicPolicyDefaultPanics(t *testing.T) {
+	mustPanic(t, "called Unwrap on None value", func() { None[int]().Unwrap() })
+	mustPanic(t, "called Unwrap on Wildcard value", func() { Wildcard[int]().Unwrap() })
+	mustPanic(t, "called UnwrapLeft on Right value", func() { Right[int](1).UnwrapLeft() })
+	mustPanic(t, "called UnwrapRight on Left value", func() { Left[int, string](1).UnwrapRight() })
+}
+
+func TestPanicPolicyZero(t *testing.T) {
+	defer SetPanicPolicy(ZeroOnMisuse)()
+
+	if v := None[int]().Unwrap(); v != 0 {
+		t.Errorf("Unwrap = %d, want 0", v)
+	}
+	if v := Right[string](1).UnwrapLeft(); v != "" {
+		t.Errorf("UnwrapLeft = %q, want empty", v)
+	}
+	if v := Some(3).Unwrap(); v != 3 {
+		t.Errorf("Unwrap = %d, want 3", v)
+	}
+}
+
+func TestPanicPolicyHook(t *testing.T) {
+	defer SetPanicPolicy(HookOnMisuse)()
+
+	mustPanic(t, "called Unwrap on None value", func() { None[int]().Unwrap() })
+
+	var got []error
+	defer SetPanicHook(func(err error) { got = append(got, err) })()
+	None[int]().Unwrap()
+	Left[int, string](1).UnwrapRight()
+
+	if len(got) != 2 {
+		t.Fatalf("hook calls = %d, want 2", len(got))
+	}
+	var me *MisuseError
+	if !errors.As(got[1], &me) || me.Op != "Either.UnwrapRight" {
+		t.Errorf("hook error = %v, want Either.UnwrapRight misuse", got[1])
+	}
+	if !errors.Is(got[0], ErrMisuse) {
+		t.Errorf("hook error %v does not match ErrMisuse", got[0])
+	}
+}
+
+func TestPanicPolicyPerCall(t *testing.T) {
+	defer SetPanicPolicy(ZeroOnMisuse)()
+
+	mustPanic(t, "called Unwrap on None value", func() { None[int]().UnwrapWith(PanicOnMisuse) })
+	if v := None[int]().UnwrapWith(LogOnMisuse); v != 0 {
+		t.Errorf("UnwrapWith = %d, want 0", v)
+	}
+	if v := Left[int, string](4).UnwrapLeftWith(PanicOnMisuse); v != 4 {
+		t.Errorf("UnwrapLeftWith = %d, want 4", v)
+	}
+}
+
+func TestTryUnwrap(t *testing.T) {
+	if v, err := Some(5).TryUnwrap(); v != 5 || err != nil {
+		t.Errorf("TryUnwrap = %d, %v; want 5, nil", v, err)
+	}
+	if _, err := Wildcard[int]().TryUnwrap(); !errors.Is(err, ErrMisuse) || err.Error() != "monad: called Unwrap on Wildcard value" {
+		t.Errorf("TryUnwrap error = %v", err)
+	}
+	if v, err := Right[int]("x").TryRight(); v != "x" || err != nil {
+		t.Errorf("TryRight = %q, %v; want x, nil", v, err)
+	}
+	if _, err := Right[int]("x").TryLeft(); !errors.Is(err, ErrMisuse) {
+		t.Errorf("TryLeft error = %v, want ErrMisuse", err)
+	}
+}
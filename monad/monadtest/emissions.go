@@ -0,0 +1,63 @@
+package monadtest
+
+import (
+	"context"
+	"time"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// EmissionTimeout bounds how long ExpectEmissions and ExpectRecorded wait for a stream before failing,
+// so a stream that never completes fails the test instead of hanging it
+var EmissionTimeout = 5 * time.Second
+
+// ExpectEmissions subscribes to obs and checks that it emits exactly want, in order, and completes without error
+func ExpectEmissions[T comparable](t TB, obs monad.Observable[T], want ...T) bool {
+	t.Helper()
+	return ExpectEmissionsEq(t, obs, Comparable[T](), want...)
+}
+
+// ExpectEmissionsEq is ExpectEmissions comparing values with eq
+func ExpectEmissionsEq[T any](t TB, obs monad.Observable[T], eq Eq[T], want ...T) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), EmissionTimeout)
+	defer cancel()
+
+	got, err := obs.Collect(ctx).Unwrap()
+	if err != nil {
+		t.Errorf("observable failed after emitting %v: %v", got, err)
+		return false
+	}
+	return expectValues(t, got, eq, want)
+}
+
+// ExpectRecorded waits until rec recorded len(want) events and checks they equal want, in order
+// Use it with monad.RecordTopic to assert on asynchronously delivered events without sleeping
+func ExpectRecorded[T comparable](t TB, rec *monad.EventRecorder[T], want ...T) bool {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), EmissionTimeout)
+	defer cancel()
+
+	got, err := rec.Wait(ctx, len(want)).Unwrap()
+	if err != nil {
+		t.Errorf("recorded %v, want %v: %v", rec.Values(), want, err)
+		return false
+	}
+	return expectValues(t, got, Comparable[T](), want)
+}
+
+// expectValues reports a mismatch between the emitted and expected values
+func expectValues[T any](t TB, got []T, eq Eq[T], want []T) bool {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("emitted %v, want %v", got, want)
+		return false
+	}
+	for i := range got {
+		if !eq(got[i], want[i]) {
+			t.Errorf("emission %d = %v, want %v (emitted %v)", i, got[i], want[i], got)
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package monadtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+func TestExpectEmissions(t *testing.T) {
+	events := []monad.RecordedEvent[int]{{Value: 1}, {Value: 2}, {Value: 3}}
+	doubled := monad.MapObservable(monad.ReplayObservable(events), func(v int) int { return v * 2 })
+	if !ExpectEmissions(t, doubled, 2, 4, 6) {
+		t.Error("ExpectEmissions should pass for matching emissions")
+	}
+
+	rec := &recorder{}
+	if ExpectEmissions(rec, doubled, 2, 4) {
+		t.Error("ExpectEmissions should fail for extra emissions")
+	}
+	if ExpectEmissions(rec, doubled, 2, 5, 6) {
+		t.Error("ExpectEmissions should fail for a different emission")
+	}
+	failing := monad.Observable[int](func(ctx context.Context, next func(int)) error {
+		next(1)
+		return errors.New("boom")
+	})
+	if ExpectEmissions(rec, failing, 1) {
+		t.Error("ExpectEmissions should fail for a failing stream")
+	}
+	if len(rec.errors) != 3 {
+		t.Errorf("Expected 3 reported failures, got %d", len(rec.errors))
+	}
+}
+
+func TestExpectRecorded(t *testing.T) {
+	topic := monad.NewTopic[string]("events")
+	rec := monad.RecordTopic(context.Background(), topic)
+	defer rec.Stop()
+
+	monad.ReplayTopic(topic, []monad.RecordedEvent[string]{{Value: "a"}, {Value: "b"}})
+	if !ExpectRecorded(t, rec, "a", "b") {
+		t.Error("ExpectRecorded should pass for the published events")
+	}
+
+	defer func(prev time.Duration) { EmissionTimeout = prev }(EmissionTimeout)
+	EmissionTimeout = time.Millisecond
+	failures := &recorder{}
+	if ExpectRecorded(failures, rec, "a", "b", "c") {
+		t.Error("ExpectRecorded should fail when too few events arrive")
+	}
+	if ExpectRecorded(failures, rec, "a", "x") {
+		t.Error("ExpectRecorded should fail for a different event")
+	}
+	if len(failures.errors) != 2 {
+		t.Errorf("Expected 2 reported failures, got %d", len(failures.errors))
+	}
+}
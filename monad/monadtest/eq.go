@@ -0,0 +1,85 @@
+// Package monadtest provides helpers for asserting functor and monad laws
+// over the types in the monad package and over user-provided types.
+package monadtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// Eq reports whether two values should be considered equal
+type Eq[T any] func(a, b T) bool
+
+// Comparable returns an Eq using the == operator
+func Comparable[T comparable]() Eq[T] {
+	return func(a, b T) bool { return a == b }
+}
+
+// DeepEqual returns an Eq using reflect.DeepEqual
+func DeepEqual[T any]() Eq[T] {
+	return func(a, b T) bool { return reflect.DeepEqual(a, b) }
+}
+
+// ErrorEq considers two errors equal if both are nil, or either matches the other via errors.Is
+func ErrorEq(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return errors.Is(a, b) || errors.Is(b, a)
+}
+
+// ResultEq lifts an Eq over values to an Eq over Results
+// Two Results are equal if both are Ok with equal values, or both are Err with equal errors
+func ResultEq[T any](eq Eq[T]) Eq[monad.Result[T]] {
+	return func(a, b monad.Result[T]) bool {
+		av, aerr := a.Unwrap()
+		bv, berr := b.Unwrap()
+		if a.IsOk() != b.IsOk() {
+			return false
+		}
+		if !a.IsOk() {
+			return ErrorEq(aerr, berr)
+		}
+		return eq(av, bv)
+	}
+}
+
+// OptionEq lifts an Eq over values to an Eq over Options
+// Two Options are equal if they are in the same state and, when Some, hold equal values
+func OptionEq[T any](eq Eq[T]) Eq[monad.Option[T]] {
+	return func(a, b monad.Option[T]) bool {
+		if a.IsWildcard() || b.IsWildcard() {
+			return a.IsWildcard() && b.IsWildcard()
+		}
+		if a.IsNone() || b.IsNone() {
+			return a.IsNone() && b.IsNone()
+		}
+		return eq(a.Unwrap(), b.Unwrap())
+	}
+}
+
+// EitherEq lifts Eqs over both sides to an Eq over Eithers
+func EitherEq[L, R any](eqL Eq[L], eqR Eq[R]) Eq[monad.Either[L, R]] {
+	return func(a, b monad.Either[L, R]) bool {
+		al, ar, aRight := a.Unwrap()
+		bl, br, bRight := b.Unwrap()
+		if aRight != bRight {
+			return false
+		}
+		if aRight {
+			return eqR(ar, br)
+		}
+		return eqL(al, bl)
+	}
+}
+
+// TaskEq returns an Eq that runs both Tasks with ctx and compares their Results
+func TaskEq[T any](ctx context.Context, eq Eq[T]) Eq[monad.Task[T]] {
+	resultEq := ResultEq(eq)
+	return func(a, b monad.Task[T]) bool {
+		return resultEq(a(ctx), b(ctx))
+	}
+}
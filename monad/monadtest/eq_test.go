@@ -0,0 +1,44 @@
+package monadtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+func TestEqHelpers(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	wrapped := errors.Join(errors.New("context"), sentinel)
+
+	if !ErrorEq(nil, nil) {
+		t.Error("nil errors should be equal")
+	}
+	if ErrorEq(sentinel, nil) {
+		t.Error("nil and non-nil errors should differ")
+	}
+	if !ErrorEq(wrapped, sentinel) {
+		t.Error("wrapped error should equal its cause")
+	}
+
+	resultEq := ResultEq(Comparable[int]())
+	if resultEq(monad.Ok(1), monad.Err[int](sentinel)) {
+		t.Error("Ok and Err should differ")
+	}
+	if !resultEq(monad.Err[int](sentinel), monad.Err[int](sentinel)) {
+		t.Error("Errs with the same error should be equal")
+	}
+
+	optionEq := OptionEq(Comparable[int]())
+	if optionEq(monad.None[int](), monad.Wildcard[int]()) {
+		t.Error("None and Wildcard should differ")
+	}
+	if !optionEq(monad.Some(3), monad.Some(3)) {
+		t.Error("Some values should be equal")
+	}
+
+	eitherEq := EitherEq(Comparable[string](), Comparable[int]())
+	if eitherEq(monad.Left[string, int]("x"), monad.Right[string](0)) {
+		t.Error("Left and Right should differ")
+	}
+}
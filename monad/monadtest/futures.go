@@ -0,0 +1,21 @@
+package monadtest
+
+import "github.com/snowmerak/gofn/monad"
+
+// CleanupTB is the subset of testing.TB used by CheckFutures
+type CleanupTB interface {
+	TB
+	Cleanup(func())
+}
+
+// CheckFutures fails the test at teardown for every Future created during it that was completed twice
+// or never completed; the checks only run in builds with the gofndebug tag (go test -tags gofndebug)
+func CheckFutures(t CleanupTB) {
+	t.Helper()
+	tracker := monad.TrackFutures()
+	t.Cleanup(func() {
+		for _, issue := range tracker.Issues() {
+			t.Errorf("monadtest: %s", issue)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package monadtest
+
+import (
+	"testing"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// cleanupRecorder is a recorder that runs its cleanups on demand
+type cleanupRecorder struct {
+	recorder
+	cleanups []func()
+}
+
+func (r *cleanupRecorder) Cleanup(fn func()) { r.cleanups = append(r.cleanups, fn) }
+
+func (r *cleanupRecorder) finish() {
+	for i := len(r.cleanups) - 1; i >= 0; i-- {
+		r.cleanups[i]()
+	}
+}
+
+func TestCheckFuturesCleanUsage(t *testing.T) {
+	CheckFutures(t)
+
+	r := &cleanupRecorder{}
+	CheckFutures(r)
+	monad.RunAsync(func() monad.Result[int] { return monad.Ok(1) }).Await()
+	monad.CompletedFuture("done").Await()
+	r.finish()
+
+	if len(r.errors) != 0 {
+		t.Errorf("unexpected issues: %v", r.errors)
+	}
+}
@@ -0,0 +1,146 @@
+package monadtest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// TB is the subset of testing.TB used to report law violations
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+}
+
+// errLaw is the error used to build failing values when checking the laws of the monad types
+var errLaw = errors.New("monadtest: law check error")
+
+// FunctorIdentity checks that mapping the identity function leaves fa unchanged:
+// fmap(fa, id) == fa
+func FunctorIdentity[A, FA any](t TB, fmap func(FA, func(A) A) FA, fa FA, eq Eq[FA]) bool {
+	t.Helper()
+	got := fmap(fa, func(a A) A { return a })
+	if !eq(got, fa) {
+		t.Errorf("functor identity violated: fmap(fa, id) = %v, want %v", got, fa)
+		return false
+	}
+	return true
+}
+
+// FunctorComposition checks that mapping f then g equals mapping their composition:
+// fmap(fmap(fa, f), g) == fmap(fa, g . f)
+func FunctorComposition[A, B, C, FA, FB, FC any](
+	t TB,
+	fmapAB func(FA, func(A) B) FB,
+	fmapBC func(FB, func(B) C) FC,
+	fmapAC func(FA, func(A) C) FC,
+	fa FA,
+	f func(A) B,
+	g func(B) C,
+	eq Eq[FC],
+) bool {
+	t.Helper()
+	left := fmapBC(fmapAB(fa, f), g)
+	right := fmapAC(fa, func(a A) C { return g(f(a)) })
+	if !eq(left, right) {
+		t.Errorf("functor composition violated: fmap(fmap(fa, f), g) = %v, fmap(fa, g . f) = %v", left, right)
+		return false
+	}
+	return true
+}
+
+// LeftIdentity checks that binding a lifted value equals applying f directly:
+// bind(unit(a), f) == f(a)
+func LeftIdentity[A, MA, MB any](t TB, unit func(A) MA, bind func(MA, func(A) MB) MB, a A, f func(A) MB, eq Eq[MB]) bool {
+	t.Helper()
+	left := bind(unit(a), f)
+	right := f(a)
+	if !eq(left, right) {
+		t.Errorf("left identity violated: bind(unit(a), f) = %v, f(a) = %v", left, right)
+		return false
+	}
+	return true
+}
+
+// RightIdentity checks that binding unit leaves m unchanged:
+// bind(m, unit) == m
+func RightIdentity[A, MA any](t TB, unit func(A) MA, bind func(MA, func(A) MA) MA, m MA, eq Eq[MA]) bool {
+	t.Helper()
+	got := bind(m, unit)
+	if !eq(got, m) {
+		t.Errorf("right identity violated: bind(m, unit) = %v, want %v", got, m)
+		return false
+	}
+	return true
+}
+
+// Associativity checks that the nesting of binds does not matter:
+// bind(bind(m, f), g) == bind(m, x => bind(f(x), g))
+func Associativity[A, B, MA, MB, MC any](
+	t TB,
+	bindAB func(MA, func(A) MB) MB,
+	bindBC func(MB, func(B) MC) MC,
+	bindAC func(MA, func(A) MC) MC,
+	m MA,
+	f func(A) MB,
+	g func(B) MC,
+	eq Eq[MC],
+) bool {
+	t.Helper()
+	left := bindBC(bindAB(m, f), g)
+	right := bindAC(m, func(a A) MC { return bindBC(f(a), g) })
+	if !eq(left, right) {
+		t.Errorf("associativity violated: bind(bind(m, f), g) = %v, bind(m, x => bind(f(x), g)) = %v", left, right)
+		return false
+	}
+	return true
+}
+
+// ResultLaws checks the functor and monad laws for Result using Ok(a) and a failing Result as samples
+func ResultLaws[A, B, C any](t TB, a A, f func(A) monad.Result[B], g func(B) monad.Result[C], eqA Eq[A], eqB Eq[B], eqC Eq[C]) bool {
+	t.Helper()
+	ok := LeftIdentity(t, monad.Ok[A], monad.AndThen[A, B], a, f, ResultEq(eqB))
+	for _, m := range []monad.Result[A]{monad.Ok(a), monad.Err[A](errLaw)} {
+		ok = FunctorIdentity(t, monad.Map[A, A], m, ResultEq(eqA)) && ok
+		ok = RightIdentity(t, monad.Ok[A], monad.AndThen[A, A], m, ResultEq(eqA)) && ok
+		ok = Associativity(t, monad.AndThen[A, B], monad.AndThen[B, C], monad.AndThen[A, C], m, f, g, ResultEq(eqC)) && ok
+	}
+	return ok
+}
+
+// OptionLaws checks the functor and monad laws for Option using Some(a), None and Wildcard as samples
+func OptionLaws[A, B, C any](t TB, a A, f func(A) monad.Option[B], g func(B) monad.Option[C], eqA Eq[A], eqB Eq[B], eqC Eq[C]) bool {
+	t.Helper()
+	ok := LeftIdentity(t, monad.Some[A], monad.AndThenOption[A, B], a, f, OptionEq(eqB))
+	for _, m := range []monad.Option[A]{monad.Some(a), monad.None[A](), monad.Wildcard[A]()} {
+		ok = FunctorIdentity(t, monad.MapOption[A, A], m, OptionEq(eqA)) && ok
+		ok = RightIdentity(t, monad.Some[A], monad.AndThenOption[A, A], m, OptionEq(eqA)) && ok
+		ok = Associativity(t, monad.AndThenOption[A, B], monad.AndThenOption[B, C], monad.AndThenOption[A, C], m, f, g, OptionEq(eqC)) && ok
+	}
+	return ok
+}
+
+// EitherLaws checks the functor and monad laws for the Right side of Either using Right(a) and Left(left) as samples
+func EitherLaws[L, A, B, C any](t TB, left L, a A, f func(A) monad.Either[L, B], g func(B) monad.Either[L, C], eqL Eq[L], eqA Eq[A], eqB Eq[B], eqC Eq[C]) bool {
+	t.Helper()
+	ok := LeftIdentity(t, monad.Right[L, A], monad.AndThenRight[L, A, B], a, f, EitherEq(eqL, eqB))
+	for _, m := range []monad.Either[L, A]{monad.Right[L](a), monad.Left[L, A](left)} {
+		ok = FunctorIdentity(t, monad.MapRight[L, A, A], m, EitherEq(eqL, eqA)) && ok
+		ok = RightIdentity(t, monad.Right[L, A], monad.AndThenRight[L, A, A], m, EitherEq(eqL, eqA)) && ok
+		ok = Associativity(t, monad.AndThenRight[L, A, B], monad.AndThenRight[L, B, C], monad.AndThenRight[L, A, C], m, f, g, EitherEq(eqL, eqC)) && ok
+	}
+	return ok
+}
+
+// TaskLaws checks the functor and monad laws for Task, comparing Tasks by running them with ctx
+func TaskLaws[A, B, C any](t TB, ctx context.Context, a A, f func(A) monad.Task[B], g func(B) monad.Task[C], eqA Eq[A], eqB Eq[B], eqC Eq[C]) bool {
+	t.Helper()
+	ok := LeftIdentity(t, monad.NewTaskFromValue[A], monad.AndThenTask[A, B], a, f, TaskEq(ctx, eqB))
+	for _, m := range []monad.Task[A]{monad.NewTaskFromValue(a), monad.NewTaskFromError[A](errLaw)} {
+		ok = FunctorIdentity(t, monad.MapTask[A, A], m, TaskEq(ctx, eqA)) && ok
+		ok = RightIdentity(t, monad.NewTaskFromValue[A], monad.AndThenTask[A, A], m, TaskEq(ctx, eqA)) && ok
+		ok = Associativity(t, monad.AndThenTask[A, B], monad.AndThenTask[B, C], monad.AndThenTask[A, C], m, f, g, TaskEq(ctx, eqC)) && ok
+	}
+	return ok
+}
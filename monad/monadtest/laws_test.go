@@ -0,0 +1,118 @@
+package monadtest
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// recorder is a TB that records reported violations instead of failing the test
+type recorder struct {
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, format)
+}
+
+func TestResultLaws(t *testing.T) {
+	errNegative := errors.New("negative")
+	f := func(x int) monad.Result[string] {
+		if x < 0 {
+			return monad.Err[string](errNegative)
+		}
+		return monad.Ok(strconv.Itoa(x))
+	}
+	g := func(s string) monad.Result[int] { return monad.Ok(len(s)) }
+
+	for _, a := range []int{-1, 0, 42} {
+		if !ResultLaws(t, a, f, g, Comparable[int](), Comparable[string](), Comparable[int]()) {
+			t.Errorf("Result laws should hold for %d", a)
+		}
+	}
+}
+
+func TestOptionLaws(t *testing.T) {
+	f := func(x int) monad.Option[int] {
+		if x == 0 {
+			return monad.None[int]()
+		}
+		return monad.Some(100 / x)
+	}
+	g := func(x int) monad.Option[string] { return monad.Some(strconv.Itoa(x)) }
+
+	for _, a := range []int{0, 5} {
+		if !OptionLaws(t, a, f, g, Comparable[int](), Comparable[int](), Comparable[string]()) {
+			t.Errorf("Option laws should hold for %d", a)
+		}
+	}
+}
+
+func TestEitherLaws(t *testing.T) {
+	f := func(x int) monad.Either[string, int] {
+		if x > 10 {
+			return monad.Left[string, int]("too big")
+		}
+		return monad.Right[string](x * 2)
+	}
+	g := func(x int) monad.Either[string, bool] { return monad.Right[string](x%2 == 0) }
+
+	for _, a := range []int{3, 20} {
+		if !EitherLaws(t, "left", a, f, g, Comparable[string](), Comparable[int](), Comparable[int](), Comparable[bool]()) {
+			t.Errorf("Either laws should hold for %d", a)
+		}
+	}
+}
+
+func TestTaskLaws(t *testing.T) {
+	f := func(x int) monad.Task[[]int] { return monad.NewTaskFromValue([]int{x, x}) }
+	g := func(xs []int) monad.Task[int] { return monad.NewTaskFromValue(len(xs)) }
+
+	if !TaskLaws(t, context.Background(), 7, f, g, Comparable[int](), DeepEqual[[]int](), Comparable[int]()) {
+		t.Error("Task laws should hold")
+	}
+}
+
+func TestLawViolationsAreReported(t *testing.T) {
+	// A bind that ignores its function breaks left identity
+	brokenBind := func(m monad.Result[int], f func(int) monad.Result[int]) monad.Result[int] {
+		return m
+	}
+	double := func(x int) monad.Result[int] { return monad.Ok(x * 2) }
+
+	rec := &recorder{}
+	if LeftIdentity(rec, monad.Ok[int], brokenBind, 21, double, ResultEq(Comparable[int]())) {
+		t.Error("LeftIdentity should fail for a broken bind")
+	}
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected 1 reported violation, got %d", len(rec.errors))
+	}
+
+	// A map that always adds one breaks functor identity
+	brokenMap := func(m monad.Result[int], f func(int) int) monad.Result[int] {
+		return monad.Map(m, func(x int) int { return f(x) + 1 })
+	}
+	rec = &recorder{}
+	if FunctorIdentity(rec, brokenMap, monad.Ok(1), ResultEq(Comparable[int]())) {
+		t.Error("FunctorIdentity should fail for a broken map")
+	}
+	if len(rec.errors) != 1 {
+		t.Errorf("Expected 1 reported violation, got %d", len(rec.errors))
+	}
+}
+
+func TestFunctorComposition(t *testing.T) {
+	f := func(x int) string { return strconv.Itoa(x) }
+	g := func(s string) int { return len(s) }
+
+	ok := FunctorComposition(t, monad.MapOption[int, string], monad.MapOption[string, int], monad.MapOption[int, int],
+		monad.Some(12345), f, g, OptionEq(Comparable[int]()))
+	if !ok {
+		t.Error("Option should satisfy functor composition")
+	}
+}
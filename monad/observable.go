@@ -0,0 +1,114 @@
+package monad
+
+import "context"
+
+// Observable is a cold, push-based stream of values
+// Subscribing runs the producer, which calls next for every value in order and returns once the stream ends:
+// nil when it completed, the context error when cancelled, or the error that terminated the stream
+type Observable[T any] func(ctx context.Context, next func(T)) error
+
+// Subscribe runs the Observable asynchronously and returns a Future completing when the stream ends
+func (o Observable[T]) Subscribe(ctx context.Context, next func(T)) *Future[Unit] {
+	future := NewFuture[Unit]()
+	Spawn(func() {
+		future.complete(UnitFrom(o(ctx, next)))
+	})
+	return future
+}
+
+// Collect runs the Observable to completion and gathers every emitted value
+func (o Observable[T]) Collect(ctx context.Context) Result[[]T] {
+	var values []T
+	if err := o(ctx, func(v T) { values = append(values, v) }); err != nil {
+		return Err[[]T](err)
+	}
+	return Ok(values)
+}
+
+// ToChan runs the Observable asynchronously, forwarding values to the returned channel
+// The channel is closed when the stream ends; a consumer that stops reading must cancel ctx
+func (o Observable[T]) ToChan(ctx context.Context) <-chan T {
+	ch := make(chan T)
+	Spawn(func() {
+		defer close(ch)
+		o(ctx, func(v T) {
+			select {
+			case ch <- v:
+			case <-ctx.Done():
+			}
+		})
+	})
+	return ch
+}
+
+// ObservableOf creates an Observable emitting the given values and completing
+func ObservableOf[T any](values ...T) Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		for _, v := range values {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			next(v)
+		}
+		return nil
+	}
+}
+
+// ObservableFromChan creates an Observable emitting every value received from ch until it is closed
+func ObservableFromChan[T any](ch <-chan T) Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		for {
+			select {
+			case v, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				next(v)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// ObservableFromReactive creates an Observable emitting every new value of the reactive until ctx is cancelled
+// Values are emitted in the order the reactive's notifications are delivered
+func ObservableFromReactive[T any](r *Reactive[T]) Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		values := make(chan T, 16)
+		id := r.Subscribe(func(_ T, newValue T) {
+			select {
+			case values <- newValue:
+			case <-ctx.Done():
+			}
+		})
+		defer r.Unsubscribe(id)
+
+		for {
+			select {
+			case v := <-values:
+				next(v)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// MapObservable transforms every value emitted by the source
+func MapObservable[T, U any](source Observable[T], f func(T) U) Observable[U] {
+	return func(ctx context.Context, next func(U)) error {
+		return source(ctx, func(v T) { next(f(v)) })
+	}
+}
+
+// FilterObservable emits only the source values satisfying the predicate
+func FilterObservable[T any](source Observable[T], predicate func(T) bool) Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		return source(ctx, func(v T) {
+			if predicate(v) {
+				next(v)
+			}
+		})
+	}
+}
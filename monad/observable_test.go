@@ -0,0 +1,87 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestObservableCollect(t *testing.T) {
+	values, err := ObservableOf(1, 2, 3).Collect(context.Background()).Unwrap()
+	if err != nil || len(values) != 3 || values[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v (%v)", values, err)
+	}
+
+	testErr := errors.New("stream failed")
+	failing := Observable[int](func(ctx context.Context, next func(int)) error {
+		next(1)
+		return testErr
+	})
+	if _, err := failing.Collect(context.Background()).Unwrap(); err != testErr {
+		t.Errorf("Expected %v, got %v", testErr, err)
+	}
+}
+
+func TestMapFilterObservable(t *testing.T) {
+	evens := FilterObservable(ObservableOf(1, 2, 3, 4), func(v int) bool { return v%2 == 0 })
+	doubled := MapObservable(evens, func(v int) int { return v * 2 })
+
+	values, _ := doubled.Collect(context.Background()).Unwrap()
+	if len(values) != 2 || values[0] != 4 || values[1] != 8 {
+		t.Errorf("Expected [4 8], got %v", values)
+	}
+}
+
+func TestObservableChanRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	ch := ObservableOf("a", "b").ToChan(ctx)
+
+	values, err := ObservableFromChan(ch).Collect(ctx).Unwrap()
+	if err != nil || len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Errorf("Expected [a b], got %v (%v)", values, err)
+	}
+}
+
+func TestObservableSubscribe(t *testing.T) {
+	var got []int
+	future := ObservableOf(1, 2).Subscribe(context.Background(), func(v int) { got = append(got, v) })
+
+	if result := future.AwaitWithTimeout(time.Second); !result.IsOk() {
+		t.Fatalf("Expected completion, got %v", result)
+	}
+	if len(got) != 2 {
+		t.Errorf("Expected 2 values, got %v", got)
+	}
+}
+
+func TestObservableFromReactive(t *testing.T) {
+	r := NewReactive(0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	received := make(chan int, 1)
+	future := ObservableFromReactive(r).Subscribe(ctx, func(v int) { received <- v })
+
+	// Wait until the subscription is registered before changing the value
+	for len(r.snapshot()) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	r.Set(5)
+
+	select {
+	case v := <-received:
+		if v != 5 {
+			t.Errorf("Expected 5, got %d", v)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for reactive value")
+	}
+
+	cancel()
+	if _, err := future.AwaitWithTimeout(time.Second).Unwrap(); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(r.snapshot()) != 0 {
+		t.Error("Cancelling should unsubscribe from the reactive")
+	}
+}
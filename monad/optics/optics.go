@@ -0,0 +1,73 @@
+// Package optics provides lenses, composable getters and setters that update fields of immutable values.
+// //gofn:lens generates a Lens per struct field; Compose joins them to reach nested fields, so an update
+// deep inside a value copies each struct on the path instead of modifying the original.
+package optics
+
+// Lens focuses on a part A of a whole S: Get reads it and Set returns a copy of the whole with it replaced
+// Lenses are values, safe to share and to use concurrently
+type Lens[S, A any] struct {
+	get func(S) A
+	set func(S, A) S
+}
+
+// New creates a Lens from a getter and a setter; set must return a modified copy and leave its input as is
+func New[S, A any](get func(S) A, set func(S, A) S) Lens[S, A] {
+	return Lens[S, A]{get: get, set: set}
+}
+
+// Get returns the part of s the lens focuses on
+func (l Lens[S, A]) Get(s S) A {
+	return l.get(s)
+}
+
+// Set returns a copy of s with the focused part replaced by a
+func (l Lens[S, A]) Set(s S, a A) S {
+	return l.set(s, a)
+}
+
+// Modify returns a copy of s with f applied to the focused part
+func (l Lens[S, A]) Modify(s S, f func(A) A) S {
+	return l.set(s, f(l.get(s)))
+}
+
+// Compose focuses outer on a part of a part, e.g. Compose(PersonAddressLens, AddressCityLens) reaches the
+// city of a person's address; setting through it copies both the person and the address
+func Compose[S, A, B any](outer Lens[S, A], inner Lens[A, B]) Lens[S, B] {
+	return Lens[S, B]{
+		get: func(s S) B { return inner.get(outer.get(s)) },
+		set: func(s S, b B) S { return outer.set(s, inner.set(outer.get(s), b)) },
+	}
+}
+
+// Compose3 is Compose over three lenses, reaching a part two levels down
+func Compose3[S, A, B, C any](first Lens[S, A], second Lens[A, B], third Lens[B, C]) Lens[S, C] {
+	return Compose(Compose(first, second), third)
+}
+
+// Index focuses on the element at i of a slice; Set and Modify copy the slice
+// Like indexing, using it on a slice without an element at i panics
+func Index[E any](i int) Lens[[]E, E] {
+	return Lens[[]E, E]{
+		get: func(s []E) E { return s[i] },
+		set: func(s []E, e E) []E {
+			out := append([]E(nil), s...)
+			out[i] = e
+			return out
+		},
+	}
+}
+
+// Key focuses on the value under k of a map, the zero value when it is absent; Set and Modify copy the map
+func Key[K comparable, V any](k K) Lens[map[K]V, V] {
+	return Lens[map[K]V, V]{
+		get: func(m map[K]V) V { return m[k] },
+		set: func(m map[K]V, v V) map[K]V {
+			out := make(map[K]V, len(m)+1)
+			for key, value := range m {
+				out[key] = value
+			}
+			out[k] = v
+			return out
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package optics
+
+import "testing"
+
+type address struct {
+	City string
+	Zip  string
+}
+
+type person struct {
+	Name    string
+	Address address
+	Tags    []string
+}
+
+var (
+	personName    = New(func(p person) string { return p.Name }, func(p person, v string) person { p.Name = v; return p })
+	personAddress = New(func(p person) address { return p.Address }, func(p person, v address) person { p.Address = v; return p })
+	personTags    = New(func(p person) []string { return p.Tags }, func(p person, v []string) person { p.Tags = v; return p })
+	addressCity   = New(func(a address) string { return a.City }, func(a address, v string) address { a.City = v; return a })
+)
+
+func TestLensGetSetModify(t *testing.T) {
+	p := person{Name: "kim"}
+	if got := personName.Get(p); got != "kim" {
+		t.Errorf("Expected kim, got %s", got)
+	}
+	q := personName.Set(p, "lee")
+	if q.Name != "lee" || p.Name != "kim" {
+		t.Errorf("Set should return a copy, got %v and %v", q, p)
+	}
+	if r := personName.Modify(p, func(s string) string { return s + "!" }); r.Name != "kim!" {
+		t.Errorf("Expected kim!, got %s", r.Name)
+	}
+}
+
+func TestCompose(t *testing.T) {
+	p := person{Name: "kim", Address: address{City: "Seoul", Zip: "04524"}}
+	city := Compose(personAddress, addressCity)
+	if got := city.Get(p); got != "Seoul" {
+		t.Errorf("Expected Seoul, got %s", got)
+	}
+	moved := city.Set(p, "Busan")
+	if moved.Address.City != "Busan" || moved.Address.Zip != "04524" || p.Address.City != "Seoul" {
+		t.Errorf("Unexpected update %v of %v", moved, p)
+	}
+}
+
+func TestIndexAndKey(t *testing.T) {
+	p := person{Tags: []string{"a", "b"}}
+	second := Compose(personTags, Index[string](1))
+	q := second.Modify(p, func(s string) string { return s + s })
+	if q.Tags[1] != "bb" || p.Tags[1] != "b" {
+		t.Errorf("Index should copy the slice, got %v and %v", q.Tags, p.Tags)
+	}
+	if got := Compose3(personTags, Index[string](0), New(func(s string) int { return len(s) }, func(s string, n int) string { return s[:n] })).Get(p); got != 1 {
+		t.Errorf("Expected 1, got %d", got)
+	}
+
+	m := map[string]int{"a": 1}
+	n := Key[string, int]("b").Set(m, 2)
+	if len(m) != 1 || n["a"] != 1 || n["b"] != 2 {
+		t.Errorf("Key should copy the map, got %v and %v", n, m)
+	}
+	if got := Key[string, int]("c").Get(m); got != 0 {
+		t.Errorf("An absent key should read as zero, got %d", got)
+	}
+}
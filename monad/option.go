@@ -2,58 +2,72 @@ package monad
 
 // Option represents an optional value with pattern matching support
 // Every Option is either Some (contains a value), None (explicitly empty), or Wildcard (matches anything)
+// The value is stored inline so that Some does not heap-allocate
 type Option[T any] struct {
-	value     *T
-	isWildcard bool
+	value T
+	state optionState
 }
 
+// optionState tells the three Option states apart; the zero value is None
+type optionState uint8
+
+const (
+	optionNone optionState = iota
+	optionSome
+	optionWildcard
+)
+
 // Some wraps a value in an Option
 func Some[T any](value T) Option[T] {
-	return Option[T]{value: &value, isWildcard: false}
+	return Option[T]{value: value, state: optionSome}
 }
 
 // None returns an explicitly empty Option
 func None[T any]() Option[T] {
-	return Option[T]{value: nil, isWildcard: false}
+	return Option[T]{state: optionNone}
+}
+
+// OptionOfOk lifts a comma-ok pair into an Option, so OptionOfOk(os.LookupEnv("HOME")) adapts any (T, bool) call
+// The value is dropped when ok is false
+func OptionOfOk[T any](v T, ok bool) Option[T] {
+	if !ok {
+		return None[T]()
+	}
+	return Some(v)
 }
 
 // Wildcard returns a pattern that matches any value
 func Wildcard[T any]() Option[T] {
-	return Option[T]{value: nil, isWildcard: true}
+	return Option[T]{state: optionWildcard}
 }
 
 // IsSome returns true if the option contains a value
 func (o Option[T]) IsSome() bool {
-	return o.value != nil && !o.isWildcard
+	return o.state == optionSome
 }
 
 // IsNone returns true if the option is explicitly empty (not wildcard)
 func (o Option[T]) IsNone() bool {
-	return o.value == nil && !o.isWildcard
+	return o.state == optionNone
 }
 
 // IsWildcard returns true if the option is a wildcard pattern
 func (o Option[T]) IsWildcard() bool {
-	return o.isWildcard
+	return o.state == optionWildcard
 }
 
 // Unwrap returns the contained value or panics if None or Wildcard
+// The package PanicPolicy can turn the panic into a zero value; see SetPanicPolicy
 func (o Option[T]) Unwrap() T {
-	if o.value == nil {
-		if o.isWildcard {
-			panic("called Unwrap on Wildcard value")
-		}
-		panic("called Unwrap on None value")
-	}
-	return *o.value
+	return o.UnwrapWith(currentPanicPolicy())
 }
 
 // UnwrapOr returns the contained value or a default
 func (o Option[T]) UnwrapOr(defaultValue T) T {
-	if o.value == nil {
+	if o.state != optionSome {
 		return defaultValue
 	}
-	return *o.value
+	return o.value
 }
 
 // Match checks if this Option pattern matches the given value
@@ -61,13 +75,13 @@ func (o Option[T]) UnwrapOr(defaultValue T) T {
 // - None() never matches any actual value (used for explicit absence)
 // - Wildcard() matches any value
 func (o Option[T]) Match(value T) bool {
-	if o.isWildcard {
+	if o.state == optionWildcard {
 		return true // Wildcard matches anything
 	}
-	if o.value == nil {
+	if o.state == optionNone {
 		return false // None doesn't match any actual value
 	}
-	return equals(*o.value, value)
+	return equals(o.value, value)
 }
 
 // equals compares two values of the same type
@@ -79,24 +93,24 @@ func equals[T any](a, b T) bool {
 
 // Map applies a function to the contained value (if any)
 func MapOption[T any, U any](o Option[T], f func(T) U) Option[U] {
-	if o.isWildcard {
+	if o.state == optionWildcard {
 		return Wildcard[U]()
 	}
-	if o.value == nil {
+	if o.state == optionNone {
 		return None[U]()
 	}
-	return Some(f(*o.value))
+	return Some(f(o.value))
 }
 
 // AndThen applies a function that returns an Option to the contained value
 func AndThenOption[T any, U any](o Option[T], f func(T) Option[U]) Option[U] {
-	if o.isWildcard {
+	if o.state == optionWildcard {
 		return Wildcard[U]()
 	}
-	if o.value == nil {
+	if o.state == optionNone {
 		return None[U]()
 	}
-	return f(*o.value)
+	return f(o.value)
 }
 
 // Helper functions for pattern matching
@@ -107,4 +121,32 @@ func S[T any](value T) Option[T] { return Some(value) }
 func N[T any]() Option[T] { return None[T]() }
 
 // W for Wildcard - matches any value (pattern matching wildcard)
-func W[T any]() Option[T] { return Wildcard[T]() }
\ No newline at end of file
+func W[T any]() Option[T] { return Wildcard[T]() }
+
+// SomeP is a field-level pattern for Option-typed fields: it matches a field holding Some(x) where inner matches x
+// SomeP(W[T]()) matches any Some; an inner None never matches, like N
+func SomeP[T any](inner Option[T]) Option[Option[T]] {
+	if inner.state == optionNone {
+		return None[Option[T]]()
+	}
+	return Some(inner)
+}
+
+// NoneP is a field-level pattern matching an Option-typed field that is None
+func NoneP[T any]() Option[Option[T]] { return Some(None[T]()) }
+
+// MatchOptionField matches an Option-typed field against a SomeP, NoneP or W pattern
+// Unlike Match it looks inside the field, so Some(x) and None are told apart instead of compared structurally
+func MatchOptionField[T any](pattern Option[Option[T]], value Option[T]) bool {
+	switch pattern.state {
+	case optionWildcard:
+		return true
+	case optionNone:
+		return false
+	}
+	inner := pattern.value
+	if inner.state == optionNone {
+		return value.state == optionNone
+	}
+	return value.state == optionSome && inner.Match(value.value)
+}
@@ -164,4 +164,106 @@ func TestOptionAliases(t *testing.T) {
 	if !w.IsWildcard() {
 		t.Error("W should create Wildcard")
 	}
-}
\ No newline at end of file
+}
+// ptrOption mirrors the previous pointer-based Option layout for benchmark comparison
+type ptrOption[T any] struct {
+	value      *T
+	isWildcard bool
+}
+
+//go:noinline
+func somePtr[T any](value T) ptrOption[T] {
+	return ptrOption[T]{value: &value}
+}
+
+//go:noinline
+func someInline[T any](value T) Option[T] {
+	return Some(value)
+}
+
+var (
+	benchOption    Option[int]
+	benchPtrOption ptrOption[int]
+	benchInt       int
+)
+
+func BenchmarkSome(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchOption = someInline(i)
+	}
+}
+
+func BenchmarkSomePointerLayout(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchPtrOption = somePtr(i)
+	}
+}
+
+func BenchmarkMapOption(b *testing.B) {
+	b.ReportAllocs()
+	inc := func(x int) int { return x + 1 }
+	for i := 0; i < b.N; i++ {
+		benchOption = MapOption(MapOption(Some(i), inc), inc)
+	}
+}
+
+func BenchmarkOptionMatch(b *testing.B) {
+	b.ReportAllocs()
+	pattern := Some(42)
+	for i := 0; i < b.N; i++ {
+		if pattern.Match(i) {
+			benchInt++
+		}
+	}
+}
+
+func TestSomeDoesNotAllocate(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		benchOption = someInline(benchInt)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected Some to not allocate, got %v allocs per run", allocs)
+	}
+}
+
+func TestMatchOptionField(t *testing.T) {
+	cases := []struct {
+		name    string
+		pattern Option[Option[int]]
+		value   Option[int]
+		want    bool
+	}{
+		{"SomeP exact", SomeP(S(5)), Some(5), true},
+		{"SomeP other value", SomeP(S(5)), Some(6), false},
+		{"SomeP against None", SomeP(S(5)), None[int](), false},
+		{"SomeP wildcard", SomeP(W[int]()), Some(9), true},
+		{"SomeP wildcard against None", SomeP(W[int]()), None[int](), false},
+		{"SomeP of None never matches", SomeP(N[int]()), None[int](), false},
+		{"NoneP", NoneP[int](), None[int](), true},
+		{"NoneP against Some", NoneP[int](), Some(0), false},
+		{"W", W[Option[int]](), Some(1), true},
+		{"W against None", W[Option[int]](), None[int](), true},
+	}
+	for _, c := range cases {
+		if got := MatchOptionField(c.pattern, c.value); got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestOptionOfOk(t *testing.T) {
+	lookup := func(m map[string]int, k string) (int, bool) {
+		v, ok := m[k]
+		return v, ok
+	}
+	m := map[string]int{"a": 1}
+
+	if got := OptionOfOk(lookup(m, "a")); !got.IsSome() || got.Unwrap() != 1 {
+		t.Errorf("Expected Some(1), got %v", got)
+	}
+	if got := OptionOfOk(lookup(m, "b")); !got.IsNone() {
+		t.Errorf("Expected None, got %v", got)
+	}
+}
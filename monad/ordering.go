@@ -0,0 +1,133 @@
+package monad
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// Ordering is the result of comparing two values
+type Ordering int
+
+const (
+	Less    Ordering = -1
+	Equal   Ordering = 0
+	Greater Ordering = 1
+)
+
+// OrderingOf normalizes the result of a three-way comparison such as cmp.Compare or strings.Compare
+func OrderingOf(c int) Ordering {
+	switch {
+	case c < 0:
+		return Less
+	case c > 0:
+		return Greater
+	}
+	return Equal
+}
+
+// Reverse swaps Less and Greater
+func (o Ordering) Reverse() Ordering {
+	return -o
+}
+
+// Then returns o, or next when o is Equal; use it to break ties in hand-written comparisons
+func (o Ordering) Then(next Ordering) Ordering {
+	if o != Equal {
+		return o
+	}
+	return next
+}
+
+// String returns "Less", "Equal" or "Greater"
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "Less"
+	case Greater:
+		return "Greater"
+	}
+	return "Equal"
+}
+
+// Comparator orders two values of T
+type Comparator[T any] func(a, b T) Ordering
+
+// NaturalOrder returns the Comparator of the < operator
+func NaturalOrder[T cmp.Ordered]() Comparator[T] {
+	return func(a, b T) Ordering { return OrderingOf(cmp.Compare(a, b)) }
+}
+
+// Comparing returns a Comparator ordering values by the natural order of a key, e.g. Comparing(User.Age)
+func Comparing[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) Ordering { return OrderingOf(cmp.Compare(key(a), key(b))) }
+}
+
+// ComparingWith returns a Comparator ordering values by a key compared with keyOrder
+func ComparingWith[T, K any](key func(T) K, keyOrder Comparator[K]) Comparator[T] {
+	return func(a, b T) Ordering { return keyOrder(key(a), key(b)) }
+}
+
+// Reversed returns the Comparator of the opposite order
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) Ordering { return c(b, a) }
+}
+
+// ThenComparing returns a Comparator breaking the ties of c with next
+func (c Comparator[T]) ThenComparing(next Comparator[T]) Comparator[T] {
+	return func(a, b T) Ordering { return c(a, b).Then(next(a, b)) }
+}
+
+// Func adapts the Comparator to the func(a, b T) int expected by slices.SortFunc and friends
+func (c Comparator[T]) Func() func(a, b T) int {
+	return func(a, b T) int { return int(c(a, b)) }
+}
+
+// SortBy sorts s in place by c, keeping the order of equal elements
+func SortBy[T any](s []T, c Comparator[T]) {
+	slices.SortStableFunc(s, c.Func())
+}
+
+// MinBy returns the first smallest element of s by c, or None when s is empty
+func MinBy[T any](s []T, c Comparator[T]) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(slices.MinFunc(s, c.Func()))
+}
+
+// MaxBy returns the first largest element of s by c, or None when s is empty
+func MaxBy[T any](s []T, c Comparator[T]) Option[T] {
+	if len(s) == 0 {
+		return None[T]()
+	}
+	return Some(slices.MaxFunc(s, c.Func()))
+}
+
+// MinByObservable returns a Task running source to completion and returning its first smallest value by c,
+// or None when it emitted nothing; the Task fails if the stream does
+func MinByObservable[T any](source Observable[T], c Comparator[T]) Task[Option[T]] {
+	return extremeObservable(source, c)
+}
+
+// MaxByObservable returns a Task running source to completion and returning its first largest value by c,
+// or None when it emitted nothing; the Task fails if the stream does
+func MaxByObservable[T any](source Observable[T], c Comparator[T]) Task[Option[T]] {
+	return extremeObservable(source, c.Reversed())
+}
+
+// extremeObservable keeps the first value no other value orders before
+func extremeObservable[T any](source Observable[T], c Comparator[T]) Task[Option[T]] {
+	return func(ctx context.Context) Result[Option[T]] {
+		best := None[T]()
+		err := source(ctx, func(v T) {
+			if best.IsNone() || c(v, best.Unwrap()) == Less {
+				best = Some(v)
+			}
+		})
+		if err != nil {
+			return Err[Option[T]](err)
+		}
+		return Ok(best)
+	}
+}
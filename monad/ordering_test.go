@@ -0,0 +1,89 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type rankedUser struct {
+	Name string
+	Age  int
+}
+
+func TestOrdering(t *testing.T) {
+	if OrderingOf(-5) != Less || OrderingOf(0) != Equal || OrderingOf(3) != Greater {
+		t.Error("OrderingOf should normalize three-way comparisons")
+	}
+	if OrderingOf(strings.Compare("a", "b")).Reverse() != Greater {
+		t.Error("Reverse should swap Less and Greater")
+	}
+	if Equal.Then(Less) != Less || Greater.Then(Less) != Greater {
+		t.Error("Then should only break ties")
+	}
+	if Less.String() != "Less" || Equal.String() != "Equal" || Greater.String() != "Greater" {
+		t.Error("Unexpected Ordering names")
+	}
+}
+
+func TestComparatorComposition(t *testing.T) {
+	users := []rankedUser{{"carol", 30}, {"alice", 25}, {"bob", 30}, {"dave", 25}}
+
+	byAge := Comparing(func(u rankedUser) int { return u.Age })
+	byName := Comparing(func(u rankedUser) string { return u.Name })
+
+	sorted := append([]rankedUser(nil), users...)
+	SortBy(sorted, byAge.ThenComparing(byName))
+	want := []rankedUser{{"alice", 25}, {"dave", 25}, {"bob", 30}, {"carol", 30}}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Errorf("Expected %v, got %v", want, sorted)
+	}
+
+	SortBy(sorted, byAge.Reversed().ThenComparing(byName.Reversed()))
+	want = []rankedUser{{"carol", 30}, {"bob", 30}, {"dave", 25}, {"alice", 25}}
+	if !reflect.DeepEqual(sorted, want) {
+		t.Errorf("Expected %v, got %v", want, sorted)
+	}
+
+	byNameLength := ComparingWith(func(u rankedUser) string { return u.Name }, Comparing(func(s string) int { return len(s) }))
+	if byNameLength(users[1], users[2]) != Greater {
+		t.Error("ComparingWith should compare keys with the key Comparator")
+	}
+	if NaturalOrder[int]()(1, 2) != Less {
+		t.Error("NaturalOrder should follow <")
+	}
+}
+
+func TestMinByMaxBy(t *testing.T) {
+	users := []rankedUser{{"carol", 30}, {"alice", 25}, {"bob", 30}, {"dave", 25}}
+	byAge := Comparing(func(u rankedUser) int { return u.Age })
+
+	if got := MinBy(users, byAge); got.Unwrap() != users[1] {
+		t.Errorf("Expected the first youngest user, got %v", got)
+	}
+	if got := MaxBy(users, byAge); got.Unwrap() != users[0] {
+		t.Errorf("Expected the first oldest user, got %v", got)
+	}
+	if MinBy(nil, byAge).IsSome() || MaxBy(nil, byAge).IsSome() {
+		t.Error("Empty slices have no minimum or maximum")
+	}
+
+	ctx := context.Background()
+	if got, err := MinByObservable(ObservableOf(users...), byAge)(ctx).Unwrap(); err != nil || got.Unwrap() != users[1] {
+		t.Errorf("Expected the first youngest user, got %v (%v)", got, err)
+	}
+	if got, err := MaxByObservable(ObservableOf(users...), byAge)(ctx).Unwrap(); err != nil || got.Unwrap() != users[0] {
+		t.Errorf("Expected the first oldest user, got %v (%v)", got, err)
+	}
+	if got, _ := MinByObservable(ObservableOf[rankedUser](), byAge)(ctx).Unwrap(); got.IsSome() {
+		t.Errorf("Expected None for an empty stream, got %v", got)
+	}
+
+	errBoom := errors.New("boom")
+	failing := Observable[rankedUser](func(ctx context.Context, next func(rankedUser)) error { return errBoom })
+	if _, err := MaxByObservable(failing, byAge)(ctx).Unwrap(); !errors.Is(err, errBoom) {
+		t.Errorf("Expected the stream error, got %v", err)
+	}
+}
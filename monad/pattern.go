@@ -0,0 +1,77 @@
+package monad
+
+import "reflect"
+
+// Pattern matches the value of a slice or map field in generated When clauses, which cannot compare such
+// fields with ==; the zero Pattern matches anything, like AnyP
+// Generated matchers take fields of named slice and map types as their underlying []E or map[K]V,
+// so the helpers below infer their type from their arguments
+type Pattern[T any] struct {
+	match func(T) bool
+}
+
+// AnyP returns a Pattern matching any value, the wildcard of slice and map fields
+func AnyP[T any]() Pattern[T] { return Pattern[T]{} }
+
+// Where returns a Pattern matching the values pred accepts
+func Where[T any](pred func(T) bool) Pattern[T] { return Pattern[T]{match: pred} }
+
+// Match reports whether value matches the pattern
+func (p Pattern[T]) Match(value T) bool {
+	return p.match == nil || p.match(value)
+}
+
+// And returns a Pattern matching the values both p and q match
+func (p Pattern[T]) And(q Pattern[T]) Pattern[T] {
+	return Pattern[T]{match: func(v T) bool { return p.Match(v) && q.Match(v) }}
+}
+
+// Elems matches a slice holding exactly want, in order; Elems[E]() matches an empty or nil slice
+func Elems[E comparable](want ...E) Pattern[[]E] {
+	return Pattern[[]E]{match: func(s []E) bool {
+		if len(s) != len(want) {
+			return false
+		}
+		for i := range s {
+			if s[i] != want[i] {
+				return false
+			}
+		}
+		return true
+	}}
+}
+
+// Contains matches a slice holding v
+func Contains[E comparable](v E) Pattern[[]E] {
+	return Pattern[[]E]{match: func(s []E) bool {
+		for _, e := range s {
+			if e == v {
+				return true
+			}
+		}
+		return false
+	}}
+}
+
+// ElemAt matches a slice whose element at index i exists and matches p, e.g. ElemAt(0, S("admin"))
+func ElemAt[E any](i int, p Option[E]) Pattern[[]E] {
+	return Pattern[[]E]{match: func(s []E) bool {
+		return i >= 0 && i < len(s) && p.Match(s[i])
+	}}
+}
+
+// Len matches a slice, map, array or string of n elements; T cannot be inferred, e.g. Len[[]string](2)
+// It panics when matched against a value of another kind
+func Len[T any](n int) Pattern[T] {
+	return Pattern[T]{match: func(v T) bool {
+		return reflect.ValueOf(&v).Elem().Len() == n
+	}}
+}
+
+// KeyIs matches a map holding key with a value matching p; KeyIs(k, W[V]()) only requires the key
+func KeyIs[K comparable, V any](key K, p Option[V]) Pattern[map[K]V] {
+	return Pattern[map[K]V]{match: func(m map[K]V) bool {
+		v, ok := m[key]
+		return ok && p.Match(v)
+	}}
+}
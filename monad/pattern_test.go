@@ -0,0 +1,64 @@
+package monad
+
+import "testing"
+
+func TestSlicePatterns(t *testing.T) {
+	roles := []string{"admin", "dev"}
+	cases := []struct {
+		name string
+		p    Pattern[[]string]
+		want bool
+	}{
+		{"any", AnyP[[]string](), true},
+		{"zero", Pattern[[]string]{}, true},
+		{"contains", Contains("dev"), true},
+		{"contains missing", Contains("ops"), false},
+		{"elems", Elems("admin", "dev"), true},
+		{"elems order", Elems("dev", "admin"), false},
+		{"elem at", ElemAt(0, S("admin")), true},
+		{"elem at wildcard", ElemAt(1, W[string]()), true},
+		{"elem at out of range", ElemAt(2, W[string]()), false},
+		{"len", Len[[]string](2), true},
+		{"and", Contains("dev").And(Len[[]string](3)), false},
+		{"where", Where(func(s []string) bool { return s[0] == "admin" }), true},
+	}
+	for _, c := range cases {
+		if got := c.p.Match(roles); got != c.want {
+			t.Errorf("%s: expected %v, got %v", c.name, c.want, got)
+		}
+	}
+	if !Elems[string]().Match(nil) {
+		t.Error("Elems without elements should match a nil slice")
+	}
+}
+
+func TestPatternInference(t *testing.T) {
+	// the slice and map types are inferred from the element, key and value patterns
+	var p Pattern[[]int] = Contains(3)
+	if !p.Match([]int{1, 3}) {
+		t.Error("Contains should match")
+	}
+	var q Pattern[map[string]bool] = KeyIs("on", S(true))
+	if !q.Match(map[string]bool{"on": true}) {
+		t.Error("KeyIs should match")
+	}
+	if !Len[string](2).Match("go") || !Len[[2]int](2).Match([2]int{}) {
+		t.Error("Len should measure strings and arrays")
+	}
+}
+
+func TestMapPatterns(t *testing.T) {
+	labels := map[string]int{"env": 1, "tier": 2}
+	if !KeyIs("env", S(1)).Match(labels) {
+		t.Error("KeyIs should match a key holding the value")
+	}
+	if KeyIs("env", S(2)).Match(labels) {
+		t.Error("KeyIs should not match a different value")
+	}
+	if !KeyIs("tier", W[int]()).Match(labels) || KeyIs("zone", W[int]()).Match(labels) {
+		t.Error("KeyIs with a wildcard should only require the key")
+	}
+	if !Len[map[string]int](2).Match(labels) || Len[map[string]int](0).Match(labels) {
+		t.Error("Len should count map entries")
+	}
+}
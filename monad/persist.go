@@ -0,0 +1,160 @@
+package monad
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SnapshotTo writes the current value to w as JSON
+func (r *Reactive[T]) SnapshotTo(w io.Writer) error {
+	return json.NewEncoder(w).Encode(r.Get())
+}
+
+// RestoreFrom replaces the value with the JSON read from rd, notifying subscribers
+func (r *Reactive[T]) RestoreFrom(rd io.Reader) error {
+	var value T
+	if err := json.NewDecoder(rd).Decode(&value); err != nil {
+		return err
+	}
+	r.Set(value)
+	return nil
+}
+
+// RestoreReactive creates a Reactive from a JSON snapshot written by SnapshotTo
+func RestoreReactive[T any](rd io.Reader) Result[*Reactive[T]] {
+	var value T
+	if err := json.NewDecoder(rd).Decode(&value); err != nil {
+		return Err[*Reactive[T]](err)
+	}
+	return Ok(NewReactive(value))
+}
+
+// RestoreReactiveFile creates a Reactive from the snapshot at path, starting from initial if the file does not exist yet
+func RestoreReactiveFile[T any](path string, initial T) Result[*Reactive[T]] {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Ok(NewReactive(initial))
+	}
+	if err != nil {
+		return Err[*Reactive[T]](err)
+	}
+	defer f.Close()
+	return RestoreReactive[T](f)
+}
+
+// AutosaveFile snapshots r to path once it has stopped changing for debounce
+// Call Stop on the returned Autosave to write any pending change and detach it
+func (r *Reactive[T]) AutosaveFile(path string, debounce time.Duration) *Autosave {
+	save := NewAutosave(debounce, func() error {
+		return WriteFileAtomic(path, r.SnapshotTo)
+	})
+	id := r.Subscribe(func(_, _ T) { save.Changed() })
+	save.OnStop(func() { r.Unsubscribe(id) })
+	return save
+}
+
+// WriteFileAtomic writes path through a temporary file in the same directory and renames it into place,
+// so a crash mid-write never leaves a truncated snapshot behind
+func WriteFileAtomic(path string, write func(io.Writer) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Autosave runs a save function once changes have stopped arriving for a debounce period
+// The period is measured by the package Clock; generated reactive types use it for their autosave option
+type Autosave struct {
+	debounce time.Duration
+	save     func() error
+
+	mu      sync.Mutex
+	timer   Timer
+	pending bool
+	stopped bool
+	err     error
+	onStop  []func()
+}
+
+// NewAutosave creates an Autosave calling save after each burst of Changed calls
+func NewAutosave(debounce time.Duration, save func() error) *Autosave {
+	return &Autosave{debounce: debounce, save: save}
+}
+
+// Changed records a change, (re)starting the debounce period
+func (a *Autosave) Changed() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.stopped {
+		return
+	}
+	a.pending = true
+	if a.timer != nil {
+		a.timer.Stop()
+	}
+	a.timer = currentClock().AfterFunc(a.debounce, func() { a.Flush() })
+}
+
+// Flush saves now if a change is pending and returns the save error
+func (a *Autosave) Flush() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.pending {
+		return nil
+	}
+	a.pending = false
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	a.err = a.save()
+	return a.err
+}
+
+// Err returns the error of the most recent save, nil if it succeeded
+func (a *Autosave) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.err
+}
+
+// OnStop registers fn to run when the Autosave stops, e.g. to unsubscribe from its source
+func (a *Autosave) OnStop(fn func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.onStop = append(a.onStop, fn)
+}
+
+// Stop detaches the Autosave and writes any pending change, returning that save's error
+func (a *Autosave) Stop() error {
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return nil
+	}
+	a.stopped = true
+	hooks := a.onStop
+	a.onStop = nil
+	a.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn()
+	}
+	return a.Flush()
+}
@@ -0,0 +1,108 @@
+package monad
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type settings struct {
+	Theme string `json:"theme"`
+	Size  int    `json:"size"`
+}
+
+func TestReactiveSnapshotRestore(t *testing.T) {
+	source := NewReactive(settings{Theme: "dark", Size: 12})
+
+	var buf bytes.Buffer
+	if err := source.SnapshotTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := buf.String()
+
+	restored, err := RestoreReactive[settings](&buf).Unwrap()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := restored.Get(); got != (settings{Theme: "dark", Size: 12}) {
+		t.Errorf("Unexpected restored value %+v", got)
+	}
+
+	other := NewReactive(settings{})
+	if err := other.RestoreFrom(bytes.NewBufferString(snapshot)); err != nil {
+		t.Fatal(err)
+	}
+	if other.Get().Theme != "dark" {
+		t.Errorf("RestoreFrom should replace the value, got %+v", other.Get())
+	}
+
+	if _, err := RestoreReactive[settings](bytes.NewBufferString("{")).Unwrap(); err == nil {
+		t.Error("Expected a decode error")
+	}
+}
+
+func TestRestoreReactiveFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.json")
+	r, err := RestoreReactiveFile(path, settings{Theme: "light"}).Unwrap()
+	if err != nil || r.Get().Theme != "light" {
+		t.Errorf("Expected the initial value for a missing file, got %v, %v", r, err)
+	}
+}
+
+func TestReactiveAutosaveFile(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	path := filepath.Join(t.TempDir(), "settings.json")
+	source := NewReactive(settings{Theme: "dark"})
+	autosave := source.AutosaveFile(path, 100*time.Millisecond)
+
+	source.Set(settings{Theme: "dark", Size: 1})
+	s.RunUntilIdle()
+	s.Advance(50 * time.Millisecond)
+	source.Set(settings{Theme: "dark", Size: 2})
+	s.RunUntilIdle()
+	s.Advance(50 * time.Millisecond)
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("Nothing should be written while changes keep arriving")
+	}
+
+	s.Advance(50 * time.Millisecond)
+	restored, err := RestoreReactiveFile(path, settings{}).Unwrap()
+	if err != nil || restored.Get().Size != 2 {
+		t.Fatalf("Expected the debounced snapshot with size 2, got %v, %v", restored, err)
+	}
+
+	source.Set(settings{Theme: "light", Size: 3})
+	s.RunUntilIdle()
+	if err := autosave.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	restored, _ = RestoreReactiveFile(path, settings{}).Unwrap()
+	if restored.Get().Theme != "light" {
+		t.Errorf("Stop should flush the pending change, got %+v", restored.Get())
+	}
+
+	source.Set(settings{Theme: "ignored"})
+	s.RunUntilIdle()
+	s.Advance(time.Second)
+	restored, _ = RestoreReactiveFile(path, settings{}).Unwrap()
+	if restored.Get().Theme != "light" {
+		t.Errorf("A stopped autosave should not write, got %+v", restored.Get())
+	}
+}
+
+func TestAutosaveReportsErrors(t *testing.T) {
+	boom := errors.New("disk full")
+	a := NewAutosave(time.Hour, func() error { return boom })
+	a.Changed()
+	if err := a.Flush(); err != boom || a.Err() != boom {
+		t.Errorf("Expected the save error, got %v / %v", err, a.Err())
+	}
+	if err := a.Flush(); err != nil {
+		t.Errorf("Nothing pending should not save again, got %v", err)
+	}
+}
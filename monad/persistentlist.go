@@ -0,0 +1,190 @@
+package monad
+
+import (
+	"fmt"
+	"iter"
+)
+
+const (
+	listBits  = 5
+	listWidth = 1 << listBits
+	listMask  = listWidth - 1
+)
+
+// PersistentList is an immutable vector: Append and Set return a new list sharing all but O(log32 n) nodes with
+// the original, so snapshots held by a Reactive or passed between goroutines never need deep copies
+// The zero value is an empty list
+type PersistentList[T any] struct {
+	size  int
+	shift uint
+	root  *listNode[T]
+	tail  []T // the last, partially filled leaf, kept outside the trie so appends are amortized O(1)
+}
+
+// listNode is a trie node: leaves hold values, inner nodes hold children
+type listNode[T any] struct {
+	children []*listNode[T]
+	values   []T
+}
+
+// PersistentListOf creates a PersistentList holding values in order
+func PersistentListOf[T any](values ...T) PersistentList[T] {
+	var l PersistentList[T]
+	for _, v := range values {
+		l = l.Append(v)
+	}
+	return l
+}
+
+// CollectPersistentList creates a PersistentList from the values of seq
+func CollectPersistentList[T any](seq iter.Seq[T]) PersistentList[T] {
+	var l PersistentList[T]
+	for v := range seq {
+		l = l.Append(v)
+	}
+	return l
+}
+
+// Len returns the number of elements
+func (l PersistentList[T]) Len() int {
+	return l.size
+}
+
+// tailOffset returns the index of the first element stored in the tail
+func (l PersistentList[T]) tailOffset() int {
+	if l.size < listWidth {
+		return 0
+	}
+	return ((l.size - 1) >> listBits) << listBits
+}
+
+// Get returns the element at index i, or None when i is out of range
+func (l PersistentList[T]) Get(i int) Option[T] {
+	if i < 0 || i >= l.size {
+		return None[T]()
+	}
+	return Some(l.leafFor(i)[i&listMask])
+}
+
+// leafFor returns the leaf values holding index i
+func (l PersistentList[T]) leafFor(i int) []T {
+	if i >= l.tailOffset() {
+		return l.tail
+	}
+	node := l.root
+	for level := l.shift; level > 0; level -= listBits {
+		node = node.children[(i>>level)&listMask]
+	}
+	return node.values
+}
+
+// Append returns a list with v added at the end
+func (l PersistentList[T]) Append(v T) PersistentList[T] {
+	if l.size-l.tailOffset() < listWidth {
+		tail := make([]T, len(l.tail), len(l.tail)+1)
+		copy(tail, l.tail)
+		return PersistentList[T]{size: l.size + 1, shift: l.shift, root: l.root, tail: append(tail, v)}
+	}
+
+	// the tail is full: push it into the trie and start a new one
+	leaf := &listNode[T]{values: l.tail}
+	root, shift := l.root, l.shift
+	switch {
+	case root == nil:
+		root, shift = &listNode[T]{children: []*listNode[T]{leaf}}, listBits
+	case l.size>>listBits > 1<<shift:
+		root = &listNode[T]{children: []*listNode[T]{root, newListPath(shift, leaf)}}
+		shift += listBits
+	default:
+		root = l.pushTail(shift, root, leaf)
+	}
+	return PersistentList[T]{size: l.size + 1, shift: shift, root: root, tail: []T{v}}
+}
+
+// pushTail returns a copy of parent with leaf added as its last leaf
+func (l PersistentList[T]) pushTail(level uint, parent, leaf *listNode[T]) *listNode[T] {
+	sub := ((l.size - 1) >> level) & listMask
+	node := &listNode[T]{children: append([]*listNode[T](nil), parent.children...)}
+	var child *listNode[T]
+	switch {
+	case level == listBits:
+		child = leaf
+	case sub < len(parent.children):
+		child = l.pushTail(level-listBits, parent.children[sub], leaf)
+	default:
+		child = newListPath(level-listBits, leaf)
+	}
+	if sub < len(node.children) {
+		node.children[sub] = child
+	} else {
+		node.children = append(node.children, child)
+	}
+	return node
+}
+
+// newListPath wraps leaf in inner nodes down from level
+func newListPath[T any](level uint, leaf *listNode[T]) *listNode[T] {
+	if level == 0 {
+		return leaf
+	}
+	return &listNode[T]{children: []*listNode[T]{newListPath(level-listBits, leaf)}}
+}
+
+// Set returns a list with the element at index i replaced by v; it panics if i is out of range
+func (l PersistentList[T]) Set(i int, v T) PersistentList[T] {
+	if i < 0 || i >= l.size {
+		panic(fmt.Sprintf("monad: PersistentList index %d out of range [0:%d]", i, l.size))
+	}
+	if i >= l.tailOffset() {
+		tail := append([]T(nil), l.tail...)
+		tail[i&listMask] = v
+		return PersistentList[T]{size: l.size, shift: l.shift, root: l.root, tail: tail}
+	}
+	return PersistentList[T]{size: l.size, shift: l.shift, root: setListNode(l.shift, l.root, i, v), tail: l.tail}
+}
+
+// setListNode returns a copy of the path to index i with the element replaced
+func setListNode[T any](level uint, node *listNode[T], i int, v T) *listNode[T] {
+	if level == 0 {
+		values := append([]T(nil), node.values...)
+		values[i&listMask] = v
+		return &listNode[T]{values: values}
+	}
+	children := append([]*listNode[T](nil), node.children...)
+	sub := (i >> level) & listMask
+	children[sub] = setListNode(level-listBits, children[sub], i, v)
+	return &listNode[T]{children: children}
+}
+
+// All returns an iterator over the indexes and elements in order
+func (l PersistentList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < l.size; i += listWidth {
+			for j, v := range l.leafFor(i) {
+				if !yield(i+j, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the elements in order
+func (l PersistentList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Slice copies the elements into a new slice
+func (l PersistentList[T]) Slice() []T {
+	s := make([]T, 0, l.size)
+	for v := range l.Values() {
+		s = append(s, v)
+	}
+	return s
+}
@@ -0,0 +1,83 @@
+package monad
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestPersistentListAppendGet(t *testing.T) {
+	var l PersistentList[int]
+	var want []int
+	// crosses the tail, the first root overflow and a three-level trie
+	for i := range 1100 {
+		l = l.Append(i)
+		want = append(want, i)
+	}
+
+	if l.Len() != len(want) {
+		t.Fatalf("Expected %d elements, got %d", len(want), l.Len())
+	}
+	for i, v := range want {
+		if got := l.Get(i); !got.IsSome() || got.Unwrap() != v {
+			t.Fatalf("Get(%d): expected %d, got %v", i, v, got)
+		}
+	}
+	if l.Get(-1).IsSome() || l.Get(len(want)).IsSome() {
+		t.Error("Out of range indexes should be None")
+	}
+	if !reflect.DeepEqual(l.Slice(), want) {
+		t.Error("Slice should return the elements in order")
+	}
+	if !reflect.DeepEqual(slices.Collect(l.Values()), want) {
+		t.Error("Values should yield the elements in order")
+	}
+}
+
+func TestPersistentListStructuralSharing(t *testing.T) {
+	base := PersistentListOf(0, 1, 2)
+	for i := 3; i < 100; i++ {
+		base = base.Append(i)
+	}
+
+	appended := base.Append(100)
+	changed := base.Set(5, -5).Set(99, -99)
+
+	if base.Len() != 100 || base.Get(5).Unwrap() != 5 || base.Get(99).Unwrap() != 99 {
+		t.Error("Earlier versions must not change")
+	}
+	if appended.Len() != 101 || appended.Get(100).Unwrap() != 100 {
+		t.Error("Append should add the element at the end")
+	}
+	if changed.Get(5).Unwrap() != -5 || changed.Get(99).Unwrap() != -99 || changed.Get(6).Unwrap() != 6 {
+		t.Errorf("Set should replace only the given elements, got %v", changed.Slice()[:8])
+	}
+
+	// two appends to one version must not see each other
+	a, b := base.Append(1), base.Append(2)
+	if a.Get(100).Unwrap() != 1 || b.Get(100).Unwrap() != 2 {
+		t.Error("Appends to a shared version must be independent")
+	}
+}
+
+func TestPersistentListIteration(t *testing.T) {
+	l := CollectPersistentList(slices.Values([]string{"a", "b", "c"}))
+
+	var indexes []int
+	for i, v := range l.All() {
+		indexes = append(indexes, i)
+		if v == "b" {
+			break
+		}
+	}
+	if !reflect.DeepEqual(indexes, []int{0, 1}) {
+		t.Errorf("Expected iteration to stop after b, got %v", indexes)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Set out of range should panic")
+		}
+	}()
+	l.Set(3, "d")
+}
@@ -0,0 +1,291 @@
+package monad
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+)
+
+const (
+	mapBits = 5
+	mapMask = 1<<mapBits - 1
+	// mapMaxShift is the first shift with no hash bits left; keys whose hashes agree up to it share a collision node
+	mapMaxShift = 60
+)
+
+// mapSeed seeds the key hashes of every PersistentMap
+var mapSeed = maphash.MakeSeed()
+
+// PersistentMap is an immutable hash array mapped trie: Set and Delete return a new map sharing all but
+// O(log32 n) nodes with the original, so snapshots can be kept and shared cheaply
+// The zero value is an empty map
+type PersistentMap[K comparable, V any] struct {
+	root *mapNode[K, V]
+	size int
+}
+
+// mapNode is a trie node holding the entries present in bitmap, in bit order
+// A collision node instead holds entries whose hashes agree in every bit the trie uses, searched linearly
+type mapNode[K comparable, V any] struct {
+	bitmap    uint32
+	entries   []mapEntry[K, V]
+	collision bool
+}
+
+// mapEntry is either a key-value pair or, when node is set, a sub-trie
+type mapEntry[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+	node  *mapNode[K, V]
+}
+
+// PersistentMapOf creates a PersistentMap holding the entries of m
+func PersistentMapOf[K comparable, V any](m map[K]V) PersistentMap[K, V] {
+	var pm PersistentMap[K, V]
+	for k, v := range m {
+		pm = pm.Set(k, v)
+	}
+	return pm
+}
+
+// CollectPersistentMap creates a PersistentMap from the pairs of seq; later pairs win for repeated keys
+func CollectPersistentMap[K comparable, V any](seq iter.Seq2[K, V]) PersistentMap[K, V] {
+	var pm PersistentMap[K, V]
+	for k, v := range seq {
+		pm = pm.Set(k, v)
+	}
+	return pm
+}
+
+// Len returns the number of entries
+func (m PersistentMap[K, V]) Len() int {
+	return m.size
+}
+
+// Get returns the value for key, or None when it is absent
+func (m PersistentMap[K, V]) Get(key K) Option[V] {
+	if m.root == nil {
+		return None[V]()
+	}
+	return m.root.get(maphash.Comparable(mapSeed, key), key)
+}
+
+// get looks key up in the sub-trie rooted at n
+func (n *mapNode[K, V]) get(hash uint64, key K) Option[V] {
+	node := n
+	for shift := uint(0); node != nil; shift += mapBits {
+		if node.collision {
+			for _, e := range node.entries {
+				if e.key == key {
+					return Some(e.value)
+				}
+			}
+			return None[V]()
+		}
+		bit, idx := node.position(hash, shift)
+		if node.bitmap&bit == 0 {
+			return None[V]()
+		}
+		e := node.entries[idx]
+		if e.node == nil {
+			if e.key == key {
+				return Some(e.value)
+			}
+			return None[V]()
+		}
+		node = e.node
+	}
+	return None[V]()
+}
+
+// Has reports whether key is present
+func (m PersistentMap[K, V]) Has(key K) bool {
+	return m.Get(key).IsSome()
+}
+
+// Set returns a map with key mapped to value
+func (m PersistentMap[K, V]) Set(key K, value V) PersistentMap[K, V] {
+	entry := mapEntry[K, V]{hash: maphash.Comparable(mapSeed, key), key: key, value: value}
+	root := m.root
+	if root == nil {
+		root = &mapNode[K, V]{}
+	}
+	root, added := root.set(0, entry)
+	if added {
+		return PersistentMap[K, V]{root: root, size: m.size + 1}
+	}
+	return PersistentMap[K, V]{root: root, size: m.size}
+}
+
+// Delete returns a map without key; the map itself is returned when key is absent
+func (m PersistentMap[K, V]) Delete(key K) PersistentMap[K, V] {
+	if m.root == nil {
+		return m
+	}
+	root, removed := m.root.delete(0, maphash.Comparable(mapSeed, key), key)
+	if !removed {
+		return m
+	}
+	if len(root.entries) == 0 {
+		root = nil
+	}
+	return PersistentMap[K, V]{root: root, size: m.size - 1}
+}
+
+// All returns an iterator over the entries in no particular order
+func (m PersistentMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.each(yield)
+		}
+	}
+}
+
+// Keys returns an iterator over the keys in no particular order
+func (m PersistentMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the values in no particular order
+func (m PersistentMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap copies the entries into a new Go map
+func (m PersistentMap[K, V]) ToMap() map[K]V {
+	out := make(map[K]V, m.size)
+	for k, v := range m.All() {
+		out[k] = v
+	}
+	return out
+}
+
+// position returns the bitmap bit of hash at shift and the index its entry has or would have
+func (n *mapNode[K, V]) position(hash uint64, shift uint) (bit uint32, idx int) {
+	bit = 1 << ((hash >> shift) & mapMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// set returns a copy of n with entry stored, reporting whether its key was new
+func (n *mapNode[K, V]) set(shift uint, entry mapEntry[K, V]) (*mapNode[K, V], bool) {
+	if n.collision {
+		entries := append([]mapEntry[K, V](nil), n.entries...)
+		for i, e := range entries {
+			if e.key == entry.key {
+				entries[i] = entry
+				return &mapNode[K, V]{entries: entries, collision: true}, false
+			}
+		}
+		return &mapNode[K, V]{entries: append(entries, entry), collision: true}, true
+	}
+
+	bit, idx := n.position(entry.hash, shift)
+	if n.bitmap&bit == 0 {
+		entries := make([]mapEntry[K, V], 0, len(n.entries)+1)
+		entries = append(entries, n.entries[:idx]...)
+		entries = append(entries, entry)
+		entries = append(entries, n.entries[idx:]...)
+		return &mapNode[K, V]{bitmap: n.bitmap | bit, entries: entries}, true
+	}
+
+	entries := append([]mapEntry[K, V](nil), n.entries...)
+	existing := entries[idx]
+	added := false
+	switch {
+	case existing.node != nil:
+		var child *mapNode[K, V]
+		child, added = existing.node.set(shift+mapBits, entry)
+		entries[idx] = mapEntry[K, V]{node: child}
+	case existing.key == entry.key:
+		entries[idx] = entry
+	default:
+		entries[idx] = mapEntry[K, V]{node: mergeMapEntries(shift+mapBits, existing, entry)}
+		added = true
+	}
+	return &mapNode[K, V]{bitmap: n.bitmap, entries: entries}, added
+}
+
+// mergeMapEntries creates the node at shift holding two entries whose hashes agree below it
+func mergeMapEntries[K comparable, V any](shift uint, a, b mapEntry[K, V]) *mapNode[K, V] {
+	if shift >= mapMaxShift {
+		return &mapNode[K, V]{entries: []mapEntry[K, V]{a, b}, collision: true}
+	}
+	ia, ib := (a.hash>>shift)&mapMask, (b.hash>>shift)&mapMask
+	if ia == ib {
+		return &mapNode[K, V]{bitmap: 1 << ia, entries: []mapEntry[K, V]{{node: mergeMapEntries(shift+mapBits, a, b)}}}
+	}
+	if ia > ib {
+		a, b = b, a
+	}
+	return &mapNode[K, V]{bitmap: 1<<ia | 1<<ib, entries: []mapEntry[K, V]{a, b}}
+}
+
+// delete returns a copy of n without key, reporting whether it was present
+// A sub-trie left with a single key-value pair is pulled up into its parent, keeping lookups short
+func (n *mapNode[K, V]) delete(shift uint, hash uint64, key K) (*mapNode[K, V], bool) {
+	if n.collision {
+		for i, e := range n.entries {
+			if e.key == key {
+				entries := append(append([]mapEntry[K, V](nil), n.entries[:i]...), n.entries[i+1:]...)
+				return &mapNode[K, V]{entries: entries, collision: true}, true
+			}
+		}
+		return n, false
+	}
+
+	bit, idx := n.position(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	existing := n.entries[idx]
+	if existing.node == nil {
+		if existing.key != key {
+			return n, false
+		}
+		entries := append(append([]mapEntry[K, V](nil), n.entries[:idx]...), n.entries[idx+1:]...)
+		return &mapNode[K, V]{bitmap: n.bitmap &^ bit, entries: entries}, true
+	}
+
+	child, removed := existing.node.delete(shift+mapBits, hash, key)
+	if !removed {
+		return n, false
+	}
+	entries := append([]mapEntry[K, V](nil), n.entries...)
+	switch {
+	case len(child.entries) == 0:
+		entries = append(entries[:idx], entries[idx+1:]...)
+		return &mapNode[K, V]{bitmap: n.bitmap &^ bit, entries: entries}, true
+	case len(child.entries) == 1 && child.entries[0].node == nil:
+		entries[idx] = child.entries[0]
+	default:
+		entries[idx] = mapEntry[K, V]{node: child}
+	}
+	return &mapNode[K, V]{bitmap: n.bitmap, entries: entries}, true
+}
+
+// each yields the key-value pairs of the sub-trie, reporting whether iteration should continue
+func (n *mapNode[K, V]) each(yield func(K, V) bool) bool {
+	for _, e := range n.entries {
+		if e.node != nil {
+			if !e.node.each(yield) {
+				return false
+			}
+		} else if !yield(e.key, e.value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,91 @@
+package monad
+
+import (
+	"maps"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPersistentMapMatchesGoMap(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var m PersistentMap[int, int]
+	want := map[int]int{}
+
+	for i := range 5000 {
+		key := rng.IntN(1000)
+		if rng.IntN(3) == 0 {
+			m = m.Delete(key)
+			delete(want, key)
+		} else {
+			m = m.Set(key, i)
+			want[key] = i
+		}
+	}
+
+	if m.Len() != len(want) {
+		t.Fatalf("Expected %d entries, got %d", len(want), m.Len())
+	}
+	for key := range 1000 {
+		v, ok := want[key]
+		if got := m.Get(key); got.IsSome() != ok || ok && got.Unwrap() != v {
+			t.Fatalf("Get(%d): expected (%d, %v), got %v", key, v, ok, got)
+		}
+	}
+	if !maps.Equal(m.ToMap(), want) {
+		t.Error("ToMap should return every entry")
+	}
+}
+
+func TestPersistentMapStructuralSharing(t *testing.T) {
+	base := PersistentMapOf(map[string]int{"a": 1, "b": 2})
+	updated := base.Set("a", 10).Set("c", 3)
+	removed := base.Delete("b")
+
+	if base.Len() != 2 || base.Get("a").Unwrap() != 1 || !base.Has("b") || base.Has("c") {
+		t.Error("Earlier versions must not change")
+	}
+	if updated.Len() != 3 || updated.Get("a").Unwrap() != 10 {
+		t.Error("Set should replace and add entries")
+	}
+	if removed.Len() != 1 || removed.Has("b") {
+		t.Error("Delete should remove the entry")
+	}
+	if same := base.Delete("missing"); same.Len() != 2 || same.root != base.root {
+		t.Error("Deleting a missing key should return the map itself")
+	}
+	if empty := removed.Delete("a"); empty.Len() != 0 || empty.root != nil {
+		t.Error("Deleting the last entry should leave an empty map")
+	}
+
+	keys := 0
+	for range CollectPersistentMap(maps.All(map[int]bool{1: true, 2: true, 3: true})).Keys() {
+		keys++
+	}
+	if keys != 3 {
+		t.Errorf("Expected 3 keys, got %d", keys)
+	}
+}
+
+func TestPersistentMapCollisions(t *testing.T) {
+	// crafted hashes sharing every bit the trie uses end up in a collision node
+	const hash = 0x0123456789abcdef
+	root := &mapNode[string, int]{}
+	root, _ = root.set(0, mapEntry[string, int]{hash: hash, key: "a", value: 1})
+	root, _ = root.set(0, mapEntry[string, int]{hash: hash, key: "b", value: 2})
+	root, added := root.set(0, mapEntry[string, int]{hash: hash, key: "b", value: 3})
+	if added {
+		t.Error("Replacing a colliding key should not add an entry")
+	}
+	if root.get(hash, "a").Unwrap() != 1 || root.get(hash, "b").Unwrap() != 3 || root.get(hash, "c").IsSome() {
+		t.Error("Colliding keys should be looked up by equality")
+	}
+
+	root, removed := root.delete(0, hash, "a")
+	if !removed || root.get(hash, "a").IsSome() || root.get(hash, "b").Unwrap() != 3 {
+		t.Error("Deleting a colliding key should keep the other")
+	}
+	// the remaining entry is pulled up to the root
+	if len(root.entries) != 1 || root.entries[0].node != nil {
+		t.Errorf("Expected the last colliding entry inline in the root, got %+v", root.entries)
+	}
+}
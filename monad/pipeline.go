@@ -11,20 +11,12 @@ func ErrP[T any](e error) Pipeline[T]            { return NewPipeline(Err[T](e))
 
 // MapP applies f to the inner value when Ok, producing Pipeline[U].
 func MapP[T any, U any](p Pipeline[T], f func(T) U) Pipeline[U] {
-	if !p.res.IsOk() {
-		return NewPipeline(Err[U](p.res.err))
-	}
-	v, _ := p.res.Unwrap()
-	return NewPipeline(Ok(f(v)))
+	return NewPipeline(Map(p.res, f))
 }
 
 // AndThenP applies f which returns a Result[U] when current is Ok.
 func AndThenP[T any, U any](p Pipeline[T], f func(T) Result[U]) Pipeline[U] {
-	if !p.res.IsOk() {
-		return NewPipeline(Err[U](p.res.err))
-	}
-	v, _ := p.res.Unwrap()
-	return NewPipeline(f(v))
+	return NewPipeline(AndThen(p.res, f))
 }
 
 // ThenP runs a side-effecting function that may return an error; preserves original value on success.
@@ -34,9 +26,18 @@ func ThenP[T any](p Pipeline[T], f func(T) error) Pipeline[T] {
 	}
 	v, _ := p.res.Unwrap()
 	if err := f(v); err != nil {
-		return NewPipeline(Err[T](err))
+		return NewPipeline(CarryWarnings(Err[T](err), p.res))
 	}
 	return p
 }
 
+// WarnP adds w to the warnings of the pipeline; see WithWarning.
+func WarnP[T any](p Pipeline[T], w error) Pipeline[T] { return NewPipeline(WithWarning(p.res, w)) }
+
 func (p Pipeline[T]) Unwrap() (T, error) { return p.res.Unwrap() }
+
+// Warnings returns the warnings collected by the pipeline's stages, oldest first.
+func (p Pipeline[T]) Warnings() []error { return p.res.Warnings() }
+
+// Result returns the outcome of the pipeline, warnings included.
+func (p Pipeline[T]) Result() Result[T] { return p.res }
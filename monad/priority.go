@@ -0,0 +1,224 @@
+package monad
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShed is returned for work dropped by a PriorityExecutor because its context ended before it started
+var ErrShed = errors.New("monad: work shed before start")
+
+// ErrExecutorClosed is returned for work submitted to a closed PriorityExecutor
+var ErrExecutorClosed = errors.New("monad: executor closed")
+
+// Priority orders queued work in a PriorityExecutor; higher values run first
+type Priority int
+
+const (
+	PriorityLow    Priority = -10
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 10
+)
+
+// ExecutorMetrics is a snapshot of a PriorityExecutor's counters
+type ExecutorMetrics struct {
+	Queued   int64 // currently waiting for a worker
+	Executed int64 // started by a worker
+	Shed     int64 // dropped because their context ended while queued
+}
+
+// PriorityExecutor is a fixed pool of workers running queued work by priority, then by earliest
+// context deadline, then in submission order
+// Work whose context is done by the time a worker picks it up is shed instead of run, so an
+// overloaded service drops requests nobody waits for any more
+// It implements Executor, so it can back the whole package via SetExecutor; workers must then
+// outnumber the depth of nested blocking waits (e.g. WithTimeout inside ParallelTasks) to avoid starvation
+type PriorityExecutor struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   workQueue
+	seq     uint64
+	closed  bool
+	workers sync.WaitGroup
+
+	queued   atomic.Int64
+	executed atomic.Int64
+	shed     atomic.Int64
+}
+
+// NewPriorityExecutor starts an executor with the given number of workers (at least one)
+func NewPriorityExecutor(workers int) *PriorityExecutor {
+	if workers < 1 {
+		workers = 1
+	}
+	e := &PriorityExecutor{}
+	e.cond = sync.NewCond(&e.mu)
+	e.workers.Add(workers)
+	for range workers {
+		go e.run()
+	}
+	return e
+}
+
+// Go queues fn at PriorityNormal; after Close it falls back to a new goroutine so package work is never lost
+func (e *PriorityExecutor) Go(fn func()) {
+	if _, accepted := e.submit(context.Background(), PriorityNormal, fn); !accepted {
+		go fn()
+	}
+}
+
+// Submit queues fn at PriorityNormal; see SubmitWithPriority
+func (e *PriorityExecutor) Submit(ctx context.Context, fn func()) *Future[Unit] {
+	return e.SubmitWithPriority(ctx, PriorityNormal, fn)
+}
+
+// SubmitWithPriority queues fn to run on a worker
+// The returned Future completes once fn has run, or fails with ErrShed (wrapping ctx.Err()) if ctx ended
+// before a worker started it, or with ErrExecutorClosed after Close
+func (e *PriorityExecutor) SubmitWithPriority(ctx context.Context, priority Priority, fn func()) *Future[Unit] {
+	done, _ := e.submit(ctx, priority, fn)
+	return done
+}
+
+// submit queues fn, reporting false if the executor was closed
+func (e *PriorityExecutor) submit(ctx context.Context, priority Priority, fn func()) (*Future[Unit], bool) {
+	done := NewFuture[Unit]()
+	if err := ctx.Err(); err != nil {
+		e.shed.Add(1)
+		currentMetrics().executorShed.Add(1)
+		done.CompleteWithError(fmt.Errorf("%w: %w", ErrShed, err))
+		return done, true
+	}
+
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		done.CompleteWithError(ErrExecutorClosed)
+		return done, false
+	}
+	deadline, hasDeadline := ctx.Deadline()
+	e.seq++
+	heap.Push(&e.queue, &work{
+		ctx: ctx, fn: fn, done: done,
+		priority: priority, deadline: deadline, hasDeadline: hasDeadline, seq: e.seq,
+		queuedAt: currentClock().Now(),
+	})
+	e.queued.Add(1)
+	currentMetrics().executorQueued.Add(1)
+	e.mu.Unlock()
+	e.cond.Signal()
+	return done, true
+}
+
+// SubmitTask runs task on e at the given priority, shedding it like SubmitWithPriority
+func SubmitTask[T any](e *PriorityExecutor, ctx context.Context, priority Priority, task Task[T]) *Future[T] {
+	future := NewFuture[T]()
+	submitted := e.SubmitWithPriority(ctx, priority, func() {
+		future.complete(task(ctx))
+	})
+	submitted.onComplete(func(r Result[Unit]) {
+		if _, err := r.Unwrap(); err != nil {
+			future.CompleteWithError(err)
+		}
+	})
+	return future
+}
+
+// Metrics returns a snapshot of the executor's counters
+func (e *PriorityExecutor) Metrics() ExecutorMetrics {
+	return ExecutorMetrics{
+		Queued:   e.queued.Load(),
+		Executed: e.executed.Load(),
+		Shed:     e.shed.Load(),
+	}
+}
+
+// Close stops accepting work, lets the workers drain the queue and waits for them to exit
+func (e *PriorityExecutor) Close() {
+	e.mu.Lock()
+	e.closed = true
+	e.mu.Unlock()
+	e.cond.Broadcast()
+	e.workers.Wait()
+}
+
+// run processes queued work until the executor is closed and drained
+func (e *PriorityExecutor) run() {
+	defer e.workers.Done()
+	for {
+		e.mu.Lock()
+		for e.queue.Len() == 0 && !e.closed {
+			e.cond.Wait()
+		}
+		if e.queue.Len() == 0 {
+			e.mu.Unlock()
+			return
+		}
+		w := heap.Pop(&e.queue).(*work)
+		e.queued.Add(-1)
+		e.mu.Unlock()
+
+		m := currentMetrics()
+		m.executorQueued.Add(-1)
+		m.executorQueueWait.Observe(currentClock().Now().Sub(w.queuedAt).Seconds())
+		if err := w.ctx.Err(); err != nil {
+			e.shed.Add(1)
+			m.executorShed.Add(1)
+			w.done.CompleteWithError(fmt.Errorf("%w: %w", ErrShed, err))
+			continue
+		}
+		e.executed.Add(1)
+		m.executorExecuted.Add(1)
+		w.fn()
+		w.done.Complete(Unit{})
+	}
+}
+
+// work is one queued submission
+type work struct {
+	ctx         context.Context
+	fn          func()
+	done        *Future[Unit]
+	priority    Priority
+	deadline    time.Time
+	hasDeadline bool
+	seq         uint64
+	queuedAt    time.Time
+}
+
+// workQueue is a container/heap of queued work
+type workQueue []*work
+
+func (q workQueue) Len() int { return len(q) }
+
+func (q workQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	if a.hasDeadline != b.hasDeadline {
+		return a.hasDeadline
+	}
+	if a.hasDeadline && !a.deadline.Equal(b.deadline) {
+		return a.deadline.Before(b.deadline)
+	}
+	return a.seq < b.seq
+}
+
+func (q workQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *workQueue) Push(x any) { *q = append(*q, x.(*work)) }
+
+func (q *workQueue) Pop() any {
+	old := *q
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return w
+}
@@ -0,0 +1,125 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockWorker occupies the executor's only worker until the returned function is called
+func blockWorker(e *PriorityExecutor) (release func()) {
+	started := make(chan struct{})
+	gate := make(chan struct{})
+	e.SubmitWithPriority(context.Background(), PriorityHigh, func() {
+		close(started)
+		<-gate
+	})
+	<-started
+	return func() { close(gate) }
+}
+
+func TestPriorityExecutorOrdering(t *testing.T) {
+	e := NewPriorityExecutor(1)
+	defer e.Close()
+	release := blockWorker(e)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			order = append(order, name)
+			mu.Unlock()
+		}
+	}
+
+	later, cancelLater := context.WithTimeout(context.Background(), time.Hour)
+	defer cancelLater()
+	sooner, cancelSooner := context.WithTimeout(context.Background(), time.Minute)
+	defer cancelSooner()
+
+	bg := context.Background()
+	futures := []*Future[Unit]{
+		e.SubmitWithPriority(bg, PriorityLow, record("low")),
+		e.Submit(bg, record("normal")),
+		e.Submit(later, record("normal-later-deadline")),
+		e.Submit(sooner, record("normal-sooner-deadline")),
+		e.SubmitWithPriority(bg, PriorityHigh, record("high")),
+	}
+	if m := e.Metrics(); m.Queued != 5 {
+		t.Errorf("Expected 5 queued, got %+v", m)
+	}
+	release()
+
+	if _, err := SequenceFutures(futures).Await().Unwrap(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := []string{"high", "normal-sooner-deadline", "normal-later-deadline", "normal", "low"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("Expected order %v, got %v", want, order)
+		}
+	}
+	if m := e.Metrics(); m.Executed != 6 || m.Queued != 0 || m.Shed != 0 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestPriorityExecutorShedsExpiredWork(t *testing.T) {
+	e := NewPriorityExecutor(1)
+	defer e.Close()
+	release := blockWorker(e)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ran := false
+	queued := e.Submit(ctx, func() { ran = true })
+	cancel()
+	release()
+
+	_, err := queued.Await().Unwrap()
+	if !errors.Is(err, ErrShed) || !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected ErrShed wrapping context.Canceled, got %v", err)
+	}
+	if ran {
+		t.Error("Shed work must not run")
+	}
+
+	// already expired at submission
+	task := SubmitTask(e, ctx, PriorityNormal, NewTaskFromValue(1))
+	if _, err := task.Await().Unwrap(); !errors.Is(err, ErrShed) {
+		t.Errorf("Expected ErrShed, got %v", err)
+	}
+	if m := e.Metrics(); m.Shed != 2 {
+		t.Errorf("Expected 2 shed, got %+v", m)
+	}
+}
+
+func TestSubmitTask(t *testing.T) {
+	e := NewPriorityExecutor(2)
+	defer e.Close()
+
+	value, err := SubmitTask(e, context.Background(), PriorityHigh, NewTaskFromValue(42)).Await().Unwrap()
+	if err != nil || value != 42 {
+		t.Errorf("Expected 42, got (%d, %v)", value, err)
+	}
+}
+
+func TestPriorityExecutorClose(t *testing.T) {
+	e := NewPriorityExecutor(1)
+	e.Close()
+
+	if _, err := e.Submit(context.Background(), func() {}).Await().Unwrap(); !errors.Is(err, ErrExecutorClosed) {
+		t.Errorf("Expected ErrExecutorClosed, got %v", err)
+	}
+
+	// as a package Executor it keeps running work after Close
+	done := make(chan struct{})
+	e.Go(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Go should fall back to a goroutine after Close")
+	}
+}
@@ -3,22 +3,30 @@ package monad
 import (
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Reactive wraps a value of type T and provides reactive capabilities
+// Subscribers are kept in an immutable slice that is replaced on Subscribe/Unsubscribe (copy-on-write),
+// so Set and Update only take a snapshot pointer instead of copying the subscriber list
 type Reactive[T any] struct {
 	value       T
-	subscribers map[int]func(old T, new T)
+	subscribers atomic.Pointer[[]subscriber[T]]
 	nextID      int64
 	mutex       sync.RWMutex
 }
 
+// subscriber is a registered change callback
+type subscriber[T any] struct {
+	id       int
+	callback func(old T, new T)
+}
+
 // NewReactive creates a new reactive wrapper around the given value
 func NewReactive[T any](initial T) *Reactive[T] {
 	return &Reactive[T]{
-		value:       initial,
-		subscribers: make(map[int]func(old T, new T)),
-		nextID:      0,
+		value:  initial,
+		nextID: 0,
 	}
 }
 
@@ -34,18 +42,11 @@ func (r *Reactive[T]) Set(newValue T) {
 	r.mutex.Lock()
 	oldValue := r.value
 	r.value = newValue
-	
-	// Copy subscribers to avoid holding lock during notifications
-	subscribers := make(map[int]func(old T, new T))
-	for id, callback := range r.subscribers {
-		subscribers[id] = callback
-	}
+	subscribers := r.snapshot()
 	r.mutex.Unlock()
 	
 	// Notify subscribers outside of lock to prevent deadlocks
-	for _, callback := range subscribers {
-		go callback(oldValue, newValue)
-	}
+	r.notify(subscribers, oldValue, newValue)
 }
 
 // Update applies a function to the current value and sets the result
@@ -54,18 +55,11 @@ func (r *Reactive[T]) Update(fn func(T) T) {
 	oldValue := r.value
 	newValue := fn(r.value)
 	r.value = newValue
-	
-	// Copy subscribers to avoid holding lock during notifications
-	subscribers := make(map[int]func(old T, new T))
-	for id, callback := range r.subscribers {
-		subscribers[id] = callback
-	}
+	subscribers := r.snapshot()
 	r.mutex.Unlock()
 	
 	// Notify subscribers outside of lock to prevent deadlocks
-	for _, callback := range subscribers {
-		go callback(oldValue, newValue)
-	}
+	r.notify(subscribers, oldValue, newValue)
 }
 
 // Subscribe adds a callback that will be called when the value changes
@@ -75,7 +69,12 @@ func (r *Reactive[T]) Subscribe(callback func(old T, new T)) int {
 	defer r.mutex.Unlock()
 	
 	id := int(atomic.AddInt64(&r.nextID, 1))
-	r.subscribers[id] = callback
+	current := r.snapshot()
+	next := make([]subscriber[T], len(current), len(current)+1)
+	copy(next, current)
+	next = append(next, subscriber[T]{id: id, callback: callback})
+	r.subscribers.Store(&next)
+	currentMetrics().reactiveSubscribers.Add(1)
 	return id
 }
 
@@ -83,7 +82,34 @@ func (r *Reactive[T]) Subscribe(callback func(old T, new T)) int {
 func (r *Reactive[T]) Unsubscribe(id int) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
-	delete(r.subscribers, id)
+	
+	current := r.snapshot()
+	next := make([]subscriber[T], 0, len(current))
+	for _, sub := range current {
+		if sub.id != id {
+			next = append(next, sub)
+		}
+	}
+	r.subscribers.Store(&next)
+	currentMetrics().reactiveSubscribers.Add(float64(len(next) - len(current)))
+}
+
+// snapshot returns the current immutable subscriber slice
+func (r *Reactive[T]) snapshot() []subscriber[T] {
+	if subs := r.subscribers.Load(); subs != nil {
+		return *subs
+	}
+	return nil
+}
+
+// notify calls every subscriber in the snapshot with the change asynchronously, each through Spawn, so no
+// order among the subscribers is guaranteed
+func (r *Reactive[T]) notify(subscribers []subscriber[T], oldValue, newValue T) {
+	currentMetrics().reactiveUpdates.Add(1)
+	for _, sub := range subscribers {
+		callback := sub.callback
+		Spawn(func() { callback(oldValue, newValue) })
+	}
 }
 
 // MapReactive creates a new reactive that transforms this reactive's value
@@ -139,4 +165,123 @@ func CombineReactives[T any, U any, V any](
 	})
 	
 	return result
-}
\ No newline at end of file
+}
+
+// DebounceReactive creates a reactive that takes the source's value once it has stopped changing for d
+// The quiet period is measured by the package Clock
+func DebounceReactive[T any](source *Reactive[T], d time.Duration) *Reactive[T] {
+	result := NewReactive(source.Get())
+	
+	var mu sync.Mutex
+	var timer Timer
+	var generation int
+	
+	source.Subscribe(func(_, new T) {
+		mu.Lock()
+		defer mu.Unlock()
+		
+		if timer != nil {
+			timer.Stop()
+		}
+		generation++
+		current := generation
+		timer = currentClock().AfterFunc(d, func() {
+			mu.Lock()
+			stale := current != generation
+			mu.Unlock()
+			if !stale {
+				result.Set(new)
+			}
+		})
+	})
+	
+	return result
+}
+
+// ThrottleReactive creates a reactive that takes the source's value at most once per interval d
+// The first change in an interval is applied immediately and the latest change during the interval is applied when it ends
+func ThrottleReactive[T any](source *Reactive[T], d time.Duration) *Reactive[T] {
+	result := NewReactive(source.Get())
+	
+	var mu sync.Mutex
+	var last time.Time
+	var pending bool
+	var latest T
+	
+	flush := func() {
+		mu.Lock()
+		if !pending {
+			mu.Unlock()
+			return
+		}
+		value := latest
+		pending = false
+		last = currentClock().Now()
+		mu.Unlock()
+		result.Set(value)
+	}
+	
+	source.Subscribe(func(_, new T) {
+		clock := currentClock()
+		
+		mu.Lock()
+		latest = new
+		if pending {
+			mu.Unlock()
+			return
+		}
+		pending = true
+		wait := d - clock.Now().Sub(last)
+		mu.Unlock()
+		
+		if wait <= 0 {
+			flush()
+			return
+		}
+		clock.AfterFunc(wait, flush)
+	})
+	
+	return result
+}
+
+// DerivePolicy controls what a fallible reactive derivation shows when the transform fails
+type DerivePolicy int
+
+const (
+	// PropagateErrors replaces the derived value with the Err
+	PropagateErrors DerivePolicy = iota
+	// KeepLastGood leaves the last Ok value in place; the failure only appears on the error reactive
+	// Until a first success the derived value holds the latest Err
+	KeepLastGood
+)
+
+// MapReactiveE derives a Reactive[Result[U]] from a fallible transform of source
+// The second reactive holds the latest transform error, reset to nil after each success,
+// so failures stay observable even when KeepLastGood hides them from the derived value
+// Chain derivations over a Result reactive with AndThen inside the transform
+func MapReactiveE[T any, U any](source *Reactive[T], transform func(T) Result[U], policy DerivePolicy) (*Reactive[Result[U]], *Reactive[error]) {
+	initial := transform(source.Get())
+	result := NewReactive(initial)
+	errs := NewReactive(initial.err)
+
+	var mu sync.Mutex
+	source.Subscribe(func(_, new T) {
+		next := transform(new)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if next.err == nil {
+			result.Set(next)
+			if errs.Get() != nil {
+				errs.Set(nil)
+			}
+			return
+		}
+		if policy == PropagateErrors || result.Get().err != nil {
+			result.Set(next)
+		}
+		errs.Set(next.err)
+	})
+
+	return result, errs
+}
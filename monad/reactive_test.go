@@ -1,6 +1,8 @@
 package monad
 
 import (
+	"fmt"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -285,4 +287,160 @@ func TestCombineReactives(t *testing.T) {
 	if finalValue != expected {
 		t.Errorf("Expected %s, got %s", expected, finalValue)
 	}
-}
\ No newline at end of file
+}
+
+func TestDebounceReactive(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	source := NewReactive(0)
+	debounced := DebounceReactive(source, 100*time.Millisecond)
+
+	source.Set(1)
+	s.RunUntilIdle()
+	s.Advance(50 * time.Millisecond)
+	source.Set(2)
+	s.RunUntilIdle()
+	s.Advance(50 * time.Millisecond)
+
+	if debounced.Get() != 0 {
+		t.Errorf("Debounced value should not change while source is busy, got %d", debounced.Get())
+	}
+
+	s.Advance(50 * time.Millisecond)
+	if debounced.Get() != 2 {
+		t.Errorf("Expected debounced value 2, got %d", debounced.Get())
+	}
+}
+
+func TestThrottleReactive(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	source := NewReactive(0)
+	throttled := ThrottleReactive(source, 100*time.Millisecond)
+
+	source.Set(1)
+	s.RunUntilIdle()
+	if throttled.Get() != 1 {
+		t.Errorf("First change should apply immediately, got %d", throttled.Get())
+	}
+
+	source.Set(2)
+	source.Set(3)
+	s.RunUntilIdle()
+	if throttled.Get() != 1 {
+		t.Errorf("Changes within the interval should be held back, got %d", throttled.Get())
+	}
+
+	s.Advance(100 * time.Millisecond)
+	if throttled.Get() != 3 {
+		t.Errorf("Latest change should apply when the interval ends, got %d", throttled.Get())
+	}
+}
+
+func TestReactiveSubscribersCopyOnWrite(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	reactive := NewReactive(0)
+	var calls []string
+	id1 := reactive.Subscribe(func(_, _ int) { calls = append(calls, "first") })
+	reactive.Subscribe(func(_, _ int) { calls = append(calls, "second") })
+
+	// The snapshot taken by Set is unaffected by a later Unsubscribe
+	reactive.Set(1)
+	reactive.Unsubscribe(id1)
+	s.RunUntilIdle()
+
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Errorf("Expected both subscribers in order, got %v", calls)
+	}
+
+	calls = nil
+	reactive.Set(2)
+	s.RunUntilIdle()
+	if len(calls) != 1 || calls[0] != "second" {
+		t.Errorf("Expected only the remaining subscriber, got %v", calls)
+	}
+}
+
+func BenchmarkReactiveSet(b *testing.B) {
+	defer SetExecutor(&countingExecutor{})()
+
+	for _, n := range []int{1, 100, 1000} {
+		reactive := NewReactive(0)
+		for i := 0; i < n; i++ {
+			reactive.Subscribe(func(_, _ int) {})
+		}
+		b.Run(fmt.Sprintf("subscribers=%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				reactive.Set(i)
+			}
+		})
+	}
+}
+
+func TestMapReactiveEPropagateErrors(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	source := NewReactive("1")
+	parsed, errs := MapReactiveE(source, func(s string) Result[int] {
+		return ResultOf(strconv.Atoi(s))
+	}, PropagateErrors)
+
+	if v, err := parsed.Get().Unwrap(); err != nil || v != 1 {
+		t.Fatalf("Expected Ok(1), got (%d, %v)", v, err)
+	}
+
+	source.Set("x")
+	s.RunUntilIdle()
+	if parsed.Get().IsOk() {
+		t.Error("Failure should propagate to the derived value")
+	}
+	if errs.Get() == nil {
+		t.Error("Failure should be on the error reactive")
+	}
+
+	source.Set("7")
+	s.RunUntilIdle()
+	if v, _ := parsed.Get().Unwrap(); v != 7 || errs.Get() != nil {
+		t.Errorf("Expected recovery to Ok(7) with no error, got %v / %v", parsed.Get(), errs.Get())
+	}
+}
+
+func TestMapReactiveEKeepLastGood(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	source := NewReactive("x")
+	parsed, errs := MapReactiveE(source, func(s string) Result[int] {
+		return ResultOf(strconv.Atoi(s))
+	}, KeepLastGood)
+
+	if parsed.Get().IsOk() {
+		t.Error("Without a good value yet the derived value holds the Err")
+	}
+
+	source.Set("5")
+	s.RunUntilIdle()
+	source.Set("oops")
+	s.RunUntilIdle()
+
+	if v, err := parsed.Get().Unwrap(); err != nil || v != 5 {
+		t.Errorf("Expected the last good value 5, got (%d, %v)", v, err)
+	}
+	if errs.Get() == nil {
+		t.Error("The failure should still be reported on the error reactive")
+	}
+
+	// derivations chain over the Result reactive
+	doubled, _ := MapReactiveE(parsed, func(r Result[int]) Result[int] {
+		return Map(r, func(v int) int { return v * 2 })
+	}, KeepLastGood)
+	if v, _ := doubled.Get().Unwrap(); v != 10 {
+		t.Errorf("Expected 10, got %d", v)
+	}
+}
@@ -0,0 +1,155 @@
+package monad
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RecordedEvent is one entry of a recorded event log: a value and when it was emitted,
+// relative to the start of the recording
+// Event logs are plain slices, so they can be written as JSON fixtures and replayed in tests
+type RecordedEvent[T any] struct {
+	At    time.Duration `json:"at"`
+	Value T             `json:"value"`
+}
+
+// ReplayObservable creates an Observable emitting the values of a recorded event log in order and completing
+// The recorded offsets are ignored, so replays run as fast as the subscriber consumes them
+func ReplayObservable[T any](events []RecordedEvent[T]) Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		for _, event := range events {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			next(event.Value)
+		}
+		return nil
+	}
+}
+
+// ReplayObservableTimed is ReplayObservable honouring the recorded offsets on the package Clock
+// With a FakeClock installed, each Advance releases the events that became due, without real sleeps
+func ReplayObservableTimed[T any](events []RecordedEvent[T]) Observable[T] {
+	return func(ctx context.Context, next func(T)) error {
+		clock := currentClock()
+		start := clock.Now()
+		for _, event := range events {
+			if wait := event.At - clock.Now().Sub(start); wait > 0 {
+				timer := clock.NewTimer(wait)
+				select {
+				case <-timer.C():
+				case <-ctx.Done():
+					timer.Stop()
+					return ctx.Err()
+				}
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			next(event.Value)
+		}
+		return nil
+	}
+}
+
+// ReplayTopic publishes the values of a recorded event log to topic in order
+// Fails with ErrTopicClosed if the topic is closed part way through
+func ReplayTopic[T any](topic *Topic[T], events []RecordedEvent[T]) Result[Unit] {
+	for _, event := range events {
+		if r := topic.Publish(event.Value); !r.IsOk() {
+			return r
+		}
+	}
+	return OkUnit()
+}
+
+// RecordObservable runs source to completion and returns every emitted value with its offset on the package Clock
+func RecordObservable[T any](ctx context.Context, source Observable[T]) Result[[]RecordedEvent[T]] {
+	clock := currentClock()
+	start := clock.Now()
+	var events []RecordedEvent[T]
+	err := source(ctx, func(v T) {
+		events = append(events, RecordedEvent[T]{At: clock.Now().Sub(start), Value: v})
+	})
+	if err != nil {
+		return Err[[]RecordedEvent[T]](err)
+	}
+	return Ok(events)
+}
+
+// EventRecorder records the events delivered to a Topic subscription
+// Wait blocks until a number of events arrived, so tests of asynchronous delivery need no sleeps
+type EventRecorder[T any] struct {
+	clock  Clock
+	start  time.Time
+	sub    *Subscription[T]
+	mu     sync.Mutex
+	events []RecordedEvent[T]
+	notify chan struct{}
+}
+
+// RecordTopic subscribes to topic and records every event published until ctx is cancelled or Stop is called
+// The subscription is registered before RecordTopic returns, so events published afterwards are never missed
+func RecordTopic[T any](ctx context.Context, topic *Topic[T]) *EventRecorder[T] {
+	clock := currentClock()
+	r := &EventRecorder[T]{clock: clock, start: clock.Now(), notify: make(chan struct{})}
+	r.sub = topic.Subscribe(ctx, r.record)
+	return r
+}
+
+// record appends v to the log and wakes the waiters
+func (r *EventRecorder[T]) record(v T) {
+	r.mu.Lock()
+	r.events = append(r.events, RecordedEvent[T]{At: r.clock.Now().Sub(r.start), Value: v})
+	close(r.notify)
+	r.notify = make(chan struct{})
+	r.mu.Unlock()
+}
+
+// Events returns a copy of the events recorded so far
+func (r *EventRecorder[T]) Events() []RecordedEvent[T] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedEvent[T](nil), r.events...)
+}
+
+// Values returns the values recorded so far
+func (r *EventRecorder[T]) Values() []T {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := make([]T, len(r.events))
+	for i, event := range r.events {
+		values[i] = event.Value
+	}
+	return values
+}
+
+// Wait blocks until at least n events were recorded and returns the first n values
+// Fails with the context error if ctx is done first
+func (r *EventRecorder[T]) Wait(ctx context.Context, n int) Result[[]T] {
+	for {
+		r.mu.Lock()
+		if len(r.events) >= n {
+			values := make([]T, n)
+			for i := range values {
+				values[i] = r.events[i].Value
+			}
+			r.mu.Unlock()
+			return Ok(values)
+		}
+		notify := r.notify
+		r.mu.Unlock()
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return Err[[]T](ctx.Err())
+		}
+	}
+}
+
+// Stop cancels the subscription; events already recorded are kept
+func (r *EventRecorder[T]) Stop() {
+	r.sub.Cancel()
+}
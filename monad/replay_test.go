@@ -0,0 +1,114 @@
+package monad
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestReplayObservable(t *testing.T) {
+	events := []RecordedEvent[string]{{At: 0, Value: "a"}, {At: time.Hour, Value: "b"}, {At: 2 * time.Hour, Value: "c"}}
+
+	got, err := ReplayObservable(events).Collect(context.Background()).Unwrap()
+	if err != nil || !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("Expected [a b c], got %v (%v)", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ReplayObservable(events)(ctx, func(string) {}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestReplayObservableTimed(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	events := []RecordedEvent[int]{{At: 0, Value: 1}, {At: time.Second, Value: 2}, {At: 3 * time.Second, Value: 3}}
+	values := make(chan int, 3)
+	done := ReplayObservableTimed(events).Subscribe(context.Background(), func(v int) { values <- v })
+
+	if v := <-values; v != 1 {
+		t.Fatalf("Expected the event at offset 0 immediately, got %d", v)
+	}
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Second)
+	if v := <-values; v != 2 {
+		t.Fatalf("Expected 2 after one second, got %d", v)
+	}
+	clock.BlockUntilTimers(1)
+	clock.Advance(2 * time.Second)
+	if v := <-values; v != 3 {
+		t.Fatalf("Expected 3 after three seconds, got %d", v)
+	}
+	if _, err := done.Await().Unwrap(); err != nil {
+		t.Errorf("Expected the replay to complete, got %v", err)
+	}
+}
+
+func TestRecordObservable(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	source := Observable[int](func(ctx context.Context, next func(int)) error {
+		next(1)
+		clock.Advance(time.Second)
+		next(2)
+		return nil
+	})
+	events, err := RecordObservable(context.Background(), source).Unwrap()
+	want := []RecordedEvent[int]{{At: 0, Value: 1}, {At: time.Second, Value: 2}}
+	if err != nil || !reflect.DeepEqual(events, want) {
+		t.Fatalf("Expected %v, got %v (%v)", want, events, err)
+	}
+
+	// the log round-trips through JSON and replays the same values
+	data, _ := json.Marshal(events)
+	var decoded []RecordedEvent[int]
+	if err := json.Unmarshal(data, &decoded); err != nil || !reflect.DeepEqual(decoded, want) {
+		t.Errorf("Expected the log to round-trip through JSON, got %v (%v)", decoded, err)
+	}
+
+	errBoom := errors.New("boom")
+	failing := Observable[int](func(ctx context.Context, next func(int)) error { return errBoom })
+	if _, err := RecordObservable(context.Background(), failing).Unwrap(); !errors.Is(err, errBoom) {
+		t.Errorf("Expected the stream error, got %v", err)
+	}
+}
+
+func TestRecordTopic(t *testing.T) {
+	topic := NewTopic[string]("orders")
+	rec := RecordTopic(context.Background(), topic)
+
+	events := []RecordedEvent[string]{{Value: "created"}, {Value: "paid"}, {Value: "shipped"}}
+	if !ReplayTopic(topic, events).IsOk() {
+		t.Fatal("ReplayTopic should succeed")
+	}
+
+	got, err := rec.Wait(context.Background(), 3).Unwrap()
+	if err != nil || !reflect.DeepEqual(got, []string{"created", "paid", "shipped"}) {
+		t.Errorf("Expected the replayed events in order, got %v (%v)", got, err)
+	}
+	if len(rec.Events()) != 3 || len(rec.Values()) != 3 {
+		t.Errorf("Expected 3 recorded events, got %v", rec.Events())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := rec.Wait(ctx, 4).Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected Wait to fail with context.Canceled, got %v", err)
+	}
+
+	rec.Stop()
+	if topic.Subscribers() != 0 {
+		t.Errorf("Expected Stop to cancel the subscription, %d subscribers left", topic.Subscribers())
+	}
+	topic.Close()
+	if _, err := ReplayTopic(topic, events).Unwrap(); !errors.Is(err, ErrTopicClosed) {
+		t.Errorf("Expected ErrTopicClosed, got %v", err)
+	}
+}
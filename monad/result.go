@@ -2,26 +2,40 @@ package monad
 
 // Generic Result type with basic combinators
 type Result[T any] struct {
-	val T
-	err error
+	val   T
+	err   error
+	warns *warning // non-fatal diagnostics, see WithWarning
 }
 
 func Ok[T any](v T) Result[T]      { return Result[T]{val: v, err: nil} }
 func Err[T any](e error) Result[T] { var z T; return Result[T]{val: z, err: e} }
 
+// ResultOf lifts a (T, error) pair into a Result, so ResultOf(f()) adapts any Go-style call
+// The value is dropped when err is non-nil
+func ResultOf[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Err[T](err)
+	}
+	return Ok(v)
+}
+
 func (r Result[T]) IsOk() bool         { return r.err == nil }
 func (r Result[T]) Unwrap() (T, error) { return r.val, r.err }
 
+// Map applies f to the value of an Ok Result
+// It does not allocate; for large T prefer ResultRef to avoid copying the value at every step
 func Map[T any, U any](r Result[T], f func(T) U) Result[U] {
 	if r.err != nil {
-		return Err[U](r.err)
+		return Result[U]{err: r.err, warns: r.warns}
 	}
-	return Ok(f(r.val))
+	return Result[U]{val: f(r.val), warns: r.warns}
 }
 
+// AndThen chains a Result-returning function onto an Ok Result
+// It does not allocate; for large T prefer ResultRef to avoid copying the value at every step
 func AndThen[T any, U any](r Result[T], f func(T) Result[U]) Result[U] {
 	if r.err != nil {
-		return Err[U](r.err)
+		return Result[U]{err: r.err, warns: r.warns}
 	}
-	return f(r.val)
+	return CarryWarnings(f(r.val), r)
 }
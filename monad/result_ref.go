@@ -0,0 +1,64 @@
+package monad
+
+// ResultRef is a Result holding its value by pointer
+// Combinators pass the pointer along instead of copying the value, which keeps chains over
+// large structs cheap: after the single allocation made by RefOf (or none with OkRef), MapRef,
+// AndThenRef and UpdateRef do not allocate or copy T
+type ResultRef[T any] struct {
+	ptr *T
+	err error
+}
+
+// OkRef wraps a pointer in a successful ResultRef without copying the value
+func OkRef[T any](p *T) ResultRef[T] { return ResultRef[T]{ptr: p} }
+
+// ErrRef creates a failed ResultRef
+func ErrRef[T any](e error) ResultRef[T] { return ResultRef[T]{err: e} }
+
+// RefOf moves the value of a Result to the heap, allocating once
+func RefOf[T any](r Result[T]) ResultRef[T] {
+	if r.err != nil {
+		return ResultRef[T]{err: r.err}
+	}
+	v := r.val
+	return ResultRef[T]{ptr: &v}
+}
+
+func (r ResultRef[T]) IsOk() bool          { return r.err == nil }
+func (r ResultRef[T]) Unwrap() (*T, error) { return r.ptr, r.err }
+
+// ToResult copies the value back into a Result
+func (r ResultRef[T]) ToResult() Result[T] {
+	if r.err != nil {
+		return Result[T]{err: r.err}
+	}
+	return Result[T]{val: *r.ptr}
+}
+
+// MapRef applies f to the value of an Ok ResultRef, passing it by pointer
+func MapRef[T any, U any](r ResultRef[T], f func(*T) *U) ResultRef[U] {
+	if r.err != nil {
+		return ResultRef[U]{err: r.err}
+	}
+	return ResultRef[U]{ptr: f(r.ptr)}
+}
+
+// AndThenRef chains a ResultRef-returning function onto an Ok ResultRef
+func AndThenRef[T any, U any](r ResultRef[T], f func(*T) ResultRef[U]) ResultRef[U] {
+	if r.err != nil {
+		return ResultRef[U]{err: r.err}
+	}
+	return f(r.ptr)
+}
+
+// UpdateRef modifies the value of an Ok ResultRef in place
+// If f returns an error the ResultRef becomes failed
+func UpdateRef[T any](r ResultRef[T], f func(*T) error) ResultRef[T] {
+	if r.err != nil {
+		return r
+	}
+	if err := f(r.ptr); err != nil {
+		return ResultRef[T]{err: err}
+	}
+	return r
+}
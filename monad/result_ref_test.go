@@ -0,0 +1,95 @@
+package monad
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResultRefBasics(t *testing.T) {
+	value := largeValue{}
+	ref := OkRef(&value)
+	if !ref.IsOk() {
+		t.Error("OkRef should be Ok")
+	}
+
+	ptr, err := ref.Unwrap()
+	if err != nil || ptr != &value {
+		t.Error("OkRef should hold the original pointer")
+	}
+
+	testErr := errors.New("test error")
+	errRef := ErrRef[largeValue](testErr)
+	if errRef.IsOk() {
+		t.Error("ErrRef should not be Ok")
+	}
+	if _, err := errRef.ToResult().Unwrap(); err != testErr {
+		t.Errorf("Expected test error, got %v", err)
+	}
+}
+
+func TestRefOf(t *testing.T) {
+	ref := RefOf(Ok(largeValue{data: [64]int{7}}))
+	ptr, err := ref.Unwrap()
+	if err != nil || ptr.data[0] != 7 {
+		t.Errorf("Expected value 7, got %v (%v)", ptr, err)
+	}
+
+	testErr := errors.New("test error")
+	if _, err := RefOf(Err[largeValue](testErr)).Unwrap(); err != testErr {
+		t.Errorf("Expected test error, got %v", err)
+	}
+}
+
+func TestMapRefAndThenRef(t *testing.T) {
+	value := largeValue{}
+	bump := func(v *largeValue) *largeValue { v.data[0]++; return v }
+
+	result := MapRef(MapRef(OkRef(&value), bump), bump)
+	if value.data[0] != 2 {
+		t.Errorf("MapRef should operate on the shared value, got %d", value.data[0])
+	}
+
+	first := AndThenRef(result, func(v *largeValue) ResultRef[int] {
+		n := v.data[0]
+		return OkRef(&n)
+	})
+	n, err := first.Unwrap()
+	if err != nil || *n != 2 {
+		t.Errorf("Expected 2, got %v (%v)", n, err)
+	}
+
+	testErr := errors.New("test error")
+	failed := MapRef(ErrRef[largeValue](testErr), bump)
+	if _, err := failed.Unwrap(); err != testErr {
+		t.Errorf("MapRef should propagate errors, got %v", err)
+	}
+}
+
+func TestUpdateRef(t *testing.T) {
+	value := largeValue{}
+	ok := UpdateRef(OkRef(&value), func(v *largeValue) error {
+		v.data[1] = 9
+		return nil
+	})
+	if !ok.IsOk() || value.data[1] != 9 {
+		t.Error("UpdateRef should modify the value in place")
+	}
+
+	testErr := errors.New("test error")
+	failed := UpdateRef(ok, func(*largeValue) error { return testErr })
+	if _, err := failed.Unwrap(); err != testErr {
+		t.Errorf("Expected test error, got %v", err)
+	}
+}
+
+func TestResultRefDoesNotAllocate(t *testing.T) {
+	var value largeValue
+	bump := func(v *largeValue) *largeValue { v.data[0]++; return v }
+
+	allocs := testing.AllocsPerRun(100, func() {
+		benchLargeRef = MapRef(MapRef(OkRef(&value), bump), bump)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v per run", allocs)
+	}
+}
@@ -135,4 +135,81 @@ func TestAndThenResult(t *testing.T) {
 	if err.Error() != "original error" {
 		t.Errorf("Expected 'original error', got %s", err.Error())
 	}
-}
\ No newline at end of file
+}
+// largeValue is big enough that copying it dominates combinator cost
+type largeValue struct {
+	data [64]int
+}
+
+var (
+	benchResult      Result[int]
+	benchLargeResult Result[largeValue]
+	benchLargeRef    ResultRef[largeValue]
+)
+
+func BenchmarkMapChain(b *testing.B) {
+	b.ReportAllocs()
+	inc := func(x int) int { return x + 1 }
+	for i := 0; i < b.N; i++ {
+		benchResult = Map(Map(Map(Ok(i), inc), inc), inc)
+	}
+}
+
+func BenchmarkAndThenChain(b *testing.B) {
+	b.ReportAllocs()
+	inc := func(x int) Result[int] { return Ok(x + 1) }
+	for i := 0; i < b.N; i++ {
+		benchResult = AndThen(AndThen(AndThen(Ok(i), inc), inc), inc)
+	}
+}
+
+func BenchmarkMapChainLarge(b *testing.B) {
+	b.ReportAllocs()
+	touch := func(v largeValue) largeValue { v.data[0]++; return v }
+	for i := 0; i < b.N; i++ {
+		benchLargeResult = Map(Map(Map(Ok(largeValue{}), touch), touch), touch)
+	}
+}
+
+func BenchmarkMapChainLargeRef(b *testing.B) {
+	b.ReportAllocs()
+	touch := func(v *largeValue) *largeValue { v.data[0]++; return v }
+	var v largeValue
+	for i := 0; i < b.N; i++ {
+		benchLargeRef = MapRef(MapRef(MapRef(OkRef(&v), touch), touch), touch)
+	}
+}
+
+func TestResultCombinatorsDoNotAllocate(t *testing.T) {
+	inc := func(x int) int { return x + 1 }
+	then := func(x int) Result[int] { return Ok(x * 2) }
+	testErr := errors.New("test error")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		benchResult = AndThen(Map(Ok(1), inc), then)
+		benchResult = Map(Err[int](testErr), inc)
+	})
+	if allocs != 0 {
+		t.Errorf("Expected no allocations, got %v per run", allocs)
+	}
+}
+
+func TestResultOf(t *testing.T) {
+	parse := func(s string) (int, error) {
+		if s == "" {
+			return -1, errors.New("empty")
+		}
+		return len(s), nil
+	}
+
+	if v, err := ResultOf(parse("abc")).Unwrap(); err != nil || v != 3 {
+		t.Errorf("Expected Ok(3), got (%d, %v)", v, err)
+	}
+	v, err := ResultOf(parse("")).Unwrap()
+	if err == nil || err.Error() != "empty" {
+		t.Errorf("Expected empty error, got %v", err)
+	}
+	if v != 0 {
+		t.Errorf("Expected zero value on error, got %d", v)
+	}
+}
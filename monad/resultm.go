@@ -0,0 +1,132 @@
+package monad
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+)
+
+// WarningsKey is the Meta key under which ResultM.Warn collects non-fatal diagnostics, as a []string
+const WarningsKey = "warnings"
+
+// Meta is the metadata carried by a ResultM, such as trace IDs, timings and warnings
+// A ResultM never modifies a Meta it was given or returned; every change copies it
+type Meta map[string]any
+
+// merge returns m with the entries of other added; other wins for a shared key, except for WarningsKey,
+// whose warnings are concatenated in order
+func (m Meta) merge(other Meta) Meta {
+	if len(other) == 0 {
+		return m
+	}
+	if len(m) == 0 {
+		return other
+	}
+	merged := maps.Clone(m)
+	for k, v := range other {
+		if k == WarningsKey {
+			v = slices.Concat(warnings(m), warnings(other))
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// warnings returns the warnings recorded in m
+func warnings(m Meta) []string {
+	w, _ := m[WarningsKey].([]string)
+	return w
+}
+
+// ResultM is a Result carrying Meta alongside its value or error
+// MapM and AndThenM keep the metadata through a chain, so a pipeline can report non-fatal diagnostics
+// and timings without failing; a failed ResultM keeps the metadata recorded before the failure
+type ResultM[T any] struct {
+	result Result[T]
+	meta   Meta
+}
+
+// OkM returns a successful ResultM holding v and no metadata
+func OkM[T any](v T) ResultM[T] { return ResultM[T]{result: Ok(v)} }
+
+// ErrM returns a failed ResultM holding err and no metadata
+func ErrM[T any](err error) ResultM[T] { return ResultM[T]{result: Err[T](err)} }
+
+// WithMeta returns a ResultM holding r and a copy of meta
+func WithMeta[T any](r Result[T], meta Meta) ResultM[T] {
+	return ResultM[T]{result: r, meta: maps.Clone(meta)}
+}
+
+// IsOk reports whether the ResultM holds a value
+func (r ResultM[T]) IsOk() bool { return r.result.IsOk() }
+
+// Unwrap returns the value and error of the ResultM
+func (r ResultM[T]) Unwrap() (T, error) { return r.result.Unwrap() }
+
+// Result returns the ResultM without its metadata
+func (r ResultM[T]) Result() Result[T] { return r.result }
+
+// Meta returns a copy of the metadata
+func (r ResultM[T]) Meta() Meta { return maps.Clone(r.meta) }
+
+// Get returns the metadata under key
+func (r ResultM[T]) Get(key string) (any, bool) {
+	v, ok := r.meta[key]
+	return v, ok
+}
+
+// With returns r with value recorded under key, replacing any previous value
+func (r ResultM[T]) With(key string, value any) ResultM[T] {
+	r.meta = r.meta.merge(Meta{key: value})
+	return r
+}
+
+// Warn returns r with msg appended to its warnings
+func (r ResultM[T]) Warn(msg string) ResultM[T] {
+	r.meta = r.meta.merge(Meta{WarningsKey: []string{msg}})
+	return r
+}
+
+// Warnings returns the warnings recorded so far, in order
+func (r ResultM[T]) Warnings() []string { return slices.Clone(warnings(r.meta)) }
+
+// Attrs returns the metadata as slog attributes sorted by key, for logging next to the outcome
+func (r ResultM[T]) Attrs() []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(r.meta))
+	for _, k := range slices.Sorted(maps.Keys(r.meta)) {
+		attrs = append(attrs, slog.Any(k, r.meta[k]))
+	}
+	return attrs
+}
+
+// LogValue renders a ResultM for slog as a group of its outcome, under "result", and its metadata
+func (r ResultM[T]) LogValue() slog.Value {
+	return slog.GroupValue(append([]slog.Attr{slog.Any("result", r.result)}, r.Attrs()...)...)
+}
+
+// MapM applies f to the value of a successful ResultM, keeping its metadata
+func MapM[T, U any](r ResultM[T], f func(T) U) ResultM[U] {
+	return ResultM[U]{result: Map(r.result, f), meta: r.meta}
+}
+
+// AndThenM chains f onto a successful ResultM, merging the metadata f returns into r's: f's values win
+// for a shared key and warnings are concatenated
+// A failed r is passed through with its metadata, without calling f
+func AndThenM[T, U any](r ResultM[T], f func(T) ResultM[U]) ResultM[U] {
+	v, err := r.result.Unwrap()
+	if err != nil {
+		return ResultM[U]{result: Err[U](err), meta: r.meta}
+	}
+	next := f(v)
+	return ResultM[U]{result: next.result, meta: r.meta.merge(next.meta)}
+}
+
+// TimedM is AndThenM recording how long f took, as a time.Duration under "timing." + name, measured by the
+// package Clock
+func TimedM[T, U any](name string, r ResultM[T], f func(T) ResultM[U]) ResultM[U] {
+	return AndThenM(r, func(v T) ResultM[U] {
+		start := currentClock().Now()
+		next := f(v)
+		return next.With("timing."+name, currentClock().Now().Sub(start))
+	})
+}
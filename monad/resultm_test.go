@@ -0,0 +1,93 @@
+package monad
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResultMChainMergesMeta(t *testing.T) {
+	parse := func(s string) ResultM[int] {
+		n, err := strconv.Atoi(strings.TrimSpace(s))
+		r := WithMeta(ResultOf(n, err), Meta{"stage": "parse"})
+		if s != strings.TrimSpace(s) {
+			r = r.Warn("input was trimmed")
+		}
+		return r
+	}
+	check := func(n int) ResultM[int] {
+		r := OkM(n).With("stage", "check")
+		if n > 100 {
+			r = r.Warn("value clamped")
+			n = 100
+		}
+		return MapM(r, func(int) int { return n })
+	}
+
+	start := OkM(" 250").With("trace", "abc").Warn("legacy input")
+	r := AndThenM(AndThenM(start, parse), check)
+	if v, err := r.Unwrap(); err != nil || v != 100 {
+		t.Fatalf("Expected 100, got %d, %v", v, err)
+	}
+	if got := r.Warnings(); strings.Join(got, "|") != "legacy input|input was trimmed|value clamped" {
+		t.Errorf("Warnings should concatenate in order, got %v", got)
+	}
+	if trace, _ := r.Get("trace"); trace != "abc" {
+		t.Errorf("Expected the trace to survive the chain, got %v", trace)
+	}
+	if stage, _ := r.Get("stage"); stage != "check" {
+		t.Errorf("The later stage should win, got %v", stage)
+	}
+	if w := start.Warnings(); len(w) != 1 {
+		t.Errorf("Chaining should not modify the start, got %v", w)
+	}
+}
+
+func TestResultMFailureKeepsMeta(t *testing.T) {
+	called := false
+	r := AndThenM(ErrM[int](errors.New("boom")).With("trace", "abc"), func(int) ResultM[int] {
+		called = true
+		return OkM(1)
+	})
+	if r.IsOk() || called {
+		t.Fatal("A failed ResultM should short-circuit")
+	}
+	if trace, ok := r.Get("trace"); !ok || trace != "abc" {
+		t.Errorf("A failure should keep its metadata, got %v", r.Meta())
+	}
+}
+
+func TestResultMMetaIsCopied(t *testing.T) {
+	meta := Meta{"k": 1}
+	r := WithMeta(Ok(1), meta)
+	meta["k"] = 2
+	r.Meta()["k"] = 3
+	if k, _ := r.Get("k"); k != 1 {
+		t.Errorf("Metadata should be copied in and out, got %v", k)
+	}
+}
+
+func TestTimedM(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+	r := TimedM("slow", OkM(1), func(n int) ResultM[int] {
+		clock.Advance(3 * time.Second)
+		return OkM(n + 1)
+	})
+	if d, _ := r.Get("timing.slow"); d != 3*time.Second {
+		t.Errorf("Expected a 3s timing, got %v", d)
+	}
+}
+
+func TestResultMLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("done", "r", OkM(7).With("trace", "abc").Warn("slow"))
+	if got := buf.String(); !strings.Contains(got, "r.result=Ok(7)") || !strings.Contains(got, "r.trace=abc") || !strings.Contains(got, "r.warnings=[slow]") {
+		t.Errorf("Unexpected record %q", got)
+	}
+}
@@ -0,0 +1,46 @@
+package monad
+
+import "errors"
+
+// MatchResult returns onOk applied to the value of r, or onErr applied to its error
+func MatchResult[T, R any](r Result[T], onOk func(T) R, onErr func(error) R) R {
+	val, err := r.Unwrap()
+	if err != nil {
+		return onErr(err)
+	}
+	return onOk(val)
+}
+
+// MatchFuture returns a Future of MatchResult applied to the result of future once it completes
+// The handlers run through Spawn, like MapFuture; a cancelled input reaches onErr with context.Canceled
+func MatchFuture[T, R any](future *Future[T], onOk func(T) R, onErr func(error) R) *Future[R] {
+	matched := NewFuture[R]()
+	future.onComplete(func(result Result[T]) {
+		Spawn(func() {
+			matched.complete(Ok(MatchResult(result, onOk, onErr)))
+		})
+	})
+	return matched
+}
+
+// MatchErrIs is MatchResult with a separate handler for errors matching target according to errors.Is
+func MatchErrIs[T, R any](r Result[T], target error, onOk func(T) R, onIs func(error) R, onErr func(error) R) R {
+	return MatchResult(r, onOk, func(err error) R {
+		if errors.Is(err, target) {
+			return onIs(err)
+		}
+		return onErr(err)
+	})
+}
+
+// MatchErrAs is MatchResult with a separate handler for errors whose chain holds an E according to errors.As,
+// e.g. MatchErrAs[*fs.PathError](r, onOk, func(e *fs.PathError) string { return e.Path }, onErr)
+func MatchErrAs[E error, T, R any](r Result[T], onOk func(T) R, onAs func(E) R, onErr func(error) R) R {
+	return MatchResult(r, onOk, func(err error) R {
+		var target E
+		if errors.As(err, &target) {
+			return onAs(target)
+		}
+		return onErr(err)
+	})
+}
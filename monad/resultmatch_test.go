@@ -0,0 +1,70 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"testing"
+)
+
+func TestMatchResult(t *testing.T) {
+	describe := func(r Result[int]) string {
+		return MatchResult(r, strconv.Itoa, func(err error) string { return "error: " + err.Error() })
+	}
+	if got := describe(Ok(3)); got != "3" {
+		t.Errorf("Expected 3, got %q", got)
+	}
+	if got := describe(Err[int](errors.New("boom"))); got != "error: boom" {
+		t.Errorf("Expected the error handler, got %q", got)
+	}
+}
+
+func TestMatchFuture(t *testing.T) {
+	matched := MatchFuture(CompletedFuture(2), func(v int) int { return v * 10 }, func(error) int { return -1 })
+	if v, err := matched.Await().Unwrap(); err != nil || v != 20 {
+		t.Errorf("Expected Ok(20), got %v, %v", v, err)
+	}
+
+	cancelled := NewFuture[int]()
+	cancelled.Cancel()
+	isCanceled := MatchFuture(cancelled, func(int) bool { return false }, func(err error) bool { return errors.Is(err, context.Canceled) })
+	if v, _ := isCanceled.Await().Unwrap(); !v {
+		t.Error("A cancelled input should reach onErr with context.Canceled")
+	}
+}
+
+func TestMatchErrIs(t *testing.T) {
+	classify := func(r Result[int]) string {
+		return MatchErrIs(r, fs.ErrNotExist,
+			func(int) string { return "ok" },
+			func(error) string { return "missing" },
+			func(error) string { return "other" })
+	}
+	if got := classify(Err[int](fmt.Errorf("stat: %w", fs.ErrNotExist))); got != "missing" {
+		t.Errorf("Expected missing, got %q", got)
+	}
+	if got := classify(Err[int](errors.New("boom"))); got != "other" {
+		t.Errorf("Expected other, got %q", got)
+	}
+	if got := classify(Ok(1)); got != "ok" {
+		t.Errorf("Expected ok, got %q", got)
+	}
+}
+
+func TestMatchErrAs(t *testing.T) {
+	pathOf := func(r Result[int]) string {
+		return MatchErrAs(r,
+			func(int) string { return "" },
+			func(e *fs.PathError) string { return e.Path },
+			func(error) string { return "?" })
+	}
+	pathErr := &fs.PathError{Op: "open", Path: "/tmp/x", Err: fs.ErrNotExist}
+	if got := pathOf(Err[int](fmt.Errorf("load: %w", pathErr))); got != "/tmp/x" {
+		t.Errorf("Expected the path, got %q", got)
+	}
+	if got := pathOf(Err[int](errors.New("boom"))); got != "?" {
+		t.Errorf("Expected the fallback handler, got %q", got)
+	}
+}
@@ -0,0 +1,111 @@
+package monad
+
+import "errors"
+
+// Saga is the synchronous counterpart of WorkflowTask for steps producing typed Results:
+// run each step through WithCompensation, and when one fails the compensations of every completed step
+// run in reverse order and later steps are skipped
+// A Saga is not safe for concurrent use; Report returns the trace of what ran
+type Saga struct {
+	trace  WorkflowTrace
+	undos  []func() error // per trace entry, nil for steps without compensation
+	failed *WorkflowError
+}
+
+// NewSaga creates an empty Saga; name identifies it in the trace and errors
+func NewSaga(name string) *Saga {
+	return &Saga{trace: WorkflowTrace{Workflow: name}}
+}
+
+// WithCompensation runs step as the next step of the saga and returns its Result
+// On success undo is registered to reverse it if a later step fails; undo may be nil for steps with nothing to undo
+// If the step fails, the completed steps are compensated and the Result carries the step's error
+// If the saga already failed, step is skipped and the Result fails with the saga's *WorkflowError
+func WithCompensation[T any](s *Saga, name string, step func() Result[T], undo func(T) error) Result[T] {
+	if s.failed != nil {
+		s.record(StepTrace{Name: name, Status: StepPending}, nil)
+		return Err[T](s.failed)
+	}
+
+	clock := currentClock()
+	start := clock.Now()
+	value, err := step().Unwrap()
+	duration := clock.Now().Sub(start)
+	if err != nil {
+		s.record(StepTrace{Name: name, Status: StepFailed, Err: err, Duration: duration}, nil)
+		s.fail(name, err)
+		return Err[T](err)
+	}
+
+	var compensate func() error
+	if undo != nil {
+		compensate = func() error { return undo(value) }
+	}
+	s.record(StepTrace{Name: name, Status: StepSucceeded, Duration: duration}, compensate)
+	return Ok(value)
+}
+
+// Abort fails the saga with err outside of any step, e.g. when a check between steps fails,
+// compensating the completed steps; it does nothing if the saga already failed
+func (s *Saga) Abort(err error) {
+	if s.failed == nil {
+		s.fail("abort", err)
+	}
+}
+
+// Err returns the saga's *WorkflowError, or nil while no step failed
+func (s *Saga) Err() error {
+	if s.failed == nil {
+		return nil
+	}
+	return s.failed
+}
+
+// Report returns the trace of every step run so far, failing with the *WorkflowError if the saga failed
+// The error's Trace includes the outcome of every compensation
+func (s *Saga) Report() Result[WorkflowTrace] {
+	if s.failed != nil {
+		s.failed.Trace = s.snapshot()
+		return Err[WorkflowTrace](s.failed)
+	}
+	return Ok(s.snapshot())
+}
+
+// CompensationErrors returns the errors of compensations that failed, joined, or nil
+func (s *Saga) CompensationErrors() error {
+	var errs []error
+	for _, step := range s.trace.Steps {
+		if step.Status == StepCompensationFailed {
+			errs = append(errs, step.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// record appends a step to the trace
+func (s *Saga) record(trace StepTrace, undo func() error) {
+	s.trace.Steps = append(s.trace.Steps, trace)
+	s.undos = append(s.undos, undo)
+}
+
+// fail compensates the completed steps in reverse order and marks the saga failed
+func (s *Saga) fail(step string, err error) {
+	for i := len(s.undos) - 1; i >= 0; i-- {
+		if s.undos[i] == nil {
+			continue
+		}
+		if undoErr := s.undos[i](); undoErr != nil {
+			s.trace.Steps[i].Status = StepCompensationFailed
+			s.trace.Steps[i].Err = undoErr
+		} else {
+			s.trace.Steps[i].Status = StepCompensated
+		}
+		s.undos[i] = nil
+	}
+	s.failed = &WorkflowError{Step: step, Err: err, Trace: s.snapshot()}
+}
+
+// snapshot copies the trace so later steps do not change a returned report
+func (s *Saga) snapshot() WorkflowTrace {
+	return WorkflowTrace{Workflow: s.trace.Workflow, Steps: append([]StepTrace(nil), s.trace.Steps...)}
+}
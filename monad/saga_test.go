@@ -0,0 +1,92 @@
+package monad
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSagaSucceeds(t *testing.T) {
+	saga := NewSaga("checkout")
+	order := WithCompensation(saga, "reserve", func() Result[int] { return Ok(42) }, func(int) error {
+		t.Error("a successful saga must not compensate")
+		return nil
+	})
+	receipt := WithCompensation(saga, "charge", func() Result[string] { return Ok("receipt") }, nil)
+
+	if v, _ := order.Unwrap(); v != 42 {
+		t.Errorf("Expected 42, got %v", order)
+	}
+	if v, _ := receipt.Unwrap(); v != "receipt" {
+		t.Errorf("Expected receipt, got %v", receipt)
+	}
+	trace, err := saga.Report().Unwrap()
+	if err != nil || saga.Err() != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if trace.Workflow != "checkout" || len(trace.Steps) != 2 || trace.Steps[1].Status != StepSucceeded {
+		t.Errorf("Unexpected trace %+v", trace)
+	}
+}
+
+func TestSagaCompensatesInReverse(t *testing.T) {
+	var undone []string
+	chargeErr := errors.New("card declined")
+	releaseErr := errors.New("release failed")
+
+	saga := NewSaga("checkout")
+	WithCompensation(saga, "reserve", func() Result[string] { return Ok("seat-1") }, func(seat string) error {
+		undone = append(undone, "release "+seat)
+		return releaseErr
+	})
+	WithCompensation(saga, "hold", func() Result[int] { return Ok(7) }, func(id int) error {
+		undone = append(undone, "unhold")
+		return nil
+	})
+	charge := WithCompensation(saga, "charge", func() Result[string] { return Err[string](chargeErr) }, nil)
+	ship := WithCompensation(saga, "ship", func() Result[int] {
+		t.Error("ship should not run after a failure")
+		return Ok(0)
+	}, nil)
+
+	if _, err := charge.Unwrap(); !errors.Is(err, chargeErr) {
+		t.Errorf("Expected the step error, got %v", charge)
+	}
+	var wfErr *WorkflowError
+	if _, err := ship.Unwrap(); !errors.As(err, &wfErr) || wfErr.Step != "charge" {
+		t.Errorf("Expected skipped steps to fail with the saga error, got %v", ship)
+	}
+	if len(undone) != 2 || undone[0] != "unhold" || undone[1] != "release seat-1" {
+		t.Errorf("Expected compensations in reverse order, got %v", undone)
+	}
+
+	_, err := saga.Report().Unwrap()
+	if !errors.As(err, &wfErr) || !errors.Is(err, chargeErr) {
+		t.Fatalf("Expected a *WorkflowError wrapping the step error, got %v", err)
+	}
+	want := []StepStatus{StepCompensationFailed, StepCompensated, StepFailed, StepPending}
+	for i, status := range want {
+		if got := wfErr.Trace.Steps[i].Status; got != status {
+			t.Errorf("Step %d: expected %v, got %v", i, status, got)
+		}
+	}
+	if !errors.Is(saga.CompensationErrors(), releaseErr) {
+		t.Errorf("Expected the failed compensation, got %v", saga.CompensationErrors())
+	}
+}
+
+func TestSagaAbort(t *testing.T) {
+	compensated := 0
+	invalid := errors.New("invalid order")
+
+	saga := NewSaga("checkout")
+	WithCompensation(saga, "reserve", func() Result[int] { return Ok(1) }, func(int) error { compensated++; return nil })
+	saga.Abort(invalid)
+	saga.Abort(errors.New("ignored"))
+
+	if compensated != 1 {
+		t.Errorf("Expected one compensation, got %d", compensated)
+	}
+	if !errors.Is(saga.Err(), invalid) {
+		t.Errorf("Expected the abort error, got %v", saga.Err())
+	}
+}
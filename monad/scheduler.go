@@ -0,0 +1,78 @@
+package monad
+
+import (
+	"sync"
+	"time"
+)
+
+// TestScheduler is a deterministic Executor with a manually advanced FakeClock
+// Work submitted through the package (RunAsync, Task.Run, Reactive notifications, ...) is queued
+// and only runs when the test calls RunNext, RunUntilIdle or Advance
+type TestScheduler struct {
+	*FakeClock
+
+	mu    sync.Mutex
+	queue []func()
+}
+
+// NewTestScheduler creates a TestScheduler whose clock starts at the Unix epoch
+func NewTestScheduler() *TestScheduler {
+	return &TestScheduler{FakeClock: NewFakeClock(time.Unix(0, 0))}
+}
+
+// Install makes s the package Executor and Clock, returning a function that restores the previous ones
+func (s *TestScheduler) Install() (restore func()) {
+	restoreExecutor := SetExecutor(s)
+	restoreClock := SetClock(s)
+
+	return func() {
+		restoreClock()
+		restoreExecutor()
+	}
+}
+
+// Go queues fn to run on the next RunNext or RunUntilIdle call
+func (s *TestScheduler) Go(fn func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queue = append(s.queue, fn)
+}
+
+// Pending returns the number of queued functions
+func (s *TestScheduler) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.queue)
+}
+
+// RunNext runs the oldest queued function, returning false if the queue was empty
+func (s *TestScheduler) RunNext() bool {
+	s.mu.Lock()
+	if len(s.queue) == 0 {
+		s.mu.Unlock()
+		return false
+	}
+	fn := s.queue[0]
+	s.queue = s.queue[1:]
+	s.mu.Unlock()
+
+	fn()
+	return true
+}
+
+// RunUntilIdle runs queued functions, including ones queued while running, until the queue is empty
+// Returns the number of functions run
+func (s *TestScheduler) RunUntilIdle() int {
+	n := 0
+	for s.RunNext() {
+		n++
+	}
+	return n
+}
+
+// Advance moves the fake clock forward by d, fires every timer that became due
+// and then runs queued work until idle
+func (s *TestScheduler) Advance(d time.Duration) {
+	s.FakeClock.Advance(d)
+	s.RunUntilIdle()
+}
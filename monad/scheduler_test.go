@@ -0,0 +1,130 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTestSchedulerRunAsync(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	future := RunAsync(func() Result[int] { return Ok(21) })
+	doubled := MapFuture(future, func(x int) int { return x * 2 })
+
+	if future.IsDone() {
+		t.Error("Future should not run before the scheduler does")
+	}
+	if s.Pending() != 1 {
+		t.Errorf("Expected 1 pending function, got %d", s.Pending())
+	}
+
+	s.RunUntilIdle()
+
+	result, ok := doubled.Poll()
+	if !ok {
+		t.Fatal("Mapped future should be done after RunUntilIdle")
+	}
+	if val, _ := result.Unwrap(); val != 42 {
+		t.Errorf("Expected 42, got %d", val)
+	}
+}
+
+func TestTestSchedulerTaskChain(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	task := AndThenTask(NewTaskFromValue(2), func(x int) Task[int] {
+		return NewTaskFromValue(x + 3)
+	})
+	future := AndThenFuture(task.Run(context.Background()), func(x int) *Future[int] {
+		return RunAsync(func() Result[int] { return Ok(x * 10) })
+	})
+
+	s.RunUntilIdle()
+
+	result, ok := future.Poll()
+	if !ok {
+		t.Fatal("Chained future should be done")
+	}
+	if val, _ := result.Unwrap(); val != 50 {
+		t.Errorf("Expected 50, got %d", val)
+	}
+}
+
+func TestTestSchedulerReactiveOrdering(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	reactive := NewReactive(0)
+	var received []int
+	reactive.Subscribe(func(_, newValue int) {
+		received = append(received, newValue)
+	})
+
+	reactive.Set(1)
+	reactive.Set(2)
+	reactive.Update(func(x int) int { return x + 1 })
+
+	if len(received) != 0 {
+		t.Errorf("Notifications should be queued, got %v", received)
+	}
+
+	s.RunUntilIdle()
+
+	expected := []int{1, 2, 3}
+	if len(received) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, received)
+		}
+	}
+}
+
+func TestTestSchedulerTimeout(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	future := NewFuture[int]()
+	results := make(chan Result[int], 1)
+	go func() {
+		results <- future.AwaitWithTimeout(time.Second)
+	}()
+
+	s.BlockUntilTimers(1)
+	s.Advance(500 * time.Millisecond)
+
+	select {
+	case <-results:
+		t.Fatal("Await should not time out before the deadline")
+	default:
+	}
+
+	s.Advance(500 * time.Millisecond)
+
+	result := <-results
+	_, err := result.Unwrap()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+	if got := s.Now(); !got.Equal(time.Unix(1, 0)) {
+		t.Errorf("Expected fake clock at 1s, got %v", got)
+	}
+}
+
+func TestTestSchedulerAfterZero(t *testing.T) {
+	s := NewTestScheduler()
+
+	select {
+	case <-s.After(0):
+	default:
+		t.Error("After(0) should fire immediately")
+	}
+	if s.PendingTimers() != 0 {
+		t.Errorf("Expected no pending timers, got %d", s.PendingTimers())
+	}
+}
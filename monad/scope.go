@@ -0,0 +1,134 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrScopeClosed is returned for children spawned into a Scope after it was closed
+var ErrScopeClosed = errors.New("monad: scope closed")
+
+// ScopePolicy controls how a Scope reacts to a failing child
+type ScopePolicy int
+
+const (
+	// CancelOnFailure cancels every other child on the first failure; Wait reports that failure
+	CancelOnFailure ScopePolicy = iota
+	// CollectFailures lets the other children run on; Wait reports every failure as Errors
+	CollectFailures
+)
+
+// Scope groups child computations so none outlives it: children share a context cancelled when the
+// scope closes (or, under CancelOnFailure, when any child fails), and Wait returns only after all of them
+// Use it instead of ad-hoc RunAsync calls whose goroutines could be orphaned
+type Scope struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	policy ScopePolicy
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	errs     Errors
+	closed   bool
+	failFast bool // the scope cancelled its children after a failure
+}
+
+// NewScope creates a Scope under ctx with the CancelOnFailure policy
+func NewScope(ctx context.Context) *Scope {
+	return NewScopeWithPolicy(ctx, CancelOnFailure)
+}
+
+// NewScopeWithPolicy creates a Scope under ctx with the given failure policy
+func NewScopeWithPolicy(ctx context.Context, policy ScopePolicy) *Scope {
+	ctx, cancel := context.WithCancelCause(ctx)
+	return &Scope{ctx: ctx, cancel: cancel, policy: policy}
+}
+
+// Context returns the context shared by the scope's children
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// SpawnScoped runs fn as a child of s with the scope's context
+// Spawning after Wait or Close returned yields a Future failed with ErrScopeClosed;
+// children may spawn further children while they run
+func SpawnScoped[T any](s *Scope, fn func(context.Context) Result[T]) *Future[T] {
+	future := NewFuture[T]()
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		future.CompleteWithError(ErrScopeClosed)
+		return future
+	}
+	s.wg.Add(1)
+	s.mu.Unlock()
+
+	Spawn(func() {
+		defer s.wg.Done()
+		future.dbg.own()
+		result := fn(s.ctx)
+		if _, err := result.Unwrap(); err != nil {
+			s.fail(err)
+		}
+		future.complete(result)
+	})
+	return future
+}
+
+// Go runs a side-effect-only child; see SpawnScoped
+func (s *Scope) Go(fn func(context.Context) error) *Future[Unit] {
+	return SpawnScoped(s, func(ctx context.Context) Result[Unit] {
+		return UnitFrom(fn(ctx))
+	})
+}
+
+// Wait blocks until every child has finished, then closes the scope
+// Under CancelOnFailure it returns the first failure; under CollectFailures every failure as Errors;
+// nil when all children succeeded
+func (s *Scope) Wait() error {
+	s.wg.Wait()
+
+	s.mu.Lock()
+	s.closed = true
+	errs := s.errs
+	s.mu.Unlock()
+	s.cancel(ErrScopeClosed)
+
+	switch {
+	case len(errs) == 0:
+		return nil
+	case s.policy == CancelOnFailure:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// Close cancels every child and waits for them, returning what Wait would
+// Failures caused by the cancellation itself are not reported
+func (s *Scope) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cancel(ErrScopeClosed)
+	return s.Wait()
+}
+
+// fail records a child's failure, cancelling the siblings under CancelOnFailure
+func (s *Scope) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// children failing because the scope itself cancelled them are the cancellation echoing back;
+	// a cancelled parent context is still reported
+	ownCancel := s.failFast || errors.Is(context.Cause(s.ctx), ErrScopeClosed)
+	if ownCancel && (errors.Is(err, context.Canceled) || errors.Is(err, ErrScopeClosed)) {
+		return
+	}
+	s.errs = append(s.errs, err)
+	if s.policy == CancelOnFailure && !s.failFast {
+		s.failFast = true
+		s.cancel(err)
+	}
+}
@@ -0,0 +1,117 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestScopeWaitsForChildren(t *testing.T) {
+	checkNoLeaks(t, func() {
+		scope := NewScope(context.Background())
+		a := SpawnScoped(scope, func(ctx context.Context) Result[int] { return Ok(1) })
+		b := SpawnScoped(scope, func(ctx context.Context) Result[string] { return Ok("b") })
+
+		if err := scope.Wait(); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !a.IsDone() || !b.IsDone() {
+			t.Error("Every child should be done after Wait")
+		}
+	})
+}
+
+func TestScopeCancelOnFailure(t *testing.T) {
+	checkNoLeaks(t, func() {
+		scope := NewScope(context.Background())
+		boom := errors.New("boom")
+
+		sibling := scope.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		scope.Go(func(ctx context.Context) error { return boom })
+
+		if err := scope.Wait(); err != boom {
+			t.Errorf("Expected boom, got %v", err)
+		}
+		if _, err := sibling.Await().Unwrap(); !errors.Is(err, context.Canceled) {
+			t.Errorf("Sibling should be cancelled, got %v", err)
+		}
+		if !errors.Is(context.Cause(scope.Context()), boom) {
+			t.Errorf("Scope context cause should be boom, got %v", context.Cause(scope.Context()))
+		}
+	})
+}
+
+func TestScopeCollectFailures(t *testing.T) {
+	scope := NewScopeWithPolicy(context.Background(), CollectFailures)
+	errA, errB := errors.New("a"), errors.New("b")
+
+	scope.Go(func(ctx context.Context) error { return errA })
+	scope.Go(func(ctx context.Context) error { return errB })
+	survivor := scope.Go(func(ctx context.Context) error { return ctx.Err() })
+
+	err := scope.Wait()
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("Expected both failures, got %v", err)
+	}
+	if _, err := survivor.Await().Unwrap(); err != nil {
+		t.Errorf("Siblings should keep running under CollectFailures, got %v", err)
+	}
+}
+
+func TestScopeClose(t *testing.T) {
+	checkNoLeaks(t, func() {
+		scope := NewScope(context.Background())
+		started := make(chan struct{})
+		child := scope.Go(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		<-started
+
+		if err := scope.Close(); err != nil {
+			t.Errorf("Cancellation by Close should not be reported, got %v", err)
+		}
+		if !child.IsDone() {
+			t.Error("Close should wait for children")
+		}
+
+		late := scope.Go(func(ctx context.Context) error { return nil })
+		if _, err := late.Await().Unwrap(); !errors.Is(err, ErrScopeClosed) {
+			t.Errorf("Expected ErrScopeClosed, got %v", err)
+		}
+	})
+}
+
+func TestScopeParentCancellation(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	scope := NewScope(parent)
+	scope.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	cancel()
+
+	if err := scope.Wait(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Parent cancellation should be reported, got %v", err)
+	}
+}
+
+func TestScopeNestedSpawn(t *testing.T) {
+	scope := NewScope(context.Background())
+	var inner *Future[int]
+	scope.Go(func(ctx context.Context) error {
+		inner = SpawnScoped(scope, func(ctx context.Context) Result[int] { return Ok(2) })
+		return nil
+	})
+
+	if err := scope.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := inner.Poll(); !v.IsOk() {
+		t.Error("Grandchild should finish before Wait returns")
+	}
+}
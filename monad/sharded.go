@@ -0,0 +1,104 @@
+package monad
+
+import (
+	"context"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// ShardedReactive is a value written from many goroutines at once, such as a request counter
+// Writers update one of several independently locked shards, and readers see the shards merged,
+// so writers only contend with the few writers landing on the same shard
+// merge must be associative and commutative with zero as identity (e.g. addition from 0), since writes
+// land on arbitrary shards and shards are merged in no particular order
+type ShardedReactive[T any] struct {
+	zero   T
+	merge  func(T, T) T
+	shards []valueShard[T]
+}
+
+// valueShard is one independently locked part of a ShardedReactive, padded so shards do not share a cache line
+type valueShard[T any] struct {
+	mu      sync.Mutex
+	value   T
+	version uint64 // writes to the shard, so views can skip refreshes without sharing a hot counter
+	_       [64]byte
+}
+
+// NewShardedReactive creates a ShardedReactive with the given number of shards (GOMAXPROCS when not positive)
+func NewShardedReactive[T any](shards int, zero T, merge func(T, T) T) *ShardedReactive[T] {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	s := &ShardedReactive[T]{zero: zero, merge: merge, shards: make([]valueShard[T], shards)}
+	for i := range s.shards {
+		s.shards[i].value = zero
+	}
+	return s
+}
+
+// NewShardedCounter creates a striped counter: a ShardedReactive merging by addition
+func NewShardedCounter(shards int) *ShardedReactive[int64] {
+	return NewShardedReactive(shards, 0, func(a, b int64) int64 { return a + b })
+}
+
+// Add merges delta into a randomly chosen shard
+func (s *ShardedReactive[T]) Add(delta T) {
+	shard := &s.shards[rand.IntN(len(s.shards))]
+	shard.mu.Lock()
+	shard.value = s.merge(shard.value, delta)
+	shard.version++
+	shard.mu.Unlock()
+}
+
+// Get merges every shard into the current value
+// Writes racing with Get may or may not be included, but each write is seen whole
+func (s *ShardedReactive[T]) Get() T {
+	value, _ := s.merged()
+	return value
+}
+
+// Reset sets every shard back to zero and returns the merged value they held
+func (s *ShardedReactive[T]) Reset() T {
+	value := s.zero
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		value = s.merge(value, shard.value)
+		shard.value = s.zero
+		shard.version++
+		shard.mu.Unlock()
+	}
+	return value
+}
+
+// merged returns the merged value with the total number of writes it includes
+func (s *ShardedReactive[T]) merged() (T, uint64) {
+	value := s.zero
+	var version uint64
+	for i := range s.shards {
+		shard := &s.shards[i]
+		shard.mu.Lock()
+		value = s.merge(value, shard.value)
+		version += shard.version
+		shard.mu.Unlock()
+	}
+	return value, version
+}
+
+// Reactive returns an eventually consistent view of the merged value, refreshed on the package Clock
+// every d while writes happened since the last refresh, until ctx is done
+// Subscribers of the view are notified at most once per d however hot the value is
+func (s *ShardedReactive[T]) Reactive(ctx context.Context, d time.Duration) *Reactive[T] {
+	value, seen := s.merged()
+	view := NewReactive(value)
+	Ticker(ctx, d).Subscribe(ctx, func(time.Time) {
+		if value, version := s.merged(); version != seen {
+			seen = version
+			view.Set(value)
+		}
+	})
+	return view
+}
@@ -0,0 +1,106 @@
+package monad
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardedCounter(t *testing.T) {
+	counter := NewShardedCounter(8)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 1000 {
+				counter.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := counter.Get(); got != 16000 {
+		t.Errorf("Expected 16000, got %d", got)
+	}
+	if got := counter.Reset(); got != 16000 {
+		t.Errorf("Expected Reset to return 16000, got %d", got)
+	}
+	if got := counter.Get(); got != 0 {
+		t.Errorf("Expected 0 after Reset, got %d", got)
+	}
+}
+
+func TestShardedReactiveMerge(t *testing.T) {
+	peak := NewShardedReactive(4, 0, func(a, b int) int { return max(a, b) })
+	for _, v := range []int{3, 9, 4, 7} {
+		peak.Add(v)
+	}
+	if got := peak.Get(); got != 9 {
+		t.Errorf("Expected the maximum 9, got %d", got)
+	}
+
+	if got := NewShardedCounter(0); len(got.shards) == 0 {
+		t.Error("Expected a default shard count")
+	}
+}
+
+func TestShardedReactiveView(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	counter := NewShardedCounter(4)
+	view := counter.Reactive(ctx, time.Second)
+
+	updates := make(chan int64, 4)
+	view.Subscribe(func(_, v int64) { updates <- v })
+
+	for range 5 {
+		counter.Add(2)
+	}
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Second)
+	if got := <-updates; got != 10 {
+		t.Errorf("Expected the merged value 10, got %d", got)
+	}
+
+	// no writes since the last refresh, so the next tick publishes nothing
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Second)
+	counter.Add(1)
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Second)
+	if got := <-updates; got != 11 {
+		t.Errorf("Expected a single refresh to 11, got %d", got)
+	}
+}
+
+func BenchmarkShardedReactive(b *testing.B) {
+	defer SetExecutor(&countingExecutor{})()
+
+	for _, procs := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("reactive/parallelism=%d", procs), func(b *testing.B) {
+			counter := NewReactive(int64(0))
+			b.SetParallelism(procs)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					counter.Update(func(v int64) int64 { return v + 1 })
+				}
+			})
+		})
+		b.Run(fmt.Sprintf("sharded/parallelism=%d", procs), func(b *testing.B) {
+			counter := NewShardedCounter(0)
+			b.SetParallelism(procs)
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					counter.Add(1)
+				}
+			})
+		})
+	}
+}
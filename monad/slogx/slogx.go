@@ -0,0 +1,133 @@
+// Package slogx logs the monad runtime types through log/slog: Task and pipeline stage middleware,
+// a sampled Reactive change logger and attribute helpers for Results and Options.
+// Every record carries the same structured fields — operation, stage, duration and err — so one
+// query finds a failing operation whichever layer logged it.
+package slogx
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// Config sets the levels and sampling of the middlewares; the zero Config is ready to use
+type Config struct {
+	// Level is the level of successful operations and changes; nil means slog.LevelInfo
+	Level slog.Leveler
+	// ErrorLevel is the level of failed operations; nil means slog.LevelError
+	ErrorLevel slog.Leveler
+	// SampleEvery logs only every Nth reactive change; 0 or 1 logs every change
+	SampleEvery int
+	// SampleInterval logs at most one reactive change per interval; 0 disables it
+	SampleInterval time.Duration
+}
+
+// level returns the level of an outcome
+func (c Config) level(err error) slog.Level {
+	if err != nil {
+		if c.ErrorLevel == nil {
+			return slog.LevelError
+		}
+		return c.ErrorLevel.Level()
+	}
+	if c.Level == nil {
+		return slog.LevelInfo
+	}
+	return c.Level.Level()
+}
+
+// outcome returns the attributes shared by every record: operation, duration and err when failed
+func outcome(operation string, duration time.Duration, err error, attrs ...slog.Attr) []slog.Attr {
+	attrs = append([]slog.Attr{slog.String("operation", operation)}, attrs...)
+	attrs = append(attrs, slog.Duration("duration", duration))
+	if err != nil {
+		attrs = append(attrs, slog.Any("err", err))
+	}
+	return attrs
+}
+
+// TaskLogging returns a Task decorator logging every run of the task with its duration and error
+// The record is logged with the run's context, so handlers can pick up request-scoped values
+func TaskLogging[T any](logger *slog.Logger, operation string, cfg Config) func(monad.Task[T]) monad.Task[T] {
+	return func(task monad.Task[T]) monad.Task[T] {
+		return func(ctx context.Context) monad.Result[T] {
+			start := time.Now()
+			result := task(ctx)
+			_, err := result.Unwrap()
+			logger.LogAttrs(ctx, cfg.level(err), "task", outcome(operation, time.Since(start), err)...)
+			return result
+		}
+	}
+}
+
+// PipelineLogging wraps a pipeline stage, e.g. the function passed to monad.AndThenP,
+// logging each call with the operation, the stage name, its duration and error
+func PipelineLogging[T, U any](logger *slog.Logger, operation, stage string, cfg Config, f func(T) monad.Result[U]) func(T) monad.Result[U] {
+	return func(v T) monad.Result[U] {
+		start := time.Now()
+		result := f(v)
+		_, err := result.Unwrap()
+		logger.LogAttrs(context.Background(), cfg.level(err), "pipeline stage", outcome(operation, time.Since(start), err, slog.String("stage", stage))...)
+		return result
+	}
+}
+
+// ReactiveChangeLogger logs the changes of r as operation with their old and new values until the
+// returned function is called
+// Hot values are sampled with Config.SampleEvery and Config.SampleInterval; each record counts the
+// changes skipped since the previous one in a "skipped" field
+func ReactiveChangeLogger[T any](logger *slog.Logger, operation string, r *monad.Reactive[T], cfg Config) (stop func()) {
+	var mu sync.Mutex
+	var changes, skipped int
+	var last time.Time
+
+	id := r.Subscribe(func(old, new T) {
+		mu.Lock()
+		changes++
+		now := time.Now()
+		sampled := (cfg.SampleEvery <= 1 || changes%cfg.SampleEvery == 0) &&
+			(cfg.SampleInterval <= 0 || last.IsZero() || now.Sub(last) >= cfg.SampleInterval)
+		if !sampled {
+			skipped++
+			mu.Unlock()
+			return
+		}
+		dropped := skipped
+		skipped, last = 0, now
+		mu.Unlock()
+
+		logger.LogAttrs(context.Background(), cfg.level(nil), "reactive change",
+			slog.String("operation", operation),
+			slog.Any("old", old),
+			slog.Any("new", new),
+			slog.Int("skipped", dropped),
+		)
+	})
+	return func() { r.Unsubscribe(id) }
+}
+
+// ResultAttr returns an attribute grouping the state of r: ok with value, or err
+func ResultAttr[T any](key string, r monad.Result[T]) slog.Attr {
+	v, err := r.Unwrap()
+	if err != nil {
+		return slog.Group(key, slog.Bool("ok", false), slog.Any("err", err))
+	}
+	return slog.Group(key, slog.Bool("ok", true), slog.Any("value", v))
+}
+
+// OptionAttr returns an attribute holding the value of o; it is an empty group, which handlers omit, when o has none
+func OptionAttr[T any](key string, o monad.Option[T]) slog.Attr {
+	if o.IsSome() {
+		return slog.Any(key, o.Unwrap())
+	}
+	return slog.Group(key)
+}
+
+// LogResult logs r with msg at the level of its outcome, adding the Result under "result"
+func LogResult[T any](ctx context.Context, logger *slog.Logger, msg string, r monad.Result[T], cfg Config, attrs ...slog.Attr) {
+	_, err := r.Unwrap()
+	logger.LogAttrs(ctx, cfg.level(err), msg, append(attrs, ResultAttr("result", r))...)
+}
@@ -0,0 +1,115 @@
+package slogx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// capture returns a logger writing JSON records and a function decoding the records written so far
+func capture() (*slog.Logger, func() []map[string]any) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return logger, func() []map[string]any {
+		var records []map[string]any
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var record map[string]any
+			json.Unmarshal([]byte(line), &record)
+			records = append(records, record)
+		}
+		return records
+	}
+}
+
+func TestTaskLogging(t *testing.T) {
+	logger, records := capture()
+	logged := TaskLogging[int](logger, "load-user", Config{Level: slog.LevelDebug})
+
+	logged(monad.NewTaskFromValue(1))(context.Background())
+	logged(monad.NewTaskFromError[int](errors.New("not found")))(context.Background())
+
+	got := records()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 records, got %v", got)
+	}
+	if got[0]["level"] != "DEBUG" || got[0]["operation"] != "load-user" || got[0]["duration"] == nil || got[0]["err"] != nil {
+		t.Errorf("Unexpected success record %v", got[0])
+	}
+	if got[1]["level"] != "ERROR" || got[1]["err"] != "not found" {
+		t.Errorf("Unexpected failure record %v", got[1])
+	}
+}
+
+func TestPipelineLogging(t *testing.T) {
+	logger, records := capture()
+	parse := PipelineLogging(logger, "import", "parse", Config{ErrorLevel: slog.LevelWarn}, func(s string) monad.Result[int] {
+		if s == "" {
+			return monad.Err[int](errors.New("empty"))
+		}
+		return monad.Ok(len(s))
+	})
+
+	monad.AndThenP(monad.OkP("abc"), parse)
+	monad.AndThenP(monad.OkP(""), parse)
+
+	got := records()
+	if len(got) != 2 || got[0]["stage"] != "parse" || got[0]["level"] != "INFO" {
+		t.Fatalf("Unexpected records %v", got)
+	}
+	if got[1]["level"] != "WARN" || got[1]["err"] != "empty" {
+		t.Errorf("Unexpected failure record %v", got[1])
+	}
+}
+
+func TestReactiveChangeLoggerSampling(t *testing.T) {
+	s := monad.NewTestScheduler()
+	defer s.Install()()
+
+	logger, records := capture()
+	counter := monad.NewReactive(0)
+	stop := ReactiveChangeLogger(logger, "counter", counter, Config{SampleEvery: 3})
+
+	for i := 1; i <= 7; i++ {
+		counter.Set(i)
+		s.RunUntilIdle()
+	}
+	stop()
+	counter.Set(8)
+	s.RunUntilIdle()
+
+	got := records()
+	if len(got) != 2 {
+		t.Fatalf("Expected every third change, got %v", got)
+	}
+	if got[0]["new"] != 3.0 || got[0]["skipped"] != 2.0 || got[1]["new"] != 6.0 || got[1]["operation"] != "counter" {
+		t.Errorf("Unexpected records %v", got)
+	}
+}
+
+func TestResultAttrs(t *testing.T) {
+	logger, records := capture()
+	LogResult(context.Background(), logger, "saved", monad.Ok(7), Config{}, OptionAttr("owner", monad.Some("ann")), OptionAttr("group", monad.None[string]()))
+	LogResult(context.Background(), logger, "saved", monad.Err[int](errors.New("disk full")), Config{})
+
+	got := records()
+	ok := got[0]["result"].(map[string]any)
+	if ok["ok"] != true || ok["value"] != 7.0 || got[0]["owner"] != "ann" {
+		t.Errorf("Unexpected success record %v", got[0])
+	}
+	if _, present := got[0]["group"]; present {
+		t.Error("A None option should be omitted")
+	}
+	failed := got[1]["result"].(map[string]any)
+	if got[1]["level"] != "ERROR" || failed["ok"] != false || failed["err"] != "disk full" {
+		t.Errorf("Unexpected failure record %v", got[1])
+	}
+}
@@ -0,0 +1,118 @@
+package monad
+
+// State is a state transition: run on a state of type S, it returns a value and the next state
+// A failed transition returns the state it was given, so a chain that fails part way leaves the state as it
+// was and the transitions of its earlier steps are discarded
+type State[S, T any] func(S) (Result[T], S)
+
+// StateOf lifts a pure transition returning a value and the next state into a State
+func StateOf[S, T any](fn func(S) (T, S)) State[S, T] {
+	return func(s S) (Result[T], S) {
+		v, next := fn(s)
+		return Ok(v), next
+	}
+}
+
+// PureState returns a State yielding v and leaving the state unchanged
+func PureState[S, T any](v T) State[S, T] {
+	return func(s S) (Result[T], S) {
+		return Ok(v), s
+	}
+}
+
+// FailState returns a State failing with err
+func FailState[S, T any](err error) State[S, T] {
+	return func(s S) (Result[T], S) {
+		return Err[T](err), s
+	}
+}
+
+// GetState returns a State yielding the current state
+func GetState[S any]() State[S, S] {
+	return func(s S) (Result[S], S) {
+		return Ok(s), s
+	}
+}
+
+// GetsState returns a State yielding a value derived from the current state, e.g. a field of it
+func GetsState[S, T any](fn func(S) T) State[S, T] {
+	return func(s S) (Result[T], S) {
+		return Ok(fn(s)), s
+	}
+}
+
+// PutState returns a State replacing the state with next
+func PutState[S any](next S) State[S, Unit] {
+	return func(S) (Result[Unit], S) {
+		return OkUnit(), next
+	}
+}
+
+// ModifyState returns a State replacing the state with fn applied to it
+func ModifyState[S any](fn func(S) S) State[S, Unit] {
+	return func(s S) (Result[Unit], S) {
+		return OkUnit(), fn(s)
+	}
+}
+
+// Run runs the transition on s, returning its result and the next state
+func (st State[S, T]) Run(s S) (Result[T], S) {
+	return st(s)
+}
+
+// Eval runs the transition on s and returns its result, dropping the next state
+func (st State[S, T]) Eval(s S) Result[T] {
+	r, _ := st(s)
+	return r
+}
+
+// Exec runs the transition on s and returns the next state, which is s when it fails
+func (st State[S, T]) Exec(s S) S {
+	_, next := st(s)
+	return next
+}
+
+// MapState transforms the value of a State, keeping its transition
+func MapState[S, T, U any](st State[S, T], fn func(T) U) State[S, U] {
+	return func(s S) (Result[U], S) {
+		r, next := st(s)
+		return Map(r, fn), next
+	}
+}
+
+// AndThenState runs st, then the State fn returns for its value on the next state
+// When either fails, the state st was given is returned unchanged
+func AndThenState[S, T, U any](st State[S, T], fn func(T) State[S, U]) State[S, U] {
+	return func(s S) (Result[U], S) {
+		r, next := st(s)
+		v, err := r.Unwrap()
+		if err != nil {
+			return CarryWarnings(Err[U](err), r), s
+		}
+		r2, final := fn(v)(next)
+		if !r2.IsOk() {
+			final = s
+		}
+		return CarryWarnings(r2, r), final
+	}
+}
+
+// RunStateOn runs st on the value of r atomically, holding r's lock so no Set, Update or other run interleaves
+// On success r takes the next state and its subscribers get a single notification, however many steps changed
+// the state; on failure r is left unchanged and nobody is notified
+// st runs under the lock, so it must not call methods of r
+func RunStateOn[S, T any](r *Reactive[S], st State[S, T]) Result[T] {
+	r.mutex.Lock()
+	oldValue := r.value
+	result, newValue := st(oldValue)
+	if !result.IsOk() {
+		r.mutex.Unlock()
+		return result
+	}
+	r.value = newValue
+	subscribers := r.snapshot()
+	r.mutex.Unlock()
+
+	r.notify(subscribers, oldValue, newValue)
+	return result
+}
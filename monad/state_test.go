@@ -0,0 +1,111 @@
+package monad
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+// account is the state of the transitions below
+type account struct {
+	balance int
+	log     []string
+}
+
+func withdraw(amount int) State[account, int] {
+	return AndThenState(GetsState(func(a account) int { return a.balance }), func(balance int) State[account, int] {
+		if balance < amount {
+			return FailState[account, int](errors.New("insufficient funds"))
+		}
+		return MapState(ModifyState(func(a account) account {
+			a.balance -= amount
+			a.log = append(a.log[:len(a.log):len(a.log)], "withdraw")
+			return a
+		}), func(Unit) int { return balance - amount })
+	})
+}
+
+func TestStateChains(t *testing.T) {
+	twice := AndThenState(withdraw(30), func(int) State[account, int] { return withdraw(50) })
+
+	r, next := twice.Run(account{balance: 100})
+	if v, err := r.Unwrap(); err != nil || v != 20 || next.balance != 20 || len(next.log) != 2 {
+		t.Errorf("Expected both withdrawals, got %d, %v, %+v", v, err, next)
+	}
+
+	r, next = twice.Run(account{balance: 60})
+	if r.IsOk() || next.balance != 60 || len(next.log) != 0 {
+		t.Errorf("A failed chain should leave the state as it was, got %+v", next)
+	}
+
+	if got := StateOf(func(n int) (string, int) { return "inc", n + 1 }).Exec(1); got != 2 {
+		t.Errorf("Exec should return the next state, got %d", got)
+	}
+	if v, _ := PureState[int]("x").Eval(5).Unwrap(); v != "x" {
+		t.Errorf("Eval should return the value, got %q", v)
+	}
+	if _, s := PutState(9).Run(1); s != 9 {
+		t.Errorf("PutState should replace the state, got %d", s)
+	}
+	if v, _ := GetState[int]().Eval(4).Unwrap(); v != 4 {
+		t.Errorf("GetState should yield the state, got %d", v)
+	}
+}
+
+func TestStateCarriesWarnings(t *testing.T) {
+	warned := func(s int) (Result[int], int) { return WithWarning(Ok(s), errors.New("low")), s + 1 }
+	chain := AndThenState(State[int, int](warned), func(v int) State[int, int] { return PureState[int](v * 10) })
+	if r := chain.Eval(1); len(r.Warnings()) != 1 {
+		t.Errorf("Expected the warning of the first step, got %v", r.Warnings())
+	}
+}
+
+func TestRunStateOn(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+	r := NewReactive(account{balance: 100})
+
+	var mu sync.Mutex
+	var notifications []int
+	r.Subscribe(func(old, new account) {
+		mu.Lock()
+		defer mu.Unlock()
+		notifications = append(notifications, new.balance)
+	})
+
+	twice := AndThenState(withdraw(10), func(int) State[account, int] { return withdraw(20) })
+	if v, err := RunStateOn(r, twice).Unwrap(); err != nil || v != 70 {
+		t.Fatalf("Expected 70, got %d, %v", v, err)
+	}
+	if RunStateOn(r, withdraw(500)).IsOk() {
+		t.Error("Expected the overdraft to fail")
+	}
+	s.RunUntilIdle()
+
+	if got := r.Get(); got.balance != 70 || len(got.log) != 2 {
+		t.Errorf("Expected the successful run only, got %+v", got)
+	}
+	if len(notifications) != 1 || notifications[0] != 70 {
+		t.Errorf("Expected a single notification for the successful run, got %v", notifications)
+	}
+}
+
+func TestRunStateOnIsAtomic(t *testing.T) {
+	r := NewReactive(0)
+	inc := AndThenState(GetState[int](), func(n int) State[int, Unit] { return PutState(n + 1) })
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				RunStateOn(r, inc)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := r.Get(); got != 800 {
+		t.Errorf("Read-then-write transitions should not interleave, got %d", got)
+	}
+}
@@ -0,0 +1,66 @@
+package monad
+
+import "sync"
+
+// Store holds state of type S that only changes by dispatching actions of type A through a reducer, Redux style
+// Reductions are serialized, and the state is a Reactive so changes reach subscribers and selectors
+type Store[S, A any] struct {
+	state    *Reactive[S]
+	reduce   func(S, A) S
+	dispatch func(A)
+}
+
+// Middleware wraps the dispatch of a Store: it gets each action before next, and may log, transform, drop or
+// delay it, or dispatch other actions through the store
+type Middleware[S, A any] func(store *Store[S, A], next func(A)) func(A)
+
+// NewStore creates a Store starting at initial and reducing actions with reduce
+// The first middleware sees an action first; the last one calls the reducer
+func NewStore[S, A any](initial S, reduce func(S, A) S, middleware ...Middleware[S, A]) *Store[S, A] {
+	s := &Store[S, A]{state: NewReactive(initial), reduce: reduce}
+	dispatch := s.apply
+	for i := len(middleware) - 1; i >= 0; i-- {
+		dispatch = middleware[i](s, dispatch)
+	}
+	s.dispatch = dispatch
+	return s
+}
+
+// Dispatch passes action through the middleware to the reducer
+// The reducer runs under the state's lock, so it must not dispatch itself; middleware may
+func (s *Store[S, A]) Dispatch(action A) {
+	s.dispatch(action)
+}
+
+// State returns the current state
+func (s *Store[S, A]) State() S {
+	return s.state.Get()
+}
+
+// Reactive returns the state as a Reactive, e.g. to Subscribe to every change
+func (s *Store[S, A]) Reactive() *Reactive[S] {
+	return s.state
+}
+
+// apply reduces the state with action and notifies the state's subscribers
+func (s *Store[S, A]) apply(action A) {
+	s.state.Update(func(state S) S {
+		return s.reduce(state, action)
+	})
+}
+
+// Select returns a Reactive holding selector applied to the store's state, e.g. a total or a filtered view
+// It is recomputed on every state change but only set, and so only notifies, when the selected value changes
+func Select[S, A any, T comparable](s *Store[S, A], selector func(S) T) *Reactive[T] {
+	result := NewReactive(selector(s.State()))
+	var mu sync.Mutex
+	s.state.Subscribe(func(_, _ S) {
+		// read the latest state rather than the notified one, since notifications may arrive out of order
+		mu.Lock()
+		defer mu.Unlock()
+		if next := selector(s.State()); next != result.Get() {
+			result.Set(next)
+		}
+	})
+	return result
+}
@@ -0,0 +1,87 @@
+package monad
+
+import (
+	"slices"
+	"testing"
+)
+
+type counterAction struct {
+	delta int
+}
+
+func reduceCounter(state int, a counterAction) int {
+	return state + a.delta
+}
+
+func TestStoreDispatch(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+	store := NewStore(0, reduceCounter)
+
+	var changes [][2]int
+	store.Reactive().Subscribe(func(old, new int) {
+		changes = append(changes, [2]int{old, new})
+	})
+	store.Dispatch(counterAction{delta: 2})
+	store.Dispatch(counterAction{delta: 3})
+	s.RunUntilIdle()
+
+	if store.State() != 5 {
+		t.Errorf("Expected state 5, got %d", store.State())
+	}
+	if !slices.Equal(changes, [][2]int{{0, 2}, {2, 5}}) {
+		t.Errorf("Unexpected changes %v", changes)
+	}
+}
+
+func TestStoreMiddlewareOrder(t *testing.T) {
+	var seen []string
+	trace := func(name string) Middleware[int, counterAction] {
+		return func(_ *Store[int, counterAction], next func(counterAction)) func(counterAction) {
+			return func(a counterAction) {
+				seen = append(seen, name)
+				next(a)
+			}
+		}
+	}
+	dropNegative := func(_ *Store[int, counterAction], next func(counterAction)) func(counterAction) {
+		return func(a counterAction) {
+			if a.delta >= 0 {
+				next(a)
+			}
+		}
+	}
+	store := NewStore(0, reduceCounter, trace("first"), trace("second"), dropNegative)
+
+	store.Dispatch(counterAction{delta: 4})
+	store.Dispatch(counterAction{delta: -10})
+	if store.State() != 4 {
+		t.Errorf("Expected the negative action to be dropped, got state %d", store.State())
+	}
+	if !slices.Equal(seen, []string{"first", "second", "first", "second"}) {
+		t.Errorf("Middleware should run in order, got %v", seen)
+	}
+}
+
+func TestSelectNotifiesOnChange(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+	store := NewStore(1, reduceCounter)
+	parity := Select(store, func(n int) bool { return n%2 == 0 })
+
+	var notified []bool
+	parity.Subscribe(func(_, new bool) {
+		notified = append(notified, new)
+	})
+	for _, d := range []int{2, 1, 2} {
+		store.Dispatch(counterAction{delta: d})
+		s.RunUntilIdle()
+	}
+
+	if !parity.Get() {
+		t.Error("Expected 6 to select even")
+	}
+	if !slices.Equal(notified, []bool{true}) {
+		t.Errorf("Only the change of parity should notify, got %v", notified)
+	}
+}
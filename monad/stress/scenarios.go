@@ -0,0 +1,217 @@
+package stress
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// reactiveSubscribers increments a Reactive from every worker while cfg.Subscribers subscribers count the
+// notifications and other workers subscribe and unsubscribe in a loop
+// Invariants: the final value is the number of updates, every notification of a subscriber present
+// throughout carries new == old+1, and each such subscriber is notified exactly once per update
+func reactiveSubscribers(cfg Config) error {
+	var v violations
+	r := monad.NewReactive(0)
+	counts := make([]atomic.Int64, cfg.Subscribers)
+	ids := make([]int, cfg.Subscribers)
+	for i := range counts {
+		ids[i] = r.Subscribe(func(old, new int) {
+			if new != old+1 {
+				v.addf("subscriber %d: notified of %d -> %d", i, old, new)
+			}
+			counts[i].Add(1)
+		})
+	}
+	defer func() {
+		for _, id := range ids {
+			r.Unsubscribe(id)
+		}
+	}()
+
+	perWorker := max(cfg.Iterations/cfg.Workers, 1)
+	updates := perWorker * cfg.Workers
+	churners := max(cfg.Workers/4, 1)
+	parallel(cfg.Workers+churners, func(w int) {
+		if w >= cfg.Workers {
+			for range perWorker {
+				r.Unsubscribe(r.Subscribe(func(int, int) {}))
+			}
+			return
+		}
+		for range perWorker {
+			r.Update(func(n int) int { return n + 1 })
+		}
+	})
+
+	if got := r.Get(); got != updates {
+		v.addf("final value %d after %d updates", got, updates)
+	}
+	delivered := waitUntil(cfg.Settle, func() bool {
+		for i := range counts {
+			if counts[i].Load() < int64(updates) {
+				return false
+			}
+		}
+		return true
+	})
+	for i := range counts {
+		if n := counts[i].Load(); n != int64(updates) || !delivered {
+			v.addf("subscriber %d: %d notifications for %d updates", i, n, updates)
+		}
+	}
+	return v.err()
+}
+
+// futureCancelRace completes each of cfg.Iterations Futures per worker while Cancel races the completion
+// and a waiter and a MapFuture continuation observe it
+// Invariants: exactly one of Complete and Cancel takes effect, and the waiter, the Future and the
+// continuation all agree on the outcome
+func futureCancelRace(cfg Config) error {
+	var v violations
+	parallel(cfg.Workers, func(w int) {
+		for i := range cfg.Iterations {
+			f := monad.NewFuture[int]()
+			mapped := monad.MapFuture(f, func(n int) int { return n + 1 })
+			var cancelled atomic.Bool
+			var waited monad.Result[int]
+			var wg sync.WaitGroup
+			wg.Add(3)
+			go func() { defer wg.Done(); f.Complete(i) }()
+			go func() { defer wg.Done(); cancelled.Store(f.Cancel()) }()
+			go func() { defer wg.Done(); waited = f.AwaitWithTimeout(cfg.Settle) }()
+			wg.Wait()
+
+			value, err := f.Await().Unwrap()
+			wValue, wErr := waited.Unwrap()
+			mValue, mErr := mapped.AwaitWithTimeout(cfg.Settle).Unwrap()
+			switch {
+			case cancelled.Load():
+				if !f.IsCancelled() || !errors.Is(err, context.Canceled) {
+					v.addf("worker %d future %d: Cancel won but the result is %d, %v", w, i, value, err)
+				}
+				if !errors.Is(mErr, context.Canceled) {
+					v.addf("worker %d future %d: the continuation of a cancelled Future got %d, %v", w, i, mValue, mErr)
+				}
+			case f.IsCancelled() || err != nil || value != i:
+				v.addf("worker %d future %d: Complete won but the result is %d, %v", w, i, value, err)
+			case mErr != nil || mValue != i+1:
+				v.addf("worker %d future %d: the continuation got %d, %v", w, i, mValue, mErr)
+			}
+			if wValue != value || !errors.Is(wErr, err) {
+				v.addf("worker %d future %d: the waiter saw %d, %v and the Future %d, %v", w, i, wValue, wErr, value, err)
+			}
+		}
+	})
+	return v.err()
+}
+
+// storeDispatch dispatches cfg.Iterations increments per worker to a Store with a counting middleware and
+// a selector over the state's parity
+// Invariants: the final state is the number of dispatches, every dispatch passes the middleware once, and
+// the selector settles on the parity of the final state
+func storeDispatch(cfg Config) error {
+	var v violations
+	var seen atomic.Int64
+	count := func(_ *monad.Store[int, int], next func(int)) func(int) {
+		return func(action int) {
+			seen.Add(1)
+			next(action)
+		}
+	}
+	store := monad.NewStore(0, func(s, a int) int { return s + a }, count)
+	even := monad.Select(store, func(s int) bool { return s%2 == 0 })
+
+	parallel(cfg.Workers, func(int) {
+		for range cfg.Iterations {
+			store.Dispatch(1)
+		}
+	})
+
+	total := cfg.Workers * cfg.Iterations
+	if got := store.State(); got != total {
+		v.addf("final state %d after %d dispatches", got, total)
+	}
+	if got := seen.Load(); got != int64(total) {
+		v.addf("the middleware saw %d of %d dispatches", got, total)
+	}
+	if !waitUntil(cfg.Settle, func() bool { return even.Get() == (total%2 == 0) }) {
+		v.addf("the selector settled on even=%v for state %d", even.Get(), total)
+	}
+	return v.err()
+}
+
+// countingExecutor runs each function on a new goroutine, or inline, counting the functions it ran
+type countingExecutor struct {
+	inline bool
+	ran    *atomic.Int64
+}
+
+func (e countingExecutor) Go(fn func()) {
+	e.ran.Add(1)
+	if e.inline {
+		fn()
+		return
+	}
+	go fn()
+}
+
+// executorChurn spawns functions and runs RunAsync Futures from every worker while another goroutine keeps
+// replacing the package Executor with a goroutine executor, an inline one and the default
+// Invariants: every spawned function runs exactly once and every Future completes with its value
+// The previous Executor is restored afterwards
+func executorChurn(cfg Config) error {
+	var v violations
+	defer monad.SetExecutor(nil)()
+
+	var dispatched atomic.Int64
+	executors := []monad.Executor{
+		countingExecutor{ran: &dispatched},
+		countingExecutor{inline: true, ran: &dispatched},
+		nil,
+	}
+	stop := make(chan struct{})
+	churned := make(chan struct{})
+	go func() {
+		defer close(churned)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				monad.SetExecutor(executors[i%len(executors)])
+			}
+		}
+	}()
+
+	runs := make([]atomic.Int32, cfg.Workers*cfg.Iterations)
+	parallel(cfg.Workers, func(w int) {
+		for i := range cfg.Iterations {
+			slot := &runs[w*cfg.Iterations+i]
+			monad.Spawn(func() { slot.Add(1) })
+			if value, err := monad.RunAsync(func() monad.Result[int] { return monad.Ok(i) }).AwaitWithTimeout(cfg.Settle).Unwrap(); err != nil || value != i {
+				v.addf("worker %d: RunAsync(%d) completed with %d, %v", w, i, value, err)
+			}
+		}
+	})
+	close(stop)
+	<-churned
+
+	waitUntil(cfg.Settle, func() bool {
+		for i := range runs {
+			if runs[i].Load() == 0 {
+				return false
+			}
+		}
+		return true
+	})
+	for i := range runs {
+		if n := runs[i].Load(); n != 1 {
+			v.addf("worker %d: spawned function %d ran %d times", i/cfg.Iterations, i%cfg.Iterations, n)
+		}
+	}
+	return v.err()
+}
@@ -0,0 +1,141 @@
+// Package stress runs high-concurrency scenarios against the concurrency primitives of the monad package
+// and checks invariants that must hold however the goroutines interleave: thousands of Reactive
+// subscribers, Futures whose completion races Cancel, Stores dispatched from many goroutines, and Spawn
+// while the Executor is being replaced. The scenarios are too slow for go test ./..., so their tests only
+// build with the stress tag; run them under the race detector:
+//
+//	go test -race -tags=stress ./monad/stress -stress.workers=64 -stress.iterations=2000
+package stress
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/snowmerak/gofn/monad"
+)
+
+// Config sizes a scenario run
+type Config struct {
+	Workers     int           // goroutines hammering a primitive at once
+	Iterations  int           // operations per worker; the Reactive scenario spreads them over all workers instead
+	Subscribers int           // subscribers of the Reactive scenario
+	Settle      time.Duration // how long asynchronous work may take to finish before it counts as lost or leaked
+}
+
+// DefaultConfig returns a Config that finishes in seconds under the race detector
+func DefaultConfig() Config {
+	return Config{Workers: 16, Iterations: 1000, Subscribers: 1000, Settle: 10 * time.Second}
+}
+
+// Scenario is a named stress scenario; Run returns the invariant violations it observed, or nil
+type Scenario struct {
+	Name string
+	Run  func(cfg Config) error
+}
+
+// Scenarios returns every scenario of the package
+func Scenarios() []Scenario {
+	return []Scenario{
+		{Name: "ReactiveSubscribers", Run: reactiveSubscribers},
+		{Name: "FutureCancelRace", Run: futureCancelRace},
+		{Name: "StoreDispatch", Run: storeDispatch},
+		{Name: "ExecutorChurn", Run: executorChurn},
+	}
+}
+
+// Run runs s with cfg and then checks that every goroutine it started has exited within cfg.Settle
+// It returns the violations of both checks in a monad.Errors, or nil
+func Run(s Scenario, cfg Config) error {
+	before := runtime.NumGoroutine()
+	err := s.Run(cfg)
+	var errs monad.Errors
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if leaked := waitGoroutines(before, cfg.Settle); leaked != nil {
+		errs = append(errs, leaked)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", s.Name, errs)
+}
+
+// waitGoroutines waits until at most n goroutines are running, and otherwise reports those inside gofn
+func waitGoroutines(n int, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<20)
+			var leaked []string
+			for _, g := range strings.Split(string(buf[:runtime.Stack(buf, true)]), "\n\n") {
+				if strings.Contains(g, "gofn/monad.") && !strings.Contains(g, "stress.waitGoroutines") {
+					leaked = append(leaked, g)
+				}
+			}
+			return fmt.Errorf("goroutines leaked: %d before, %d after\n%s", n, runtime.NumGoroutine(), strings.Join(leaked, "\n\n"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return nil
+}
+
+// maxViolations bounds the violations a scenario reports; the rest are only counted
+const maxViolations = 10
+
+// violations collects invariant violations from concurrent goroutines
+type violations struct {
+	mu    sync.Mutex
+	errs  monad.Errors
+	count int
+}
+
+func (v *violations) addf(format string, args ...any) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.count++
+	if len(v.errs) < maxViolations {
+		v.errs = append(v.errs, fmt.Errorf(format, args...))
+	}
+}
+
+// err returns the collected violations, or nil when there were none
+func (v *violations) err() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.count == 0 {
+		return nil
+	}
+	if v.count > len(v.errs) {
+		return fmt.Errorf("%d violations, first %d: %w", v.count, len(v.errs), v.errs)
+	}
+	return v.errs
+}
+
+// waitUntil polls cond until it holds or timeout elapses, reporting whether it held
+func waitUntil(timeout time.Duration, cond func() bool) bool {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
+
+// parallel runs fn(worker) on n goroutines and waits for them all
+func parallel(n int, fn func(worker int)) {
+	var wg sync.WaitGroup
+	for w := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(w)
+		}()
+	}
+	wg.Wait()
+}
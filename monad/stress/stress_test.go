@@ -0,0 +1,44 @@
+//go:build stress
+
+package stress
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	workers     = flag.Int("stress.workers", DefaultConfig().Workers, "goroutines hammering a primitive at once")
+	iterations  = flag.Int("stress.iterations", DefaultConfig().Iterations, "operations per worker")
+	subscribers = flag.Int("stress.subscribers", DefaultConfig().Subscribers, "subscribers of the Reactive scenario")
+	settle      = flag.Duration("stress.settle", DefaultConfig().Settle, "how long asynchronous work may take to finish")
+)
+
+func TestStress(t *testing.T) {
+	cfg := Config{Workers: *workers, Iterations: *iterations, Subscribers: *subscribers, Settle: *settle}
+	for _, s := range Scenarios() {
+		t.Run(s.Name, func(t *testing.T) {
+			start := time.Now()
+			if err := Run(s, cfg); err != nil {
+				t.Error(err)
+			}
+			t.Logf("%+v in %v", cfg, time.Since(start))
+		})
+	}
+}
+
+func TestRunReportsViolations(t *testing.T) {
+	failing := Scenario{Name: "Failing", Run: func(Config) error {
+		var v violations
+		for i := range maxViolations + 5 {
+			v.addf("violation %d", i)
+		}
+		return v.err()
+	}}
+	err := Run(failing, Config{Settle: time.Second})
+	if err == nil || !strings.HasPrefix(err.Error(), "Failing: 15 violations") {
+		t.Errorf("Expected the violation count in the error, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package monad
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SWRMetrics is a snapshot of an SWR's counters
+type SWRMetrics struct {
+	Hits          int64 // calls served a fresh value
+	StaleHits     int64 // calls served a stale value while it was refreshed
+	Misses        int64 // calls that waited for a load, with no value or an expired one
+	Loads         int64 // task runs, refreshes included
+	Refreshes     int64 // task runs started in the background by a stale hit
+	LoadErrors    int64 // failed task runs callers waited for
+	RefreshErrors int64 // failed background refreshes; the stale value is kept
+}
+
+// SWR caches the result of a Task with stale-while-revalidate semantics, as used for configuration and catalog
+// lookups: a value younger than ttl is served as is, and for staleTTL after that it is still served immediately
+// while one refresh runs in the background through the package Executor
+// Once the value is older than ttl+staleTTL, or before the first success, callers wait for a load; concurrent
+// callers share one run of the task. Failed loads are not cached, and a failed refresh keeps the stale value
+// Age is measured by the package Clock
+type SWR[T any] struct {
+	task          Task[T]
+	ttl, staleTTL time.Duration
+
+	mu       sync.Mutex
+	value    T
+	loadedAt time.Time
+	loaded   bool
+	inflight *Future[T] // load or refresh in progress
+
+	hits, staleHits, misses, loads, refreshes, loadErrors, refreshErrors atomic.Int64
+}
+
+// StaleWhileRevalidate returns an SWR serving the result of task; see SWR for how ttl and staleTTL apply
+// A staleTTL of 0 makes it a plain TTL cache of the task's result
+func StaleWhileRevalidate[T any](task Task[T], ttl, staleTTL time.Duration) *SWR[T] {
+	return &SWR[T]{task: task, ttl: ttl, staleTTL: staleTTL}
+}
+
+// Get returns the cached value, refreshing it in the background when it is stale, or waits for a load
+// A background refresh runs with ctx's values but not its cancellation, so it outlives the call that started it
+func (c *SWR[T]) Get(ctx context.Context) Result[T] {
+	m := currentMetrics()
+	c.mu.Lock()
+	if c.loaded {
+		age := currentClock().Now().Sub(c.loadedAt)
+		if age < c.ttl {
+			value := c.value
+			c.mu.Unlock()
+			c.hits.Add(1)
+			m.cacheHits.Add(1)
+			return Ok(value)
+		}
+		if age < c.ttl+c.staleTTL {
+			value := c.value
+			if c.inflight == nil {
+				pending := NewFuture[T]()
+				c.inflight = pending
+				c.refreshes.Add(1)
+				m.cacheRefreshes.Add(1)
+				refreshCtx := context.WithoutCancel(ctx)
+				Spawn(func() { c.load(refreshCtx, pending, true) })
+			}
+			c.mu.Unlock()
+			c.staleHits.Add(1)
+			m.cacheHits.Add(1)
+			m.cacheStaleHits.Add(1)
+			return Ok(value)
+		}
+	}
+
+	c.misses.Add(1)
+	m.cacheMisses.Add(1)
+	if pending := c.inflight; pending != nil {
+		c.mu.Unlock()
+		return pending.AwaitWithContext(ctx)
+	}
+	pending := NewFuture[T]()
+	c.inflight = pending
+	c.mu.Unlock()
+	return c.load(ctx, pending, false)
+}
+
+// Task returns a Task running Get, so the cache composes with other Tasks, e.g. c.Task().Run(ctx) for a Future
+func (c *SWR[T]) Task() Task[T] {
+	return c.Get
+}
+
+// Invalidate drops the cached value, so the next Get waits for a load; a load in progress is not interrupted
+func (c *SWR[T]) Invalidate() {
+	c.mu.Lock()
+	var zero T
+	c.value, c.loaded = zero, false
+	c.mu.Unlock()
+}
+
+// Metrics returns a snapshot of the cache's counters
+func (c *SWR[T]) Metrics() SWRMetrics {
+	return SWRMetrics{
+		Hits:          c.hits.Load(),
+		StaleHits:     c.staleHits.Load(),
+		Misses:        c.misses.Load(),
+		Loads:         c.loads.Load(),
+		Refreshes:     c.refreshes.Load(),
+		LoadErrors:    c.loadErrors.Load(),
+		RefreshErrors: c.refreshErrors.Load(),
+	}
+}
+
+// load runs the task, caches a successful value and completes pending with the result
+func (c *SWR[T]) load(ctx context.Context, pending *Future[T], refresh bool) Result[T] {
+	m := currentMetrics()
+	c.loads.Add(1)
+	m.cacheLoads.Add(1)
+	start := currentClock().Now()
+	result := c.task(ctx)
+	m.cacheLoadSeconds.Observe(currentClock().Now().Sub(start).Seconds())
+
+	value, err := result.Unwrap()
+	c.mu.Lock()
+	if err == nil {
+		c.value, c.loadedAt, c.loaded = value, currentClock().Now(), true
+	}
+	c.inflight = nil
+	c.mu.Unlock()
+	switch {
+	case err != nil && refresh:
+		c.refreshErrors.Add(1)
+		m.cacheLoadErrors.Add(1)
+	case err != nil:
+		c.loadErrors.Add(1)
+		m.cacheLoadErrors.Add(1)
+	}
+	pending.complete(result)
+	return result
+}
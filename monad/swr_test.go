@@ -0,0 +1,140 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/snowmerak/gofn/monad/metrics"
+)
+
+// versions returns a Task yielding 1, 2, 3, ... on successive runs, failing while fail is set
+func versions(fail *atomic.Bool) Task[int] {
+	var n atomic.Int32
+	return func(context.Context) Result[int] {
+		if fail != nil && fail.Load() {
+			return Err[int](errors.New("down"))
+		}
+		return Ok(int(n.Add(1)))
+	}
+}
+
+func TestSWRFreshStaleExpired(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+	ctx := context.Background()
+	cache := StaleWhileRevalidate(versions(nil), time.Minute, time.Hour)
+
+	if v, _ := cache.Get(ctx).Unwrap(); v != 1 {
+		t.Fatalf("Expected the first load to wait, got %d", v)
+	}
+	s.Advance(30 * time.Second)
+	if v, _ := cache.Get(ctx).Unwrap(); v != 1 || s.Pending() != 0 {
+		t.Errorf("A fresh value should be served without a refresh, got %d", v)
+	}
+
+	s.Advance(time.Minute)
+	if v, _ := cache.Get(ctx).Unwrap(); v != 1 {
+		t.Errorf("A stale value should be served immediately, got %d", v)
+	}
+	cache.Get(ctx) // a refresh is already queued
+	if s.Pending() != 1 {
+		t.Fatalf("Expected one background refresh, got %d", s.Pending())
+	}
+	s.RunUntilIdle()
+	if v, _ := cache.Get(ctx).Unwrap(); v != 2 {
+		t.Errorf("Expected the refreshed value, got %d", v)
+	}
+
+	s.Advance(2 * time.Hour)
+	if v, _ := cache.Get(ctx).Unwrap(); v != 3 {
+		t.Errorf("An expired value should wait for a load, got %d", v)
+	}
+	if m := cache.Metrics(); m.Hits != 2 || m.StaleHits != 2 || m.Misses != 2 || m.Loads != 3 || m.Refreshes != 1 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+}
+
+func TestSWRFailedRefreshKeepsStale(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+	ctx := context.Background()
+	var fail atomic.Bool
+	cache := StaleWhileRevalidate(versions(&fail), time.Minute, time.Hour)
+
+	cache.Get(ctx)
+	fail.Store(true)
+	s.Advance(2 * time.Minute)
+	cache.Get(ctx)
+	s.RunUntilIdle()
+	if v, err := cache.Get(ctx).Unwrap(); err != nil || v != 1 {
+		t.Errorf("A failed refresh should keep the stale value, got %d, %v", v, err)
+	}
+
+	s.Advance(2 * time.Hour)
+	if cache.Get(ctx).IsOk() {
+		t.Error("A failed load of an expired value should fail")
+	}
+	if m := cache.Metrics(); m.RefreshErrors != 2 || m.LoadErrors != 1 {
+		t.Errorf("Unexpected metrics %+v", m)
+	}
+
+	fail.Store(false)
+	cache.Invalidate()
+	if v, _ := cache.Get(ctx).Unwrap(); v != 2 {
+		t.Errorf("Expected a load after Invalidate, got %d", v)
+	}
+}
+
+func TestSWRSharedLoad(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	cache := StaleWhileRevalidate(Task[string](func(context.Context) Result[string] {
+		loads.Add(1)
+		<-release
+		return Ok("v")
+	}), time.Minute, 0)
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cache.Task()(context.Background())
+		}()
+	}
+	for cache.Metrics().Misses < 5 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if loads.Load() != 1 {
+		t.Errorf("Concurrent misses should share one load, got %d", loads.Load())
+	}
+}
+
+func TestSWRRuntimeMetrics(t *testing.T) {
+	m := metrics.NewMemory()
+	defer SetMetrics(m)()
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	cache := StaleWhileRevalidate(versions(nil), time.Minute, time.Hour)
+	cache.Get(context.Background())
+	s.Advance(2 * time.Minute)
+	cache.Get(context.Background())
+	s.RunUntilIdle()
+
+	for name, want := range map[string]int64{
+		"gofn_cache_stale_hits_total": 1,
+		"gofn_cache_refreshes_total":  1,
+		"gofn_cache_loads_total":      2,
+	} {
+		if got := m.CounterValue(name); got != want {
+			t.Errorf("%s: expected %d, got %d", name, want, got)
+		}
+	}
+}
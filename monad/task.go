@@ -2,6 +2,8 @@ package monad
 
 import (
 	"context"
+	"log/slog"
+	"time"
 )
 
 // Task represents a computation that can be executed asynchronously
@@ -39,13 +41,17 @@ func NewTaskFromError[T any](err error) Task[T] {
 }
 
 // Run executes the Task and returns a Future
+// Cancelling the Future cancels the context the Task runs with
 func (t Task[T]) Run(ctx context.Context) *Future[T] {
 	future := NewFuture[T]()
+	ctx, cancel := context.WithCancel(ctx)
+	future.onComplete(func(Result[T]) { cancel() })
 
-	go func() {
+	Spawn(func() {
+		future.dbg.own()
 		result := t(ctx)
 		future.complete(result)
-	}()
+	})
 
 	return future
 }
@@ -65,83 +71,100 @@ func AndThenTask[T, U any](task Task[T], f func(T) Task[U]) Task[U] {
 		if !result.IsOk() {
 			val, err := result.Unwrap()
 			_ = val // unused
-			return Err[U](err)
+			return CarryWarnings(Err[U](err), result)
 		}
 		val, _ := result.Unwrap()
-		return f(val)(ctx)
+		return CarryWarnings(f(val)(ctx), result)
 	}
 }
 
-// SequenceTasks executes Tasks sequentially and collects results
+// SequenceTasks executes Tasks sequentially and collects results, keeping the warnings of every task run
 func SequenceTasks[T any](tasks []Task[T]) Task[[]T] {
 	return func(ctx context.Context) Result[[]T] {
 		results := make([]T, 0, len(tasks))
+		var warns *warning
 		for _, task := range tasks {
 			select {
 			case <-ctx.Done():
-				return Err[[]T](ctx.Err())
+				return Result[[]T]{err: ctx.Err(), warns: warns}
 			default:
 			}
 
 			result := task(ctx)
+			warns = appendWarnings(warns, result.warns)
 			if !result.IsOk() {
 				val, err := result.Unwrap()
 				_ = val // unused
-				return Err[[]T](err)
+				return Result[[]T]{err: err, warns: warns}
 			}
 			val, _ := result.Unwrap()
 			results = append(results, val)
 		}
-		return Ok(results)
+		return Result[[]T]{val: results, warns: warns}
 	}
 }
 
 // ParallelTasks executes Tasks in parallel and collects results
-func ParallelTasks[T any](tasks []Task[T]) Task[[]T] {
+// The tasks share a context that is cancelled when ParallelTasks returns, so a failure stops the siblings
+// By default the first failure in input order is reported; pass FailFast to fail on the first failure to happen
+// Warnings of the collected results are kept in input order, except under FailFast, which drops them
+func ParallelTasks[T any](tasks []Task[T], mode ...FailureMode) Task[[]T] {
+	failFast := len(mode) > 0 && mode[0] == FailFast
 	return func(ctx context.Context) Result[[]T] {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
 		futures := make([]*Future[T], len(tasks))
 
 		// Start all tasks
 		for i, task := range tasks {
 			futures[i] = task.Run(ctx)
 		}
+		if failFast {
+			return SequenceFuturesCtx(ctx, futures, FailFast).Await()
+		}
 
 		// Collect results
 		results := make([]T, len(tasks))
+		var warns *warning
 		for i, future := range futures {
 			result := future.AwaitWithContext(ctx)
+			warns = appendWarnings(warns, result.warns)
 			if !result.IsOk() {
 				val, err := result.Unwrap()
 				_ = val // unused
-				return Err[[]T](err)
+				return Result[[]T]{err: err, warns: warns}
 			}
 			val, _ := result.Unwrap()
 			results[i] = val
 		}
 
-		return Ok(results)
+		return Result[[]T]{val: results, warns: warns}
 	}
 }
 
 // RaceTasks executes Tasks in parallel and returns the first successful result
+// The losing tasks are cancelled through their context once RaceTasks returns
 func RaceTasks[T any](tasks []Task[T]) Task[T] {
 	return func(ctx context.Context) Result[T] {
 		if len(tasks) == 0 {
 			return Err[T](context.Canceled)
 		}
 
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
 		futures := make([]*Future[T], len(tasks))
 		done := make(chan Result[T], len(tasks))
 
 		// Start all tasks
 		for i, task := range tasks {
 			futures[i] = task.Run(ctx)
-			go func(future *Future[T]) {
-				result := future.AwaitWithContext(ctx)
+			futures[i].onComplete(func(result Result[T]) {
 				if result.IsOk() {
 					done <- result
 				}
-			}(futures[i])
+			})
 		}
 
 		// Wait for first success or context cancellation
@@ -153,3 +176,177 @@ func RaceTasks[T any](tasks []Task[T]) Task[T] {
 		}
 	}
 }
+
+// WithTimeout runs task with a deadline of d measured by the package Clock
+// The task's context is cancelled when the deadline passes and the Task fails with context.DeadlineExceeded
+// A CPU-bound task only stops if it watches its context, e.g. through Checkpoint or ChunkedLoop; otherwise it
+// keeps running in the background after the deadline
+func WithTimeout[T any](task Task[T], d time.Duration) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		timer := currentClock().NewTimer(d)
+		defer timer.Stop()
+
+		done := make(chan Result[T], 1)
+		Spawn(func() {
+			done <- task(ctx)
+		})
+
+		select {
+		case result := <-done:
+			return result
+		case <-timer.C():
+			return Err[T](context.DeadlineExceeded)
+		case <-ctx.Done():
+			return Err[T](ctx.Err())
+		}
+	}
+}
+
+// RetryTask runs task up to attempts times until it succeeds
+// backoff returns the delay before the given retry (starting at 1) and is measured by the package Clock
+// No retry starts once ctx is done, so a task stopped by Checkpoint under WithTimeout is not run again; the
+// Task then fails with ctx's error and the warnings of the last attempt
+func RetryTask[T any](task Task[T], attempts int, backoff func(retry int) time.Duration) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		result := task(ctx)
+		for retry := 1; retry < attempts && !result.IsOk(); retry++ {
+			if ctx.Err() != nil {
+				return CarryWarnings(Err[T](ctx.Err()), result)
+			}
+			if backoff != nil {
+				timer := currentClock().NewTimer(backoff(retry))
+				select {
+				case <-timer.C():
+				case <-ctx.Done():
+					timer.Stop()
+					return CarryWarnings(Err[T](ctx.Err()), result)
+				}
+			}
+			result = task(ctx)
+		}
+		return result
+	}
+}
+
+// ExponentialBackoff returns a backoff doubling from base on every retry, capped at max
+func ExponentialBackoff(base, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		d := base
+		for i := 1; i < retry && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			return max
+		}
+		return d
+	}
+}
+
+// MapTaskErr transforms the error of a failed Task, leaving successful values untouched
+func MapTaskErr[T any](task Task[T], f func(error) error) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		result := task(ctx)
+		if result.IsOk() {
+			return result
+		}
+		return CarryWarnings(Err[T](f(result.err)), result)
+	}
+}
+
+// TapTask observes the outcome of a Task without changing it
+// onOk is called with the value on success and onErr with the error on failure; either may be nil
+func TapTask[T any](task Task[T], onOk func(T), onErr func(error)) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		result := task(ctx)
+		if result.IsOk() {
+			if onOk != nil {
+				onOk(result.val)
+			}
+		} else if onErr != nil {
+			onErr(result.err)
+		}
+		return result
+	}
+}
+
+// WithTaskLogging logs the start and outcome of a Task with logger under the given name
+// Successes are logged at Debug and failures at Error, both with the elapsed duration
+func WithTaskLogging[T any](task Task[T], logger *slog.Logger, name string) Task[T] {
+	return func(ctx context.Context) Result[T] {
+		clock := currentClock()
+		start := clock.Now()
+		logger.DebugContext(ctx, "task started", slog.String("task", name))
+
+		result := task(ctx)
+		elapsed := clock.Now().Sub(start)
+		if result.IsOk() {
+			logger.DebugContext(ctx, "task succeeded", slog.String("task", name), slog.Duration("elapsed", elapsed))
+		} else {
+			logger.ErrorContext(ctx, "task failed", slog.String("task", name), slog.Duration("elapsed", elapsed), slog.Any("error", result.err))
+		}
+		return result
+	}
+}
+
+// HedgedTask runs task and, while no attempt has succeeded, starts another speculative attempt every delay,
+// up to maxHedges extra attempts; the first success wins and the other attempts are cancelled through their context
+// An attempt failing while no other is running starts the next hedge at once instead of waiting out the delay
+// When every attempt fails the Task fails with an Errors holding each failure in completion order
+// A negative maxHedges is treated as 0: task runs once, without hedging
+func HedgedTask[T any](task Task[T], delay time.Duration, maxHedges int) Task[T] {
+	if maxHedges < 0 {
+		maxHedges = 0
+	}
+	return func(ctx context.Context) Result[T] {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		results := make(chan Result[T], maxHedges+1)
+		launch := func() {
+			Spawn(func() {
+				results <- task(ctx)
+			})
+		}
+
+		timer := currentClock().NewTimer(delay)
+		defer timer.Stop()
+
+		launch()
+		started := 1
+		var errs Errors
+		for {
+			// stop hedging once every attempt has been started
+			var hedge <-chan time.Time
+			if started <= maxHedges {
+				hedge = timer.C()
+			}
+
+			select {
+			case result := <-results:
+				_, err := result.Unwrap()
+				if err == nil {
+					return result
+				}
+				errs = append(errs, err)
+				if len(errs) < started {
+					continue
+				}
+				if started > maxHedges {
+					return Err[T](errs)
+				}
+				launch()
+				started++
+				timer.Reset(delay)
+			case <-hedge:
+				launch()
+				started++
+				timer.Reset(delay)
+			case <-ctx.Done():
+				return Err[T](ctx.Err())
+			}
+		}
+	}
+}
@@ -1,8 +1,13 @@
 package monad
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -380,4 +385,215 @@ func TestRaceTasks(t *testing.T) {
 	if result2.IsOk() {
 		t.Error("Empty race should return error")
 	}
-}
\ No newline at end of file
+}
+
+func TestWithTimeout(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	blocking := NewTask(func(ctx context.Context) Result[int] {
+		<-ctx.Done()
+		return Err[int](ctx.Err())
+	})
+
+	results := make(chan Result[int], 1)
+	go func() {
+		results <- WithTimeout(blocking, time.Second)(context.Background())
+	}()
+
+	s.BlockUntilTimers(1)
+	s.Advance(time.Second)
+
+	_, err := (<-results).Unwrap()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected DeadlineExceeded, got %v", err)
+	}
+
+	// A task finishing in time is returned unchanged
+	fast := WithTimeout(NewTaskFromValue(5), time.Second)
+	go func() {
+		results <- fast(context.Background())
+	}()
+	for s.RunUntilIdle() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if val, err := (<-results).Unwrap(); err != nil || val != 5 {
+		t.Errorf("Expected 5, got %d (%v)", val, err)
+	}
+}
+
+func TestRetryTask(t *testing.T) {
+	s := NewTestScheduler()
+	defer s.Install()()
+
+	attempts := 0
+	flaky := NewTask(func(ctx context.Context) Result[string] {
+		attempts++
+		if attempts < 3 {
+			return Err[string](errors.New("transient"))
+		}
+		return Ok("done")
+	})
+
+	results := make(chan Result[string], 1)
+	go func() {
+		results <- RetryTask(flaky, 5, ExponentialBackoff(100*time.Millisecond, time.Second))(context.Background())
+	}()
+
+	// First retry waits 100ms, second waits 200ms
+	s.BlockUntilTimers(1)
+	s.Advance(100 * time.Millisecond)
+	s.BlockUntilTimers(1)
+	s.Advance(200 * time.Millisecond)
+
+	val, err := (<-results).Unwrap()
+	if err != nil || val != "done" {
+		t.Errorf("Expected done, got %q (%v)", val, err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %d", attempts)
+	}
+
+	// Exhausted retries return the last error
+	failing := RetryTask(NewTaskFromError[int](errors.New("permanent")), 2, nil)
+	if _, err := failing(context.Background()).Unwrap(); err == nil || err.Error() != "permanent" {
+		t.Errorf("Expected permanent error, got %v", err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Millisecond, 50*time.Millisecond)
+	expected := []time.Duration{10 * time.Millisecond, 20 * time.Millisecond, 40 * time.Millisecond, 50 * time.Millisecond, 50 * time.Millisecond}
+	for i, want := range expected {
+		if got := backoff(i + 1); got != want {
+			t.Errorf("retry %d: expected %v, got %v", i+1, want, got)
+		}
+	}
+}
+
+func TestMapTaskErr(t *testing.T) {
+	testErr := errors.New("not found")
+	wrapped := MapTaskErr(NewTaskFromError[int](testErr), func(err error) error {
+		return fmt.Errorf("loading user: %w", err)
+	})
+
+	_, err := wrapped(context.Background()).Unwrap()
+	if !errors.Is(err, testErr) || err.Error() != "loading user: not found" {
+		t.Errorf("Expected wrapped error, got %v", err)
+	}
+
+	ok := MapTaskErr(NewTaskFromValue(1), func(err error) error { return errors.New("unused") })
+	if val, err := ok(context.Background()).Unwrap(); err != nil || val != 1 {
+		t.Errorf("Expected 1, got %d (%v)", val, err)
+	}
+}
+
+func TestTapTask(t *testing.T) {
+	var seen int
+	var seenErr error
+	tapped := TapTask(NewTaskFromValue(3), func(v int) { seen = v }, func(err error) { seenErr = err })
+	if val, _ := tapped(context.Background()).Unwrap(); val != 3 || seen != 3 || seenErr != nil {
+		t.Errorf("Expected value to be observed, got seen=%d err=%v", seen, seenErr)
+	}
+
+	testErr := errors.New("failed")
+	failing := TapTask(NewTaskFromError[int](testErr), nil, func(err error) { seenErr = err })
+	if _, err := failing(context.Background()).Unwrap(); err != testErr || seenErr != testErr {
+		t.Errorf("Expected error to be observed, got %v", seenErr)
+	}
+}
+
+func TestWithTaskLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	WithTaskLogging(NewTaskFromValue(1), logger, "load")(context.Background())
+	WithTaskLogging(NewTaskFromError[int](errors.New("boom")), logger, "save")(context.Background())
+
+	out := buf.String()
+	for _, want := range []string{"task succeeded", "task=load", "task failed", "task=save", "error=boom"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected log output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestHedgedTaskStartsHedgeAfterDelay(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	var attempts atomic.Int32
+	slowCancelled := make(chan struct{})
+	task := Task[int](func(ctx context.Context) Result[int] {
+		if attempts.Add(1) == 1 {
+			<-ctx.Done()
+			close(slowCancelled)
+			return Err[int](ctx.Err())
+		}
+		return Ok(2)
+	})
+
+	future := HedgedTask(task, 50*time.Millisecond, 2).Run(context.Background())
+	clock.BlockUntilTimers(1)
+	clock.Advance(50 * time.Millisecond)
+
+	value, err := future.Await().Unwrap()
+	if err != nil || value != 2 {
+		t.Fatalf("Expected the hedge to win with 2, got (%d, %v)", value, err)
+	}
+	select {
+	case <-slowCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("The slow attempt should be cancelled")
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("Expected 2 attempts, got %d", n)
+	}
+}
+
+func TestHedgedTaskAllFail(t *testing.T) {
+	var attempts atomic.Int32
+	task := Task[int](func(ctx context.Context) Result[int] {
+		return Err[int](fmt.Errorf("attempt %d", attempts.Add(1)))
+	})
+
+	_, err := HedgedTask(task, time.Hour, 2)(context.Background()).Unwrap()
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("Expected 3 failures, got %v", err)
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("Failures should start hedges without waiting, got %d attempts", n)
+	}
+}
+
+func TestHedgedTaskWithoutHedges(t *testing.T) {
+	var attempts atomic.Int32
+	task := Task[int](func(ctx context.Context) Result[int] {
+		attempts.Add(1)
+		return Ok(1)
+	})
+
+	if v, err := HedgedTask(task, time.Millisecond, 0)(context.Background()).Unwrap(); err != nil || v != 1 {
+		t.Errorf("Expected Ok(1), got (%d, %v)", v, err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("Expected a single attempt, got %d", n)
+	}
+}
+
+func TestHedgedTaskNegativeHedges(t *testing.T) {
+	var attempts atomic.Int32
+	task := Task[int](func(ctx context.Context) Result[int] {
+		return Err[int](fmt.Errorf("attempt %d", attempts.Add(1)))
+	})
+
+	_, err := HedgedTask(task, time.Millisecond, -5)(context.Background()).Unwrap()
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 1 {
+		t.Fatalf("Expected a single failure, got %v", err)
+	}
+	if n := attempts.Load(); n != 1 {
+		t.Errorf("A negative maxHedges should run the task once, got %d attempts", n)
+	}
+}
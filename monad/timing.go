@@ -0,0 +1,52 @@
+package monad
+
+import (
+	"context"
+	"time"
+)
+
+// Ticker creates an Observable emitting the package Clock's time every d until ctx or the subscription context is done
+// Ticks are spaced d apart after each emission, so a slow subscriber delays later ticks instead of queueing them
+func Ticker(ctx context.Context, d time.Duration) Observable[time.Time] {
+	return func(subCtx context.Context, next func(time.Time)) error {
+		clock := currentClock()
+		timer := clock.NewTimer(d)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-timer.C():
+				next(clock.Now())
+				timer.Reset(d)
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-subCtx.Done():
+				return subCtx.Err()
+			}
+		}
+	}
+}
+
+// TimeoutAfter returns WithTimeout as a reusable Task decorator, for applying one deadline to many tasks
+// or passing it where a func(Task[T]) Task[T] is expected
+func TimeoutAfter[T any](d time.Duration) func(Task[T]) Task[T] {
+	return func(task Task[T]) Task[T] {
+		return WithTimeout(task, d)
+	}
+}
+
+// DeadlineFromContext returns the deadline of ctx, or None when it has none
+func DeadlineFromContext(ctx context.Context) Option[time.Time] {
+	if deadline, ok := ctx.Deadline(); ok {
+		return Some(deadline)
+	}
+	return None[time.Time]()
+}
+
+// NowEvery creates a Reactive holding the package Clock's time, refreshed every d until ctx is done
+// Derive time-dependent values from it with MapReactive, e.g. an "updated 5m ago" label
+func NowEvery(ctx context.Context, d time.Duration) *Reactive[time.Time] {
+	now := NewReactive(currentClock().Now())
+	Ticker(ctx, d).Subscribe(ctx, now.Set)
+	return now
+}
@@ -0,0 +1,91 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTicker(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	defer SetClock(clock)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticks := make(chan time.Time, 4)
+	done := Ticker(ctx, time.Second).Subscribe(context.Background(), func(tick time.Time) { ticks <- tick })
+
+	for i := 1; i <= 2; i++ {
+		clock.BlockUntilTimers(1)
+		clock.Advance(time.Second)
+		if got := <-ticks; !got.Equal(start.Add(time.Duration(i) * time.Second)) {
+			t.Errorf("Tick %d: expected %v, got %v", i, start.Add(time.Duration(i)*time.Second), got)
+		}
+	}
+
+	cancel()
+	if _, err := done.Await().Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the ticker to end with context.Canceled, got %v", err)
+	}
+	if clock.PendingTimers() != 0 {
+		t.Errorf("Expected the ticker timer to be stopped, %d pending", clock.PendingTimers())
+	}
+}
+
+func TestTimeoutAfter(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	withDeadline := TimeoutAfter[int](time.Second)
+	slow := withDeadline(func(ctx context.Context) Result[int] {
+		<-ctx.Done()
+		return Err[int](ctx.Err())
+	})
+	fast := withDeadline(NewTaskFromValue(7))
+
+	if v, err := fast(context.Background()).Unwrap(); err != nil || v != 7 {
+		t.Errorf("Expected Ok(7), got %v, %v", v, err)
+	}
+
+	result := slow.Run(context.Background())
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Second)
+	if _, err := result.Await().Unwrap(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestDeadlineFromContext(t *testing.T) {
+	if DeadlineFromContext(context.Background()).IsSome() {
+		t.Error("Background context should have no deadline")
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if got := DeadlineFromContext(ctx); !got.IsSome() || !got.Unwrap().Equal(deadline) {
+		t.Errorf("Expected Some(%v), got %v", deadline, got)
+	}
+}
+
+func TestNowEvery(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewFakeClock(start)
+	defer SetClock(clock)()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	now := NowEvery(ctx, time.Minute)
+	if !now.Get().Equal(start) {
+		t.Errorf("Expected the initial time %v, got %v", start, now.Get())
+	}
+
+	updated := make(chan time.Time, 1)
+	now.Subscribe(func(_, v time.Time) { updated <- v })
+	clock.BlockUntilTimers(1)
+	clock.Advance(time.Minute)
+	if got := <-updated; !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected %v, got %v", start.Add(time.Minute), got)
+	}
+}
@@ -0,0 +1,46 @@
+package monad
+
+// Tuple2 groups two values, e.g. the results of a function returning (A, B, error) as one Result value
+type Tuple2[A, B any] struct {
+	V1 A
+	V2 B
+}
+
+// Tuple3 groups three values
+type Tuple3[A, B, C any] struct {
+	V1 A
+	V2 B
+	V3 C
+}
+
+// Tuple4 groups four values
+type Tuple4[A, B, C, D any] struct {
+	V1 A
+	V2 B
+	V3 C
+	V4 D
+}
+
+// TupleOf2 creates a Tuple2
+func TupleOf2[A, B any](a A, b B) Tuple2[A, B] {
+	return Tuple2[A, B]{V1: a, V2: b}
+}
+
+// TupleOf3 creates a Tuple3
+func TupleOf3[A, B, C any](a A, b B, c C) Tuple3[A, B, C] {
+	return Tuple3[A, B, C]{V1: a, V2: b, V3: c}
+}
+
+// TupleOf4 creates a Tuple4
+func TupleOf4[A, B, C, D any](a A, b B, c C, d D) Tuple4[A, B, C, D] {
+	return Tuple4[A, B, C, D]{V1: a, V2: b, V3: c, V4: d}
+}
+
+// Unpack returns the values of the tuple
+func (t Tuple2[A, B]) Unpack() (A, B) { return t.V1, t.V2 }
+
+// Unpack returns the values of the tuple
+func (t Tuple3[A, B, C]) Unpack() (A, B, C) { return t.V1, t.V2, t.V3 }
+
+// Unpack returns the values of the tuple
+func (t Tuple4[A, B, C, D]) Unpack() (A, B, C, D) { return t.V1, t.V2, t.V3, t.V4 }
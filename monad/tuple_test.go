@@ -0,0 +1,29 @@
+package monad
+
+import "testing"
+
+func TestTuples(t *testing.T) {
+	q, r := TupleOf2(3, 1).Unpack()
+	if q != 3 || r != 1 {
+		t.Errorf("Tuple2 = %d, %d; want 3, 1", q, r)
+	}
+
+	name, age, ok := TupleOf3("kim", 30, true).Unpack()
+	if name != "kim" || age != 30 || !ok {
+		t.Errorf("Tuple3 = %q, %d, %v", name, age, ok)
+	}
+
+	t4 := TupleOf4(1, "b", 'c', 4.0)
+	if t4.V1 != 1 || t4.V2 != "b" || t4.V3 != 'c' || t4.V4 != 4.0 {
+		t.Errorf("Tuple4 = %+v", t4)
+	}
+}
+
+func TestTupleInResult(t *testing.T) {
+	divMod := func(a, b int) (int, int, error) { return a / b, a % b, nil }
+	q, r, err := divMod(7, 2)
+	result := Map(ResultOf(TupleOf2(q, r), err), func(t Tuple2[int, int]) int { return t.V1*2 + t.V2 })
+	if v, err := result.Unwrap(); err != nil || v != 7 {
+		t.Errorf("result = %d, %v; want 7, nil", v, err)
+	}
+}
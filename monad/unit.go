@@ -0,0 +1,43 @@
+package monad
+
+import "context"
+
+// Unit is the canonical "no value" type for side-effect-only Results, Tasks and Futures
+type Unit struct{}
+
+// OkUnit returns a successful Result carrying Unit
+func OkUnit() Result[Unit] {
+	return Ok(Unit{})
+}
+
+// ErrUnit returns a failed Result[Unit]
+func ErrUnit(err error) Result[Unit] {
+	return Err[Unit](err)
+}
+
+// UnitFrom converts a plain error into a Result[Unit]
+func UnitFrom(err error) Result[Unit] {
+	if err != nil {
+		return Err[Unit](err)
+	}
+	return Ok(Unit{})
+}
+
+// TaskUnit creates a Task[Unit] from a side-effect-only function
+func TaskUnit(f func(context.Context) error) Task[Unit] {
+	return func(ctx context.Context) Result[Unit] {
+		return UnitFrom(f(ctx))
+	}
+}
+
+// Discard drops the value of a Result, keeping only its error
+func Discard[T any](r Result[T]) Result[Unit] {
+	return UnitFrom(r.err)
+}
+
+// DiscardTask drops the value produced by a Task, keeping only its error
+func DiscardTask[T any](task Task[T]) Task[Unit] {
+	return func(ctx context.Context) Result[Unit] {
+		return Discard(task(ctx))
+	}
+}
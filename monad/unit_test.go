@@ -0,0 +1,59 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestOkUnit(t *testing.T) {
+	if !OkUnit().IsOk() {
+		t.Error("OkUnit should be Ok")
+	}
+
+	testErr := errors.New("failed")
+	if _, err := ErrUnit(testErr).Unwrap(); err != testErr {
+		t.Errorf("Expected %v, got %v", testErr, err)
+	}
+}
+
+func TestUnitFrom(t *testing.T) {
+	if !UnitFrom(nil).IsOk() {
+		t.Error("UnitFrom(nil) should be Ok")
+	}
+	if UnitFrom(errors.New("failed")).IsOk() {
+		t.Error("UnitFrom(err) should be Err")
+	}
+}
+
+func TestTaskUnit(t *testing.T) {
+	called := false
+	task := TaskUnit(func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	result := task(context.Background())
+	if !result.IsOk() || !called {
+		t.Error("TaskUnit should run the function and succeed")
+	}
+
+	testErr := errors.New("write failed")
+	failing := TaskUnit(func(ctx context.Context) error { return testErr })
+	if _, err := failing(context.Background()).Unwrap(); err != testErr {
+		t.Errorf("Expected %v, got %v", testErr, err)
+	}
+}
+
+func TestDiscardTask(t *testing.T) {
+	task := DiscardTask(NewTaskFromValue(42))
+	if !task(context.Background()).IsOk() {
+		t.Error("Discarded Ok task should be Ok")
+	}
+
+	testErr := errors.New("failed")
+	failing := DiscardTask(NewTaskFromError[int](testErr))
+	if _, err := failing(context.Background()).Unwrap(); err != testErr {
+		t.Errorf("Expected %v, got %v", testErr, err)
+	}
+}
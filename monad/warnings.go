@@ -0,0 +1,57 @@
+package monad
+
+import "slices"
+
+// warning is a node of the immutable list of a Result's warnings, newest first, so adding a warning never
+// copies the ones before it and Results sharing a prefix of warnings share its nodes
+type warning struct {
+	err  error
+	prev *warning
+}
+
+// WithWarning returns r with w added to its warnings: a non-fatal diagnostic that leaves r's value or error
+// as it is, for degraded but usable outcomes such as a skipped record or a fallback value
+// Map, AndThen, the Pipeline helpers, MapTask, MapTaskErr, AndThenTask, SequenceTasks, ParallelTasks and
+// RetryTask carry warnings to the Result they return; a nil w is ignored
+func WithWarning[T any](r Result[T], w error) Result[T] {
+	if w != nil {
+		r.warns = &warning{err: w, prev: r.warns}
+	}
+	return r
+}
+
+// Warnings returns the warnings added to r, oldest first, or nil when there are none
+func (r Result[T]) Warnings() []error {
+	var ws []error
+	for w := r.warns; w != nil; w = w.prev {
+		ws = append(ws, w.err)
+	}
+	slices.Reverse(ws)
+	return ws
+}
+
+// CarryWarnings returns r with the warnings of from placed before its own
+// Use it when unwrapping a Result by hand, so the warnings of earlier steps reach the final Result
+func CarryWarnings[T, U any](r Result[T], from Result[U]) Result[T] {
+	r.warns = appendWarnings(from.warns, r.warns)
+	return r
+}
+
+// appendWarnings returns the list of the warnings of before followed by those of after
+func appendWarnings(before, after *warning) *warning {
+	switch {
+	case before == nil:
+		return after
+	case after == nil:
+		return before
+	}
+	var rest []error
+	for w := after; w != nil; w = w.prev {
+		rest = append(rest, w.err)
+	}
+	list := before
+	for i := len(rest) - 1; i >= 0; i-- {
+		list = &warning{err: rest[i], prev: list}
+	}
+	return list
+}
@@ -0,0 +1,116 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// messages returns the messages of errs, for comparing warnings
+func messages(errs []error) []string {
+	var msgs []string
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
+
+func TestWarningsThroughMapAndThen(t *testing.T) {
+	base := WithWarning(WithWarning(Ok(1), errors.New("a")), nil)
+	mapped := Map(base, func(n int) int { return n + 1 })
+	chained := AndThen(mapped, func(n int) Result[int] {
+		return WithWarning(Ok(n*10), errors.New("b"))
+	})
+	if v, _ := chained.Unwrap(); v != 20 {
+		t.Fatalf("Warnings should not change the value, got %d", v)
+	}
+	if got := messages(chained.Warnings()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("Expected warnings [a b], got %v", got)
+	}
+
+	failed := AndThen(WithWarning(Err[int](errors.New("boom")), errors.New("c")), func(int) Result[int] { return Ok(0) })
+	if failed.IsOk() || len(failed.Warnings()) != 1 {
+		t.Errorf("A failure should keep its warnings, got %v", failed.Warnings())
+	}
+	if Ok(1).Warnings() != nil {
+		t.Error("A Result without warnings should return nil")
+	}
+}
+
+func TestWarningsShareNodes(t *testing.T) {
+	base := WithWarning(Ok(1), errors.New("a"))
+	left := WithWarning(base, errors.New("left"))
+	right := WithWarning(base, errors.New("right"))
+	if got := messages(left.Warnings()); !slices.Equal(got, []string{"a", "left"}) {
+		t.Errorf("Unexpected left warnings %v", got)
+	}
+	if got := messages(right.Warnings()); !slices.Equal(got, []string{"a", "right"}) {
+		t.Errorf("Unexpected right warnings %v", got)
+	}
+}
+
+func TestCarryWarnings(t *testing.T) {
+	first := WithWarning(Ok("x"), errors.New("1"))
+	second := WithWarning(WithWarning(Ok(2), errors.New("2")), errors.New("3"))
+	if got := messages(CarryWarnings(second, first).Warnings()); !slices.Equal(got, []string{"1", "2", "3"}) {
+		t.Errorf("Expected warnings [1 2 3], got %v", got)
+	}
+}
+
+func TestPipelineWarnings(t *testing.T) {
+	p := AndThenP(WarnP(OkP(" 42"), errors.New("padded")), func(s string) Result[int] {
+		var n int
+		_, err := fmt.Sscan(s, &n)
+		return ResultOf(n, err)
+	})
+	p = ThenP(p, func(int) error { return errors.New("rejected") })
+	if _, err := p.Unwrap(); err == nil {
+		t.Fatal("Expected ThenP to fail")
+	}
+	if got := messages(p.Warnings()); !slices.Equal(got, []string{"padded"}) {
+		t.Errorf("A failing ThenP should keep earlier warnings, got %v", got)
+	}
+}
+
+func TestTaskWarnings(t *testing.T) {
+	warned := func(n int, w string) Task[int] {
+		return func(context.Context) Result[int] { return WithWarning(Ok(n), errors.New(w)) }
+	}
+	ctx := context.Background()
+
+	chained := AndThenTask(warned(1, "a"), func(n int) Task[int] { return warned(n+1, "b") })(ctx)
+	if got := messages(chained.Warnings()); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("AndThenTask: expected [a b], got %v", got)
+	}
+
+	tasks := []Task[int]{warned(1, "a"), NewTaskFromValue(2), warned(3, "c")}
+	for name, task := range map[string]Task[[]int]{"SequenceTasks": SequenceTasks(tasks), "ParallelTasks": ParallelTasks(tasks)} {
+		r := task(ctx)
+		if v, err := r.Unwrap(); err != nil || len(v) != 3 {
+			t.Errorf("%s: unexpected result %v, %v", name, v, err)
+		}
+		if got := messages(r.Warnings()); !slices.Equal(got, []string{"a", "c"}) {
+			t.Errorf("%s: expected warnings in input order, got %v", name, got)
+		}
+	}
+
+	failing := func(context.Context) Result[int] {
+		return WithWarning(Err[int](errors.New("boom")), errors.New("partial"))
+	}
+	mapped := MapTaskErr(failing, func(err error) error { return fmt.Errorf("wrapped: %w", err) })(ctx)
+	if _, err := mapped.Unwrap(); err == nil || err.Error() != "wrapped: boom" {
+		t.Errorf("MapTaskErr: unexpected error %v", err)
+	}
+	if got := messages(mapped.Warnings()); !slices.Equal(got, []string{"partial"}) {
+		t.Errorf("MapTaskErr: expected [partial], got %v", got)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	retried := RetryTask(failing, 3, nil)(cancelled)
+	if _, err := retried.Unwrap(); !errors.Is(err, context.Canceled) || !slices.Equal(messages(retried.Warnings()), []string{"partial"}) {
+		t.Errorf("RetryTask: expected the cancellation with [partial], got %v, %v", err, retried.Warnings())
+	}
+}
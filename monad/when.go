@@ -0,0 +1,165 @@
+package monad
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Indexed pairs a value with the position of the input that produced it
+type Indexed[T any] struct {
+	Index int
+	Value T
+}
+
+// Settled is the outcome of one input to WhenAll
+type Settled[T any] struct {
+	Result Result[T]
+	// Duration is the time from the WhenAll call until the input completed, measured by the package Clock
+	Duration time.Duration
+}
+
+// IsOk reports whether the input succeeded
+func (s Settled[T]) IsOk() bool { return s.Result.IsOk() }
+
+// WhenAny completes with the first input to succeed, together with its index
+// If every input fails it fails with an Errors holding each failure in input order;
+// with no inputs it fails with context.Canceled, like FirstCompleted
+func WhenAny[T any](futures []*Future[T]) *Future[Indexed[T]] {
+	resultFuture := NewFuture[Indexed[T]]()
+	if len(futures) == 0 {
+		resultFuture.CompleteWithError(context.Canceled)
+		return resultFuture
+	}
+
+	var mu sync.Mutex
+	errs := make(Errors, len(futures))
+	failed := 0
+	for i, future := range futures {
+		future.onComplete(func(result Result[T]) {
+			val, err := result.Unwrap()
+			if err == nil {
+				resultFuture.Complete(Indexed[T]{Index: i, Value: val})
+				return
+			}
+			mu.Lock()
+			errs[i] = err
+			failed++
+			all := failed == len(futures)
+			mu.Unlock()
+			if all {
+				resultFuture.CompleteWithError(errs)
+			}
+		})
+	}
+	return resultFuture
+}
+
+// WhenAll waits for every input and reports each outcome with its latency; it never fails
+// Results keep input order, so the caller can count successes for quorum decisions
+func WhenAll[T any](futures []*Future[T]) *Future[[]Settled[T]] {
+	resultFuture := NewFuture[[]Settled[T]]()
+	settled := make([]Settled[T], len(futures))
+	if len(futures) == 0 {
+		resultFuture.Complete(settled)
+		return resultFuture
+	}
+
+	clock := currentClock()
+	start := clock.Now()
+	var mu sync.Mutex
+	remaining := len(futures)
+	for i, future := range futures {
+		future.onComplete(func(result Result[T]) {
+			mu.Lock()
+			settled[i] = Settled[T]{Result: result, Duration: clock.Now().Sub(start)}
+			remaining--
+			done := remaining == 0
+			mu.Unlock()
+			if done {
+				resultFuture.Complete(settled)
+			}
+		})
+	}
+	return resultFuture
+}
+
+// WhenQuorum completes with the first n successes in completion order, each tagged with its input index
+// It fails with an Errors of the failures so far as soon as n successes are no longer possible, and at once
+// when there are fewer than n futures
+func WhenQuorum[T any](futures []*Future[T], n int) *Future[[]Indexed[T]] {
+	resultFuture := NewFuture[[]Indexed[T]]()
+	if n <= 0 {
+		resultFuture.Complete(nil)
+		return resultFuture
+	}
+	if n > len(futures) {
+		resultFuture.CompleteWithError(fmt.Errorf("monad: quorum of %d needs at least %d futures, got %d", n, n, len(futures)))
+		return resultFuture
+	}
+
+	var mu sync.Mutex
+	oks := make([]Indexed[T], 0, n)
+	var errs Errors
+	for i, future := range futures {
+		future.onComplete(func(result Result[T]) {
+			val, err := result.Unwrap()
+			mu.Lock()
+			if err == nil {
+				oks = append(oks, Indexed[T]{Index: i, Value: val})
+			} else {
+				errs = append(errs, err)
+			}
+			reached := err == nil && len(oks) == n
+			impossible := err != nil && len(futures)-len(errs) < n
+			var snapshot []Indexed[T]
+			var failures Errors
+			if reached {
+				snapshot = append(snapshot, oks...)
+			}
+			if impossible {
+				failures = append(failures, errs...)
+			}
+			mu.Unlock()
+
+			switch {
+			case reached:
+				resultFuture.Complete(snapshot)
+			case impossible:
+				resultFuture.CompleteWithError(failures)
+			}
+		})
+	}
+	return resultFuture
+}
+
+// ParallelTasksStream runs tasks in parallel and emits each outcome as soon as it completes, in completion order,
+// tagged with its input index and its latency, so callers can report progress or act on early results
+// The stream completes once every task has been emitted; failures are emitted rather than ending the stream,
+// and ending the subscription cancels the tasks still running
+func ParallelTasksStream[T any](tasks []Task[T]) Observable[Indexed[Settled[T]]] {
+	return func(ctx context.Context, next func(Indexed[Settled[T]])) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		clock := currentClock()
+		start := clock.Now()
+		completed := make(chan Indexed[Settled[T]], len(tasks))
+		for i, task := range tasks {
+			task.Run(ctx).onComplete(func(result Result[T]) {
+				completed <- Indexed[Settled[T]]{Index: i, Value: Settled[T]{Result: result, Duration: clock.Now().Sub(start)}}
+			})
+		}
+
+		for range tasks {
+			select {
+			case outcome := <-completed:
+				next(outcome)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return nil
+	}
+}
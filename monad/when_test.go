@@ -0,0 +1,164 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWhenAnyReportsFirstSuccess(t *testing.T) {
+	futures := []*Future[string]{NewFuture[string](), NewFuture[string](), NewFuture[string]()}
+	first := WhenAny(futures)
+
+	futures[0].CompleteWithError(errors.New("slow replica down"))
+	futures[2].Complete("replica-2")
+	futures[1].Complete("replica-1")
+
+	got, err := first.Await().Unwrap()
+	if err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if got.Index != 2 || got.Value != "replica-2" {
+		t.Errorf("Expected {2 replica-2}, got %+v", got)
+	}
+}
+
+func TestWhenAnyAllFail(t *testing.T) {
+	errA, errB := errors.New("a"), errors.New("b")
+	_, err := WhenAny([]*Future[int]{FailedFuture[int](errA), FailedFuture[int](errB)}).Await().Unwrap()
+
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs[0] != errA || errs[1] != errB {
+		t.Errorf("Expected Errors{a, b} in input order, got %v", err)
+	}
+
+	if _, err := WhenAny[int](nil).Await().Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled for no inputs, got %v", err)
+	}
+}
+
+func TestWhenAllSettlesEveryInput(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	futures := []*Future[int]{NewFuture[int](), NewFuture[int]()}
+	all := WhenAll(futures)
+
+	clock.Advance(10 * time.Millisecond)
+	futures[1].CompleteWithError(errors.New("boom"))
+	clock.Advance(15 * time.Millisecond)
+	futures[0].Complete(7)
+
+	settled, err := all.Await().Unwrap()
+	if err != nil {
+		t.Fatalf("WhenAll should not fail, got %v", err)
+	}
+	if !settled[0].IsOk() || settled[0].Duration != 25*time.Millisecond {
+		t.Errorf("Unexpected first outcome %+v", settled[0])
+	}
+	if v, _ := settled[0].Result.Unwrap(); v != 7 {
+		t.Errorf("Expected 7, got %d", v)
+	}
+	if settled[1].IsOk() || settled[1].Duration != 10*time.Millisecond {
+		t.Errorf("Unexpected second outcome %+v", settled[1])
+	}
+
+	empty, _ := WhenAll[int](nil).Await().Unwrap()
+	if len(empty) != 0 {
+		t.Errorf("Expected no outcomes, got %v", empty)
+	}
+}
+
+func TestWhenQuorum(t *testing.T) {
+	futures := []*Future[int]{NewFuture[int](), NewFuture[int](), NewFuture[int]()}
+	quorum := WhenQuorum(futures, 2)
+
+	futures[1].Complete(10)
+	if quorum.IsDone() {
+		t.Fatal("Quorum should wait for a second success")
+	}
+	futures[2].Complete(20)
+
+	got, err := quorum.Await().Unwrap()
+	if err != nil {
+		t.Fatalf("Expected quorum, got %v", err)
+	}
+	if len(got) != 2 || got[0].Index != 1 || got[1].Index != 2 {
+		t.Errorf("Unexpected quorum %+v", got)
+	}
+}
+
+func TestWhenQuorumUnreachable(t *testing.T) {
+	futures := []*Future[int]{NewFuture[int](), NewFuture[int](), NewFuture[int]()}
+	quorum := WhenQuorum(futures, 2)
+
+	futures[0].CompleteWithError(errors.New("a"))
+	futures[1].CompleteWithError(errors.New("b"))
+
+	if _, err := quorum.Await().Unwrap(); err == nil {
+		t.Fatal("Expected failure once quorum is unreachable")
+	}
+}
+
+func TestWhenQuorumTooFewFutures(t *testing.T) {
+	futures := []*Future[int]{NewFuture[int]()}
+	_, err := WhenQuorum(futures, 2).Await().Unwrap()
+	if err == nil || errors.Is(err, context.Canceled) || err.Error() != "monad: quorum of 2 needs at least 2 futures, got 1" {
+		t.Errorf("Expected a descriptive error, got %v", err)
+	}
+}
+
+func TestParallelTasksStream(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	defer SetClock(clock)()
+
+	release := make(chan struct{})
+	slow := func(ctx context.Context) Result[int] {
+		<-release
+		clock.Advance(20 * time.Millisecond)
+		return Ok(1)
+	}
+	failing := NewTaskFromError[int](errors.New("boom"))
+
+	var outcomes []Indexed[Settled[int]]
+	err := ParallelTasksStream([]Task[int]{slow, failing})(context.Background(), func(o Indexed[Settled[int]]) {
+		outcomes = append(outcomes, o)
+		if len(outcomes) == 1 {
+			close(release)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Stream should complete, got %v", err)
+	}
+	if len(outcomes) != 2 {
+		t.Fatalf("Expected 2 outcomes, got %d", len(outcomes))
+	}
+	if outcomes[0].Index != 1 || outcomes[0].Value.IsOk() || outcomes[0].Value.Duration != 0 {
+		t.Errorf("Expected the failing task first, got %+v", outcomes[0])
+	}
+	if outcomes[1].Index != 0 || !outcomes[1].Value.IsOk() || outcomes[1].Value.Duration != 20*time.Millisecond {
+		t.Errorf("Expected the slow task second, got %+v", outcomes[1])
+	}
+}
+
+func TestParallelTasksStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	blocked := func(taskCtx context.Context) Result[int] {
+		<-taskCtx.Done()
+		close(stopped)
+		return Err[int](taskCtx.Err())
+	}
+
+	done := ParallelTasksStream([]Task[int]{blocked}).Subscribe(ctx, func(Indexed[Settled[int]]) {})
+	cancel()
+	if _, err := done.Await().Unwrap(); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("Ending the subscription should cancel running tasks")
+	}
+}
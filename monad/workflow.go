@@ -0,0 +1,127 @@
+package monad
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StepStatus is the outcome of a single workflow step
+type StepStatus int
+
+const (
+	// StepPending means the step never ran because an earlier step failed
+	StepPending StepStatus = iota
+	// StepSucceeded means the step ran and its effects were kept
+	StepSucceeded
+	// StepFailed means the step itself returned an error
+	StepFailed
+	// StepCompensated means the step succeeded and was undone after a later failure
+	StepCompensated
+	// StepCompensationFailed means the step succeeded but undoing it returned an error
+	StepCompensationFailed
+)
+
+// String returns the lower-case name of the StepStatus
+func (s StepStatus) String() string {
+	switch s {
+	case StepSucceeded:
+		return "succeeded"
+	case StepFailed:
+		return "failed"
+	case StepCompensated:
+		return "compensated"
+	case StepCompensationFailed:
+		return "compensation_failed"
+	default:
+		return "pending"
+	}
+}
+
+// WorkflowStep is a named step with an optional compensation undoing its effects
+type WorkflowStep struct {
+	Name       string
+	Run        func(context.Context) error
+	Compensate func(context.Context) error
+}
+
+// StepTrace records what happened to one step
+// Err holds the step's error for StepFailed and the compensation's error for StepCompensationFailed
+type StepTrace struct {
+	Name     string
+	Status   StepStatus
+	Err      error
+	Duration time.Duration
+}
+
+// WorkflowTrace is the structured execution trace of a workflow run, one entry per declared step
+type WorkflowTrace struct {
+	Workflow string
+	Steps    []StepTrace
+}
+
+// WorkflowError is returned when a step fails; Trace describes which steps ran and were compensated
+type WorkflowError struct {
+	Step  string
+	Err   error
+	Trace WorkflowTrace
+}
+
+// Error describes the failing step
+func (e *WorkflowError) Error() string {
+	return fmt.Sprintf("workflow %s: step %s: %v", e.Trace.Workflow, e.Step, e.Err)
+}
+
+// Unwrap returns the error of the failing step
+func (e *WorkflowError) Unwrap() error {
+	return e.Err
+}
+
+// WorkflowTask creates a Task executing steps sequentially
+// When a step fails, or ctx is cancelled between steps, the compensations of every completed step run
+// in reverse order and the Task fails with a *WorkflowError carrying the trace.
+// Compensations run with a context detached from cancellation so that they can still clean up.
+func WorkflowTask(name string, steps []WorkflowStep) Task[WorkflowTrace] {
+	return func(ctx context.Context) Result[WorkflowTrace] {
+		clock := currentClock()
+		trace := WorkflowTrace{Workflow: name, Steps: make([]StepTrace, len(steps))}
+		for i, step := range steps {
+			trace.Steps[i] = StepTrace{Name: step.Name, Status: StepPending}
+		}
+
+		for i, step := range steps {
+			err := ctx.Err()
+			if err == nil {
+				start := clock.Now()
+				err = step.Run(ctx)
+				trace.Steps[i].Duration = clock.Now().Sub(start)
+			}
+			if err == nil {
+				trace.Steps[i].Status = StepSucceeded
+				continue
+			}
+
+			trace.Steps[i].Status = StepFailed
+			trace.Steps[i].Err = err
+			compensateSteps(context.WithoutCancel(ctx), steps[:i], trace.Steps[:i])
+			return Err[WorkflowTrace](&WorkflowError{Step: step.Name, Err: err, Trace: trace})
+		}
+
+		return Ok(trace)
+	}
+}
+
+// compensateSteps undoes completed steps in reverse order, recording the outcome in traces
+func compensateSteps(ctx context.Context, steps []WorkflowStep, traces []StepTrace) {
+	for i := len(steps) - 1; i >= 0; i-- {
+		if steps[i].Compensate == nil {
+			continue
+		}
+		if err := steps[i].Compensate(ctx); err != nil {
+			traces[i].Status = StepCompensationFailed
+			traces[i].Err = err
+			continue
+		}
+		traces[i].Status = StepCompensated
+	}
+}
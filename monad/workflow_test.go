@@ -0,0 +1,104 @@
+package monad
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWorkflowTaskSucceeds(t *testing.T) {
+	var log []string
+	step := func(name string) WorkflowStep {
+		return WorkflowStep{
+			Name:       name,
+			Run:        func(ctx context.Context) error { log = append(log, name); return nil },
+			Compensate: func(ctx context.Context) error { log = append(log, "undo "+name); return nil },
+		}
+	}
+
+	trace, err := WorkflowTask("checkout", []WorkflowStep{step("reserve"), step("charge")})(context.Background()).Unwrap()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(log) != 2 || log[0] != "reserve" || log[1] != "charge" {
+		t.Errorf("Expected steps in order without compensation, got %v", log)
+	}
+	for _, s := range trace.Steps {
+		if s.Status != StepSucceeded {
+			t.Errorf("Expected %s to succeed, got %v", s.Name, s.Status)
+		}
+	}
+}
+
+func TestWorkflowTaskCompensatesInReverse(t *testing.T) {
+	var undone []string
+	chargeErr := errors.New("card declined")
+	undoErr := errors.New("release failed")
+
+	steps := []WorkflowStep{
+		{
+			Name:       "reserve",
+			Run:        func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error { undone = append(undone, "reserve"); return undoErr },
+		},
+		{
+			Name:       "notify",
+			Run:        func(ctx context.Context) error { return nil },
+			Compensate: func(ctx context.Context) error { undone = append(undone, "notify"); return nil },
+		},
+		{
+			Name: "charge",
+			Run:  func(ctx context.Context) error { return chargeErr },
+		},
+		{
+			Name: "ship",
+			Run:  func(ctx context.Context) error { t.Error("ship should not run"); return nil },
+		},
+	}
+
+	_, err := WorkflowTask("checkout", steps)(context.Background()).Unwrap()
+	if !errors.Is(err, chargeErr) {
+		t.Fatalf("Expected card declined, got %v", err)
+	}
+	if len(undone) != 2 || undone[0] != "notify" || undone[1] != "reserve" {
+		t.Errorf("Expected compensations in reverse order, got %v", undone)
+	}
+
+	var wfErr *WorkflowError
+	if !errors.As(err, &wfErr) || wfErr.Step != "charge" {
+		t.Fatalf("Expected WorkflowError for charge, got %v", err)
+	}
+	want := []StepStatus{StepCompensationFailed, StepCompensated, StepFailed, StepPending}
+	for i, s := range wfErr.Trace.Steps {
+		if s.Status != want[i] {
+			t.Errorf("Step %s: expected %v, got %v", s.Name, want[i], s.Status)
+		}
+	}
+	if wfErr.Trace.Steps[0].Err != undoErr {
+		t.Errorf("Expected compensation error in trace, got %v", wfErr.Trace.Steps[0].Err)
+	}
+}
+
+func TestWorkflowTaskCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	compensated := false
+	steps := []WorkflowStep{
+		{
+			Name: "first",
+			Run:  func(ctx context.Context) error { cancel(); return nil },
+			Compensate: func(ctx context.Context) error {
+				compensated = ctx.Err() == nil
+				return nil
+			},
+		},
+		{Name: "second", Run: func(ctx context.Context) error { return nil }},
+	}
+
+	_, err := WorkflowTask("cancelled", steps)(ctx).Unwrap()
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if !compensated {
+		t.Error("Compensation should run with a live context after cancellation")
+	}
+}
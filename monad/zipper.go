@@ -0,0 +1,73 @@
+package monad
+
+// Zipper is a cursor over a PersistentList for local edits: the focused element can be read and edited in O(1),
+// and an edit is only written back to the list when the focus moves away or List is called
+// A Zipper is immutable like the list: every method returns a new Zipper, so earlier positions stay valid
+// Compose it with lenses by passing their Modify to Zipper.Modify, e.g. to edit one field of the focused element
+type Zipper[T any] struct {
+	list  PersistentList[T]
+	index int
+	focus T
+	dirty bool
+}
+
+// ZipperOf creates a Zipper focused on the first element of l, or None when l is empty
+func ZipperOf[T any](l PersistentList[T]) Option[Zipper[T]] {
+	return ZipperAt(l, 0)
+}
+
+// ZipperAt creates a Zipper focused on the element at index i, or None when i is out of range
+func ZipperAt[T any](l PersistentList[T], i int) Option[Zipper[T]] {
+	focus := l.Get(i)
+	if !focus.IsSome() {
+		return None[Zipper[T]]()
+	}
+	return Some(Zipper[T]{list: l, index: i, focus: focus.Unwrap()})
+}
+
+// Focus returns the focused element
+func (z Zipper[T]) Focus() T {
+	return z.focus
+}
+
+// Index returns the index of the focused element
+func (z Zipper[T]) Index() int {
+	return z.index
+}
+
+// Set replaces the focused element
+func (z Zipper[T]) Set(v T) Zipper[T] {
+	z.focus, z.dirty = v, true
+	return z
+}
+
+// Modify replaces the focused element with f applied to it
+func (z Zipper[T]) Modify(f func(T) T) Zipper[T] {
+	return z.Set(f(z.focus))
+}
+
+// Left moves the focus to the previous element, or returns None at the first one
+func (z Zipper[T]) Left() Option[Zipper[T]] {
+	return z.MoveTo(z.index - 1)
+}
+
+// Right moves the focus to the next element, or returns None at the last one
+func (z Zipper[T]) Right() Option[Zipper[T]] {
+	return z.MoveTo(z.index + 1)
+}
+
+// MoveTo moves the focus to index i, writing back a pending edit, or returns None when i is out of range
+func (z Zipper[T]) MoveTo(i int) Option[Zipper[T]] {
+	if i < 0 || i >= z.list.Len() {
+		return None[Zipper[T]]()
+	}
+	return ZipperAt(z.List(), i)
+}
+
+// List returns the list with the pending edit of the focused element written back
+func (z Zipper[T]) List() PersistentList[T] {
+	if !z.dirty {
+		return z.list
+	}
+	return z.list.Set(z.index, z.focus)
+}
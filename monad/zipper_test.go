@@ -0,0 +1,52 @@
+package monad
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestZipperEdits(t *testing.T) {
+	list := PersistentListOf("a", "b", "c")
+	z := ZipperOf(list).Unwrap()
+
+	z = z.Modify(strings.ToUpper).Right().Unwrap().Set("x").Right().Unwrap()
+	if z.Index() != 2 || z.Focus() != "c" {
+		t.Fatalf("Expected focus on c at 2, got %q at %d", z.Focus(), z.Index())
+	}
+	z = z.Modify(func(s string) string { return s + s })
+
+	if got := z.List().Slice(); !reflect.DeepEqual(got, []string{"A", "x", "cc"}) {
+		t.Errorf("Expected [A x cc], got %v", got)
+	}
+	if got := list.Slice(); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Errorf("The original list must not change, got %v", got)
+	}
+
+	back := z.Left().Unwrap().Left().Unwrap()
+	if back.Focus() != "A" || back.Left().IsSome() {
+		t.Errorf("Expected the written-back edit at the first element, got %q", back.Focus())
+	}
+	if z.Right().IsSome() {
+		t.Error("Moving past the last element should be None")
+	}
+}
+
+func TestZipperPositions(t *testing.T) {
+	if ZipperOf(PersistentList[int]{}).IsSome() {
+		t.Error("An empty list has no focus")
+	}
+	list := PersistentListOf(1, 2, 3, 4)
+	if ZipperAt(list, 4).IsSome() || ZipperAt(list, -1).IsSome() {
+		t.Error("Out of range indexes have no focus")
+	}
+
+	z := ZipperAt(list, 1).Unwrap()
+	edited := z.Set(20)
+	if z.List().Get(1).Unwrap() != 2 {
+		t.Error("Earlier zipper positions must stay valid")
+	}
+	if moved := edited.MoveTo(3).Unwrap(); moved.Focus() != 4 || moved.List().Get(1).Unwrap() != 20 {
+		t.Error("MoveTo should write the pending edit back")
+	}
+}
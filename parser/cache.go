@@ -0,0 +1,117 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache remembers the declarations parsed from each file, keyed by path and content hash,
+// so reparsing a package in the same process (repeated runs, tools sharing the parser) skips unchanged files
+// Files are still read on every call to compute their hash; only parsing is saved
+type Cache struct {
+	mu     sync.Mutex
+	files  map[string]cachedFile
+	hits   int
+	misses int
+}
+
+// cachedFile is the parse of one file version
+type cachedFile struct {
+	hash [sha256.Size]byte
+	decl Package
+}
+
+// CacheStats reports the effectiveness of a Cache
+type CacheStats struct {
+	Hits   int // files served from the cache
+	Misses int // files parsed because they were new or changed
+	Files  int // files currently cached
+}
+
+// NewCache creates an empty Cache
+func NewCache() *Cache {
+	return &Cache{files: map[string]cachedFile{}}
+}
+
+// shared is the Cache behind ParsePackage and ParseDir
+var shared = NewCache()
+
+// SharedCache returns the Cache used by ParsePackage and ParseDir
+func SharedCache() *Cache {
+	return shared
+}
+
+// ParsePackage is ParsePackage using c
+func (c *Cache) ParsePackage(dir string) (Package, error) {
+	var pkg Package
+	err := c.StreamPackage(dir, func(_ string, decl Package) error {
+		pkg.Structs = append(pkg.Structs, decl.Structs...)
+		pkg.Funcs = append(pkg.Funcs, decl.Funcs...)
+		pkg.Types = append(pkg.Types, decl.Types...)
+		pkg.Vars = append(pkg.Vars, decl.Vars...)
+		return nil
+	})
+	if err != nil {
+		return Package{}, err
+	}
+	return pkg, nil
+}
+
+// StreamPackage is StreamPackage using c
+func (c *Cache) StreamPackage(dir string, fn func(path string, decl Package) error) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		decl, err := c.parseFile(f)
+		if err != nil {
+			return err
+		}
+		if err := fn(f, decl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseFile returns the declarations of the file at path, parsing it only if its content changed
+func (c *Cache) parseFile(path string) (Package, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return Package{}, err
+	}
+	key, err := filepath.Abs(path)
+	if err != nil {
+		return Package{}, err
+	}
+	hash := sha256.Sum256(src)
+
+	c.mu.Lock()
+	cached, ok := c.files[key]
+	if ok && cached.hash == hash {
+		c.hits++
+		c.mu.Unlock()
+		return cached.decl, nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	decl, err := parseFile(path, src)
+	if err != nil {
+		return Package{}, err
+	}
+	c.mu.Lock()
+	c.files[key] = cachedFile{hash: hash, decl: decl}
+	c.mu.Unlock()
+	return decl, nil
+}
+
+// Stats returns the hits and misses since the Cache was created
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Files: len(c.files)}
+}
@@ -4,141 +4,365 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
-	"io/ioutil"
-	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 // ParseDir scans a directory for Go files and returns structs and funcs with //gofn: directives
 func ParseDir(dir string) ([]StructInfo, []FuncInfo, error) {
+	pkg, err := ParsePackage(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkg.Structs, pkg.Funcs, nil
+}
+
+// ParsePackage scans a directory for Go files and returns its structs, funcs and named non-struct types
+// Files whose content did not change since an earlier call are served from the shared Cache
+func ParsePackage(dir string) (Package, error) {
+	return shared.ParsePackage(dir)
+}
+
+// StreamPackage parses the Go files of dir one at a time, calling fn with the declarations of each file as soon as
+// it is parsed, so callers can generate code and report errors before the rest of the package is read
+// Only one file's syntax tree is alive at a time; an error from fn stops the walk and is returned as is
+func StreamPackage(dir string, fn func(path string, decl Package) error) error {
+	return shared.StreamPackage(dir, fn)
+}
+
+// parseFile returns the declarations of one Go source file
+func parseFile(path string, src []byte) (Package, error) {
 	fset := token.NewFileSet()
 	var structs []StructInfo
 	var funcs []FuncInfo
+	var types []TypeInfo
 
-	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
 	if err != nil {
-		return nil, nil, err
+		return Package{}, err
 	}
 
-	for _, f := range files {
-		src, err := ioutil.ReadFile(f)
-		if err != nil {
-			return nil, nil, err
+	pkg := file.Name.Name
+	imports := fileImports(file)
+
+	// comments are inspected per-declaration below using x.Doc on nodes;
+	// a //gofn:file pragma supplies directives for top-level structs without their own, exported or not
+	fileDirs := filePragma(file)
+	topLevel := map[*ast.TypeSpec]bool{}
+	for _, decl := range file.Decls {
+		if gd, ok := decl.(*ast.GenDecl); ok {
+			for _, spec := range gd.Specs {
+				if ts, ok := spec.(*ast.TypeSpec); ok {
+					topLevel[ts] = true
+				}
+			}
 		}
-		file, err := parser.ParseFile(fset, f, src, parser.ParseComments)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		pkg := file.Name.Name
-
-		// comments are inspected per-declaration below using x.Doc on nodes
-
-		ast.Inspect(file, func(n ast.Node) bool {
-			switch x := n.(type) {
-			case *ast.TypeSpec:
-				if st, ok := x.Type.(*ast.StructType); ok {
-					pos := fset.Position(x.Pos())
-					dir := ""
-					// try to find preceding comment for the type
-					if x.Doc != nil {
-						for _, c := range x.Doc.List {
-							txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
-							if strings.HasPrefix(txt, "gofn:") {
-								dir = strings.TrimSpace(strings.TrimPrefix(txt, "gofn:"))
-								break
-							}
-						}
-					}
-					// If TypeSpec.Doc is empty, the comment may be attached to the enclosing GenDecl
-					if dir == "" {
-						// search file declarations to find the GenDecl that contains this TypeSpec
-						for _, decl := range file.Decls {
-							gd, ok := decl.(*ast.GenDecl)
-							if !ok || gd.Doc == nil {
-								continue
-							}
-							for _, spec := range gd.Specs {
-								if ts, ok := spec.(*ast.TypeSpec); ok && ts == x {
-									for _, c := range gd.Doc.List {
-										txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
-										if strings.HasPrefix(txt, "gofn:") {
-											dir = strings.TrimSpace(strings.TrimPrefix(txt, "gofn:"))
-											break
-										}
-									}
-								}
-								if dir != "" {
-									break
-								}
-							}
-							if dir != "" {
-								break
-							}
-						}
+	}
+
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.TypeSpec:
+			st, ok := x.Type.(*ast.StructType)
+			if !ok {
+				// named non-struct types and aliases
+				types = append(types, TypeInfo{
+					Package:    pkg,
+					Name:       x.Name.Name,
+					Underlying: exprString(x.Type),
+					Kind:       kindOf(x.Type),
+					Alias:      x.Assign.IsValid(),
+					Methods:    interfaceMethods(x.Type),
+					Directive:  typeDirective(file, x),
+					Imports:    imports,
+					Pos:        fset.Position(x.Pos()),
+				})
+				return true
+			}
+			pos := fset.Position(x.Pos())
+			dirs := typeDirectives(file, x)
+			fields := []FieldInfo{}
+			for _, f := range st.Fields.List {
+				t := exprString(f.Type)
+				tag := ""
+				if f.Tag != nil {
+					if unquoted, err := strconv.Unquote(f.Tag.Value); err == nil {
+						tag = unquoted
 					}
-					fields := []FieldInfo{}
-					for _, f := range st.Fields.List {
-						t := exprString(f.Type)
-						tag := ""
-						if f.Tag != nil {
-							tag = strings.Trim(f.Tag.Value, "`\"")
-						}
-						if len(f.Names) == 0 {
-							fields = append(fields, FieldInfo{Name: "", Type: t, Tag: tag})
-						} else {
-							for _, nm := range f.Names {
-								fields = append(fields, FieldInfo{Name: nm.Name, Type: t, Tag: tag})
-							}
-						}
+				}
+				if len(f.Names) == 0 {
+					fields = append(fields, FieldInfo{Name: "", Type: t, Tag: tag})
+				} else {
+					for _, nm := range f.Names {
+						fields = append(fields, FieldInfo{Name: nm.Name, Type: t, Tag: tag})
 					}
-					structs = append(structs, StructInfo{Package: pkg, Name: x.Name.Name, Fields: fields, Directive: dir, Pos: pos})
 				}
-			case *ast.FuncDecl:
-				pos := fset.Position(x.Pos())
-				dir := ""
-				if x.Doc != nil {
-					for _, c := range x.Doc.List {
-						txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
-						if strings.HasPrefix(txt, "gofn:") {
-							dir = strings.TrimSpace(strings.TrimPrefix(txt, "gofn:"))
-							break
+			}
+			// a struct documented with several directives is recorded once per directive
+			switch {
+			case len(dirs) == 1 && dirs[0] == OptOut:
+				dirs = []string{""}
+			case len(dirs) == 0 && topLevel[x] && len(fileDirs) > 0:
+				dirs = fileDirs
+			case len(dirs) == 0:
+				dirs = []string{""}
+			}
+			for _, d := range dirs {
+				structs = append(structs, StructInfo{Package: pkg, Name: x.Name.Name, Fields: fields, Directive: d, Imports: imports, Pos: pos})
+			}
+		case *ast.FuncDecl:
+			pos := fset.Position(x.Pos())
+			dir := commentDirective(x.Doc)
+			params := []ParamInfo{}
+			if x.Type.Params != nil {
+				for _, p := range x.Type.Params.List {
+					t := exprString(p.Type)
+					if len(p.Names) == 0 {
+						params = append(params, ParamInfo{Name: "", Type: t})
+					} else {
+						for _, n := range p.Names {
+							params = append(params, ParamInfo{Name: n.Name, Type: t})
 						}
 					}
 				}
-				params := []ParamInfo{}
-				if x.Type.Params != nil {
-					for _, p := range x.Type.Params.List {
-						t := exprString(p.Type)
-						if len(p.Names) == 0 {
-							params = append(params, ParamInfo{Name: "", Type: t})
-						} else {
-							for _, n := range p.Names {
-								params = append(params, ParamInfo{Name: n.Name, Type: t})
-							}
+			}
+			results := []ParamInfo{}
+			if x.Type.Results != nil {
+				for _, r := range x.Type.Results.List {
+					t := exprString(r.Type)
+					if len(r.Names) == 0 {
+						results = append(results, ParamInfo{Name: "", Type: t})
+					} else {
+						for _, n := range r.Names {
+							results = append(results, ParamInfo{Name: n.Name, Type: t})
 						}
 					}
 				}
-				results := []ParamInfo{}
-				if x.Type.Results != nil {
-					for _, r := range x.Type.Results.List {
-						t := exprString(r.Type)
-						if len(r.Names) == 0 {
-							results = append(results, ParamInfo{Name: "", Type: t})
-						} else {
-							for _, n := range r.Names {
-								results = append(results, ParamInfo{Name: n.Name, Type: t})
-							}
+			}
+			recv := ""
+			if x.Recv != nil && len(x.Recv.List) > 0 {
+				recv = exprString(x.Recv.List[0].Type)
+			}
+			funcs = append(funcs, FuncInfo{Package: pkg, Name: x.Name.Name, Params: params, Results: results, Receiver: recv, Directive: dir, Imports: imports, Pos: pos})
+		}
+		return true
+	})
+
+	vars := packageVars(fset, file)
+	return Package{Structs: structs, Funcs: funcs, Types: types, Vars: vars}, nil
+}
+
+// typeDirective returns the first //gofn: directive documenting a type spec, looking at the
+// spec's own doc comment first and then at the enclosing GenDecl
+func typeDirective(file *ast.File, x *ast.TypeSpec) string {
+	if dirs := typeDirectives(file, x); len(dirs) > 0 {
+		return dirs[0]
+	}
+	return ""
+}
+
+// typeDirectives returns every //gofn: directive documenting a type spec, one per line, from the
+// spec's own doc comment or else from the enclosing GenDecl
+func typeDirectives(file *ast.File, x *ast.TypeSpec) []string {
+	if dirs := commentDirectives(x.Doc); len(dirs) > 0 {
+		return dirs
+	}
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Doc == nil {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			if ts, ok := spec.(*ast.TypeSpec); ok && ts == x {
+				return commentDirectives(gd.Doc)
+			}
+		}
+	}
+	return nil
+}
+
+// packageVars returns the top-level variables of file that carry a directive, on the spec or its var block
+func packageVars(fset *token.FileSet, file *ast.File) []VarInfo {
+	var vars []VarInfo
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			dir := commentDirective(vs.Doc)
+			if dir == "" {
+				dir = commentDirective(gd.Doc)
+			}
+			if dir == "" {
+				continue
+			}
+			for i, name := range vs.Names {
+				var elems []string
+				if i < len(vs.Values) {
+					if lit, ok := vs.Values[i].(*ast.CompositeLit); ok {
+						for _, elt := range lit.Elts {
+							elems = append(elems, exprString(elt))
 						}
 					}
 				}
-				funcs = append(funcs, FuncInfo{Package: pkg, Name: x.Name.Name, Params: params, Results: results, Directive: dir, Pos: pos})
+				vars = append(vars, VarInfo{
+					Package:   file.Name.Name,
+					Name:      name.Name,
+					Elems:     elems,
+					Directive: dir,
+					Pos:       fset.Position(name.Pos()),
+				})
+			}
+		}
+	}
+	return vars
+}
+
+// OptOut is the directive (//gofn:skip) excluding a struct from its file's //gofn:file pragma
+// It is spelled as a word so gofmt keeps it a directive comment, without the space it adds after // otherwise
+const OptOut = "skip"
+
+// commentDirective returns the value after the first //gofn: line of a comment group
+// A //gofn:file pragma is not a declaration directive and is skipped
+func commentDirective(doc *ast.CommentGroup) string {
+	if dirs := commentDirectives(doc); len(dirs) > 0 {
+		return dirs[0]
+	}
+	return ""
+}
+
+// commentDirectives returns the values after every //gofn: line of a comment group, in order
+func commentDirectives(doc *ast.CommentGroup) []string {
+	if doc == nil {
+		return nil
+	}
+	var dirs []string
+	for _, c := range doc.List {
+		txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if strings.HasPrefix(txt, "gofn:") && !isFilePragma(txt) {
+			dirs = append(dirs, strings.TrimSpace(strings.TrimPrefix(txt, "gofn:")))
+		}
+	}
+	return dirs
+}
+
+// isFilePragma reports whether a comment line (without the leading //) is a //gofn:file pragma
+func isFilePragma(txt string) bool {
+	rest, ok := strings.CutPrefix(txt, "gofn:file")
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// filePragma returns the comma separated directives of the file's //gofn:file pragma, if any
+// e.g. `//gofn:file record, reactive stream` yields ["record", "reactive stream"]
+func filePragma(file *ast.File) []string {
+	for _, group := range file.Comments {
+		for _, c := range group.List {
+			txt := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+			if !isFilePragma(txt) {
+				continue
+			}
+			var dirs []string
+			for _, d := range strings.Split(strings.TrimPrefix(txt, "gofn:file"), ",") {
+				if d = strings.TrimSpace(d); d != "" {
+					dirs = append(dirs, d)
+				}
 			}
-			return true
-		})
+			return dirs
+		}
+	}
+	return nil
+}
+
+// fileImports returns the imports of file that qualify its identifiers; blank and dot imports are left out
+func fileImports(file *ast.File) []ImportInfo {
+	var imports []ImportInfo
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := ImportName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		imports = append(imports, ImportInfo{Name: name, Path: path})
+	}
+	return imports
+}
+
+// ImportName guesses the package name of an import path without loading it: the last path element,
+// skipping a major version suffix such as /v2 and a go- prefix, e.g. "github.com/google/uuid" is uuid
+// and "gopkg.in/yaml.v3" is yaml
+func ImportName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexAny(name, ".-"); i > 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// isMajorVersion reports whether a path element is a major version suffix such as v2
+func isMajorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(elem[1:])
+	return err == nil
+}
+
+// kindOf classifies the type expression on the right of a type declaration
+func kindOf(e ast.Expr) TypeKind {
+	switch t := e.(type) {
+	case *ast.Ident:
+		if isBasic(t.Name) {
+			return KindBasic
+		}
+		return KindNamed
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+		return KindNamed
+	case *ast.StarExpr:
+		return KindPointer
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return KindArray
+		}
+		return KindSlice
+	case *ast.MapType:
+		return KindMap
+	case *ast.FuncType:
+		return KindFunc
+	case *ast.InterfaceType:
+		return KindInterface
+	case *ast.ChanType:
+		return KindChan
+	case *ast.StructType:
+		return KindStruct
+	case *ast.ParenExpr:
+		return kindOf(t.X)
+	default:
+		return KindUnknown
 	}
+}
 
-	return structs, funcs, nil
+// isBasic reports whether name is a predeclared basic type
+func isBasic(name string) bool {
+	switch name {
+	case "bool", "string", "error", "any",
+		"int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"float32", "float64", "complex64", "complex128", "byte", "rune":
+		return true
+	}
+	return false
 }
 
 // exprString renders a limited set of expr types to string for type names
@@ -153,12 +377,94 @@ func exprString(e ast.Expr) string {
 	case *ast.SelectorExpr:
 		return exprString(t.X) + "." + t.Sel.Name
 	case *ast.ArrayType:
+		if t.Len != nil {
+			return "[" + exprString(t.Len) + "]" + exprString(t.Elt)
+		}
 		return "[]" + exprString(t.Elt)
 	case *ast.MapType:
 		return "map[" + exprString(t.Key) + "]" + exprString(t.Value)
 	case *ast.FuncType:
-		return "func"
+		return "func" + funcSignature(t)
+	case *ast.IndexExpr:
+		return exprString(t.X) + "[" + exprString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		parts := make([]string, len(t.Indices))
+		for i, idx := range t.Indices {
+			parts[i] = exprString(idx)
+		}
+		return exprString(t.X) + "[" + strings.Join(parts, ", ") + "]"
+	case *ast.ChanType:
+		switch t.Dir {
+		case ast.SEND:
+			return "chan<- " + exprString(t.Value)
+		case ast.RECV:
+			return "<-chan " + exprString(t.Value)
+		}
+		return "chan " + exprString(t.Value)
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+		return "<unknown>"
+	case *ast.StructType:
+		if t.Fields == nil || len(t.Fields.List) == 0 {
+			return "struct{}"
+		}
+		return "<unknown>"
+	case *ast.BasicLit:
+		return t.Value
+	case *ast.ParenExpr:
+		return "(" + exprString(t.X) + ")"
 	default:
 		return "<unknown>"
 	}
 }
+
+// interfaceMethods returns the names of the methods an interface type declares, or nil for other types
+func interfaceMethods(e ast.Expr) []string {
+	it, ok := e.(*ast.InterfaceType)
+	if !ok || it.Methods == nil {
+		return nil
+	}
+	var names []string
+	for _, m := range it.Methods.List {
+		if _, ok := m.Type.(*ast.FuncType); !ok {
+			continue
+		}
+		for _, n := range m.Names {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// funcSignature renders the parameters and results of a function type, e.g. "(context.Context, int) error"
+func funcSignature(t *ast.FuncType) string {
+	list := func(fl *ast.FieldList) []string {
+		var parts []string
+		if fl == nil {
+			return parts
+		}
+		for _, f := range fl.List {
+			typ := exprString(f.Type)
+			if len(f.Names) == 0 {
+				parts = append(parts, typ)
+				continue
+			}
+			for _, n := range f.Names {
+				parts = append(parts, n.Name+" "+typ)
+			}
+		}
+		return parts
+	}
+
+	sig := "(" + strings.Join(list(t.Params), ", ") + ")"
+	results := list(t.Results)
+	switch {
+	case len(results) == 1 && !strings.Contains(results[0], " "):
+		sig += " " + results[0]
+	case len(results) > 0:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
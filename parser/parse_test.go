@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"go/format"
+	"slices"
+	"testing"
+)
+
+func TestFilePragma(t *testing.T) {
+	src := `//gofn:file record, arbitrary
+
+package models
+
+type user struct{ name string }
+
+// Account is exported and gets the pragma too.
+type Account struct{ ID int }
+
+//gofn:stringer
+type level struct{ n int }
+
+// cache opts out of the pragma.
+//
+//gofn:skip
+type cache struct{ hits int }
+
+func helper() {
+	type local struct{ x int }
+	_ = local{}
+}
+`
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(formatted) != src {
+		t.Errorf("The fixture must be gofmt-clean so //gofn:skip stays a directive, gofmt gives:\n%s", formatted)
+	}
+
+	pkg, err := parseFile("models.go", []byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string][]string{}
+	for _, s := range pkg.Structs {
+		got[s.Name] = append(got[s.Name], s.Directive)
+	}
+	want := map[string][]string{
+		"user":    {"record", "arbitrary"},
+		"Account": {"record", "arbitrary"},
+		"level":   {"stringer"},
+		"cache":   {""},
+		"local":   {""},
+	}
+	for name, dirs := range want {
+		if !slices.Equal(got[name], dirs) {
+			t.Errorf("%s: expected directives %q, got %q", name, dirs, got[name])
+		}
+	}
+}
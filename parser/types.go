@@ -9,12 +9,19 @@ type FieldInfo struct {
 	Tag  string
 }
 
+// ImportInfo describes an import of the file declaring a type or function
+type ImportInfo struct {
+	Name string // name the file refers to the package by: its alias, or the last element of Path
+	Path string
+}
+
 // StructInfo describes a parsed struct and its gofn directive (if any)
 type StructInfo struct {
 	Package   string
 	Name      string
 	Fields    []FieldInfo
 	Directive string // raw value after //gofn:
+	Imports   []ImportInfo
 	Pos       token.Position
 }
 
@@ -30,6 +37,56 @@ type FuncInfo struct {
 	Name      string
 	Params    []ParamInfo
 	Results   []ParamInfo
+	Receiver  string // receiver type for methods, e.g. "*Account"; empty for functions
+	Directive string
+	Imports   []ImportInfo
+	Pos       token.Position
+}
+
+// TypeKind classifies the underlying type of a named non-struct type
+type TypeKind string
+
+const (
+	KindBasic     TypeKind = "basic" // predeclared type such as string or int
+	KindNamed     TypeKind = "named" // another named type, possibly qualified or instantiated
+	KindPointer   TypeKind = "pointer"
+	KindSlice     TypeKind = "slice"
+	KindArray     TypeKind = "array"
+	KindMap       TypeKind = "map"
+	KindFunc      TypeKind = "func"
+	KindInterface TypeKind = "interface"
+	KindChan      TypeKind = "chan"
+	KindStruct    TypeKind = "struct" // only for aliases of struct literals; plain structs are StructInfo
+	KindUnknown   TypeKind = "unknown"
+)
+
+// TypeInfo describes a named non-struct type or alias, e.g. `type UserID string` or `type Names = []string`
+type TypeInfo struct {
+	Package    string
+	Name       string
+	Underlying string // type expression on the right of the declaration
+	Kind       TypeKind
+	Alias      bool     // declared with `=`
+	Methods    []string // method names of an interface, in source order; embedded interfaces are not listed
+	Directive  string
+	Imports    []ImportInfo
+	Pos        token.Position
+}
+
+// VarInfo describes a package-level variable carrying a gofn directive, e.g. the function list of //gofn:pipe
+// Variables without a directive are not recorded
+type VarInfo struct {
+	Package   string
+	Name      string
+	Elems     []string // elements of a composite literal value, e.g. ["trim", "strconv.Atoi"]
 	Directive string
 	Pos       token.Position
 }
+
+// Package is everything ParsePackage found in a directory
+type Package struct {
+	Structs []StructInfo
+	Funcs   []FuncInfo
+	Types   []TypeInfo
+	Vars    []VarInfo
+}